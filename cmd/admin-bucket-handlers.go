@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
@@ -26,6 +27,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -35,8 +37,10 @@ import (
 	"github.com/minio/kes"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/internal/bucket/bandwidth"
 	"github.com/minio/minio/internal/bucket/lifecycle"
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/bucket/versioning"
 	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/kms"
@@ -151,6 +155,172 @@ func (a adminAPIHandlers) GetBucketQuotaConfigHandler(w http.ResponseWriter, r *
 	writeSuccessResponseJSON(w, configData)
 }
 
+// PutBucketPoolAffinityHandler - PUT Bucket pool affinity configuration.
+// ----------
+// Places a pool affinity configuration on the specified bucket, new
+// objects of the bucket are placed on the pools allowed by it. An
+// empty list of rules removes the configuration.
+func (a adminAPIHandlers) PutBucketPoolAffinityHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketPoolAffinity")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketQuotaAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	affinity, err := parseBucketPoolAffinity(bucket, data)
+	if err == nil {
+		err = affinity.Validate(len(globalEndpoints))
+	}
+	if pools, ok := objectAPI.(*erasureServerPools); ok && err == nil {
+		err = pools.checkPoolAffinity(bucket, affinity, -1)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, AdminError{
+			Code:       "XMinioAdminInvalidPoolAffinity",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		}), r.URL)
+		return
+	}
+
+	if len(affinity.Rules) == 0 {
+		_, err = globalBucketMetadataSys.Delete(ctx, bucket, bucketPoolAffinityConfigFile)
+	} else {
+		_, err = globalBucketMetadataSys.Update(ctx, bucket, bucketPoolAffinityConfigFile, data)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketPoolAffinityHandler - gets bucket pool affinity configuration
+func (a adminAPIHandlers) GetBucketPoolAffinityHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketPoolAffinity")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketQuotaAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	affinity, err := globalBucketMetadataSys.GetPoolAffinityConfig(bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := json.Marshal(affinity)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, configData)
+}
+
+// BucketPoolAffinityReportHandler - reports the objects of a bucket stored
+// on pools not allowed by its pool affinity configuration, up to
+// 'max-objects' of them are listed. The report is generated in the
+// background, the first request starts it and the following ones return
+// its status until it is complete, 'refresh=true' generates it again.
+func (a adminAPIHandlers) BucketPoolAffinityReportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "BucketPoolAffinityReport")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketQuotaAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	maxObjects := 1000
+	if v := r.Form.Get("max-objects"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		maxObjects = n
+	}
+
+	affinity, err := globalBucketMetadataSys.GetPoolAffinityConfig(bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// The report is kept by the server of the first endpoint, whichever
+	// server the client polls.
+	if ep := globalEndpoints[0].Endpoints[0]; !ep.IsLocal {
+		for nodeIdx, proxyEp := range globalProxyEndpoints {
+			if proxyEp.Endpoint.Host == ep.Host {
+				if proxyRequestByNodeIndex(ctx, w, r, nodeIdx) {
+					return
+				}
+			}
+		}
+	}
+
+	st := globalAdminJobs.start("pool-affinity-report/"+bucket, r.Form.Get("refresh") == "true",
+		func(ctx context.Context) (interface{}, error) {
+			return pools.poolAffinityReport(ctx, bucket, affinity, maxObjects)
+		})
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
 // SetRemoteTargetHandler - sets a remote target for bucket
 func (a adminAPIHandlers) SetRemoteTargetHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SetBucketTarget")
@@ -190,6 +360,29 @@ func (a adminAPIHandlers) SetRemoteTargetHandler(w http.ResponseWriter, r *http.
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
 		return
 	}
+	// The bandwidth schedule of the target is set along with the target,
+	// or updated with the "bandwidthSchedule" update type. Targets which
+	// are not MinIO deployments are added as generic targets.
+	var sched struct {
+		BandwidthSchedule []BandwidthScheduleEntry `json:"bandwidthSchedule"`
+		Generic           bool                     `json:"generic"`
+	}
+	if err = json.Unmarshal(reqBytes, &sched); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+	updateSchedule := !update || r.Form.Get("bandwidthSchedule") == "true"
+	var bwSchedule bandwidth.Schedule
+	if updateSchedule {
+		if bwSchedule, err = parseBandwidthSchedule(sched.BandwidthSchedule); err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, AdminError{
+				Code:       "XMinioAdminInvalidBandwidthSchedule",
+				Message:    err.Error(),
+				StatusCode: http.StatusBadRequest,
+			}), r.URL)
+			return
+		}
+	}
 	sameTarget, _ := isLocalHost(target.URL().Hostname(), target.URL().Port(), globalMinioPort)
 	if sameTarget && bucket == target.TargetBucket {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrBucketRemoteIdenticalToSource), r.URL)
@@ -237,11 +430,17 @@ func (a adminAPIHandlers) SetRemoteTargetHandler(w http.ResponseWriter, r *http.
 	}
 
 	// enforce minimum bandwidth limit as 100MBps
-	if target.BandwidthLimit > 0 && target.BandwidthLimit < 100*1000*1000 {
+	if target.BandwidthLimit > 0 && target.BandwidthLimit < minBandwidthLimit {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrReplicationBandwidthLimitError, err), r.URL)
 		return
 	}
-	if err = globalBucketTargetSys.SetTarget(ctx, bucket, &target, update); err != nil {
+	generic := !update && sched.Generic
+	if generic {
+		err = globalBucketTargetSys.SetGenericTarget(ctx, bucket, &target)
+	} else {
+		err = globalBucketTargetSys.SetTarget(ctx, bucket, &target, update)
+	}
+	if err != nil {
 		switch err.(type) {
 		case RemoteTargetConnectionErr:
 			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrReplicationRemoteConnectionError, err), r.URL)
@@ -250,6 +449,14 @@ func (a adminAPIHandlers) SetRemoteTargetHandler(w http.ResponseWriter, r *http.
 		}
 		return
 	}
+	// The generic targets are saved first, the peers reload the targets
+	// along with them.
+	if generic {
+		if err = updateGenericTarget(ctx, bucket, target.Arn, true); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	}
 	targets, err := globalBucketTargetSys.ListBucketTargets(ctx, bucket)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
@@ -264,6 +471,13 @@ func (a adminAPIHandlers) SetRemoteTargetHandler(w http.ResponseWriter, r *http.
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if updateSchedule && (len(bwSchedule) > 0 || getBandwidthSchedules(bucket).Schedule(target.Arn) != nil) {
+		if err = updateBandwidthSchedule(ctx, bucket, target.Arn, sched.BandwidthSchedule); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		globalBucketMonitor.SetBandwidthSchedule(bucket, target.Arn, bwSchedule)
+	}
 
 	data, err := json.Marshal(target.Arn)
 	if err != nil {
@@ -301,7 +515,23 @@ func (a adminAPIHandlers) ListRemoteTargetsHandler(w http.ResponseWriter, r *htt
 		}
 	}
 	targets := globalBucketTargetSys.ListTargets(ctx, bucket, arnType)
-	data, err := json.Marshal(targets)
+	// List the bandwidth schedules of the targets and whether they are
+	// generic along with the targets.
+	type bucketTargetWithSchedule struct {
+		madmin.BucketTarget
+		BandwidthSchedule []BandwidthScheduleEntry `json:"bandwidthSchedule,omitempty"`
+		Generic           bool                     `json:"generic,omitempty"`
+	}
+	tgts := make([]bucketTargetWithSchedule, 0, len(targets))
+	for _, t := range targets {
+		tgt := bucketTargetWithSchedule{BucketTarget: t}
+		if schedules := getBandwidthSchedules(t.SourceBucket); schedules != nil {
+			tgt.BandwidthSchedule = schedules.Targets[t.Arn]
+		}
+		tgt.Generic = getGenericTargets(t.SourceBucket).Contains(t.Arn)
+		tgts = append(tgts, tgt)
+	}
+	data, err := json.Marshal(tgts)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -349,19 +579,95 @@ func (a adminAPIHandlers) RemoveRemoteTargetHandler(w http.ResponseWriter, r *ht
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if getBandwidthSchedules(bucket).Schedule(arn) != nil {
+		if err = updateBandwidthSchedule(ctx, bucket, arn, nil); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	}
+	if err = updateGenericTarget(ctx, bucket, arn, false); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
 
 	// Write success response.
 	writeSuccessNoContent(w)
 }
 
-// ExportBucketMetadataHandler - exports all bucket metadata as a zipped file
+// bucketMetadataExportInfo - describes a bucket metadata export, it is
+// stored at the root of the zipped file.
+type bucketMetadataExportInfo struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	Buckets    []string  `json:"buckets"`
+}
+
+const (
+	bucketMetadataExportFile = "bucket-metadata-export.json"
+
+	// Version 1 adds the export info, remote targets are exported as
+	// JSON instead of XML, encrypted with the secret key of the exporting
+	// user.
+	bucketMetadataExportVersion = 1
+)
+
+// encryptBucketTargets - returns the remote targets of a bucket for export.
+// They hold the credentials of the remote sites, so they are encrypted with
+// the secret key of the exporting user, like the other admin APIs returning
+// credentials.
+func encryptBucketTargets(targets *madmin.BucketTargets, secretKey string) ([]byte, error) {
+	data, err := json.Marshal(targets)
+	if err != nil {
+		return nil, err
+	}
+	return madmin.EncryptData(secretKey, data)
+}
+
+// decryptBucketTargets - returns the remote targets exported in the given
+// export version, decrypting them with the secret key of the importing user.
+func decryptBucketTargets(data []byte, version int, secretKey string) (targets madmin.BucketTargets, err error) {
+	// Remote targets were exported as plain XML before version 1.
+	if version == 0 {
+		err = xml.Unmarshal(data, &targets)
+		return targets, err
+	}
+	data, err = madmin.DecryptData(secretKey, bytes.NewReader(data))
+	if err != nil {
+		return targets, err
+	}
+	err = json.Unmarshal(data, &targets)
+	return targets, err
+}
+
+// Import modes of bucket metadata.
+const (
+	// Overwrite the configuration of existing buckets.
+	bucketMetaImportOverwrite = "overwrite"
+	// Leave the existing configuration of existing buckets unchanged.
+	bucketMetaImportSkip = "skip"
+)
+
+// selectedBuckets - returns the buckets in the comma separated list of the
+// bucket parameter, if any.
+func selectedBuckets(r *http.Request) (buckets []string) {
+	for _, bucket := range strings.Split(r.Form.Get("bucket"), ",") {
+		if bucket = pathClean(strings.TrimSpace(bucket)); bucket != "" {
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets
+}
+
+// ExportBucketMetadataHandler - exports the metadata of all buckets, or of
+// the comma separated list of buckets, as a zipped file. Remote targets are
+// exported with their credentials, encrypted with the secret key of the
+// requesting user; they are imported by a user with the same secret key.
 func (a adminAPIHandlers) ExportBucketMetadataHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ExportBucketMetadata")
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	bucket := pathClean(r.Form.Get("bucket"))
 	// Get current object layer instance.
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ExportBucketMetadataAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ExportBucketMetadataAction)
 	if objectAPI == nil {
 		return
 	}
@@ -370,13 +676,15 @@ func (a adminAPIHandlers) ExportBucketMetadataHandler(w http.ResponseWriter, r *
 		buckets []BucketInfo
 		err     error
 	)
-	if bucket != "" {
-		// Check if bucket exists.
-		if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
-			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
-			return
+	if selected := selectedBuckets(r); len(selected) > 0 {
+		for _, bucket := range selected {
+			// Check if bucket exists.
+			if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+				writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+				return
+			}
+			buckets = append(buckets, BucketInfo{Name: bucket})
 		}
-		buckets = append(buckets, BucketInfo{Name: bucket})
 	} else {
 		buckets, err = objectAPI.ListBuckets(ctx, BucketOptions{})
 		if err != nil {
@@ -414,6 +722,23 @@ func (a adminAPIHandlers) ExportBucketMetadataHandler(w http.ResponseWriter, r *
 		return nil
 	}
 
+	info := bucketMetadataExportInfo{
+		Version:    bucketMetadataExportVersion,
+		ExportedAt: UTCNow(),
+	}
+	for _, bi := range buckets {
+		info.Buckets = append(info.Buckets, bi.Name)
+	}
+	infoData, err := json.Marshal(info)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if err = rawDataFn(bytes.NewReader(infoData), bucketMetadataExportFile, len(infoData)); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
 	cfgFiles := []string{
 		bucketPolicyConfig,
 		bucketNotificationConfig,
@@ -431,6 +756,24 @@ func (a adminAPIHandlers) ExportBucketMetadataHandler(w http.ResponseWriter, r *
 			cfgPath := pathJoin(bi.Name, cfgFile)
 			bucket := bi.Name
 			switch cfgFile {
+			case bucketPolicyConfig:
+				config, _, err := globalBucketMetadataSys.GetPolicyConfig(bucket)
+				if err != nil {
+					if errors.Is(err, BucketPolicyNotFound{Bucket: bucket}) {
+						continue
+					}
+					writeErrorResponse(ctx, w, exportError(ctx, err, cfgFile, bucket), r.URL)
+					return
+				}
+				configData, err := json.Marshal(config)
+				if err != nil {
+					writeErrorResponse(ctx, w, exportError(ctx, err, cfgFile, bucket), r.URL)
+					return
+				}
+				if err = rawDataFn(bytes.NewReader(configData), cfgPath, len(configData)); err != nil {
+					writeErrorResponse(ctx, w, exportError(ctx, err, cfgFile, bucket), r.URL)
+					return
+				}
 			case bucketNotificationConfig:
 				config, err := globalBucketMetadataSys.GetNotificationConfig(bucket)
 				if err != nil {
@@ -587,7 +930,10 @@ func (a adminAPIHandlers) ExportBucketMetadataHandler(w http.ResponseWriter, r *
 					writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 					return
 				}
-				configData, err := xml.Marshal(config)
+				if config.Empty() {
+					continue
+				}
+				configData, err := encryptBucketTargets(config, cred.SecretKey)
 				if err != nil {
 					writeErrorResponse(ctx, w, exportError(ctx, err, cfgFile, bucket), r.URL)
 					return
@@ -601,8 +947,34 @@ func (a adminAPIHandlers) ExportBucketMetadataHandler(w http.ResponseWriter, r *
 	}
 }
 
+// bucketMetaImportStatus - the import status of the metadata of a bucket, it
+// extends madmin.BucketStatus with the configurations it does not report.
+type bucketMetaImportStatus struct {
+	madmin.BucketStatus
+	Replication   madmin.MetaStatus `json:"replication"`
+	RemoteTargets madmin.MetaStatus `json:"remoteTargets"`
+
+	// Created is set when the bucket was created by the import.
+	Created bool `json:"created,omitempty"`
+
+	// Skipped lists the configurations left unchanged in skip mode.
+	Skipped []string `json:"skipped,omitempty"`
+}
+
 type importMetaReport struct {
-	madmin.BucketMetaImportErrs
+	Buckets map[string]bucketMetaImportStatus `json:"buckets,omitempty"`
+}
+
+func (i *importMetaReport) SetCreated(bucket string) {
+	st := i.Buckets[bucket]
+	st.Created = true
+	i.Buckets[bucket] = st
+}
+
+func (i *importMetaReport) SetSkipped(bucket, fname string) {
+	st := i.Buckets[bucket]
+	st.Skipped = append(st.Skipped, fname)
+	i.Buckets[bucket] = st
 }
 
 func (i *importMetaReport) SetStatus(bucket, fname string, err error) {
@@ -628,16 +1000,80 @@ func (i *importMetaReport) SetStatus(bucket, fname string, err error) {
 		st.ObjectLock = madmin.MetaStatus{IsSet: true, Err: errMsg}
 	case bucketVersioningConfig:
 		st.Versioning = madmin.MetaStatus{IsSet: true, Err: errMsg}
+	case bucketReplicationConfig:
+		st.Replication = madmin.MetaStatus{IsSet: true, Err: errMsg}
+	case bucketTargetsFile:
+		st.RemoteTargets = madmin.MetaStatus{IsSet: true, Err: errMsg}
 	default:
 		st.Err = errMsg
 	}
 	i.Buckets[bucket] = st
 }
 
-// ImportBucketMetadataHandler - imports all bucket metadata from a zipped file and overwrite bucket metadata config
+// bucketConfigExists - returns true if the bucket has the configuration.
+func bucketConfigExists(meta BucketMetadata, fname string) bool {
+	switch fname {
+	case bucketPolicyConfig:
+		return len(meta.PolicyConfigJSON) > 0
+	case bucketNotificationConfig:
+		return len(meta.NotificationConfigXML) > 0
+	case bucketLifecycleConfig:
+		return len(meta.LifecycleConfigXML) > 0
+	case bucketSSEConfig:
+		return len(meta.EncryptionConfigXML) > 0
+	case bucketTaggingConfig:
+		return len(meta.TaggingConfigXML) > 0
+	case bucketQuotaConfigFile:
+		return len(meta.QuotaConfigJSON) > 0
+	case objectLockConfig:
+		return len(meta.ObjectLockConfigXML) > 0
+	case bucketVersioningConfig:
+		return len(meta.VersioningConfigXML) > 0
+	case bucketReplicationConfig:
+		return len(meta.ReplicationConfigXML) > 0
+	case bucketTargetsFile:
+		return len(meta.BucketTargetsConfigJSON) > 0
+	}
+	return false
+}
+
+// importBucketTargets - sets the remote targets of a bucket. The ARNs of the
+// targets are kept, the imported replication configuration refers to them.
+func importBucketTargets(ctx context.Context, bucket string, targets madmin.BucketTargets) error {
+	for _, target := range targets.Targets {
+		target := target
+		sameTarget, _ := isLocalHost(target.URL().Hostname(), target.URL().Port(), globalMinioPort)
+		if sameTarget && bucket == target.TargetBucket {
+			return fmt.Errorf("%s", errorCodes[ErrBucketRemoteIdenticalToSource].Description)
+		}
+		target.SourceBucket = bucket
+		if target.Arn == "" {
+			target.Arn = globalBucketTargetSys.getRemoteARN(bucket, &target)
+		}
+		update := !globalBucketTargetSys.GetRemoteBucketTargetByArn(ctx, bucket, target.Arn).Empty()
+		if err := globalBucketTargetSys.SetTarget(ctx, bucket, &target, update); err != nil {
+			return err
+		}
+	}
+	targetsCfg, err := globalBucketTargetSys.ListBucketTargets(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	tgtBytes, err := json.Marshal(targetsCfg)
+	if err != nil {
+		return err
+	}
+	_, err = globalBucketMetadataSys.Update(ctx, bucket, bucketTargetsFile, tgtBytes)
+	return err
+}
+
+// ImportBucketMetadataHandler - imports bucket metadata from a zipped file, for all buckets in the file or
+// the comma separated list of buckets. The mode is either "overwrite" (default), which overwrites the
+// configuration of existing buckets, or "skip", which leaves their existing configuration unchanged.
 // There are some caveats regarding the following:
 // 1. object lock config - object lock should have been specified at time of bucket creation. Only default retention settings are imported here.
-// 2. Replication config - is omitted from import as remote target credentials are not available from exported data for security reasons.
+// 2. Replication config - remote targets are imported first, their remote buckets must be reachable and versioned.
+// The remote targets are decrypted with the secret key of the importing user, which must match the exporting user.
 // 3. lifecycle config - if transition rules are present, tier name needs to have been defined.
 func (a adminAPIHandlers) ImportBucketMetadataHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ImportBucketMetadata")
@@ -645,10 +1081,25 @@ func (a adminAPIHandlers) ImportBucketMetadataHandler(w http.ResponseWriter, r *
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
 	// Get current object layer instance.
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ImportBucketMetadataAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ImportBucketMetadataAction)
 	if objectAPI == nil {
 		return
 	}
+
+	mode := r.Form.Get("mode")
+	switch mode {
+	case "":
+		mode = bucketMetaImportOverwrite
+	case bucketMetaImportOverwrite, bucketMetaImportSkip:
+	default:
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, fmt.Errorf("unknown import mode %s", mode)), r.URL)
+		return
+	}
+	selected := make(map[string]struct{})
+	for _, bucket := range selectedBuckets(r) {
+		selected[bucket] = struct{}{}
+	}
+
 	data, err := io.ReadAll(r.Body)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
@@ -660,28 +1111,96 @@ func (a adminAPIHandlers) ImportBucketMetadataHandler(w http.ResponseWriter, r *
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
 		return
 	}
-	bucketMap := make(map[string]struct{}, 1)
-	rpt := importMetaReport{
-		madmin.BucketMetaImportErrs{
-			Buckets: make(map[string]madmin.BucketStatus, len(zr.File)),
-		},
-	}
-	// import object lock config if any - order of import matters here.
+
+	// Files exported before the export info was added are version 0.
+	var info bucketMetadataExportInfo
 	for _, file := range zr.File {
-		slc := strings.Split(file.Name, slashSeparator)
-		if len(slc) != 2 { // expecting bucket/configfile in the zipfile
-			rpt.SetStatus(file.Name, "", fmt.Errorf("malformed zip - expecting format bucket/<config.json>"))
+		if file.Name != bucketMetadataExportFile {
 			continue
 		}
-		bucket, fileName := slc[0], slc[1]
-		switch fileName {
-		case objectLockConfig:
-			reader, err := file.Open()
-			if err != nil {
-				rpt.SetStatus(bucket, fileName, err)
-				continue
-			}
-			config, err := objectlock.ParseObjectLockConfig(reader)
+		reader, err := file.Open()
+		if err == nil {
+			err = json.NewDecoder(reader).Decode(&info)
+			reader.Close()
+		}
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+			return
+		}
+	}
+	if info.Version > bucketMetadataExportVersion {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest,
+			fmt.Errorf("unsupported bucket metadata export version %d", info.Version)), r.URL)
+		return
+	}
+
+	rpt := importMetaReport{
+		Buckets: make(map[string]bucketMetaImportStatus, len(zr.File)),
+	}
+
+	// bucketFile - returns the bucket and the configuration of a file,
+	// ok is false for files that are not imported.
+	bucketFile := func(file *zip.File) (bucket, fileName string, ok bool) {
+		if file.Name == bucketMetadataExportFile {
+			return "", "", false
+		}
+		slc := strings.Split(file.Name, slashSeparator)
+		if len(slc) != 2 { // expecting bucket/configfile in the zipfile
+			rpt.SetStatus(file.Name, "", fmt.Errorf("malformed zip - expecting format bucket/<config.json>"))
+			return "", "", false
+		}
+		if _, ok := selected[slc[0]]; len(selected) > 0 && !ok {
+			return "", "", false
+		}
+		return slc[0], slc[1], true
+	}
+
+	// Buckets are created once, with the options of the first
+	// configuration imported.
+	bucketMap := make(map[string]struct{}, 1)
+	makeBucket := func(bucket string, opts MakeBucketOptions) error {
+		if _, ok := bucketMap[bucket]; ok {
+			return nil
+		}
+		if err := objectAPI.MakeBucketWithLocation(ctx, bucket, opts); err != nil {
+			if _, ok := err.(BucketExists); !ok {
+				return err
+			}
+		} else {
+			rpt.SetCreated(bucket)
+		}
+		bucketMap[bucket] = struct{}{}
+		return nil
+	}
+
+	// skip - returns true if the configuration of an existing bucket is
+	// left unchanged.
+	skip := func(bucket, fileName string) bool {
+		if mode != bucketMetaImportSkip || rpt.Buckets[bucket].Created {
+			return false
+		}
+		meta, err := globalBucketMetadataSys.GetConfig(ctx, bucket)
+		if err != nil || !bucketConfigExists(meta, fileName) {
+			return false
+		}
+		rpt.SetSkipped(bucket, fileName)
+		return true
+	}
+
+	// import object lock config if any - order of import matters here.
+	for _, file := range zr.File {
+		bucket, fileName, ok := bucketFile(file)
+		if !ok {
+			continue
+		}
+		switch fileName {
+		case objectLockConfig:
+			reader, err := file.Open()
+			if err != nil {
+				rpt.SetStatus(bucket, fileName, err)
+				continue
+			}
+			config, err := objectlock.ParseObjectLockConfig(reader)
 			if err != nil {
 				rpt.SetStatus(bucket, fileName, fmt.Errorf("%s (%s)", errorCodes[ErrMalformedXML].Description, err))
 				continue
@@ -692,18 +1211,15 @@ func (a adminAPIHandlers) ImportBucketMetadataHandler(w http.ResponseWriter, r *
 				rpt.SetStatus(bucket, fileName, err)
 				continue
 			}
-			if _, ok := bucketMap[bucket]; !ok {
-				opts := MakeBucketOptions{
-					LockEnabled: config.ObjectLockEnabled == "Enabled",
-				}
-				err = objectAPI.MakeBucketWithLocation(ctx, bucket, opts)
-				if err != nil {
-					if _, ok := err.(BucketExists); !ok {
-						rpt.SetStatus(bucket, fileName, err)
-						continue
-					}
-				}
-				bucketMap[bucket] = struct{}{}
+			opts := MakeBucketOptions{
+				LockEnabled: config.ObjectLockEnabled == "Enabled",
+			}
+			if err = makeBucket(bucket, opts); err != nil {
+				rpt.SetStatus(bucket, fileName, err)
+				continue
+			}
+			if skip(bucket, fileName) {
+				continue
 			}
 
 			// Deny object locking configuration settings on existing buckets without object lock enabled.
@@ -738,12 +1254,10 @@ func (a adminAPIHandlers) ImportBucketMetadataHandler(w http.ResponseWriter, r *
 
 	// import versioning metadata
 	for _, file := range zr.File {
-		slc := strings.Split(file.Name, slashSeparator)
-		if len(slc) != 2 { // expecting bucket/configfile in the zipfile
-			rpt.SetStatus(file.Name, "", fmt.Errorf("malformed zip - expecting format bucket/<config.json>"))
+		bucket, fileName, ok := bucketFile(file)
+		if !ok {
 			continue
 		}
-		bucket, fileName := slc[0], slc[1]
 		switch fileName {
 		case bucketVersioningConfig:
 			reader, err := file.Open()
@@ -756,14 +1270,12 @@ func (a adminAPIHandlers) ImportBucketMetadataHandler(w http.ResponseWriter, r *
 				rpt.SetStatus(bucket, fileName, err)
 				continue
 			}
-			if _, ok := bucketMap[bucket]; !ok {
-				if err = objectAPI.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
-					if _, ok := err.(BucketExists); !ok {
-						rpt.SetStatus(bucket, fileName, err)
-						continue
-					}
-				}
-				bucketMap[bucket] = struct{}{}
+			if err = makeBucket(bucket, MakeBucketOptions{}); err != nil {
+				rpt.SetStatus(bucket, fileName, err)
+				continue
+			}
+			if skip(bucket, fileName) {
+				continue
 			}
 
 			if globalSiteReplicationSys.isEnabled() && v.Suspended() {
@@ -794,33 +1306,65 @@ func (a adminAPIHandlers) ImportBucketMetadataHandler(w http.ResponseWriter, r *
 		}
 	}
 
+	// import remote targets, the replication configuration refers to them.
 	for _, file := range zr.File {
+		bucket, fileName, ok := bucketFile(file)
+		if !ok || fileName != bucketTargetsFile {
+			continue
+		}
+		if err = makeBucket(bucket, MakeBucketOptions{}); err != nil {
+			rpt.SetStatus(bucket, fileName, err)
+			continue
+		}
+		if skip(bucket, fileName) {
+			continue
+		}
 		reader, err := file.Open()
 		if err != nil {
-			rpt.SetStatus(file.Name, "", err)
+			rpt.SetStatus(bucket, fileName, err)
 			continue
 		}
-		sz := file.FileInfo().Size()
-		slc := strings.Split(file.Name, slashSeparator)
-		if len(slc) != 2 { // expecting bucket/configfile in the zipfile
-			rpt.SetStatus(file.Name, "", fmt.Errorf("malformed zip - expecting format bucket/<config.json>"))
+		tgtData, err := io.ReadAll(reader)
+		if err != nil {
+			rpt.SetStatus(bucket, fileName, err)
+			continue
+		}
+		targets, err := decryptBucketTargets(tgtData, info.Version, cred.SecretKey)
+		if err != nil {
+			rpt.SetStatus(bucket, fileName, err)
+			continue
+		}
+		if err = importBucketTargets(ctx, bucket, targets); err != nil {
+			rpt.SetStatus(bucket, fileName, err)
+			continue
+		}
+		rpt.SetStatus(bucket, fileName, nil)
+	}
+
+	for _, file := range zr.File {
+		bucket, fileName, ok := bucketFile(file)
+		if !ok {
 			continue
 		}
-		bucket, fileName := slc[0], slc[1]
 		// create bucket if it does not exist yet.
-		if _, ok := bucketMap[bucket]; !ok {
-			err = objectAPI.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{})
-			if err != nil {
-				if _, ok := err.(BucketExists); !ok {
-					rpt.SetStatus(bucket, "", err)
-					continue
-				}
-			}
-			bucketMap[bucket] = struct{}{}
+		if err = makeBucket(bucket, MakeBucketOptions{}); err != nil {
+			rpt.SetStatus(bucket, "", err)
+			continue
 		}
-		if _, ok := bucketMap[bucket]; !ok {
+		switch fileName {
+		case objectLockConfig, bucketVersioningConfig, bucketTargetsFile:
+			// imported above.
+			continue
+		}
+		if skip(bucket, fileName) {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			rpt.SetStatus(bucket, fileName, err)
 			continue
 		}
+		sz := file.FileInfo().Size()
 		switch fileName {
 		case bucketNotificationConfig:
 			config, err := event.ParseConfig(io.LimitReader(reader, sz), globalSite.Region, globalEventNotifier.targetList)
@@ -1049,10 +1593,44 @@ func (a adminAPIHandlers) ImportBucketMetadataHandler(w http.ResponseWriter, r *
 				rpt.SetStatus(bucket, fileName, err)
 				continue
 			}
+		case bucketReplicationConfig:
+			if globalSiteReplicationSys.isEnabled() && cred.AccessKey != globalActiveCred.AccessKey {
+				rpt.SetStatus(bucket, fileName, fmt.Errorf("%s", errorCodes[ErrReplicationDenyEditError].Description))
+				continue
+			}
+			if !globalBucketVersioningSys.Enabled(bucket) {
+				rpt.SetStatus(bucket, fileName, fmt.Errorf("%s", errorCodes[ErrReplicationNeedsVersioningError].Description))
+				continue
+			}
+			replicationConfig, err := replication.ParseConfig(io.LimitReader(reader, sz))
+			if err != nil {
+				rpt.SetStatus(bucket, fileName, fmt.Errorf("%s (%s)", errorCodes[ErrMalformedXML].Description, err))
+				continue
+			}
+			sameTarget, apiErr := validateReplicationDestination(ctx, bucket, replicationConfig, true)
+			if apiErr != noError {
+				rpt.SetStatus(bucket, fileName, fmt.Errorf("%s", apiErr.Description))
+				continue
+			}
+			if err = replicationConfig.Validate(bucket, sameTarget); err != nil {
+				rpt.SetStatus(bucket, fileName, err)
+				continue
+			}
+
+			configData, err := xml.Marshal(replicationConfig)
+			if err != nil {
+				rpt.SetStatus(bucket, fileName, err)
+				continue
+			}
+			if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketReplicationConfig, configData); err != nil {
+				rpt.SetStatus(bucket, fileName, err)
+				continue
+			}
+			rpt.SetStatus(bucket, fileName, nil)
 		}
 	}
 
-	rptData, err := json.Marshal(rpt.BucketMetaImportErrs)
+	rptData, err := json.Marshal(rpt)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
@@ -1124,3 +1702,314 @@ func (a adminAPIHandlers) ReplicationDiffHandler(w http.ResponseWriter, r *http.
 		}
 	}
 }
+
+// replicationDLQTargetArns returns the replication targets of the bucket
+// selected by the arn query parameter, all targets if not set.
+func replicationDLQTargetArns(ctx context.Context, objectAPI ObjectLayer, bucket, arn string) ([]string, APIError) {
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		return nil, toAPIError(ctx, err)
+	}
+	if arn != "" {
+		tgt := globalBucketTargetSys.GetRemoteBucketTargetByArn(ctx, bucket, arn)
+		if tgt.Empty() {
+			return nil, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, fmt.Errorf("invalid arn : '%s'", arn))
+		}
+		return []string{arn}, noError
+	}
+	var arns []string
+	for _, tgt := range globalBucketTargetSys.ListTargets(ctx, bucket, string(madmin.ReplicationService)) {
+		arns = append(arns, tgt.Arn)
+	}
+	return arns, noError
+}
+
+// ReplicationDLQListHandler - GET /minio/admin/v3/replication/dlq?bucket={bucket}&arn={arn}
+// ----------
+// Lists the object versions which failed to replicate with permanent errors,
+// filtered on the prefix, object, versionId and error query parameters.
+func (a adminAPIHandlers) ReplicationDLQListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationDLQList")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := mux.Vars(r)["bucket"]
+	arns, apiErr := replicationDLQTargetArns(ctx, objectAPI, bucket, r.Form.Get("arn"))
+	if apiErr != noError {
+		writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+		return
+	}
+	// Failures are recorded by all the nodes.
+	flushClusterReplicationRecords(ctx, objectAPI)
+	filter := extractReplicationDLQFilter(r.Form)
+	entries := []ReplicationDLQEntry{}
+	for _, arn := range arns {
+		tgtEntries, err := globalReplicationDLQ.List(ctx, objectAPI, bucket, arn, filter)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		entries = append(entries, tgtEntries...)
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// ReplicationDLQRetryHandler - POST /minio/admin/v3/replication/dlq/retry?bucket={bucket}&arn={arn}
+// ----------
+// Queues the replication of the dead-letter entries matching the filter
+// query parameters of ReplicationDLQListHandler.
+func (a adminAPIHandlers) ReplicationDLQRetryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationDLQRetry")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := mux.Vars(r)["bucket"]
+	arns, apiErr := replicationDLQTargetArns(ctx, objectAPI, bucket, r.Form.Get("arn"))
+	if apiErr != noError {
+		writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+		return
+	}
+	// Failures are recorded by all the nodes.
+	flushClusterReplicationRecords(ctx, objectAPI)
+	filter := extractReplicationDLQFilter(r.Form)
+	var res replicationDLQResult
+	for _, arn := range arns {
+		n, err := globalReplicationDLQ.Retry(ctx, objectAPI, bucket, arn, filter)
+		res.Count += n
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// ReplicationDLQPurgeHandler - DELETE /minio/admin/v3/replication/dlq?bucket={bucket}&arn={arn}
+// ----------
+// Removes the dead-letter entries matching the filter query parameters of
+// ReplicationDLQListHandler.
+func (a adminAPIHandlers) ReplicationDLQPurgeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationDLQPurge")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := mux.Vars(r)["bucket"]
+	arns, apiErr := replicationDLQTargetArns(ctx, objectAPI, bucket, r.Form.Get("arn"))
+	if apiErr != noError {
+		writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+		return
+	}
+	// Failures are recorded by all the nodes.
+	flushClusterReplicationRecords(ctx, objectAPI)
+	filter := extractReplicationDLQFilter(r.Form)
+	var res replicationDLQResult
+	for _, arn := range arns {
+		n, err := globalReplicationDLQ.Purge(ctx, objectAPI, bucket, arn, filter)
+		res.Count += n
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// ReplicationConflictsListHandler - GET /minio/admin/v3/replication/conflicts?bucket={bucket}
+// ----------
+// Lists the replication conflicts of the bucket recorded at this site,
+// filtered on the prefix, object and kind query parameters.
+func (a adminAPIHandlers) ReplicationConflictsListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationConflictsList")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := mux.Vars(r)["bucket"]
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	// Conflicts are recorded by all the nodes.
+	flushClusterReplicationRecords(ctx, objectAPI)
+	conflicts, err := globalReplicationConflicts.List(ctx, objectAPI, bucket, extractReplicationConflictFilter(r.Form))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	data, err := json.Marshal(conflicts)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// ReplicationConflictsClearHandler - DELETE /minio/admin/v3/replication/conflicts?bucket={bucket}
+// ----------
+// Removes the replication conflicts matching the filter query parameters of
+// ReplicationConflictsListHandler.
+func (a adminAPIHandlers) ReplicationConflictsClearHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationConflictsClear")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := mux.Vars(r)["bucket"]
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	flushClusterReplicationRecords(ctx, objectAPI)
+	n, err := globalReplicationConflicts.Clear(ctx, objectAPI, bucket, extractReplicationConflictFilter(r.Form))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	data, err := json.Marshal(replicationConflictResult{Count: n})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// PutReplicationConflictPolicyHandler - PUT /minio/admin/v3/replication/conflict-policy?bucket={bucket}
+// ----------
+// Places a replication conflict policy on the specified bucket, an empty
+// policy removes it. The preferred site of the prefer-site policy is
+// identified by its deployment ID, or by its name with site replication.
+func (a adminAPIHandlers) PutReplicationConflictPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutReplicationConflictPolicy")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := mux.Vars(r)["bucket"]
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	policy, err := parseBucketReplicationConflictPolicy(bucket, data)
+	if err == nil && policy.Policy != "" {
+		if info, serr := globalSiteReplicationSys.GetClusterInfo(ctx); serr == nil && info.Enabled {
+			for _, site := range info.Sites {
+				if site.Name == policy.Site {
+					policy.Site = site.DeploymentID
+				}
+				for i := range policy.Sites {
+					if site.Name == policy.Sites[i] {
+						policy.Sites[i] = site.DeploymentID
+					}
+				}
+			}
+		}
+		err = policy.Validate()
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, AdminError{
+			Code:       "XMinioAdminInvalidReplicationConflictPolicy",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		}), r.URL)
+		return
+	}
+
+	if policy.Policy == "" {
+		_, err = globalBucketMetadataSys.Delete(ctx, bucket, bucketReplicationConflictPolicyFile)
+	} else {
+		if data, err = json.Marshal(policy); err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		_, err = globalBucketMetadataSys.Update(ctx, bucket, bucketReplicationConflictPolicyFile, data)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetReplicationConflictPolicyHandler - GET /minio/admin/v3/replication/conflict-policy?bucket={bucket}
+func (a adminAPIHandlers) GetReplicationConflictPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetReplicationConflictPolicy")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := mux.Vars(r)["bucket"]
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	policy, err := globalBucketMetadataSys.GetReplicationConflictPolicy(bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"testing"
+
+	"github.com/klauspost/compress/zip"
+	"github.com/minio/madmin-go"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+func TestBucketMetadataExportImportSuite(t *testing.T) {
+	if runtime.GOOS == globalWindowsOSName {
+		t.Skip("windows is clunky disable these tests")
+	}
+	for i, testCase := range iamTestSuites {
+		t.Run(
+			fmt.Sprintf("Test: %d, ServerType: %s", i+1, testCase.ServerTypeDescription),
+			func(t *testing.T) {
+				suite := testCase
+				c := &check{t, testCase.serverType}
+
+				suite.SetUpSuite(c)
+				suite.TestBucketMetadataExportImport(c)
+				suite.TearDownSuite(c)
+			},
+		)
+	}
+}
+
+func (s *TestSuiteIAM) importBucketMetadata(ctx context.Context, c *check, data []byte, mode string) (importMetaReport, int) {
+	resp, err := s.adm.ExecuteMethod(ctx, http.MethodPut, madmin.RequestData{
+		RelPath:     "/v3/import-bucket-metadata",
+		QueryValues: url.Values{"mode": []string{mode}},
+		Content:     data,
+	})
+	if err != nil {
+		c.Fatalf("Unable to import bucket metadata: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var rpt importMetaReport
+	if resp.StatusCode == http.StatusOK {
+		if err = json.NewDecoder(resp.Body).Decode(&rpt); err != nil {
+			c.Fatalf("Unable to decode import report: %v", err)
+		}
+	}
+	return rpt, resp.StatusCode
+}
+
+func (s *TestSuiteIAM) TestBucketMetadataExportImport(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	bucket := getRandomBucketName()
+	err := s.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{})
+	if err != nil {
+		c.Fatalf("bucket create error: %v", err)
+	}
+	err = s.client.EnableVersioning(ctx, bucket)
+	if err != nil {
+		c.Fatalf("Unable to enable versioning: %v", err)
+	}
+	err = s.client.SetBucketPolicy(ctx, bucket, fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Principal": {"AWS": ["*"]},
+   "Action": ["s3:GetObject"],
+   "Resource": ["arn:aws:s3:::%s/*"]
+  }
+ ]
+}`, bucket))
+	if err != nil {
+		c.Fatalf("Unable to set bucket policy: %v", err)
+	}
+	bucketTags, err := tags.NewTags(map[string]string{"team": "storage"}, false)
+	if err != nil {
+		c.Fatal(err)
+	}
+	err = s.client.SetBucketTagging(ctx, bucket, bucketTags)
+	if err != nil {
+		c.Fatalf("Unable to set bucket tags: %v", err)
+	}
+
+	lockedBucket := getRandomBucketName()
+	err = s.client.MakeBucket(ctx, lockedBucket, minio.MakeBucketOptions{ObjectLocking: true})
+	if err != nil {
+		c.Fatalf("bucket create error: %v", err)
+	}
+
+	// 1. Export the metadata of the selected buckets.
+	rd, err := s.adm.ExportBucketMetadata(ctx, bucket+","+lockedBucket)
+	if err != nil {
+		c.Fatalf("Unable to export bucket metadata: %v", err)
+	}
+	data, err := io.ReadAll(rd)
+	rd.Close()
+	if err != nil {
+		c.Fatalf("Unable to read bucket metadata: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		c.Fatalf("Unable to read exported zip: %v", err)
+	}
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	for _, name := range []string{
+		bucketMetadataExportFile,
+		pathJoin(bucket, bucketPolicyConfig),
+		pathJoin(bucket, bucketVersioningConfig),
+		pathJoin(bucket, bucketTaggingConfig),
+		pathJoin(lockedBucket, objectLockConfig),
+	} {
+		if _, ok := files[name]; !ok {
+			c.Fatalf("%s was not exported", name)
+		}
+	}
+	f, err := files[bucketMetadataExportFile].Open()
+	if err != nil {
+		c.Fatal(err)
+	}
+	var info bucketMetadataExportInfo
+	err = json.NewDecoder(f).Decode(&info)
+	f.Close()
+	if err != nil || info.Version != bucketMetadataExportVersion || len(info.Buckets) != 2 {
+		c.Fatalf("unexpected export info %#v: %v", info, err)
+	}
+
+	// 2. Import recreates the buckets with their metadata.
+	for _, b := range []string{bucket, lockedBucket} {
+		if err = s.client.RemoveBucket(ctx, b); err != nil {
+			c.Fatalf("Unable to remove bucket: %v", err)
+		}
+	}
+	rpt, status := s.importBucketMetadata(ctx, c, data, bucketMetaImportOverwrite)
+	if status != http.StatusOK {
+		c.Fatalf("Unexpected import status %d", status)
+	}
+	st := rpt.Buckets[bucket]
+	if !st.Created || st.Err != "" {
+		c.Fatalf("bucket was not created: %#v", st)
+	}
+	for name, ms := range map[string]madmin.MetaStatus{
+		bucketPolicyConfig:     st.Policy,
+		bucketVersioningConfig: st.Versioning,
+		bucketTaggingConfig:    st.Tagging,
+	} {
+		if !ms.IsSet || ms.Err != "" {
+			c.Fatalf("%s was not imported: %#v", name, ms)
+		}
+	}
+	if st = rpt.Buckets[lockedBucket]; !st.Created || !st.ObjectLock.IsSet || st.ObjectLock.Err != "" {
+		c.Fatalf("object lock was not imported: %#v", st)
+	}
+	if rcfg, _ := globalBucketObjectLockSys.Get(lockedBucket); !rcfg.LockEnabled {
+		c.Fatalf("bucket was created without object lock")
+	}
+	if !globalBucketVersioningSys.Enabled(bucket) {
+		c.Fatalf("versioning was not imported")
+	}
+
+	// 3. In skip mode, only the missing configurations are imported.
+	if err = s.client.RemoveBucketTagging(ctx, bucket); err != nil {
+		c.Fatalf("Unable to remove bucket tags: %v", err)
+	}
+	rpt, status = s.importBucketMetadata(ctx, c, data, bucketMetaImportSkip)
+	if status != http.StatusOK {
+		c.Fatalf("Unexpected import status %d", status)
+	}
+	st = rpt.Buckets[bucket]
+	if st.Created || st.Policy.IsSet || st.Versioning.IsSet || !st.Tagging.IsSet {
+		c.Fatalf("unexpected import status in skip mode: %#v", st)
+	}
+	skipped := set.CreateStringSet(st.Skipped...)
+	if !skipped.Contains(bucketPolicyConfig) || !skipped.Contains(bucketVersioningConfig) || skipped.Contains(bucketTaggingConfig) {
+		c.Fatalf("unexpected configurations skipped: %v", st.Skipped)
+	}
+	if _, err = s.client.GetBucketTagging(ctx, bucket); err != nil {
+		c.Fatalf("bucket tags were not imported: %v", err)
+	}
+
+	// 4. Unknown modes are rejected.
+	if _, status = s.importBucketMetadata(ctx, c, data, "merge"); status != http.StatusBadRequest {
+		c.Fatalf("Unexpected import status %d for an unknown mode", status)
+	}
+}
+
+func TestBucketTargetsExportEncryption(t *testing.T) {
+	targets := &madmin.BucketTargets{Targets: []madmin.BucketTarget{{
+		SourceBucket: "bucket",
+		Endpoint:     "remote:9000",
+		TargetBucket: "target",
+		Credentials:  &madmin.Credentials{AccessKey: "remote-access", SecretKey: "remote-secret"},
+		Arn:          "arn:minio:replication::id:target",
+		Type:         madmin.ReplicationService,
+	}}}
+
+	data, err := encryptBucketTargets(targets, "admin-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("remote-secret")) {
+		t.Fatal("remote target credentials were exported in plain text")
+	}
+
+	got, err := decryptBucketTargets(data, bucketMetadataExportVersion, "admin-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Targets) != 1 || got.Targets[0].Credentials == nil || got.Targets[0].Credentials.SecretKey != "remote-secret" {
+		t.Fatalf("unexpected remote targets %#v", got)
+	}
+
+	// Another secret key cannot decrypt the remote targets.
+	if _, err = decryptBucketTargets(data, bucketMetadataExportVersion, "other-secret"); err == nil {
+		t.Fatal("remote targets were decrypted with another secret key")
+	}
+}
@@ -110,9 +110,21 @@ func toAdminAPIErr(ctx context.Context, err error) APIError {
 			Description:    e.Err,
 			HTTPStatusCode: http.StatusBadRequest,
 		}
+	case BucketPoolAffinityNotFound:
+		apiErr = APIError{
+			Code:           "XMinioAdminNoSuchPoolAffinity",
+			Description:    e.Error(),
+			HTTPStatusCode: http.StatusNotFound,
+		}
+	case BucketReplicationConflictPolicyNotFound:
+		apiErr = APIError{
+			Code:           "XMinioAdminNoSuchReplicationConflictPolicy",
+			Description:    e.Error(),
+			HTTPStatusCode: http.StatusNotFound,
+		}
 	default:
 		switch {
-		case errors.Is(err, errTooManyPolicies):
+		case errors.Is(err, errTooManyPolicies), errors.Is(err, errInvalidSimulationRequest):
 			apiErr = APIError{
 				Code:           "XMinioAdminInvalidRequest",
 				Description:    err.Error(),
@@ -142,6 +154,18 @@ func toAdminAPIErr(ctx context.Context, err error) APIError {
 				Description:    err.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case errors.Is(err, errParityUpgradeAlreadyRunning), errors.Is(err, errParityUpgradeNotAllowed):
+			apiErr = APIError{
+				Code:           "XMinioParityUpgradeNotAllowed",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case errors.Is(err, errParityUpgradeNotStarted):
+			apiErr = APIError{
+				Code:           "XMinioParityUpgradeNotStarted",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusNotFound,
+			}
 		case errors.Is(err, errConfigNotFound):
 			apiErr = APIError{
 				Code:           "XMinioConfigError",
@@ -166,6 +190,12 @@ func toAdminAPIErr(ctx context.Context, err error) APIError {
 				Description:    err.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case errors.Is(err, errIAMServiceAccountInvalidExpiry), errors.Is(err, errIAMServiceAccountInvalidAllowedIPs):
+			apiErr = APIError{
+				Code:           "XMinioIAMServiceAccountInvalidRestriction",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
 		case errors.Is(err, errIAMNotInitialized):
 			apiErr = APIError{
 				Code:           "XMinioIAMNotInitialized",
@@ -228,6 +258,12 @@ func toAdminAPIErr(ctx context.Context, err error) APIError {
 				Description:    err.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case errors.Is(err, errTierCredsUnsupported):
+			apiErr = APIError{
+				Code:           "XMinioAdminTierCredsUnsupported",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
 		case errIsTierPermError(err):
 			apiErr = APIError{
 				Code:           "XMinioAdminTierInsufficientPermissions",
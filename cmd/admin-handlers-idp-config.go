@@ -83,12 +83,6 @@ func (a adminAPIHandlers) addOrUpdateIDPHandler(ctx context.Context, w http.Resp
 	cfgTarget := madmin.Default
 	if cfgName != "" {
 		cfgTarget = cfgName
-		if idpCfgType == madmin.LDAPIDPCfg && cfgName != madmin.Default {
-			// LDAP does not support multiple configurations. So cfgName must be
-			// empty or `madmin.Default`.
-			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrBadRequest), r.URL)
-			return
-		}
 	}
 
 	// Check that this is a valid Create vs Update API call.
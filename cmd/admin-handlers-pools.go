@@ -18,12 +18,16 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
+	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	iampolicy "github.com/minio/pkg/iam/policy"
 )
@@ -55,11 +59,6 @@ func (a adminAPIHandlers) StartDecommission(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	if pools.IsRebalanceStarted() {
-		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errDecommissionRebalanceAlreadyRunning), r.URL)
-		return
-	}
-
 	vars := mux.Vars(r)
 	v := vars["pool"]
 
@@ -80,6 +79,29 @@ func (a adminAPIHandlers) StartDecommission(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
+	// A dry-run only reports what decommissioning the pool would do,
+	// including why it cannot be started now. It walks the pool in the
+	// background on the server decommissioning it, the client polls its
+	// status.
+	if r.Form.Get("dry-run") == "true" {
+		st := globalAdminJobs.start("decommission-dry-run/"+strconv.Itoa(idx), r.Form.Get("refresh") == "true",
+			func(ctx context.Context) (interface{}, error) {
+				return pools.decommissionDryRun(ctx, idx)
+			})
+		data, err := json.Marshal(st)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		writeSuccessResponseJSON(w, data)
+		return
+	}
+
+	if pools.IsRebalanceStarted() {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errDecommissionRebalanceAlreadyRunning), r.URL)
+		return
+	}
+
 	if err := pools.Decommission(r.Context(), idx); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -250,19 +272,53 @@ func (a adminAPIHandlers) RebalanceStart(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	bucketInfos, err := objectAPI.ListBuckets(ctx, BucketOptions{})
+	// Rebalance options are optional, all the buckets are rebalanced
+	// without limits by default.
+	var opts rebalanceOpts
+	data, err := io.ReadAll(r.Body)
 	if err != nil {
-		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
 		return
 	}
+	if len(data) > 0 {
+		err = json.Unmarshal(data, &opts)
+		if err == nil {
+			err = opts.validate(len(pools.serverPools))
+		}
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, AdminError{
+				Code:       "XMinioAdminInvalidRebalanceOptions",
+				Message:    err.Error(),
+				StatusCode: http.StatusBadRequest,
+			}), r.URL)
+			return
+		}
+	}
 
-	buckets := make([]string, 0, len(bucketInfos))
-	for _, bInfo := range bucketInfos {
-		buckets = append(buckets, bInfo.Name)
+	var buckets []string
+	if len(opts.Buckets) > 0 {
+		for _, bucket := range opts.Buckets {
+			if _, err = objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+				writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+				return
+			}
+			buckets = append(buckets, bucket)
+		}
+	} else {
+		bucketInfos, err := objectAPI.ListBuckets(ctx, BucketOptions{})
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+
+		buckets = make([]string, 0, len(bucketInfos))
+		for _, bInfo := range bucketInfos {
+			buckets = append(buckets, bInfo.Name)
+		}
 	}
 
 	var id string
-	if id, err = pools.initRebalanceMeta(ctx, buckets); err != nil {
+	if id, err = pools.initRebalanceMeta(ctx, buckets, opts); err != nil {
 		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
@@ -345,3 +401,343 @@ func (a adminAPIHandlers) RebalanceStop(w http.ResponseWriter, r *http.Request)
 	writeSuccessResponseHeadersOnly(w)
 	logger.LogIf(ctx, pools.saveRebalanceStats(GlobalContext, 0, rebalSaveStoppedAt))
 }
+
+// proxyToParityUpgradeLeader proxies the request to the node running the
+// parity upgrades, returns true if the request was proxied.
+func proxyToParityUpgradeLeader(ctx context.Context, w http.ResponseWriter, r *http.Request) bool {
+	if ep := globalEndpoints[0].Endpoints[0]; !ep.IsLocal {
+		for nodeIdx, proxyEp := range globalProxyEndpoints {
+			if proxyEp.Endpoint.Host == ep.Host {
+				if proxyRequestByNodeIndex(ctx, w, r, nodeIdx) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ParityUpgradeStart - POST /minio/admin/v3/parity-upgrade/start
+// Starts re-encoding the objects with a parity below the parity of
+// their storage class.
+func (a adminAPIHandlers) ParityUpgradeStart(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ParityUpgradeStart")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// parity upgrades are run on the first pool's first node.
+	if proxyToParityUpgradeLeader(ctx, w, r) {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	buckets, err := parityUpgradeBuckets(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	id, err := pools.initParityUpgradeMeta(ctx, buckets)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	pools.StartParityUpgrade()
+
+	b, err := json.Marshal(struct {
+		ID string `json:"id"`
+	}{ID: id})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, b)
+}
+
+// ParityUpgradeStatus - GET /minio/admin/v3/parity-upgrade/status
+// Returns the progress of the last parity upgrade.
+func (a adminAPIHandlers) ParityUpgradeStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ParityUpgradeStatus")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if proxyToParityUpgradeLeader(ctx, w, r) {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	status, err := pools.parityUpgradeStatus()
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	logger.LogIf(r.Context(), json.NewEncoder(w).Encode(status))
+}
+
+// ParityUpgradeStop - POST /minio/admin/v3/parity-upgrade/stop
+// Stops the parity upgrade in progress.
+func (a adminAPIHandlers) ParityUpgradeStop(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ParityUpgradeStop")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if proxyToParityUpgradeLeader(ctx, w, r) {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	if err := pools.StopParityUpgrade(); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// proxyToDriveNode proxies the request to the node of the drive endpoint
+// drive, returns true if the request was proxied.
+func proxyToDriveNode(ctx context.Context, w http.ResponseWriter, r *http.Request, drive string) bool {
+	for _, pool := range globalEndpoints {
+		for _, ep := range pool.Endpoints {
+			if ep.String() != drive || ep.IsLocal {
+				continue
+			}
+			for nodeIdx, proxyEp := range globalProxyEndpoints {
+				if proxyEp.Endpoint.Host == ep.Host {
+					if proxyRequestByNodeIndex(ctx, w, r, nodeIdx) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// EvacuateDrive - POST /minio/admin/v3/drive/evacuate?drive=<endpoint>&replacement=<path>
+// Starts evacuating a drive onto a replacement drive mounted on the same node.
+func (a adminAPIHandlers) EvacuateDrive(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "EvacuateDrive")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	drive := vars["drive"]
+	if proxyToDriveNode(ctx, w, r, drive) {
+		return
+	}
+
+	if err := pools.EvacuateDrive(ctx, drive, vars["replacement"]); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// DriveEvacuationStatus - GET /minio/admin/v3/drive/evacuate/status?drive=<endpoint>
+// Returns the progress of the evacuation of a drive.
+func (a adminAPIHandlers) DriveEvacuationStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DriveEvacuationStatus")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction, iampolicy.DecommissionAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	drive := mux.Vars(r)["drive"]
+	if proxyToDriveNode(ctx, w, r, drive) {
+		return
+	}
+
+	status, err := pools.DriveEvacuationStatus(drive)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	logger.LogIf(r.Context(), json.NewEncoder(w).Encode(status))
+}
+
+// CancelDriveEvacuation - POST /minio/admin/v3/drive/evacuate/cancel?drive=<endpoint>
+// Cancels the evacuation of a drive, bringing it back online.
+func (a adminAPIHandlers) CancelDriveEvacuation(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelDriveEvacuation")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	drive := mux.Vars(r)["drive"]
+	if proxyToDriveNode(ctx, w, r, drive) {
+		return
+	}
+
+	if err := pools.CancelDriveEvacuation(ctx, drive); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// PromoteSpare - POST /minio/admin/v3/spares/promote?drive=<endpoint>
+// Replaces an offline drive by a spare drive of its pool.
+func (a adminAPIHandlers) PromoteSpare(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PromoteSpare")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	spare, err := pools.PromoteSpare(ctx, mux.Vars(r)["drive"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	logger.LogIf(r.Context(), json.NewEncoder(w).Encode(map[string]string{"spare": spare}))
+}
+
+// ListSpares - GET /minio/admin/v3/spares/list
+// Lists the spare drives of all the pools.
+func (a adminAPIHandlers) ListSpares(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListSpares")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	logger.LogIf(r.Context(), json.NewEncoder(w).Encode(pools.ListSpares()))
+}
+
+// StartScrub - POST /minio/admin/v3/scrub/start
+// Starts scrubbing all the drives, resuming the scrubs in progress.
+func (a adminAPIHandlers) StartScrub(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartScrub")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	if err := pools.RequestScrub(ctx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ScrubStatus - GET /minio/admin/v3/scrub/status
+// Returns the status of the last scrub of all the online drives.
+func (a adminAPIHandlers) ScrubStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ScrubStatus")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	logger.LogIf(r.Context(), json.NewEncoder(w).Encode(pools.ScrubReports(ctx, "", false)))
+}
+
+// ScrubReport - GET /minio/admin/v3/scrub/report?drive=http://server/drive
+// Downloads the report of the last scrub with the corrupted shards found,
+// of all the online drives or of the given drive only.
+func (a adminAPIHandlers) ScrubReport(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ScrubReport")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	w.Header().Set(xhttp.ContentDisposition, "attachment; filename=scrub-report.json")
+	logger.LogIf(r.Context(), json.NewEncoder(w).Encode(pools.ScrubReports(ctx, r.Form.Get("drive"), true)))
+}
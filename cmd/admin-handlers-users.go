@@ -21,10 +21,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -160,6 +162,94 @@ func (a adminAPIHandlers) ListUsers(w http.ResponseWriter, r *http.Request) {
 	writeSuccessResponseJSON(w, econfigData)
 }
 
+// ListAccessKeyUsage - GET /minio/admin/v3/list-access-key-usage?unusedDays={days}
+//
+// Reports when, from where and how often each user and service account
+// authenticated, optionally only those not used for the given number of
+// days.
+func (a adminAPIHandlers) ListAccessKeyUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListAccessKeyUsage")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ListUsersAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var unusedSince time.Time
+	if v := r.Form.Get("unusedDays"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		unusedSince = UTCNow().AddDate(0, 0, -days)
+	}
+
+	usage, err := globalIAMSys.ListAccessKeyUsage(ctx, unusedSince)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(usage)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	econfigData, err := madmin.EncryptData(cred.SecretKey, data)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, econfigData)
+}
+
+// SimulatePolicy - POST /minio/admin/v3/simulate-policy
+// ----------
+// Evaluates a request for a principal, action and resource with the
+// policies of the principal and returns the decision with the statements
+// matching it.
+func (a adminAPIHandlers) SimulatePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SimulatePolicy")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.GetPolicyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var req policySimulationRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxEConfigJSONSize)).Decode(&req); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	result, err := globalIAMSys.SimulatePolicy(ctx, req)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	econfigData, err := madmin.EncryptData(cred.SecretKey, data)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, econfigData)
+}
+
 // GetUserInfo - GET /minio/admin/v3/user-info
 func (a adminAPIHandlers) GetUserInfo(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "GetUserInfo")
@@ -528,6 +618,32 @@ func (a adminAPIHandlers) AddUser(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// addServiceAccountReq - extends madmin.AddServiceAccountReq with the
+// optional expiry and source network restrictions of the service account.
+type addServiceAccountReq struct {
+	madmin.AddServiceAccountReq
+	Expiration *time.Time `json:"expiration,omitempty"`
+	AllowedIPs []string   `json:"allowedIPs,omitempty"`
+}
+
+// updateServiceAccountReq - extends madmin.UpdateServiceAccountReq, a nil
+// NewExpiration or NewAllowedIPs leaves the current value unchanged while a
+// zero time or an empty list removes the restriction.
+type updateServiceAccountReq struct {
+	madmin.UpdateServiceAccountReq
+	NewExpiration *time.Time `json:"newExpiration,omitempty"`
+	NewAllowedIPs []string   `json:"newAllowedIPs"`
+}
+
+// infoServiceAccountResp - extends madmin.InfoServiceAccountResp with the
+// restrictions of the service account and when it was last used.
+type infoServiceAccountResp struct {
+	madmin.InfoServiceAccountResp
+	Expiration *time.Time `json:"expiration,omitempty"`
+	AllowedIPs []string   `json:"allowedIPs,omitempty"`
+	LastUsed   *time.Time `json:"lastUsed,omitempty"`
+}
+
 // AddServiceAccount - PUT /minio/admin/v3/add-service-account
 func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "AddServiceAccount")
@@ -554,7 +670,7 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	var createReq madmin.AddServiceAccountReq
+	var createReq addServiceAccountReq
 	if err = json.Unmarshal(reqBytes, &createReq); err != nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
 		return
@@ -635,7 +751,8 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 		// In case of LDAP/OIDC we need to set `opts.claims` to ensure
 		// it is associated with the LDAP/OIDC user properly.
 		for k, v := range cred.Claims {
-			if k == expClaim {
+			switch k {
+			case expClaim, svcAccExpiryClaim, svcAccAllowedIPsClaim:
 				continue
 			}
 			opts.claims[k] = v
@@ -682,6 +799,18 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
+	if createReq.Expiration != nil {
+		if err = setSvcAccExpiryClaim(opts.claims, *createReq.Expiration); err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+
+	if err = setSvcAccAllowedIPsClaim(opts.claims, createReq.AllowedIPs); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
 	opts.sessionPolicy = sp
 	newCred, updatedAt, err := globalIAMSys.NewServiceAccount(ctx, targetUser, targetGroups, opts)
 	if err != nil {
@@ -791,12 +920,22 @@ func (a adminAPIHandlers) UpdateServiceAccount(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	var updateReq madmin.UpdateServiceAccountReq
+	var updateReq updateServiceAccountReq
 	if err = json.Unmarshal(reqBytes, &updateReq); err != nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
 		return
 	}
 
+	// Site replication only carries secret key, status and policy updates
+	// of service accounts, reject restriction changes instead of letting
+	// the sites drift apart.
+	if (updateReq.NewExpiration != nil || updateReq.NewAllowedIPs != nil) &&
+		svcAccount.ParentUser != globalActiveCred.AccessKey && globalSiteReplicationSys.isEnabled() {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, fmt.Errorf("%w: service account expiration and allowed IPs cannot be updated while site replication is enabled",
+			errIAMActionNotAllowed)), r.URL)
+		return
+	}
+
 	var sp *iampolicy.Policy
 	if len(updateReq.NewPolicy) > 0 {
 		sp, err = iampolicy.ParseConfig(bytes.NewReader(updateReq.NewPolicy))
@@ -809,6 +948,8 @@ func (a adminAPIHandlers) UpdateServiceAccount(w http.ResponseWriter, r *http.Re
 		secretKey:     updateReq.NewSecretKey,
 		status:        updateReq.NewStatus,
 		sessionPolicy: sp,
+		expiration:    updateReq.NewExpiration,
+		allowedIPs:    updateReq.NewAllowedIPs,
 	}
 	updatedAt, err := globalIAMSys.UpdateServiceAccount(ctx, accessKey, opts)
 	if err != nil {
@@ -864,7 +1005,7 @@ func (a adminAPIHandlers) InfoServiceAccount(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	svcAccount, policy, err := globalIAMSys.GetServiceAccount(ctx, accessKey)
+	svcAccount, policy, err := globalIAMSys.getServiceAccount(ctx, accessKey)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -882,7 +1023,7 @@ func (a adminAPIHandlers) InfoServiceAccount(w http.ResponseWriter, r *http.Requ
 			requestUser = cred.ParentUser
 		}
 
-		if requestUser != svcAccount.ParentUser {
+		if requestUser != svcAccount.Credentials.ParentUser {
 			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
 			return
 		}
@@ -893,7 +1034,7 @@ func (a adminAPIHandlers) InfoServiceAccount(w http.ResponseWriter, r *http.Requ
 	if policy != nil {
 		svcAccountPolicy = *policy
 	} else {
-		policiesNames, err := globalIAMSys.PolicyDBGet(svcAccount.ParentUser, false)
+		policiesNames, err := globalIAMSys.PolicyDBGet(svcAccount.Credentials.ParentUser, false)
 		if err != nil {
 			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 			return
@@ -907,11 +1048,30 @@ func (a adminAPIHandlers) InfoServiceAccount(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	infoResp := madmin.InfoServiceAccountResp{
-		ParentUser:    svcAccount.ParentUser,
-		AccountStatus: svcAccount.Status,
-		ImpliedPolicy: policy == nil,
-		Policy:        string(policyJSON),
+	// An unparsable restriction is logged rather than failing the call, so
+	// that the account can still be inspected.
+	restrictions, err := getSvcAccRestrictions(svcAccount.Credentials)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("service account %s has invalid restrictions: %w", accessKey, err))
+	}
+
+	infoResp := infoServiceAccountResp{
+		InfoServiceAccountResp: madmin.InfoServiceAccountResp{
+			ParentUser:    svcAccount.Credentials.ParentUser,
+			AccountStatus: svcAccount.Credentials.Status,
+			ImpliedPolicy: policy == nil,
+			Policy:        string(policyJSON),
+		},
+		AllowedIPs: restrictions.AllowedIPs(),
+	}
+	if !restrictions.expiration.IsZero() {
+		infoResp.Expiration = &restrictions.expiration
+	}
+	usage, err := globalIAMSys.GetAccessKeyUsage(ctx, accessKey)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("unable to get the usage of service account %s: %w", accessKey, err))
+	} else if !usage.LastUsed.IsZero() {
+		infoResp.LastUsed = &usage.LastUsed
 	}
 
 	data, err := json.Marshal(infoResp)
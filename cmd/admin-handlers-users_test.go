@@ -40,6 +40,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/s3utils"
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio-go/v7/pkg/signer"
+	"github.com/minio/minio/internal/arn"
 	"github.com/minio/minio/internal/auth"
 )
 
@@ -209,6 +210,9 @@ func TestIAMInternalIDPServerSuite(t *testing.T) {
 				suite.TestServiceAccountOpsByAdmin(c)
 				suite.TestServiceAccountOpsByUser(c)
 				suite.TestAddServiceAccountPerms(c)
+				suite.TestServiceAccountExpiry(c)
+				suite.TestAccessKeyUsage(c)
+				suite.TestPolicySimulator(c)
 				suite.TearDownSuite(c)
 			},
 		)
@@ -980,6 +984,335 @@ func (s *TestSuiteIAM) TestServiceAccountOpsByAdmin(c *check) {
 	c.assertSvcAccDeletion(ctx, s, s.adm, accessKey, bucket)
 }
 
+func (s *TestSuiteIAM) TestServiceAccountExpiry(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err := s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+
+	err = s.adm.SetPolicy(ctx, "readwrite", accessKey, false)
+	if err != nil {
+		c.Fatalf("Unable to set policy: %v", err)
+	}
+
+	newSvcAcc := func(expiration time.Time) auth.Credentials {
+		cred, _, err := globalIAMSys.NewServiceAccount(ctx, accessKey, nil, newServiceAccountOpts{
+			claims: map[string]interface{}{
+				svcAccExpiryClaim: expiration.Unix(),
+			},
+		})
+		if err != nil {
+			c.Fatalf("Unable to create service account: %v", err)
+		}
+		return cred
+	}
+
+	// 1. Expired service accounts are refused before they are purged.
+	expired := newSvcAcc(UTCNow().Add(-time.Minute))
+	_, err = s.getUserClient(c, expired.AccessKey, expired.SecretKey, "").ListBuckets(ctx)
+	if err == nil {
+		c.Fatalf("expired service account was able to list buckets")
+	}
+
+	// 2. Service accounts expiring in the future are usable and their use
+	// is persisted.
+	valid := newSvcAcc(UTCNow().Add(time.Hour))
+	c.mustListBuckets(ctx, s.getUserClient(c, valid.AccessKey, valid.SecretKey, ""))
+
+	globalIAMSys.persistAccessKeyUsage(ctx)
+	persisted, err := globalIAMSys.loadAccessKeyUsage(ctx)
+	if err != nil {
+		c.Fatalf("Unable to load access key usage: %v", err)
+	}
+	if u := persisted[valid.AccessKey]; u.LastUsed.IsZero() {
+		c.Fatalf("service account usage was not persisted: %#v", u)
+	}
+
+	// 3. Only the expired service account is purged.
+	globalIAMSys.purgeExpiredServiceAccounts(ctx, newObjectLayerFn())
+	if _, err = s.adm.InfoServiceAccount(ctx, expired.AccessKey); err == nil {
+		c.Fatalf("expired service account was not purged")
+	}
+	c.assertSvcAccInfoQueryable(ctx, s.adm, accessKey, valid.AccessKey, false)
+
+	err = s.adm.DeleteServiceAccount(ctx, valid.AccessKey)
+	if err != nil {
+		c.Fatalf("Unable to delete service account: %v", err)
+	}
+
+	// 4. Network restricted service accounts can log in to the console
+	// only from an allowed source address.
+	claims := make(map[string]interface{})
+	if err = setSvcAccAllowedIPsClaim(claims, []string{"10.0.0.0/8"}); err != nil {
+		c.Fatalf("Unable to set allowed IPs: %v", err)
+	}
+	restricted, _, err := globalIAMSys.NewServiceAccount(ctx, accessKey, nil, newServiceAccountOpts{claims: claims})
+	if err != nil {
+		c.Fatalf("Unable to create service account: %v", err)
+	}
+	if _, err = authenticateJWTUsersWithCredentials(restricted, "10.1.2.3", UTCNow().Add(defaultJWTExpiry)); err != nil {
+		c.Fatalf("service account was refused from an allowed source: %v", err)
+	}
+	if _, err = authenticateJWTUsersWithCredentials(restricted, "192.168.1.2", UTCNow().Add(defaultJWTExpiry)); err != errAuthentication {
+		c.Fatalf("Expected %v from a disallowed source, got %v", errAuthentication, err)
+	}
+
+	err = s.adm.DeleteServiceAccount(ctx, restricted.AccessKey)
+	if err != nil {
+		c.Fatalf("Unable to delete service account: %v", err)
+	}
+}
+
+func (s *TestSuiteIAM) TestAccessKeyUsage(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	findUsage := func(usage []accessKeyUsageInfo, accessKey string) (accessKeyUsageInfo, bool) {
+		for _, u := range usage {
+			if u.AccessKey == accessKey {
+				return u, true
+			}
+		}
+		return accessKeyUsageInfo{}, false
+	}
+
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err := s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+
+	err = s.adm.SetPolicy(ctx, "readwrite", accessKey, false)
+	if err != nil {
+		c.Fatalf("Unable to set policy: %v", err)
+	}
+
+	idleKey, idleSecret := mustGenerateCredentials(c)
+	err = s.adm.SetUser(ctx, idleKey, idleSecret, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+
+	client := s.getUserClient(c, accessKey, secretKey, "")
+	c.mustListBuckets(ctx, client)
+	c.mustListBuckets(ctx, client)
+
+	// 1. Usage recorded in memory is reported.
+	usage, err := globalIAMSys.ListAccessKeyUsage(ctx, time.Time{})
+	if err != nil {
+		c.Fatalf("Unable to list access key usage: %v", err)
+	}
+	u, ok := findUsage(usage, accessKey)
+	if !ok || u.LastUsed == nil || u.Calls != 2 || u.LastSourceIP == "" {
+		c.Fatalf("unexpected usage for %s: %#v", accessKey, u)
+	}
+	if u.AccountType != accessKeyUsageUser {
+		c.Fatalf("unexpected account type %s", u.AccountType)
+	}
+
+	// 2. Persisting usage neither loses nor double counts calls.
+	globalIAMSys.persistAccessKeyUsage(ctx)
+	persisted, err := globalIAMSys.loadAccessKeyUsage(ctx)
+	if err != nil {
+		c.Fatalf("Unable to load access key usage: %v", err)
+	}
+	if persisted[accessKey].Calls != 2 {
+		c.Fatalf("user usage was not persisted: %#v", persisted[accessKey])
+	}
+	usage, err = globalIAMSys.ListAccessKeyUsage(ctx, time.Time{})
+	if err != nil {
+		c.Fatalf("Unable to list access key usage: %v", err)
+	}
+	if u, _ = findUsage(usage, accessKey); u.Calls != 2 {
+		c.Fatalf("expected 2 calls after persisting, got %d", u.Calls)
+	}
+
+	// 3. Usage survives updating the user.
+	err = s.adm.SetUserStatus(ctx, accessKey, madmin.AccountDisabled)
+	if err != nil {
+		c.Fatalf("Unable to disable user: %v", err)
+	}
+	usage, err = globalIAMSys.ListAccessKeyUsage(ctx, time.Time{})
+	if err != nil {
+		c.Fatalf("Unable to list access key usage: %v", err)
+	}
+	if u, _ = findUsage(usage, accessKey); u.Calls != 2 {
+		c.Fatalf("user usage was lost on update: %#v", u)
+	}
+
+	// 4. Only credentials unused since the given time are reported.
+	usage, err = globalIAMSys.ListAccessKeyUsage(ctx, UTCNow().Add(-time.Hour))
+	if err != nil {
+		c.Fatalf("Unable to list access key usage: %v", err)
+	}
+	if _, ok = findUsage(usage, accessKey); ok {
+		c.Fatalf("recently used %s reported as unused", accessKey)
+	}
+	if u, ok = findUsage(usage, idleKey); !ok || u.LastUsed != nil || u.Calls != 0 {
+		c.Fatalf("unused %s not reported: %#v", idleKey, u)
+	}
+}
+
+func (s *TestSuiteIAM) TestPolicySimulator(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	bucket := getRandomBucketName()
+	err := s.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{})
+	if err != nil {
+		c.Fatalf("bucket create error: %v", err)
+	}
+
+	policy := "mypolicy-test-simulator"
+	policyBytes := []byte(fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": ["s3:GetObject", "s3:PutObject"],
+   "Resource": ["arn:aws:s3:::%s/*"]
+  },
+  {
+   "Effect": "Deny",
+   "Action": ["s3:PutObject"],
+   "Resource": ["arn:aws:s3:::%s/*"],
+   "Condition": {"NotIpAddress": {"aws:SourceIp": "10.0.0.0/8"}}
+  }
+ ]
+}`, bucket, bucket))
+	err = s.adm.AddCannedPolicy(ctx, policy, policyBytes)
+	if err != nil {
+		c.Fatalf("policy add error: %v", err)
+	}
+
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err = s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+	group := "test-simulator-group"
+	err = s.adm.UpdateGroupMembers(ctx, madmin.GroupAddRemove{Group: group, Members: []string{accessKey}})
+	if err != nil {
+		c.Fatalf("Unable to add user to group: %v", err)
+	}
+	err = s.adm.SetPolicy(ctx, policy, group, true)
+	if err != nil {
+		c.Fatalf("Unable to set policy: %v", err)
+	}
+
+	svcPolicy := []byte(fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": ["s3:GetObject"],
+   "Resource": ["arn:aws:s3:::%s/*"]
+  }
+ ]
+}`, bucket))
+	cr, err := s.adm.AddServiceAccount(ctx, madmin.AddServiceAccountReq{
+		TargetUser: accessKey,
+		Policy:     svcPolicy,
+	})
+	if err != nil {
+		c.Fatalf("Unable to create service account: %v", err)
+	}
+
+	err = s.client.SetBucketPolicy(ctx, bucket, fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Principal": {"AWS": ["*"]},
+   "Action": ["s3:GetObject"],
+   "Resource": ["arn:aws:s3:::%s/public/*"]
+  }
+ ]
+}`, bucket))
+	if err != nil {
+		c.Fatalf("Unable to set bucket policy: %v", err)
+	}
+
+	roleArn, err := arn.NewIAMRoleARN("test-simulator", globalSite.Region)
+	if err != nil {
+		c.Fatalf("Unable to create role ARN: %v", err)
+	}
+	globalIAMSys.rolesMap[roleArn] = policy
+	defer delete(globalIAMSys.rolesMap, roleArn)
+
+	resource := fmt.Sprintf("arn:aws:s3:::%s/public/object", bucket)
+	testCases := []struct {
+		req        policySimulationRequest
+		allowed    bool
+		source     string
+		statements int
+	}{
+		// 1. Group policies apply to the members of the group.
+		{policySimulationRequest{PrincipalType: simulatedUser, Principal: accessKey, Action: "s3:GetObject", Resource: resource}, true, policySourceIdentity, 1},
+		// 2. The Deny statement matches outside of the allowed network.
+		{policySimulationRequest{PrincipalType: simulatedUser, Principal: accessKey, Action: "s3:PutObject", Resource: resource}, false, policySourceIdentity, 2},
+		// 3. The condition context is taken into account.
+		{policySimulationRequest{
+			PrincipalType: simulatedUser, Principal: accessKey, Action: "s3:PutObject", Resource: resource,
+			Conditions: map[string][]string{"aws:SourceIp": {"10.1.2.3"}},
+		}, true, policySourceIdentity, 1},
+		{policySimulationRequest{PrincipalType: simulatedGroup, Principal: group, Action: "s3:GetObject", Resource: resource}, true, policySourceIdentity, 1},
+		// 4. The inline policy of the service account limits its permissions.
+		{policySimulationRequest{PrincipalType: simulatedServiceAccount, Principal: cr.AccessKey, Action: "s3:GetObject", Resource: resource}, true, policySourceServiceAccess, 1},
+		{policySimulationRequest{
+			PrincipalType: simulatedServiceAccount, Principal: cr.AccessKey, Action: "s3:PutObject", Resource: resource,
+			Conditions: map[string][]string{"aws:SourceIp": {"10.1.2.3"}},
+		}, false, policySourceServiceAccess, 0},
+		// 5. Role policies apply to the sessions of the role.
+		{policySimulationRequest{PrincipalType: simulatedSTS, Principal: roleArn.String(), Action: "s3:GetObject", Resource: resource}, true, policySourceRole, 1},
+		{policySimulationRequest{PrincipalType: simulatedSTS, Principal: roleArn.String(), Action: "s3:PutObject", Resource: resource}, false, policySourceRole, 2},
+		// 6. Bucket policies apply to anonymous requests.
+		{policySimulationRequest{PrincipalType: simulatedAnonymous, Action: "s3:GetObject", Resource: resource}, true, policySourceBucket, 1},
+		{policySimulationRequest{PrincipalType: simulatedAnonymous, Action: "s3:PutObject", Resource: resource}, false, policySourceBucket, 0},
+	}
+	for i, testCase := range testCases {
+		res, err := globalIAMSys.SimulatePolicy(ctx, testCase.req)
+		if err != nil {
+			c.Fatalf("Test %d: Unable to simulate policy: %v", i+1, err)
+		}
+		if res.Allowed != testCase.allowed {
+			c.Fatalf("Test %d: expected allowed %v, got %#v", i+1, testCase.allowed, res)
+		}
+		var found bool
+		for _, src := range res.Sources {
+			if src.Source == testCase.source {
+				found = true
+				if len(src.Statements) != testCase.statements {
+					c.Fatalf("Test %d: expected %d matching statements, got %#v", i+1, testCase.statements, src)
+				}
+			}
+		}
+		if !found {
+			c.Fatalf("Test %d: source %s was not evaluated: %#v", i+1, testCase.source, res)
+		}
+	}
+
+	// 7. Invalid requests are rejected.
+	invalid := []policySimulationRequest{
+		{PrincipalType: simulatedUser, Principal: accessKey, Action: "s3:NoSuchAction", Resource: resource},
+		{PrincipalType: simulatedUser, Principal: accessKey, Action: "s3:GetObject", Resource: bucket},
+		{PrincipalType: "role", Principal: accessKey, Action: "s3:GetObject", Resource: resource},
+		{PrincipalType: simulatedServiceAccount, Principal: accessKey, Action: "s3:GetObject", Resource: resource},
+		{PrincipalType: simulatedUser, Principal: "nosuchuser", Action: "s3:GetObject", Resource: resource},
+		{PrincipalType: simulatedGroup, Principal: "nosuchgroup", Action: "s3:GetObject", Resource: resource},
+		{PrincipalType: simulatedSTS, Principal: "arn:minio:iam:::role/nosuchrole", Action: "s3:GetObject", Resource: resource},
+	}
+	for i, req := range invalid {
+		if _, err = globalIAMSys.SimulatePolicy(ctx, req); err == nil {
+			c.Fatalf("Invalid request %d: expected an error", i+1)
+		}
+	}
+}
+
 func (s *TestSuiteIAM) SetUpAccMgmtPlugin(c *check) {
 	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
 	defer cancel()
@@ -1101,7 +1101,7 @@ func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
 
 // getAggregatedBackgroundHealState returns the heal state of disks.
 // If no ObjectLayer is provided no set status is returned.
-func getAggregatedBackgroundHealState(ctx context.Context, o ObjectLayer) (madmin.BgHealState, error) {
+func getAggregatedBackgroundHealState(ctx context.Context, o ObjectLayer) (bgHealState, error) {
 	// Get local heal status first
 	bgHealStates, ok := getBackgroundHealStatus(ctx, o)
 	if !ok {
@@ -1119,7 +1119,7 @@ func getAggregatedBackgroundHealState(ctx context.Context, o ObjectLayer) (madmi
 			}
 		}
 		if errCount == len(nerrs) {
-			return madmin.BgHealState{}, fmt.Errorf("all remote servers failed to report heal status, cluster is unhealthy")
+			return bgHealState{}, fmt.Errorf("all remote servers failed to report heal status, cluster is unhealthy")
 		}
 		bgHealStates.Merge(peersHealStates...)
 	}
@@ -1783,15 +1783,9 @@ func getServerInfo(ctx context.Context, r *http.Request) madmin.InfoMessage {
 
 	ldap := madmin.LDAP{}
 	if globalLDAPConfig.Enabled() {
-		ldapConn, err := globalLDAPConfig.LDAP.Connect()
-		//nolint:gocritic
-		if err != nil {
+		if err := globalLDAPConfig.CheckConnectivity(); err != nil {
 			ldap.Status = string(madmin.ItemOffline)
-		} else if ldapConn == nil {
-			ldap.Status = "Not Configured"
 		} else {
-			// Close ldap connection to avoid leaks.
-			ldapConn.Close()
 			ldap.Status = string(madmin.ItemOnline)
 		}
 	}
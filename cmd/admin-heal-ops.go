@@ -132,7 +132,9 @@ func (ahs *allHealState) popHealLocalDisks(healLocalDisks ...Endpoint) {
 func (ahs *allHealState) updateHealStatus(tracker *healingTracker) {
 	ahs.Lock()
 	defer ahs.Unlock()
-	ahs.healStatus[tracker.ID] = *tracker
+	h := *tracker
+	h.Classes = append([]healClassProgress(nil), tracker.Classes...)
+	ahs.healStatus[tracker.ID] = h
 }
 
 // Sort by zone, set and disk index
@@ -161,6 +163,21 @@ func (ahs *allHealState) getLocalHealingDisks() map[string]madmin.HealingDisk {
 	return dst
 }
 
+// getLocalHealingClasses returns the progress per priority class of local
+// healing disks indexed by endpoint.
+func (ahs *allHealState) getLocalHealingClasses() map[string][]healClassProgress {
+	ahs.RLock()
+	defer ahs.RUnlock()
+	dst := make(map[string][]healClassProgress, len(ahs.healStatus))
+	for _, v := range ahs.healStatus {
+		if len(v.Classes) > 0 {
+			dst[v.Endpoint] = v.Classes
+		}
+	}
+
+	return dst
+}
+
 // getHealLocalDiskEndpoints() returns the list of disks that need
 // to be healed but there is no healing routine in progress on them.
 func (ahs *allHealState) getHealLocalDiskEndpoints() Endpoints {
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Status of an admin job.
+const (
+	adminJobRunning  = "running"
+	adminJobComplete = "complete"
+	adminJobFailed   = "failed"
+)
+
+// adminJobStatus - the status of an admin job, Result is set once the job
+// is complete.
+type adminJobStatus struct {
+	Status    string      `json:"status"`
+	StartedAt time.Time   `json:"startedAt"`
+	EndedAt   time.Time   `json:"endedAt,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	Result    interface{} `json:"result,omitempty"`
+}
+
+// adminJobs - admin operations walking the namespace, too long to be run
+// within a request. They run in the background on the server receiving
+// them and their status is polled by the client, the last run of each job
+// is kept.
+type adminJobs struct {
+	mu   sync.Mutex
+	jobs map[string]*adminJobStatus
+}
+
+var globalAdminJobs = &adminJobs{
+	jobs: make(map[string]*adminJobStatus),
+}
+
+// start runs fn in the background as the job key and returns its status.
+// The job is not started again while it is running, nor once it has run
+// unless restart is set, its status is returned instead.
+func (j *adminJobs) start(key string, restart bool, fn func(ctx context.Context) (interface{}, error)) adminJobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if st, ok := j.jobs[key]; ok && (st.Status == adminJobRunning || !restart) {
+		return *st
+	}
+
+	st := &adminJobStatus{
+		Status:    adminJobRunning,
+		StartedAt: UTCNow(),
+	}
+	j.jobs[key] = st
+	go func() {
+		res, err := fn(GlobalContext)
+
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		st.EndedAt = UTCNow()
+		if err != nil {
+			st.Status = adminJobFailed
+			st.Error = err.Error()
+			return
+		}
+		st.Status = adminJobComplete
+		st.Result = res
+	}()
+	return *st
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAdminJobs(t *testing.T) {
+	j := &adminJobs{jobs: make(map[string]*adminJobStatus)}
+
+	waitDone := func(key string) adminJobStatus {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			st := j.start(key, false, nil)
+			if st.Status != adminJobRunning {
+				return st
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("job %s did not complete", key)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	release := make(chan struct{})
+	runs := 0
+	job := func(ctx context.Context) (interface{}, error) {
+		<-release
+		runs++
+		return runs, nil
+	}
+
+	// The job is not started again while it is running.
+	if st := j.start("job", false, job); st.Status != adminJobRunning {
+		t.Fatalf("expected the job to be running, got %#v", st)
+	}
+	if st := j.start("job", true, job); st.Status != adminJobRunning {
+		t.Fatalf("expected the job to be running, got %#v", st)
+	}
+	close(release)
+	if st := waitDone("job"); st.Status != adminJobComplete || st.Result != 1 || st.EndedAt.IsZero() {
+		t.Fatalf("unexpected job status %#v", st)
+	}
+
+	// The last run is kept unless the job is restarted.
+	if st := j.start("job", false, job); st.Result != 1 {
+		t.Fatalf("expected the last run to be kept, got %#v", st)
+	}
+	j.start("job", true, job)
+	if st := waitDone("job"); st.Result != 2 {
+		t.Fatalf("expected the job to run again, got %#v", st)
+	}
+
+	j.start("failed", false, func(ctx context.Context) (interface{}, error) {
+		return nil, errors.New("failed")
+	})
+	if st := waitDone("failed"); st.Status != adminJobFailed || st.Error != "failed" {
+		t.Fatalf("unexpected job status %#v", st)
+	}
+}
@@ -89,6 +89,25 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rebalance/start").HandlerFunc(gz(httpTraceAll(adminAPI.RebalanceStart)))
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/rebalance/status").HandlerFunc(gz(httpTraceAll(adminAPI.RebalanceStatus)))
 			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rebalance/stop").HandlerFunc(gz(httpTraceAll(adminAPI.RebalanceStop)))
+
+			// Parity upgrade operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/parity-upgrade/start").HandlerFunc(gz(httpTraceAll(adminAPI.ParityUpgradeStart)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/parity-upgrade/status").HandlerFunc(gz(httpTraceAll(adminAPI.ParityUpgradeStatus)))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/parity-upgrade/stop").HandlerFunc(gz(httpTraceAll(adminAPI.ParityUpgradeStop)))
+
+			// Drive evacuation operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/drive/evacuate").HandlerFunc(gz(httpTraceAll(adminAPI.EvacuateDrive))).Queries("drive", "{drive:.*}", "replacement", "{replacement:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/drive/evacuate/status").HandlerFunc(gz(httpTraceAll(adminAPI.DriveEvacuationStatus))).Queries("drive", "{drive:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/drive/evacuate/cancel").HandlerFunc(gz(httpTraceAll(adminAPI.CancelDriveEvacuation))).Queries("drive", "{drive:.*}")
+
+			// Spare drives operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/spares/promote").HandlerFunc(gz(httpTraceAll(adminAPI.PromoteSpare))).Queries("drive", "{drive:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/spares/list").HandlerFunc(gz(httpTraceAll(adminAPI.ListSpares)))
+
+			// Bitrot scrub operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/scrub/start").HandlerFunc(gz(httpTraceAll(adminAPI.StartScrub)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/scrub/status").HandlerFunc(gz(httpTraceAll(adminAPI.ScrubStatus)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/scrub/report").HandlerFunc(gz(httpTraceAll(adminAPI.ScrubReport)))
 		}
 
 		// Profiling operations - deprecated API
@@ -163,6 +182,12 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/list-users").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListBucketUsers))).Queries("bucket", "{bucket:.*}")
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/list-users").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListUsers)))
 
+		// Access key usage report
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/list-access-key-usage").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListAccessKeyUsage)))
+
+		// Policy simulator
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/simulate-policy").HandlerFunc(gz(httpTraceHdrs(adminAPI.SimulatePolicy)))
+
 		// User info
 		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/user-info").HandlerFunc(gz(httpTraceHdrs(adminAPI.GetUserInfo))).Queries("accessKey", "{accessKey:.*}")
 		// Add/Remove members from group
@@ -202,6 +227,16 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-quota").HandlerFunc(
 			gz(httpTraceHdrs(adminAPI.PutBucketQuotaConfigHandler))).Queries("bucket", "{bucket:.*}")
 
+		// GetBucketPoolAffinity
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-pool-affinity").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketPoolAffinityHandler))).Queries("bucket", "{bucket:.*}")
+		// PutBucketPoolAffinity
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-pool-affinity").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketPoolAffinityHandler))).Queries("bucket", "{bucket:.*}")
+		// BucketPoolAffinityReport
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/bucket-pool-affinity-report").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.BucketPoolAffinityReportHandler))).Queries("bucket", "{bucket:.*}")
+
 		// Bucket replication operations
 		// GetBucketTargetHandler
 		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/list-remote-targets").HandlerFunc(
@@ -215,6 +250,22 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		// ReplicationDiff - MinIO extension API
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replication/diff").HandlerFunc(
 			gz(httpTraceHdrs(adminAPI.ReplicationDiffHandler))).Queries("bucket", "{bucket:.*}")
+		// Replication dead-letter list - MinIO extension API
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication/dlq").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.ReplicationDLQListHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replication/dlq/retry").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.ReplicationDLQRetryHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/replication/dlq").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.ReplicationDLQPurgeHandler))).Queries("bucket", "{bucket:.*}")
+		// Replication conflicts - MinIO extension API
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication/conflicts").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.ReplicationConflictsListHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/replication/conflicts").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.ReplicationConflictsClearHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication/conflict-policy").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetReplicationConflictPolicyHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/replication/conflict-policy").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutReplicationConflictPolicyHandler))).Queries("bucket", "{bucket:.*}")
 
 		// Batch job operations
 		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/start-job").HandlerFunc(
@@ -84,6 +84,8 @@ const (
 	ErrInternalError
 	ErrInvalidAccessKeyID
 	ErrAccessKeyDisabled
+	ErrAccessKeyExpired
+	ErrAccessKeySourceNotAllowed
 	ErrInvalidBucketName
 	ErrInvalidDigest
 	ErrInvalidRange
@@ -133,6 +135,7 @@ const (
 	ErrReplicationBucketNeedsVersioningError
 	ErrReplicationDenyEditError
 	ErrReplicationNoExistingObjects
+	ErrReplicationConflict
 	ErrObjectRestoreAlreadyInProgress
 	ErrNoSuchKey
 	ErrNoSuchUpload
@@ -540,6 +543,16 @@ var errorCodes = errorCodeMap{
 		Description:    "Your account is disabled; please contact your administrator.",
 		HTTPStatusCode: http.StatusForbidden,
 	},
+	ErrAccessKeyExpired: {
+		Code:           "InvalidAccessKeyId",
+		Description:    "The Access Key Id you provided has expired; please contact your administrator.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrAccessKeySourceNotAllowed: {
+		Code:           "AccessDenied",
+		Description:    "The Access Key Id you provided is not allowed to be used from this network.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	ErrInvalidBucketName: {
 		Code:           "InvalidBucketName",
 		Description:    "The specified bucket is not valid.",
@@ -920,6 +933,11 @@ var errorCodes = errorCodeMap{
 		Description:    "No matching ExistingsObjects rule enabled",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrReplicationConflict: {
+		Code:           "XMinioReplicationConflict",
+		Description:    "The replica lost a replication conflict against the latest version of the object",
+		HTTPStatusCode: http.StatusConflict,
+	},
 	ErrReplicationDenyEditError: {
 		Code:           "XMinioReplicationDenyEdit",
 		Description:    "Cannot alter local replication config since this server is in a cluster replication setup",
@@ -2158,6 +2176,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrRemoteTargetNotVersionedError
 	case BucketReplicationSourceNotVersioned:
 		apiErr = ErrReplicationSourceNotVersionedError
+	case ReplicationConflictRejected:
+		apiErr = ErrReplicationConflict
 	case TransitionStorageClassNotFound:
 		apiErr = ErrTransitionStorageClassNotFoundError
 	case InvalidObjectState:
@@ -18,303 +18,306 @@ func _() {
 	_ = x[ErrInternalError-7]
 	_ = x[ErrInvalidAccessKeyID-8]
 	_ = x[ErrAccessKeyDisabled-9]
-	_ = x[ErrInvalidBucketName-10]
-	_ = x[ErrInvalidDigest-11]
-	_ = x[ErrInvalidRange-12]
-	_ = x[ErrInvalidRangePartNumber-13]
-	_ = x[ErrInvalidCopyPartRange-14]
-	_ = x[ErrInvalidCopyPartRangeSource-15]
-	_ = x[ErrInvalidMaxKeys-16]
-	_ = x[ErrInvalidEncodingMethod-17]
-	_ = x[ErrInvalidMaxUploads-18]
-	_ = x[ErrInvalidMaxParts-19]
-	_ = x[ErrInvalidPartNumberMarker-20]
-	_ = x[ErrInvalidPartNumber-21]
-	_ = x[ErrInvalidRequestBody-22]
-	_ = x[ErrInvalidCopySource-23]
-	_ = x[ErrInvalidMetadataDirective-24]
-	_ = x[ErrInvalidCopyDest-25]
-	_ = x[ErrInvalidPolicyDocument-26]
-	_ = x[ErrInvalidObjectState-27]
-	_ = x[ErrMalformedXML-28]
-	_ = x[ErrMissingContentLength-29]
-	_ = x[ErrMissingContentMD5-30]
-	_ = x[ErrMissingRequestBodyError-31]
-	_ = x[ErrMissingSecurityHeader-32]
-	_ = x[ErrNoSuchBucket-33]
-	_ = x[ErrNoSuchBucketPolicy-34]
-	_ = x[ErrNoSuchBucketLifecycle-35]
-	_ = x[ErrNoSuchLifecycleConfiguration-36]
-	_ = x[ErrInvalidLifecycleWithObjectLock-37]
-	_ = x[ErrNoSuchBucketSSEConfig-38]
-	_ = x[ErrNoSuchCORSConfiguration-39]
-	_ = x[ErrNoSuchWebsiteConfiguration-40]
-	_ = x[ErrReplicationConfigurationNotFoundError-41]
-	_ = x[ErrRemoteDestinationNotFoundError-42]
-	_ = x[ErrReplicationDestinationMissingLock-43]
-	_ = x[ErrRemoteTargetNotFoundError-44]
-	_ = x[ErrReplicationRemoteConnectionError-45]
-	_ = x[ErrReplicationBandwidthLimitError-46]
-	_ = x[ErrBucketRemoteIdenticalToSource-47]
-	_ = x[ErrBucketRemoteAlreadyExists-48]
-	_ = x[ErrBucketRemoteLabelInUse-49]
-	_ = x[ErrBucketRemoteArnTypeInvalid-50]
-	_ = x[ErrBucketRemoteArnInvalid-51]
-	_ = x[ErrBucketRemoteRemoveDisallowed-52]
-	_ = x[ErrRemoteTargetNotVersionedError-53]
-	_ = x[ErrReplicationSourceNotVersionedError-54]
-	_ = x[ErrReplicationNeedsVersioningError-55]
-	_ = x[ErrReplicationBucketNeedsVersioningError-56]
-	_ = x[ErrReplicationDenyEditError-57]
-	_ = x[ErrReplicationNoExistingObjects-58]
-	_ = x[ErrObjectRestoreAlreadyInProgress-59]
-	_ = x[ErrNoSuchKey-60]
-	_ = x[ErrNoSuchUpload-61]
-	_ = x[ErrInvalidVersionID-62]
-	_ = x[ErrNoSuchVersion-63]
-	_ = x[ErrNotImplemented-64]
-	_ = x[ErrPreconditionFailed-65]
-	_ = x[ErrRequestTimeTooSkewed-66]
-	_ = x[ErrSignatureDoesNotMatch-67]
-	_ = x[ErrMethodNotAllowed-68]
-	_ = x[ErrInvalidPart-69]
-	_ = x[ErrInvalidPartOrder-70]
-	_ = x[ErrAuthorizationHeaderMalformed-71]
-	_ = x[ErrMalformedPOSTRequest-72]
-	_ = x[ErrPOSTFileRequired-73]
-	_ = x[ErrSignatureVersionNotSupported-74]
-	_ = x[ErrBucketNotEmpty-75]
-	_ = x[ErrAllAccessDisabled-76]
-	_ = x[ErrMalformedPolicy-77]
-	_ = x[ErrMissingFields-78]
-	_ = x[ErrMissingCredTag-79]
-	_ = x[ErrCredMalformed-80]
-	_ = x[ErrInvalidRegion-81]
-	_ = x[ErrInvalidServiceS3-82]
-	_ = x[ErrInvalidServiceSTS-83]
-	_ = x[ErrInvalidRequestVersion-84]
-	_ = x[ErrMissingSignTag-85]
-	_ = x[ErrMissingSignHeadersTag-86]
-	_ = x[ErrMalformedDate-87]
-	_ = x[ErrMalformedPresignedDate-88]
-	_ = x[ErrMalformedCredentialDate-89]
-	_ = x[ErrMalformedCredentialRegion-90]
-	_ = x[ErrMalformedExpires-91]
-	_ = x[ErrNegativeExpires-92]
-	_ = x[ErrAuthHeaderEmpty-93]
-	_ = x[ErrExpiredPresignRequest-94]
-	_ = x[ErrRequestNotReadyYet-95]
-	_ = x[ErrUnsignedHeaders-96]
-	_ = x[ErrMissingDateHeader-97]
-	_ = x[ErrInvalidQuerySignatureAlgo-98]
-	_ = x[ErrInvalidQueryParams-99]
-	_ = x[ErrBucketAlreadyOwnedByYou-100]
-	_ = x[ErrInvalidDuration-101]
-	_ = x[ErrBucketAlreadyExists-102]
-	_ = x[ErrTooManyBuckets-103]
-	_ = x[ErrMetadataTooLarge-104]
-	_ = x[ErrUnsupportedMetadata-105]
-	_ = x[ErrMaximumExpires-106]
-	_ = x[ErrSlowDown-107]
-	_ = x[ErrInvalidPrefixMarker-108]
-	_ = x[ErrBadRequest-109]
-	_ = x[ErrKeyTooLongError-110]
-	_ = x[ErrInvalidBucketObjectLockConfiguration-111]
-	_ = x[ErrObjectLockConfigurationNotFound-112]
-	_ = x[ErrObjectLockConfigurationNotAllowed-113]
-	_ = x[ErrNoSuchObjectLockConfiguration-114]
-	_ = x[ErrObjectLocked-115]
-	_ = x[ErrInvalidRetentionDate-116]
-	_ = x[ErrPastObjectLockRetainDate-117]
-	_ = x[ErrUnknownWORMModeDirective-118]
-	_ = x[ErrBucketTaggingNotFound-119]
-	_ = x[ErrObjectLockInvalidHeaders-120]
-	_ = x[ErrInvalidTagDirective-121]
-	_ = x[ErrInvalidEncryptionMethod-122]
-	_ = x[ErrInvalidEncryptionKeyID-123]
-	_ = x[ErrInsecureSSECustomerRequest-124]
-	_ = x[ErrSSEMultipartEncrypted-125]
-	_ = x[ErrSSEEncryptedObject-126]
-	_ = x[ErrInvalidEncryptionParameters-127]
-	_ = x[ErrInvalidSSECustomerAlgorithm-128]
-	_ = x[ErrInvalidSSECustomerKey-129]
-	_ = x[ErrMissingSSECustomerKey-130]
-	_ = x[ErrMissingSSECustomerKeyMD5-131]
-	_ = x[ErrSSECustomerKeyMD5Mismatch-132]
-	_ = x[ErrInvalidSSECustomerParameters-133]
-	_ = x[ErrIncompatibleEncryptionMethod-134]
-	_ = x[ErrKMSNotConfigured-135]
-	_ = x[ErrKMSKeyNotFoundException-136]
-	_ = x[ErrNoAccessKey-137]
-	_ = x[ErrInvalidToken-138]
-	_ = x[ErrEventNotification-139]
-	_ = x[ErrARNNotification-140]
-	_ = x[ErrRegionNotification-141]
-	_ = x[ErrOverlappingFilterNotification-142]
-	_ = x[ErrFilterNameInvalid-143]
-	_ = x[ErrFilterNamePrefix-144]
-	_ = x[ErrFilterNameSuffix-145]
-	_ = x[ErrFilterValueInvalid-146]
-	_ = x[ErrOverlappingConfigs-147]
-	_ = x[ErrUnsupportedNotification-148]
-	_ = x[ErrContentSHA256Mismatch-149]
-	_ = x[ErrContentChecksumMismatch-150]
-	_ = x[ErrReadQuorum-151]
-	_ = x[ErrWriteQuorum-152]
-	_ = x[ErrStorageFull-153]
-	_ = x[ErrRequestBodyParse-154]
-	_ = x[ErrObjectExistsAsDirectory-155]
-	_ = x[ErrInvalidObjectName-156]
-	_ = x[ErrInvalidObjectNamePrefixSlash-157]
-	_ = x[ErrInvalidResourceName-158]
-	_ = x[ErrServerNotInitialized-159]
-	_ = x[ErrOperationTimedOut-160]
-	_ = x[ErrClientDisconnected-161]
-	_ = x[ErrOperationMaxedOut-162]
-	_ = x[ErrInvalidRequest-163]
-	_ = x[ErrTransitionStorageClassNotFoundError-164]
-	_ = x[ErrInvalidStorageClass-165]
-	_ = x[ErrBackendDown-166]
-	_ = x[ErrMalformedJSON-167]
-	_ = x[ErrAdminNoSuchUser-168]
-	_ = x[ErrAdminNoSuchGroup-169]
-	_ = x[ErrAdminGroupNotEmpty-170]
-	_ = x[ErrAdminNoSuchJob-171]
-	_ = x[ErrAdminNoSuchPolicy-172]
-	_ = x[ErrAdminInvalidArgument-173]
-	_ = x[ErrAdminInvalidAccessKey-174]
-	_ = x[ErrAdminInvalidSecretKey-175]
-	_ = x[ErrAdminConfigNoQuorum-176]
-	_ = x[ErrAdminConfigTooLarge-177]
-	_ = x[ErrAdminConfigBadJSON-178]
-	_ = x[ErrAdminNoSuchConfigTarget-179]
-	_ = x[ErrAdminConfigEnvOverridden-180]
-	_ = x[ErrAdminConfigDuplicateKeys-181]
-	_ = x[ErrAdminConfigInvalidIDPType-182]
-	_ = x[ErrAdminConfigLDAPValidation-183]
-	_ = x[ErrAdminConfigIDPCfgNameAlreadyExists-184]
-	_ = x[ErrAdminConfigIDPCfgNameDoesNotExist-185]
-	_ = x[ErrAdminCredentialsMismatch-186]
-	_ = x[ErrInsecureClientRequest-187]
-	_ = x[ErrObjectTampered-188]
-	_ = x[ErrSiteReplicationInvalidRequest-189]
-	_ = x[ErrSiteReplicationPeerResp-190]
-	_ = x[ErrSiteReplicationBackendIssue-191]
-	_ = x[ErrSiteReplicationServiceAccountError-192]
-	_ = x[ErrSiteReplicationBucketConfigError-193]
-	_ = x[ErrSiteReplicationBucketMetaError-194]
-	_ = x[ErrSiteReplicationIAMError-195]
-	_ = x[ErrSiteReplicationConfigMissing-196]
-	_ = x[ErrAdminRebalanceAlreadyStarted-197]
-	_ = x[ErrAdminRebalanceNotStarted-198]
-	_ = x[ErrAdminBucketQuotaExceeded-199]
-	_ = x[ErrAdminNoSuchQuotaConfiguration-200]
-	_ = x[ErrHealNotImplemented-201]
-	_ = x[ErrHealNoSuchProcess-202]
-	_ = x[ErrHealInvalidClientToken-203]
-	_ = x[ErrHealMissingBucket-204]
-	_ = x[ErrHealAlreadyRunning-205]
-	_ = x[ErrHealOverlappingPaths-206]
-	_ = x[ErrIncorrectContinuationToken-207]
-	_ = x[ErrEmptyRequestBody-208]
-	_ = x[ErrUnsupportedFunction-209]
-	_ = x[ErrInvalidExpressionType-210]
-	_ = x[ErrBusy-211]
-	_ = x[ErrUnauthorizedAccess-212]
-	_ = x[ErrExpressionTooLong-213]
-	_ = x[ErrIllegalSQLFunctionArgument-214]
-	_ = x[ErrInvalidKeyPath-215]
-	_ = x[ErrInvalidCompressionFormat-216]
-	_ = x[ErrInvalidFileHeaderInfo-217]
-	_ = x[ErrInvalidJSONType-218]
-	_ = x[ErrInvalidQuoteFields-219]
-	_ = x[ErrInvalidRequestParameter-220]
-	_ = x[ErrInvalidDataType-221]
-	_ = x[ErrInvalidTextEncoding-222]
-	_ = x[ErrInvalidDataSource-223]
-	_ = x[ErrInvalidTableAlias-224]
-	_ = x[ErrMissingRequiredParameter-225]
-	_ = x[ErrObjectSerializationConflict-226]
-	_ = x[ErrUnsupportedSQLOperation-227]
-	_ = x[ErrUnsupportedSQLStructure-228]
-	_ = x[ErrUnsupportedSyntax-229]
-	_ = x[ErrUnsupportedRangeHeader-230]
-	_ = x[ErrLexerInvalidChar-231]
-	_ = x[ErrLexerInvalidOperator-232]
-	_ = x[ErrLexerInvalidLiteral-233]
-	_ = x[ErrLexerInvalidIONLiteral-234]
-	_ = x[ErrParseExpectedDatePart-235]
-	_ = x[ErrParseExpectedKeyword-236]
-	_ = x[ErrParseExpectedTokenType-237]
-	_ = x[ErrParseExpected2TokenTypes-238]
-	_ = x[ErrParseExpectedNumber-239]
-	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-240]
-	_ = x[ErrParseExpectedTypeName-241]
-	_ = x[ErrParseExpectedWhenClause-242]
-	_ = x[ErrParseUnsupportedToken-243]
-	_ = x[ErrParseUnsupportedLiteralsGroupBy-244]
-	_ = x[ErrParseExpectedMember-245]
-	_ = x[ErrParseUnsupportedSelect-246]
-	_ = x[ErrParseUnsupportedCase-247]
-	_ = x[ErrParseUnsupportedCaseClause-248]
-	_ = x[ErrParseUnsupportedAlias-249]
-	_ = x[ErrParseUnsupportedSyntax-250]
-	_ = x[ErrParseUnknownOperator-251]
-	_ = x[ErrParseMissingIdentAfterAt-252]
-	_ = x[ErrParseUnexpectedOperator-253]
-	_ = x[ErrParseUnexpectedTerm-254]
-	_ = x[ErrParseUnexpectedToken-255]
-	_ = x[ErrParseUnexpectedKeyword-256]
-	_ = x[ErrParseExpectedExpression-257]
-	_ = x[ErrParseExpectedLeftParenAfterCast-258]
-	_ = x[ErrParseExpectedLeftParenValueConstructor-259]
-	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-260]
-	_ = x[ErrParseExpectedArgumentDelimiter-261]
-	_ = x[ErrParseCastArity-262]
-	_ = x[ErrParseInvalidTypeParam-263]
-	_ = x[ErrParseEmptySelect-264]
-	_ = x[ErrParseSelectMissingFrom-265]
-	_ = x[ErrParseExpectedIdentForGroupName-266]
-	_ = x[ErrParseExpectedIdentForAlias-267]
-	_ = x[ErrParseUnsupportedCallWithStar-268]
-	_ = x[ErrParseNonUnaryAgregateFunctionCall-269]
-	_ = x[ErrParseMalformedJoin-270]
-	_ = x[ErrParseExpectedIdentForAt-271]
-	_ = x[ErrParseAsteriskIsNotAloneInSelectList-272]
-	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-273]
-	_ = x[ErrParseInvalidContextForWildcardInSelectList-274]
-	_ = x[ErrIncorrectSQLFunctionArgumentType-275]
-	_ = x[ErrValueParseFailure-276]
-	_ = x[ErrEvaluatorInvalidArguments-277]
-	_ = x[ErrIntegerOverflow-278]
-	_ = x[ErrLikeInvalidInputs-279]
-	_ = x[ErrCastFailed-280]
-	_ = x[ErrInvalidCast-281]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-282]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-283]
-	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-284]
-	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-285]
-	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-286]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-287]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-288]
-	_ = x[ErrEvaluatorBindingDoesNotExist-289]
-	_ = x[ErrMissingHeaders-290]
-	_ = x[ErrInvalidColumnIndex-291]
-	_ = x[ErrAdminConfigNotificationTargetsFailed-292]
-	_ = x[ErrAdminProfilerNotEnabled-293]
-	_ = x[ErrInvalidDecompressedSize-294]
-	_ = x[ErrAddUserInvalidArgument-295]
-	_ = x[ErrAdminResourceInvalidArgument-296]
-	_ = x[ErrAdminAccountNotEligible-297]
-	_ = x[ErrAccountNotEligible-298]
-	_ = x[ErrAdminServiceAccountNotFound-299]
-	_ = x[ErrPostPolicyConditionInvalidFormat-300]
-	_ = x[ErrInvalidChecksum-301]
+	_ = x[ErrAccessKeyExpired-10]
+	_ = x[ErrAccessKeySourceNotAllowed-11]
+	_ = x[ErrInvalidBucketName-12]
+	_ = x[ErrInvalidDigest-13]
+	_ = x[ErrInvalidRange-14]
+	_ = x[ErrInvalidRangePartNumber-15]
+	_ = x[ErrInvalidCopyPartRange-16]
+	_ = x[ErrInvalidCopyPartRangeSource-17]
+	_ = x[ErrInvalidMaxKeys-18]
+	_ = x[ErrInvalidEncodingMethod-19]
+	_ = x[ErrInvalidMaxUploads-20]
+	_ = x[ErrInvalidMaxParts-21]
+	_ = x[ErrInvalidPartNumberMarker-22]
+	_ = x[ErrInvalidPartNumber-23]
+	_ = x[ErrInvalidRequestBody-24]
+	_ = x[ErrInvalidCopySource-25]
+	_ = x[ErrInvalidMetadataDirective-26]
+	_ = x[ErrInvalidCopyDest-27]
+	_ = x[ErrInvalidPolicyDocument-28]
+	_ = x[ErrInvalidObjectState-29]
+	_ = x[ErrMalformedXML-30]
+	_ = x[ErrMissingContentLength-31]
+	_ = x[ErrMissingContentMD5-32]
+	_ = x[ErrMissingRequestBodyError-33]
+	_ = x[ErrMissingSecurityHeader-34]
+	_ = x[ErrNoSuchBucket-35]
+	_ = x[ErrNoSuchBucketPolicy-36]
+	_ = x[ErrNoSuchBucketLifecycle-37]
+	_ = x[ErrNoSuchLifecycleConfiguration-38]
+	_ = x[ErrInvalidLifecycleWithObjectLock-39]
+	_ = x[ErrNoSuchBucketSSEConfig-40]
+	_ = x[ErrNoSuchCORSConfiguration-41]
+	_ = x[ErrNoSuchWebsiteConfiguration-42]
+	_ = x[ErrReplicationConfigurationNotFoundError-43]
+	_ = x[ErrRemoteDestinationNotFoundError-44]
+	_ = x[ErrReplicationDestinationMissingLock-45]
+	_ = x[ErrRemoteTargetNotFoundError-46]
+	_ = x[ErrReplicationRemoteConnectionError-47]
+	_ = x[ErrReplicationBandwidthLimitError-48]
+	_ = x[ErrBucketRemoteIdenticalToSource-49]
+	_ = x[ErrBucketRemoteAlreadyExists-50]
+	_ = x[ErrBucketRemoteLabelInUse-51]
+	_ = x[ErrBucketRemoteArnTypeInvalid-52]
+	_ = x[ErrBucketRemoteArnInvalid-53]
+	_ = x[ErrBucketRemoteRemoveDisallowed-54]
+	_ = x[ErrRemoteTargetNotVersionedError-55]
+	_ = x[ErrReplicationSourceNotVersionedError-56]
+	_ = x[ErrReplicationNeedsVersioningError-57]
+	_ = x[ErrReplicationBucketNeedsVersioningError-58]
+	_ = x[ErrReplicationDenyEditError-59]
+	_ = x[ErrReplicationNoExistingObjects-60]
+	_ = x[ErrReplicationConflict-61]
+	_ = x[ErrObjectRestoreAlreadyInProgress-62]
+	_ = x[ErrNoSuchKey-63]
+	_ = x[ErrNoSuchUpload-64]
+	_ = x[ErrInvalidVersionID-65]
+	_ = x[ErrNoSuchVersion-66]
+	_ = x[ErrNotImplemented-67]
+	_ = x[ErrPreconditionFailed-68]
+	_ = x[ErrRequestTimeTooSkewed-69]
+	_ = x[ErrSignatureDoesNotMatch-70]
+	_ = x[ErrMethodNotAllowed-71]
+	_ = x[ErrInvalidPart-72]
+	_ = x[ErrInvalidPartOrder-73]
+	_ = x[ErrAuthorizationHeaderMalformed-74]
+	_ = x[ErrMalformedPOSTRequest-75]
+	_ = x[ErrPOSTFileRequired-76]
+	_ = x[ErrSignatureVersionNotSupported-77]
+	_ = x[ErrBucketNotEmpty-78]
+	_ = x[ErrAllAccessDisabled-79]
+	_ = x[ErrMalformedPolicy-80]
+	_ = x[ErrMissingFields-81]
+	_ = x[ErrMissingCredTag-82]
+	_ = x[ErrCredMalformed-83]
+	_ = x[ErrInvalidRegion-84]
+	_ = x[ErrInvalidServiceS3-85]
+	_ = x[ErrInvalidServiceSTS-86]
+	_ = x[ErrInvalidRequestVersion-87]
+	_ = x[ErrMissingSignTag-88]
+	_ = x[ErrMissingSignHeadersTag-89]
+	_ = x[ErrMalformedDate-90]
+	_ = x[ErrMalformedPresignedDate-91]
+	_ = x[ErrMalformedCredentialDate-92]
+	_ = x[ErrMalformedCredentialRegion-93]
+	_ = x[ErrMalformedExpires-94]
+	_ = x[ErrNegativeExpires-95]
+	_ = x[ErrAuthHeaderEmpty-96]
+	_ = x[ErrExpiredPresignRequest-97]
+	_ = x[ErrRequestNotReadyYet-98]
+	_ = x[ErrUnsignedHeaders-99]
+	_ = x[ErrMissingDateHeader-100]
+	_ = x[ErrInvalidQuerySignatureAlgo-101]
+	_ = x[ErrInvalidQueryParams-102]
+	_ = x[ErrBucketAlreadyOwnedByYou-103]
+	_ = x[ErrInvalidDuration-104]
+	_ = x[ErrBucketAlreadyExists-105]
+	_ = x[ErrTooManyBuckets-106]
+	_ = x[ErrMetadataTooLarge-107]
+	_ = x[ErrUnsupportedMetadata-108]
+	_ = x[ErrMaximumExpires-109]
+	_ = x[ErrSlowDown-110]
+	_ = x[ErrInvalidPrefixMarker-111]
+	_ = x[ErrBadRequest-112]
+	_ = x[ErrKeyTooLongError-113]
+	_ = x[ErrInvalidBucketObjectLockConfiguration-114]
+	_ = x[ErrObjectLockConfigurationNotFound-115]
+	_ = x[ErrObjectLockConfigurationNotAllowed-116]
+	_ = x[ErrNoSuchObjectLockConfiguration-117]
+	_ = x[ErrObjectLocked-118]
+	_ = x[ErrInvalidRetentionDate-119]
+	_ = x[ErrPastObjectLockRetainDate-120]
+	_ = x[ErrUnknownWORMModeDirective-121]
+	_ = x[ErrBucketTaggingNotFound-122]
+	_ = x[ErrObjectLockInvalidHeaders-123]
+	_ = x[ErrInvalidTagDirective-124]
+	_ = x[ErrInvalidEncryptionMethod-125]
+	_ = x[ErrInvalidEncryptionKeyID-126]
+	_ = x[ErrInsecureSSECustomerRequest-127]
+	_ = x[ErrSSEMultipartEncrypted-128]
+	_ = x[ErrSSEEncryptedObject-129]
+	_ = x[ErrInvalidEncryptionParameters-130]
+	_ = x[ErrInvalidSSECustomerAlgorithm-131]
+	_ = x[ErrInvalidSSECustomerKey-132]
+	_ = x[ErrMissingSSECustomerKey-133]
+	_ = x[ErrMissingSSECustomerKeyMD5-134]
+	_ = x[ErrSSECustomerKeyMD5Mismatch-135]
+	_ = x[ErrInvalidSSECustomerParameters-136]
+	_ = x[ErrIncompatibleEncryptionMethod-137]
+	_ = x[ErrKMSNotConfigured-138]
+	_ = x[ErrKMSKeyNotFoundException-139]
+	_ = x[ErrNoAccessKey-140]
+	_ = x[ErrInvalidToken-141]
+	_ = x[ErrEventNotification-142]
+	_ = x[ErrARNNotification-143]
+	_ = x[ErrRegionNotification-144]
+	_ = x[ErrOverlappingFilterNotification-145]
+	_ = x[ErrFilterNameInvalid-146]
+	_ = x[ErrFilterNamePrefix-147]
+	_ = x[ErrFilterNameSuffix-148]
+	_ = x[ErrFilterValueInvalid-149]
+	_ = x[ErrOverlappingConfigs-150]
+	_ = x[ErrUnsupportedNotification-151]
+	_ = x[ErrContentSHA256Mismatch-152]
+	_ = x[ErrContentChecksumMismatch-153]
+	_ = x[ErrReadQuorum-154]
+	_ = x[ErrWriteQuorum-155]
+	_ = x[ErrStorageFull-156]
+	_ = x[ErrRequestBodyParse-157]
+	_ = x[ErrObjectExistsAsDirectory-158]
+	_ = x[ErrInvalidObjectName-159]
+	_ = x[ErrInvalidObjectNamePrefixSlash-160]
+	_ = x[ErrInvalidResourceName-161]
+	_ = x[ErrServerNotInitialized-162]
+	_ = x[ErrOperationTimedOut-163]
+	_ = x[ErrClientDisconnected-164]
+	_ = x[ErrOperationMaxedOut-165]
+	_ = x[ErrInvalidRequest-166]
+	_ = x[ErrTransitionStorageClassNotFoundError-167]
+	_ = x[ErrInvalidStorageClass-168]
+	_ = x[ErrBackendDown-169]
+	_ = x[ErrMalformedJSON-170]
+	_ = x[ErrAdminNoSuchUser-171]
+	_ = x[ErrAdminNoSuchGroup-172]
+	_ = x[ErrAdminGroupNotEmpty-173]
+	_ = x[ErrAdminNoSuchJob-174]
+	_ = x[ErrAdminNoSuchPolicy-175]
+	_ = x[ErrAdminInvalidArgument-176]
+	_ = x[ErrAdminInvalidAccessKey-177]
+	_ = x[ErrAdminInvalidSecretKey-178]
+	_ = x[ErrAdminConfigNoQuorum-179]
+	_ = x[ErrAdminConfigTooLarge-180]
+	_ = x[ErrAdminConfigBadJSON-181]
+	_ = x[ErrAdminNoSuchConfigTarget-182]
+	_ = x[ErrAdminConfigEnvOverridden-183]
+	_ = x[ErrAdminConfigDuplicateKeys-184]
+	_ = x[ErrAdminConfigInvalidIDPType-185]
+	_ = x[ErrAdminConfigLDAPValidation-186]
+	_ = x[ErrAdminConfigIDPCfgNameAlreadyExists-187]
+	_ = x[ErrAdminConfigIDPCfgNameDoesNotExist-188]
+	_ = x[ErrAdminCredentialsMismatch-189]
+	_ = x[ErrInsecureClientRequest-190]
+	_ = x[ErrObjectTampered-191]
+	_ = x[ErrSiteReplicationInvalidRequest-192]
+	_ = x[ErrSiteReplicationPeerResp-193]
+	_ = x[ErrSiteReplicationBackendIssue-194]
+	_ = x[ErrSiteReplicationServiceAccountError-195]
+	_ = x[ErrSiteReplicationBucketConfigError-196]
+	_ = x[ErrSiteReplicationBucketMetaError-197]
+	_ = x[ErrSiteReplicationIAMError-198]
+	_ = x[ErrSiteReplicationConfigMissing-199]
+	_ = x[ErrAdminRebalanceAlreadyStarted-200]
+	_ = x[ErrAdminRebalanceNotStarted-201]
+	_ = x[ErrAdminBucketQuotaExceeded-202]
+	_ = x[ErrAdminNoSuchQuotaConfiguration-203]
+	_ = x[ErrHealNotImplemented-204]
+	_ = x[ErrHealNoSuchProcess-205]
+	_ = x[ErrHealInvalidClientToken-206]
+	_ = x[ErrHealMissingBucket-207]
+	_ = x[ErrHealAlreadyRunning-208]
+	_ = x[ErrHealOverlappingPaths-209]
+	_ = x[ErrIncorrectContinuationToken-210]
+	_ = x[ErrEmptyRequestBody-211]
+	_ = x[ErrUnsupportedFunction-212]
+	_ = x[ErrInvalidExpressionType-213]
+	_ = x[ErrBusy-214]
+	_ = x[ErrUnauthorizedAccess-215]
+	_ = x[ErrExpressionTooLong-216]
+	_ = x[ErrIllegalSQLFunctionArgument-217]
+	_ = x[ErrInvalidKeyPath-218]
+	_ = x[ErrInvalidCompressionFormat-219]
+	_ = x[ErrInvalidFileHeaderInfo-220]
+	_ = x[ErrInvalidJSONType-221]
+	_ = x[ErrInvalidQuoteFields-222]
+	_ = x[ErrInvalidRequestParameter-223]
+	_ = x[ErrInvalidDataType-224]
+	_ = x[ErrInvalidTextEncoding-225]
+	_ = x[ErrInvalidDataSource-226]
+	_ = x[ErrInvalidTableAlias-227]
+	_ = x[ErrMissingRequiredParameter-228]
+	_ = x[ErrObjectSerializationConflict-229]
+	_ = x[ErrUnsupportedSQLOperation-230]
+	_ = x[ErrUnsupportedSQLStructure-231]
+	_ = x[ErrUnsupportedSyntax-232]
+	_ = x[ErrUnsupportedRangeHeader-233]
+	_ = x[ErrLexerInvalidChar-234]
+	_ = x[ErrLexerInvalidOperator-235]
+	_ = x[ErrLexerInvalidLiteral-236]
+	_ = x[ErrLexerInvalidIONLiteral-237]
+	_ = x[ErrParseExpectedDatePart-238]
+	_ = x[ErrParseExpectedKeyword-239]
+	_ = x[ErrParseExpectedTokenType-240]
+	_ = x[ErrParseExpected2TokenTypes-241]
+	_ = x[ErrParseExpectedNumber-242]
+	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-243]
+	_ = x[ErrParseExpectedTypeName-244]
+	_ = x[ErrParseExpectedWhenClause-245]
+	_ = x[ErrParseUnsupportedToken-246]
+	_ = x[ErrParseUnsupportedLiteralsGroupBy-247]
+	_ = x[ErrParseExpectedMember-248]
+	_ = x[ErrParseUnsupportedSelect-249]
+	_ = x[ErrParseUnsupportedCase-250]
+	_ = x[ErrParseUnsupportedCaseClause-251]
+	_ = x[ErrParseUnsupportedAlias-252]
+	_ = x[ErrParseUnsupportedSyntax-253]
+	_ = x[ErrParseUnknownOperator-254]
+	_ = x[ErrParseMissingIdentAfterAt-255]
+	_ = x[ErrParseUnexpectedOperator-256]
+	_ = x[ErrParseUnexpectedTerm-257]
+	_ = x[ErrParseUnexpectedToken-258]
+	_ = x[ErrParseUnexpectedKeyword-259]
+	_ = x[ErrParseExpectedExpression-260]
+	_ = x[ErrParseExpectedLeftParenAfterCast-261]
+	_ = x[ErrParseExpectedLeftParenValueConstructor-262]
+	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-263]
+	_ = x[ErrParseExpectedArgumentDelimiter-264]
+	_ = x[ErrParseCastArity-265]
+	_ = x[ErrParseInvalidTypeParam-266]
+	_ = x[ErrParseEmptySelect-267]
+	_ = x[ErrParseSelectMissingFrom-268]
+	_ = x[ErrParseExpectedIdentForGroupName-269]
+	_ = x[ErrParseExpectedIdentForAlias-270]
+	_ = x[ErrParseUnsupportedCallWithStar-271]
+	_ = x[ErrParseNonUnaryAgregateFunctionCall-272]
+	_ = x[ErrParseMalformedJoin-273]
+	_ = x[ErrParseExpectedIdentForAt-274]
+	_ = x[ErrParseAsteriskIsNotAloneInSelectList-275]
+	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-276]
+	_ = x[ErrParseInvalidContextForWildcardInSelectList-277]
+	_ = x[ErrIncorrectSQLFunctionArgumentType-278]
+	_ = x[ErrValueParseFailure-279]
+	_ = x[ErrEvaluatorInvalidArguments-280]
+	_ = x[ErrIntegerOverflow-281]
+	_ = x[ErrLikeInvalidInputs-282]
+	_ = x[ErrCastFailed-283]
+	_ = x[ErrInvalidCast-284]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-285]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-286]
+	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-287]
+	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-288]
+	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-289]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-290]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-291]
+	_ = x[ErrEvaluatorBindingDoesNotExist-292]
+	_ = x[ErrMissingHeaders-293]
+	_ = x[ErrInvalidColumnIndex-294]
+	_ = x[ErrAdminConfigNotificationTargetsFailed-295]
+	_ = x[ErrAdminProfilerNotEnabled-296]
+	_ = x[ErrInvalidDecompressedSize-297]
+	_ = x[ErrAddUserInvalidArgument-298]
+	_ = x[ErrAdminResourceInvalidArgument-299]
+	_ = x[ErrAdminAccountNotEligible-300]
+	_ = x[ErrAccountNotEligible-301]
+	_ = x[ErrAdminServiceAccountNotFound-302]
+	_ = x[ErrPostPolicyConditionInvalidFormat-303]
+	_ = x[ErrInvalidChecksum-304]
 }
 
-const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDAccessKeyDisabledInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationInvalidLifecycleWithObjectLockNoSuchBucketSSEConfigNoSuchCORSConfigurationNoSuchWebsiteConfigurationReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationDenyEditErrorReplicationNoExistingObjectsObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsTooManyBucketsMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInvalidEncryptionKeyIDInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredKMSKeyNotFoundExceptionNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidOverlappingConfigsUnsupportedNotificationContentSHA256MismatchContentChecksumMismatchReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryInvalidObjectNameInvalidObjectNamePrefixSlashInvalidResourceNameServerNotInitializedOperationTimedOutClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchJobAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminNoSuchConfigTargetAdminConfigEnvOverriddenAdminConfigDuplicateKeysAdminConfigInvalidIDPTypeAdminConfigLDAPValidationAdminConfigIDPCfgNameAlreadyExistsAdminConfigIDPCfgNameDoesNotExistAdminCredentialsMismatchInsecureClientRequestObjectTamperedSiteReplicationInvalidRequestSiteReplicationPeerRespSiteReplicationBackendIssueSiteReplicationServiceAccountErrorSiteReplicationBucketConfigErrorSiteReplicationBucketMetaErrorSiteReplicationIAMErrorSiteReplicationConfigMissingAdminRebalanceAlreadyStartedAdminRebalanceNotStartedAdminBucketQuotaExceededAdminNoSuchQuotaConfigurationHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminResourceInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundPostPolicyConditionInvalidFormatInvalidChecksum"
+const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDAccessKeyDisabledAccessKeyExpiredAccessKeySourceNotAllowedInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationInvalidLifecycleWithObjectLockNoSuchBucketSSEConfigNoSuchCORSConfigurationNoSuchWebsiteConfigurationReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationDenyEditErrorReplicationNoExistingObjectsReplicationConflictObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsTooManyBucketsMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInvalidEncryptionKeyIDInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredKMSKeyNotFoundExceptionNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidOverlappingConfigsUnsupportedNotificationContentSHA256MismatchContentChecksumMismatchReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryInvalidObjectNameInvalidObjectNamePrefixSlashInvalidResourceNameServerNotInitializedOperationTimedOutClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchJobAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminNoSuchConfigTargetAdminConfigEnvOverriddenAdminConfigDuplicateKeysAdminConfigInvalidIDPTypeAdminConfigLDAPValidationAdminConfigIDPCfgNameAlreadyExistsAdminConfigIDPCfgNameDoesNotExistAdminCredentialsMismatchInsecureClientRequestObjectTamperedSiteReplicationInvalidRequestSiteReplicationPeerRespSiteReplicationBackendIssueSiteReplicationServiceAccountErrorSiteReplicationBucketConfigErrorSiteReplicationBucketMetaErrorSiteReplicationIAMErrorSiteReplicationConfigMissingAdminRebalanceAlreadyStartedAdminRebalanceNotStartedAdminBucketQuotaExceededAdminNoSuchQuotaConfigurationHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminResourceInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundPostPolicyConditionInvalidFormatInvalidChecksum"
 
-var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 146, 159, 171, 193, 213, 239, 253, 274, 291, 306, 329, 346, 364, 381, 405, 420, 441, 459, 471, 491, 508, 531, 552, 564, 582, 603, 631, 661, 682, 705, 731, 768, 798, 831, 856, 888, 918, 947, 972, 994, 1020, 1042, 1070, 1099, 1133, 1164, 1201, 1225, 1253, 1283, 1292, 1304, 1320, 1333, 1347, 1365, 1385, 1406, 1422, 1433, 1449, 1477, 1497, 1513, 1541, 1555, 1572, 1587, 1600, 1614, 1627, 1640, 1656, 1673, 1694, 1708, 1729, 1742, 1764, 1787, 1812, 1828, 1843, 1858, 1879, 1897, 1912, 1929, 1954, 1972, 1995, 2010, 2029, 2043, 2059, 2078, 2092, 2100, 2119, 2129, 2144, 2180, 2211, 2244, 2273, 2285, 2305, 2329, 2353, 2374, 2398, 2417, 2440, 2462, 2488, 2509, 2527, 2554, 2581, 2602, 2623, 2647, 2672, 2700, 2728, 2744, 2767, 2778, 2790, 2807, 2822, 2840, 2869, 2886, 2902, 2918, 2936, 2954, 2977, 2998, 3021, 3031, 3042, 3053, 3069, 3092, 3109, 3137, 3156, 3176, 3193, 3211, 3228, 3242, 3277, 3296, 3307, 3320, 3335, 3351, 3369, 3383, 3400, 3420, 3441, 3462, 3481, 3500, 3518, 3541, 3565, 3589, 3614, 3639, 3673, 3706, 3730, 3751, 3765, 3794, 3817, 3844, 3878, 3910, 3940, 3963, 3991, 4019, 4043, 4067, 4096, 4114, 4131, 4153, 4170, 4188, 4208, 4234, 4250, 4269, 4290, 4294, 4312, 4329, 4355, 4369, 4393, 4414, 4429, 4447, 4470, 4485, 4504, 4521, 4538, 4562, 4589, 4612, 4635, 4652, 4674, 4690, 4710, 4729, 4751, 4772, 4792, 4814, 4838, 4857, 4899, 4920, 4943, 4964, 4995, 5014, 5036, 5056, 5082, 5103, 5125, 5145, 5169, 5192, 5211, 5231, 5253, 5276, 5307, 5345, 5386, 5416, 5430, 5451, 5467, 5489, 5519, 5545, 5573, 5606, 5624, 5647, 5682, 5722, 5764, 5796, 5813, 5838, 5853, 5870, 5880, 5891, 5929, 5983, 6029, 6081, 6129, 6172, 6216, 6244, 6258, 6276, 6312, 6335, 6358, 6380, 6408, 6431, 6449, 6476, 6508, 6523}
+var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 145, 170, 187, 200, 212, 234, 254, 280, 294, 315, 332, 347, 370, 387, 405, 422, 446, 461, 482, 500, 512, 532, 549, 572, 593, 605, 623, 644, 672, 702, 723, 746, 772, 809, 839, 872, 897, 929, 959, 988, 1013, 1035, 1061, 1083, 1111, 1140, 1174, 1205, 1242, 1266, 1294, 1313, 1343, 1352, 1364, 1380, 1393, 1407, 1425, 1445, 1466, 1482, 1493, 1509, 1537, 1557, 1573, 1601, 1615, 1632, 1647, 1660, 1674, 1687, 1700, 1716, 1733, 1754, 1768, 1789, 1802, 1824, 1847, 1872, 1888, 1903, 1918, 1939, 1957, 1972, 1989, 2014, 2032, 2055, 2070, 2089, 2103, 2119, 2138, 2152, 2160, 2179, 2189, 2204, 2240, 2271, 2304, 2333, 2345, 2365, 2389, 2413, 2434, 2458, 2477, 2500, 2522, 2548, 2569, 2587, 2614, 2641, 2662, 2683, 2707, 2732, 2760, 2788, 2804, 2827, 2838, 2850, 2867, 2882, 2900, 2929, 2946, 2962, 2978, 2996, 3014, 3037, 3058, 3081, 3091, 3102, 3113, 3129, 3152, 3169, 3197, 3216, 3236, 3253, 3271, 3288, 3302, 3337, 3356, 3367, 3380, 3395, 3411, 3429, 3443, 3460, 3480, 3501, 3522, 3541, 3560, 3578, 3601, 3625, 3649, 3674, 3699, 3733, 3766, 3790, 3811, 3825, 3854, 3877, 3904, 3938, 3970, 4000, 4023, 4051, 4079, 4103, 4127, 4156, 4174, 4191, 4213, 4230, 4248, 4268, 4294, 4310, 4329, 4350, 4354, 4372, 4389, 4415, 4429, 4453, 4474, 4489, 4507, 4530, 4545, 4564, 4581, 4598, 4622, 4649, 4672, 4695, 4712, 4734, 4750, 4770, 4789, 4811, 4832, 4852, 4874, 4898, 4917, 4959, 4980, 5003, 5024, 5055, 5074, 5096, 5116, 5142, 5163, 5185, 5205, 5229, 5252, 5271, 5291, 5313, 5336, 5367, 5405, 5446, 5476, 5490, 5511, 5527, 5549, 5579, 5605, 5633, 5666, 5684, 5707, 5742, 5782, 5824, 5856, 5873, 5898, 5913, 5930, 5940, 5951, 5989, 6043, 6089, 6141, 6189, 6232, 6276, 6304, 6318, 6336, 6372, 6395, 6418, 6440, 6468, 6491, 6509, 6536, 6568, 6583}
 
 func (i APIErrorCode) String() string {
 	if i < 0 || i >= APIErrorCode(len(_APIErrorCode_index)-1) {
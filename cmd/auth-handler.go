@@ -25,6 +25,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -281,6 +282,11 @@ func checkClaimsFromToken(r *http.Request, cred auth.Credentials) (map[string]in
 		if err != nil {
 			return nil, toAPIErrorCode(r.Context(), err)
 		}
+		if cred.IsServiceAccount() {
+			if s3Err := checkSvcAccRestrictions(r, claims); s3Err != ErrNone {
+				return nil, s3Err
+			}
+		}
 		return claims, ErrNone
 	}
 
@@ -288,6 +294,39 @@ func checkClaimsFromToken(r *http.Request, cred auth.Credentials) (map[string]in
 	return claims.Map(), ErrNone
 }
 
+// Validate that a service account has not expired and that the request
+// comes from one of the networks the service account is restricted to.
+//
+// The source is always the address of the connected peer, client supplied
+// forwarding headers such as X-Forwarded-For are not trusted here.
+func checkSvcAccRestrictions(r *http.Request, claims map[string]interface{}) APIErrorCode {
+	sr, err := parseSvcAccRestrictions(claims)
+	if err != nil {
+		logger.LogIf(r.Context(), err)
+		return ErrAccessDenied
+	}
+
+	if sr.IsExpired(UTCNow()) {
+		return ErrAccessKeyExpired
+	}
+
+	if !sr.IsSourceAllowed(getPeerIP(r)) {
+		return ErrAccessKeySourceNotAllowed
+	}
+
+	return ErrNone
+}
+
+// getPeerIP - returns the address of the connected peer of the request,
+// unlike handlers.GetSourceIP forwarding headers are ignored.
+func getPeerIP(r *http.Request) string {
+	peerIP, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return peerIP
+}
+
 // Check request auth type verifies the incoming http request
 //   - validates the request signature
 //   - validates the policy action if anonymous tests bucket policies if any,
@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -498,3 +499,102 @@ func TestValidateAdminSignature(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckSvcAccRestrictions(t *testing.T) {
+	newClaims := func(expiration time.Time, allowedIPs ...string) map[string]interface{} {
+		claims := make(map[string]interface{})
+		if !expiration.IsZero() {
+			claims[svcAccExpiryClaim] = float64(expiration.Unix())
+		}
+		if len(allowedIPs) > 0 {
+			if err := setSvcAccAllowedIPsClaim(claims, allowedIPs); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return claims
+	}
+
+	testCases := []struct {
+		claims       map[string]interface{}
+		remoteAddr   string
+		expected     APIErrorCode
+		forwardedFor string
+	}{
+		// Test 1 - no restrictions.
+		{newClaims(time.Time{}), "10.0.0.1:9000", ErrNone, ""},
+		// Test 2 - expiry in the future.
+		{newClaims(UTCNow().Add(time.Hour)), "10.0.0.1:9000", ErrNone, ""},
+		// Test 3 - expiry in the past.
+		{newClaims(UTCNow().Add(-time.Hour)), "10.0.0.1:9000", ErrAccessKeyExpired, ""},
+		// Test 4 - source within an allowed network.
+		{newClaims(time.Time{}, "10.0.0.0/8", "192.168.1.1"), "10.20.30.40:9000", ErrNone, ""},
+		// Test 5 - source matching an allowed single address.
+		{newClaims(time.Time{}, "10.0.0.0/8", "192.168.1.1"), "192.168.1.1:9000", ErrNone, ""},
+		// Test 6 - source outside of the allowed networks.
+		{newClaims(time.Time{}, "10.0.0.0/8", "192.168.1.1"), "192.168.1.2:9000", ErrAccessKeySourceNotAllowed, ""},
+		// Test 7 - IPv6 source within an allowed network.
+		{newClaims(time.Time{}, "fd00::/8"), "[fd00::1]:9000", ErrNone, ""},
+		// Test 8 - expiry is checked before the source network.
+		{newClaims(UTCNow().Add(-time.Hour), "10.0.0.0/8"), "192.168.1.2:9000", ErrAccessKeyExpired, ""},
+		// Test 9 - malformed network restriction is rejected.
+		{map[string]interface{}{svcAccAllowedIPsClaim: "not-an-ip"}, "10.0.0.1:9000", ErrAccessDenied, ""},
+		// Test 10 - forwarding headers cannot be used to spoof an allowed source.
+		{newClaims(time.Time{}, "10.0.0.0/8"), "192.168.1.2:9000", ErrAccessKeySourceNotAllowed, "10.0.0.1"},
+		// Test 11 - forwarding headers do not deny an allowed peer.
+		{newClaims(time.Time{}, "10.0.0.0/8"), "10.0.0.1:9000", ErrNone, "192.168.1.2"},
+	}
+
+	for i, testCase := range testCases {
+		req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1:9000/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.RemoteAddr = testCase.remoteAddr
+		if testCase.forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", testCase.forwardedFor)
+		}
+		if s3Err := checkSvcAccRestrictions(req, testCase.claims); s3Err != testCase.expected {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.expected, s3Err)
+		}
+	}
+}
+
+func TestSetSvcAccRestrictionClaims(t *testing.T) {
+	claims := make(map[string]interface{})
+	if err := setSvcAccExpiryClaim(claims, UTCNow().Add(-time.Minute)); err != errIAMServiceAccountInvalidExpiry {
+		t.Fatalf("Expected %v, got %v", errIAMServiceAccountInvalidExpiry, err)
+	}
+	if err := setSvcAccAllowedIPsClaim(claims, []string{"10.0.0.1/40"}); err != errIAMServiceAccountInvalidAllowedIPs {
+		t.Fatalf("Expected %v, got %v", errIAMServiceAccountInvalidAllowedIPs, err)
+	}
+
+	expiration := UTCNow().Add(time.Hour).Truncate(time.Second)
+	if err := setSvcAccExpiryClaim(claims, expiration); err != nil {
+		t.Fatal(err)
+	}
+	if err := setSvcAccAllowedIPsClaim(claims, []string{"10.1.2.3/8", " 192.168.1.1 "}); err != nil {
+		t.Fatal(err)
+	}
+
+	sr, err := parseSvcAccRestrictions(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sr.expiration.Equal(expiration) {
+		t.Errorf("Expected expiration %v, got %v", expiration, sr.expiration)
+	}
+	if got := strings.Join(sr.AllowedIPs(), ","); got != "10.0.0.0/8,192.168.1.1/32" {
+		t.Errorf("Expected allowed IPs %s, got %s", "10.0.0.0/8,192.168.1.1/32", got)
+	}
+
+	// Zero expiration and empty network list remove the restrictions.
+	if err = setSvcAccExpiryClaim(claims, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = setSvcAccAllowedIPsClaim(claims, []string{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(claims) != 0 {
+		t.Errorf("Expected no restriction claims, got %v", claims)
+	}
+}
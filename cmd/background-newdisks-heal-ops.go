@@ -79,10 +79,29 @@ type healingTracker struct {
 
 	// Filled during heal.
 	HealedBuckets []string
+
+	// Progress per priority class.
+	Classes []healClassProgress
 	// Add future tracking capabilities
 	// Be sure that they are included in toHealingDisk
 }
 
+// healClassProgress is the healing progress of a priority class.
+type healClassProgress struct {
+	Class       string `json:"class"`
+	Weight      int    `json:"weight"`
+	ItemsHealed uint64 `json:"itemsHealed"`
+	ItemsFailed uint64 `json:"itemsFailed"`
+	BytesDone   uint64 `json:"bytesDone"`
+	BytesFailed uint64 `json:"bytesFailed"`
+
+	// Numbers when current bucket started healing.
+	ResumeItemsHealed uint64 `json:"-"`
+	ResumeItemsFailed uint64 `json:"-"`
+	ResumeBytesDone   uint64 `json:"-"`
+	ResumeBytesFailed uint64 `json:"-"`
+}
+
 // loadHealingTracker will load the healing tracker from the supplied disk.
 // The disk ID will be validated against the loaded one.
 func loadHealingTracker(ctx context.Context, disk StorageAPI) (*healingTracker, error) {
@@ -185,6 +204,13 @@ func (h *healingTracker) resume() {
 	h.ItemsFailed = h.ResumeItemsFailed
 	h.BytesDone = h.ResumeBytesDone
 	h.BytesFailed = h.ResumeBytesFailed
+	for i := range h.Classes {
+		c := &h.Classes[i]
+		c.ItemsHealed = c.ResumeItemsHealed
+		c.ItemsFailed = c.ResumeItemsFailed
+		c.BytesDone = c.ResumeBytesDone
+		c.BytesFailed = c.ResumeBytesFailed
+	}
 }
 
 // bucketDone should be called when a bucket is done healing.
@@ -194,6 +220,13 @@ func (h *healingTracker) bucketDone(bucket string) {
 	h.ResumeItemsFailed = h.ItemsFailed
 	h.ResumeBytesDone = h.BytesDone
 	h.ResumeBytesFailed = h.BytesFailed
+	for i := range h.Classes {
+		c := &h.Classes[i]
+		c.ResumeItemsHealed = c.ItemsHealed
+		c.ResumeItemsFailed = c.ItemsFailed
+		c.ResumeBytesDone = c.BytesDone
+		c.ResumeBytesFailed = c.BytesFailed
+	}
 	h.HealedBuckets = append(h.HealedBuckets, bucket)
 	for i, b := range h.QueuedBuckets {
 		if b == bucket {
@@ -203,6 +236,20 @@ func (h *healingTracker) bucketDone(bucket string) {
 	}
 }
 
+// classProgress returns the progress of the class, added if missing.
+func (h *healingTracker) classProgress(class string, weight int) *healClassProgress {
+	for i := range h.Classes {
+		if h.Classes[i].Class == class {
+			return &h.Classes[i]
+		}
+	}
+	h.Classes = append(h.Classes, healClassProgress{Class: class, Weight: weight})
+	sort.SliceStable(h.Classes, func(i, j int) bool {
+		return h.Classes[i].Weight > h.Classes[j].Weight
+	})
+	return h.classProgress(class, weight)
+}
+
 // setQueuedBuckets will add buckets, but exclude any that is already in h.HealedBuckets.
 // Order is preserved.
 func (h *healingTracker) setQueuedBuckets(buckets []BucketInfo) {
@@ -261,7 +308,11 @@ func initAutoHeal(ctx context.Context, objAPI ObjectLayer) {
 
 	globalBackgroundHealState.pushHealLocalDisks(getLocalDisksToHeal()...)
 
+	resumeDriveEvacuations(ctx, z)
+
 	go monitorLocalDisksAndHeal(ctx, z)
+
+	go runBitrotScrubs(ctx, z)
 }
 
 func getLocalDisksToHeal() (disksToHeal Endpoints) {
@@ -327,12 +378,16 @@ func healFreshDisk(ctx context.Context, z *erasureServerPools, endpoint Endpoint
 		Name: pathJoin(minioMetaBucket, bucketMetaPrefix),
 	})
 
-	// Heal latest buckets first.
+	// Heal buckets with the highest priority first, then latest buckets first.
+	priority := globalHealConfig.PriorityRules()
 	sort.Slice(buckets, func(i, j int) bool {
 		a, b := strings.HasPrefix(buckets[i].Name, minioMetaBucket), strings.HasPrefix(buckets[j].Name, minioMetaBucket)
 		if a != b {
 			return a
 		}
+		if wa, wb := priority.BucketWeight(buckets[i].Name), priority.BucketWeight(buckets[j].Name); wa != wb {
+			return wa > wb
+		}
 		return buckets[i].Created.After(buckets[j].Created)
 	})
 
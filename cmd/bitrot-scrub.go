@@ -0,0 +1,487 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/schedule"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// Scrub report of a drive, stored on the drive.
+	scrubReportFilename = ".scrub.bin"
+
+	// Last scrub requested with the admin API.
+	scrubRequestFile = bucketMetaPrefix + "/scrub-request.json"
+
+	// Maximum number of corrupted shards listed by a scrub report.
+	maxScrubReportShards = 10000
+
+	// Interval between checks for scrubs to start.
+	scrubCheckInterval = time.Minute
+)
+
+// Scrub status reported by the admin API.
+const (
+	scrubRunning     = "running"
+	scrubPaused      = "paused"      // Waiting for a scrub window
+	scrubInterrupted = "interrupted" // Stopped by a shutdown, resumed at the next start
+	scrubComplete    = "complete"
+	scrubFailed      = "failed"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+//msgp:ignore bitrotScrubs scrubRequest
+
+// scrubShard is a corrupted or missing shard found by a scrub.
+type scrubShard struct {
+	Bucket    string    `json:"bucket"`
+	Object    string    `json:"object"`
+	VersionID string    `json:"versionId,omitempty"`
+	Error     string    `json:"error"`
+	Detected  time.Time `json:"detected"`
+}
+
+// scrubReport reports the last scrub of a drive, it is stored on the drive
+// to resume the scrub after a restart.
+type scrubReport struct {
+	Endpoint   string    `json:"endpoint"`
+	Status     string    `json:"status"`
+	Started    time.Time `json:"started"`
+	LastUpdate time.Time `json:"lastUpdate"`
+	Finished   time.Time `json:"finished,omitempty"`
+	Error      string    `json:"error,omitempty"`
+
+	Versions   uint64 `json:"versions"`   // Number of object versions verified
+	Bytes      uint64 `json:"bytes"`      // Number of shard bytes verified
+	Corrupted  uint64 `json:"corrupted"`  // Number of corrupted or missing shards
+	HealQueued uint64 `json:"healQueued"` // Number of object versions queued for healing
+
+	Shards    []scrubShard `json:"shards,omitempty"`
+	Truncated bool         `json:"truncated"` // Set if more corrupted shards were found than listed
+
+	// Last object verified.
+	Bucket string `json:"bucket,omitempty"`
+	Object string `json:"object,omitempty"`
+}
+
+// scrubRequest is a scrub of all the drives requested with the admin API.
+type scrubRequest struct {
+	Requested time.Time `json:"requested"`
+}
+
+// bitrotScrubs tracks the scrubs running on the local drives.
+type bitrotScrubs struct {
+	mu      sync.Mutex
+	running map[string]struct{}
+}
+
+var globalBitrotScrubs = &bitrotScrubs{
+	running: make(map[string]struct{}),
+}
+
+// start marks the scrub of drive as running, returns false if it is
+// running already.
+func (b *bitrotScrubs) start(drive string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.running[drive]; ok {
+		return false
+	}
+	b.running[drive] = struct{}{}
+	return true
+}
+
+func (b *bitrotScrubs) done(drive string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.running, drive)
+}
+
+// loadScrubReport loads the report of the last scrub of disk.
+func loadScrubReport(ctx context.Context, disk StorageAPI) (*scrubReport, error) {
+	b, err := disk.ReadAll(ctx, minioMetaBucket, pathJoin(bucketMetaPrefix, scrubReportFilename))
+	if err != nil {
+		return nil, err
+	}
+	rpt := &scrubReport{}
+	if _, err = rpt.UnmarshalMsg(b); err != nil {
+		return nil, err
+	}
+	return rpt, nil
+}
+
+func (rpt *scrubReport) save(ctx context.Context, disk StorageAPI) error {
+	rpt.LastUpdate = time.Now().UTC()
+	b, err := rpt.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+	return disk.WriteAll(ctx, minioMetaBucket, pathJoin(bucketMetaPrefix, scrubReportFilename), b)
+}
+
+// resumable returns true if the scrub of the report is to be carried on
+// after the last object verified.
+func (rpt *scrubReport) resumable() bool {
+	switch rpt.Status {
+	case scrubRunning, scrubPaused, scrubInterrupted:
+		return true
+	}
+	return false
+}
+
+func (rpt *scrubReport) addShard(s scrubShard) {
+	rpt.Corrupted++
+	if len(rpt.Shards) >= maxScrubReportShards {
+		rpt.Truncated = true
+		return
+	}
+	rpt.Shards = append(rpt.Shards, s)
+}
+
+// scrubDue returns true if the drive with the report rpt is to be scrubbed,
+// rpt is nil if the drive was never scrubbed.
+func scrubDue(rpt *scrubReport, interval time.Duration, requested time.Time) bool {
+	if rpt == nil {
+		return interval > 0 || !requested.IsZero()
+	}
+	switch {
+	case rpt.resumable():
+		return true
+	case requested.After(rpt.Started):
+		return true
+	case interval > 0:
+		return time.Since(rpt.Started) >= interval
+	}
+	return false
+}
+
+// RequestScrub requests a scrub of all the drives, each node starts
+// scrubbing its drives at its next check.
+func (z *erasureServerPools) RequestScrub(ctx context.Context) error {
+	b, err := json.Marshal(scrubRequest{Requested: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+	if err = saveConfig(ctx, z, scrubRequestFile, b); err != nil {
+		return err
+	}
+	go z.startScrubs(GlobalContext)
+	return nil
+}
+
+// scrubRequested returns the time of the last scrub requested with the
+// admin API.
+func (z *erasureServerPools) scrubRequested(ctx context.Context) time.Time {
+	b, err := readConfig(ctx, z, scrubRequestFile)
+	if err != nil {
+		return time.Time{}
+	}
+	var req scrubRequest
+	if err = json.Unmarshal(b, &req); err != nil {
+		return time.Time{}
+	}
+	return req.Requested
+}
+
+// startScrubs starts scrubbing the local drives which are due.
+func (z *erasureServerPools) startScrubs(ctx context.Context) {
+	interval, _, _ := globalHealConfig.Scrub()
+	requested := z.scrubRequested(ctx)
+	for _, disk := range globalLocalDrives {
+		if !disk.IsOnline() || disk.Healing() != nil {
+			continue
+		}
+		rpt, err := loadScrubReport(ctx, disk)
+		if err != nil && !errors.Is(err, errFileNotFound) {
+			logger.LogIf(ctx, fmt.Errorf("Unable to load the scrub report of %s: %w", disk, err))
+			continue
+		}
+		if !scrubDue(rpt, interval, requested) {
+			continue
+		}
+		drive := disk.Endpoint().String()
+		if !globalBitrotScrubs.start(drive) {
+			continue
+		}
+		go func(disk StorageAPI, rpt *scrubReport) {
+			defer globalBitrotScrubs.done(drive)
+			if rpt == nil || !rpt.resumable() {
+				rpt = &scrubReport{
+					Endpoint: drive,
+					Started:  time.Now().UTC(),
+				}
+			}
+			if err := scrubDrive(ctx, disk, rpt); err != nil && ctx.Err() == nil {
+				logger.LogIf(ctx, fmt.Errorf("Scrubbing drive %s failed: %w", drive, err))
+			}
+		}(disk, rpt)
+	}
+}
+
+// runBitrotScrubs periodically starts scrubbing the local drives.
+func runBitrotScrubs(ctx context.Context, z *erasureServerPools) {
+	t := time.NewTimer(scrubCheckInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			z.startScrubs(ctx)
+			t.Reset(scrubCheckInterval)
+		}
+	}
+}
+
+// scrubDrive verifies the checksums of all the shards of disk, resuming
+// after the last object of rpt, and queues the corrupted objects for
+// healing.
+func scrubDrive(ctx context.Context, disk StorageAPI, rpt *scrubReport) (err error) {
+	_, windows, bandwidth := globalHealConfig.Scrub()
+	var bw *rate.Limiter
+	if bandwidth > 0 {
+		bw = rate.NewLimiter(rate.Limit(bandwidth), int(bandwidth))
+	}
+
+	rpt.Status = scrubRunning
+	rpt.Error = ""
+	defer func() {
+		switch {
+		case err == nil:
+			rpt.Status = scrubComplete
+			rpt.Finished = time.Now().UTC()
+		case ctx.Err() != nil:
+			// Shutting down, keep the last object verified to
+			// resume from it.
+			rpt.Status = scrubInterrupted
+		default:
+			rpt.Status = scrubFailed
+			rpt.Finished = time.Now().UTC()
+			rpt.Error = err.Error()
+		}
+		// ctx may be canceled, the report is saved regardless.
+		logger.LogIf(ctx, rpt.save(context.Background(), disk))
+	}()
+	if err = rpt.save(ctx, disk); err != nil {
+		return err
+	}
+
+	vols, err := disk.ListVols(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(vols, func(i, j int) bool { return vols[i].Name < vols[j].Name })
+
+	lastSave := time.Now()
+	for _, vol := range vols {
+		if isMinioMetaBucketName(vol.Name) || vol.Name < rpt.Bucket {
+			continue
+		}
+		var forwardTo string
+		if vol.Name == rpt.Bucket {
+			forwardTo = rpt.Object
+		}
+		if err = scrubBucket(ctx, disk, vol.Name, forwardTo, rpt, windows, bw, &lastSave); err != nil {
+			return err
+		}
+	}
+	rpt.Bucket, rpt.Object = "", ""
+	return nil
+}
+
+// scrubBucket verifies the shards of bucket on disk, see scrubDrive.
+func scrubBucket(ctx context.Context, disk StorageAPI, bucket, forwardTo string, rpt *scrubReport, windows schedule.Windows, bw *rate.Limiter, lastSave *time.Time) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var scrubErr error
+	lerr := listPathRaw(ctx, listPathRawOptions{
+		disks:          []StorageAPI{disk},
+		bucket:         bucket,
+		recursive:      true,
+		forwardTo:      forwardTo,
+		minDisks:       1,
+		reportNotFound: false,
+		agreed: func(entry metaCacheEntry) {
+			if entry.isDir() || !entry.isObject() {
+				return
+			}
+			if err := waitScrubWindow(ctx, disk, rpt, windows); err != nil {
+				scrubErr = err
+				cancel()
+				return
+			}
+			fivs, err := entry.fileInfoVersions(bucket)
+			if err != nil {
+				rpt.addShard(scrubShard{
+					Bucket:   bucket,
+					Object:   entry.name,
+					Error:    err.Error(),
+					Detected: time.Now().UTC(),
+				})
+				healObject(bucket, entry.name, "", madmin.HealDeepScan)
+				rpt.HealQueued++
+				return
+			}
+			for _, version := range fivs.Versions {
+				if version.Deleted || version.IsRemote() || len(version.Parts) == 0 {
+					continue
+				}
+				size := scrubVersionSize(version)
+				if bw != nil {
+					if err = waitScrubBandwidth(ctx, bw, size); err != nil {
+						scrubErr = err
+						cancel()
+						return
+					}
+				}
+				err = scrubVersion(ctx, disk, bucket, version)
+				switch {
+				case err == nil:
+					rpt.Versions++
+					rpt.Bytes += uint64(size)
+				case errors.Is(err, errDiskNotFound), errors.Is(err, errFaultyDisk), errors.Is(err, errDiskAccessDenied):
+					scrubErr = err
+					cancel()
+					return
+				default:
+					rpt.Versions++
+					rpt.addShard(scrubShard{
+						Bucket:    bucket,
+						Object:    version.Name,
+						VersionID: version.VersionID,
+						Error:     err.Error(),
+						Detected:  time.Now().UTC(),
+					})
+					healObject(bucket, version.Name, version.VersionID, madmin.HealDeepScan)
+					rpt.HealQueued++
+				}
+			}
+			rpt.Bucket, rpt.Object = bucket, entry.name
+			if time.Since(*lastSave) > 30*time.Second {
+				logger.LogIf(ctx, rpt.save(ctx, disk))
+				*lastSave = time.Now()
+			}
+		},
+	})
+	if scrubErr != nil {
+		return scrubErr
+	}
+	return lerr
+}
+
+// waitScrubWindow waits for the next scrub window to open, marking the
+// scrub as paused in the meantime.
+func waitScrubWindow(ctx context.Context, disk StorageAPI, rpt *scrubReport, windows schedule.Windows) error {
+	wait := time.Until(windows.Next(time.Now()))
+	if wait <= 0 {
+		return nil
+	}
+	rpt.Status = scrubPaused
+	logger.LogIf(ctx, rpt.save(ctx, disk))
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+	rpt.Status = scrubRunning
+	return rpt.save(ctx, disk)
+}
+
+// waitScrubBandwidth waits until n more bytes can be verified.
+func waitScrubBandwidth(ctx context.Context, bw *rate.Limiter, n int64) error {
+	for n > 0 {
+		chunk := n
+		if burst := int64(bw.Burst()); chunk > burst {
+			chunk = burst
+		}
+		if err := bw.WaitN(ctx, int(chunk)); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
+
+// scrubVersionSize returns the size of the shards of the version on a drive.
+func scrubVersionSize(fi FileInfo) (size int64) {
+	for _, part := range fi.Parts {
+		size += fi.Erasure.ShardFileSize(part.Size)
+	}
+	return size
+}
+
+// scrubVersion verifies the checksums of the shards of the object version
+// fi on disk.
+func scrubVersion(ctx context.Context, disk StorageAPI, bucket string, fi FileInfo) error {
+	if !fi.InlineData() {
+		return disk.VerifyFile(ctx, bucket, fi.Name, fi)
+	}
+	fi, err := disk.ReadVersion(ctx, bucket, fi.Name, fi.VersionID, true)
+	if err != nil {
+		return err
+	}
+	checksumInfo := fi.Erasure.GetChecksumInfo(fi.Parts[0].Number)
+	return bitrotVerify(bytes.NewReader(fi.Data), int64(len(fi.Data)),
+		fi.Erasure.ShardFileSize(fi.Size), checksumInfo.Algorithm,
+		checksumInfo.Hash, fi.Erasure.ShardSize())
+}
+
+// ScrubReports returns the reports of the last scrub of all the online
+// drives, with the corrupted shards listed if shards is set, for the
+// drive with the endpoint drive only if not empty.
+func (z *erasureServerPools) ScrubReports(ctx context.Context, drive string, shards bool) []scrubReport {
+	var reports []scrubReport
+	for _, pool := range z.serverPools {
+		for _, set := range pool.sets {
+			for _, disk := range set.getDisks() {
+				if disk == nil || !disk.IsOnline() {
+					continue
+				}
+				if drive != "" && disk.Endpoint().String() != drive {
+					continue
+				}
+				rpt, err := loadScrubReport(ctx, disk)
+				if err != nil {
+					continue
+				}
+				if !shards {
+					rpt.Shards = nil
+				}
+				reports = append(reports, *rpt)
+			}
+		}
+	}
+	return reports
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScrubDue(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		rpt       *scrubReport
+		interval  time.Duration
+		requested time.Time
+		due       bool
+	}{
+		{nil, 0, time.Time{}, false},
+		{nil, time.Hour, time.Time{}, true},
+		{nil, 0, now, true},
+		{&scrubReport{Status: scrubPaused, Started: now}, 0, time.Time{}, true},
+		{&scrubReport{Status: scrubInterrupted, Started: now}, time.Hour, time.Time{}, true},
+		{&scrubReport{Status: scrubComplete, Started: now}, time.Hour, time.Time{}, false},
+		{&scrubReport{Status: scrubComplete, Started: now.Add(-2 * time.Hour)}, time.Hour, time.Time{}, true},
+		{&scrubReport{Status: scrubFailed, Started: now}, 0, now.Add(time.Minute), true},
+		{&scrubReport{Status: scrubComplete, Started: now}, 0, now.Add(-time.Minute), false},
+	}
+	for i, tc := range testCases {
+		if due := scrubDue(tc.rpt, tc.interval, tc.requested); due != tc.due {
+			t.Errorf("Test %d: expected due %v, got %v", i+1, tc.due, due)
+		}
+	}
+}
+
+func TestScrubDrive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	setObjectLayer(obj)
+	if globalBackgroundHealState == nil {
+		globalBackgroundHealState = newHealState(GlobalContext, false)
+	}
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	for object, size := range map[string]int{"inline": 1 << 10, "large": 4 << 20} {
+		data := bytes.Repeat([]byte("a"), size)
+		_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Find the drive at fsDirs[0].
+	var disk StorageAPI
+	for _, d := range obj.(*erasureServerPools).serverPools[0].sets[0].getDisks() {
+		if d.Endpoint().Path == fsDirs[0] {
+			disk = d
+		}
+	}
+	if disk == nil {
+		t.Fatal("drive not found")
+	}
+
+	rpt := &scrubReport{Endpoint: disk.Endpoint().String(), Started: time.Now().UTC()}
+	if err = scrubDrive(ctx, disk, rpt); err != nil {
+		t.Fatal(err)
+	}
+	if rpt.Status != scrubComplete || rpt.Versions != 2 || rpt.Corrupted != 0 {
+		t.Fatalf("expected a complete scrub of 2 versions without corruption, got %+v", rpt)
+	}
+
+	// Corrupt the shard of the large object.
+	parts, err := filepath.Glob(pathJoin(fsDirs[0], bucket, "large", "*", "part.1"))
+	if err != nil || len(parts) != 1 {
+		t.Fatalf("expected a part file, got %v, %v", parts, err)
+	}
+	b, err := os.ReadFile(parts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	b[len(b)-1]++
+	if err = os.WriteFile(parts[0], b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rpt = &scrubReport{Endpoint: disk.Endpoint().String(), Started: time.Now().UTC()}
+	if err = scrubDrive(ctx, disk, rpt); err != nil {
+		t.Fatal(err)
+	}
+	if rpt.Corrupted != 1 || rpt.HealQueued != 1 || len(rpt.Shards) != 1 || rpt.Shards[0].Object != "large" {
+		t.Fatalf("expected the large object to be reported corrupted, got %+v", rpt)
+	}
+
+	saved, err := loadScrubReport(ctx, disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Status != scrubComplete || saved.Corrupted != 1 {
+		t.Fatalf("expected the report to be saved, got %+v", saved)
+	}
+
+	// A scrub stopped by a shutdown is saved as interrupted, it is
+	// resumed from its last object at the next start.
+	canceledCtx, cancelScrub := context.WithCancel(ctx)
+	cancelScrub()
+	rpt = &scrubReport{Endpoint: disk.Endpoint().String(), Started: time.Now().UTC(), Bucket: bucket, Object: "inline"}
+	if err = scrubDrive(canceledCtx, disk, rpt); err == nil {
+		t.Fatal("expected the canceled scrub to fail")
+	}
+	saved, err = loadScrubReport(ctx, disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Status != scrubInterrupted || saved.Bucket != bucket || saved.Object != "inline" || !scrubDue(saved, 0, time.Time{}) {
+		t.Fatalf("expected the report to be saved as interrupted, got %+v", saved)
+	}
+	if err = scrubDrive(ctx, disk, saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.Status != scrubComplete || saved.Versions == 0 {
+		t.Fatalf("expected the resumed scrub to complete, got %+v", saved)
+	}
+}
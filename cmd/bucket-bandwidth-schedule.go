@@ -0,0 +1,148 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/minio/minio/internal/bucket/bandwidth"
+	"github.com/minio/minio/internal/schedule"
+)
+
+const bucketBandwidthScheduleFile = "bandwidth-schedule.json"
+
+// minBandwidthLimit - minimum bandwidth limit of a remote target in bytes
+// per second.
+const minBandwidthLimit = 100 * 1000 * 1000
+
+// BandwidthScheduleEntry - bandwidth limit of a remote target in bytes per
+// second during Window, or a pause of the transfers to the target. Window
+// is a list of time windows such as "mon-fri 08:00-18:00".
+type BandwidthScheduleEntry struct {
+	Window string `json:"window"`
+	Limit  int64  `json:"limit,omitempty"`
+	Pause  bool   `json:"pause,omitempty"`
+}
+
+// BucketBandwidthSchedules - bandwidth schedules of the remote targets of
+// a bucket by target ARN.
+type BucketBandwidthSchedules struct {
+	Targets map[string][]BandwidthScheduleEntry `json:"targets"`
+
+	schedules map[string]bandwidth.Schedule
+}
+
+// parseBandwidthSchedule validates the entries of a bandwidth schedule and
+// returns the schedule applied by the bandwidth monitor.
+func parseBandwidthSchedule(entries []BandwidthScheduleEntry) (bandwidth.Schedule, error) {
+	s := make(bandwidth.Schedule, 0, len(entries))
+	for _, e := range entries {
+		if e.Window == "" {
+			return nil, errors.New("bandwidth schedule entry has no window")
+		}
+		windows, err := schedule.Parse(e.Window)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case e.Pause && e.Limit != 0:
+			return nil, fmt.Errorf("bandwidth schedule entry '%s' can either pause or limit the transfers", e.Window)
+		case !e.Pause && e.Limit < minBandwidthLimit:
+			return nil, fmt.Errorf("bandwidth limit of schedule entry '%s' must be at least %d bytes per second", e.Window, minBandwidthLimit)
+		}
+		s = append(s, bandwidth.ScheduleEntry{Windows: windows, Limit: e.Limit})
+	}
+	return s, nil
+}
+
+func parseBucketBandwidthSchedules(bucket string, data []byte) (*BucketBandwidthSchedules, error) {
+	schedules := &BucketBandwidthSchedules{}
+	if err := json.Unmarshal(data, schedules); err != nil {
+		return nil, fmt.Errorf("invalid bandwidth schedules for bucket %s: %w", bucket, err)
+	}
+	schedules.schedules = make(map[string]bandwidth.Schedule, len(schedules.Targets))
+	for arn, entries := range schedules.Targets {
+		s, err := parseBandwidthSchedule(entries)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bandwidth schedule for bucket %s target %s: %w", bucket, arn, err)
+		}
+		schedules.schedules[arn] = s
+	}
+	return schedules, nil
+}
+
+// Schedule returns the bandwidth schedule of the target arn, nil if none.
+func (s *BucketBandwidthSchedules) Schedule(arn string) bandwidth.Schedule {
+	if s == nil {
+		return nil
+	}
+	return s.schedules[arn]
+}
+
+// getBandwidthSchedules returns the bandwidth schedules of the remote
+// targets of the bucket, nil if none.
+func getBandwidthSchedules(bucket string) *BucketBandwidthSchedules {
+	if globalBucketMetadataSys == nil {
+		return nil
+	}
+	schedules, err := globalBucketMetadataSys.GetBandwidthSchedules(bucket)
+	if err != nil {
+		return nil
+	}
+	return schedules
+}
+
+// updateBandwidthSchedule replaces the bandwidth schedule of the target arn
+// of the bucket with entries, removes it if entries is empty.
+func updateBandwidthSchedule(ctx context.Context, bucket, arn string, entries []BandwidthScheduleEntry) error {
+	data, err := bandwidthSchedulesWith(bucket, arn, entries)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		_, err = globalBucketMetadataSys.Delete(ctx, bucket, bucketBandwidthScheduleFile)
+	} else {
+		_, err = globalBucketMetadataSys.Update(ctx, bucket, bucketBandwidthScheduleFile, data)
+	}
+	return err
+}
+
+// bandwidthSchedulesWith returns the bandwidth schedules config of the
+// bucket with the schedule of the target arn replaced by entries, removed
+// if entries is empty. The returned config is nil if no target has a
+// schedule left.
+func bandwidthSchedulesWith(bucket, arn string, entries []BandwidthScheduleEntry) ([]byte, error) {
+	targets := make(map[string][]BandwidthScheduleEntry)
+	if current := getBandwidthSchedules(bucket); current != nil {
+		for k, v := range current.Targets {
+			targets[k] = v
+		}
+	}
+	if len(entries) == 0 {
+		delete(targets, arn)
+	} else {
+		targets[arn] = entries
+	}
+	if len(targets) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(BucketBandwidthSchedules{Targets: targets})
+}
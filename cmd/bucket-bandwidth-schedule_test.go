@@ -0,0 +1,59 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestParseBandwidthSchedule(t *testing.T) {
+	testCases := []struct {
+		entries []BandwidthScheduleEntry
+		valid   bool
+	}{
+		{nil, true},
+		{[]BandwidthScheduleEntry{{Window: "mon-fri 08:00-18:00", Limit: minBandwidthLimit}, {Window: "sat-sun", Pause: true}}, true},
+		{[]BandwidthScheduleEntry{{Window: "mon-fri 08:00-18:00", Limit: minBandwidthLimit - 1}}, false},
+		{[]BandwidthScheduleEntry{{Window: "mon-fri 08:00-18:00"}}, false},
+		{[]BandwidthScheduleEntry{{Window: "mon-fri 08:00-18:00", Limit: minBandwidthLimit, Pause: true}}, false},
+		{[]BandwidthScheduleEntry{{Window: "someday", Pause: true}}, false},
+		{[]BandwidthScheduleEntry{{Limit: minBandwidthLimit}}, false},
+	}
+	for i, tc := range testCases {
+		s, err := parseBandwidthSchedule(tc.entries)
+		if (err == nil) != tc.valid {
+			t.Errorf("Test %d: expected valid %v, got %v", i+1, tc.valid, err)
+			continue
+		}
+		if err == nil && len(s) != len(tc.entries) {
+			t.Errorf("Test %d: expected %d entries, got %d", i+1, len(tc.entries), len(s))
+		}
+	}
+
+	data := []byte(`{"targets":{"arn1":[{"window":"sat-sun","pause":true}]}}`)
+	schedules, err := parseBucketBandwidthSchedules("bucket", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := schedules.Schedule("arn1"); len(s) != 1 || s[0].Limit != 0 {
+		t.Errorf("expected a pause for arn1, got %v", s)
+	}
+	if s := schedules.Schedule("arn2"); s != nil {
+		t.Errorf("expected no schedule for arn2, got %v", s)
+	}
+}
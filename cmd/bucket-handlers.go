@@ -985,6 +985,7 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
 		return
 	}
+	globalIAMSys.recordAccessKeyUsage(cred, getPeerIP(r))
 
 	// Once signature is validated, check if the user has
 	// explicit permissions for the user.
@@ -165,6 +165,18 @@ type transitionState struct {
 
 	lastDayMu    sync.RWMutex
 	lastDayStats map[string]*lastDayTierStats
+
+	moveMu    sync.Mutex
+	moveStats map[string]tierMoveStats
+}
+
+// tierMoveStats counts the object versions moved into and out of a tier by
+// transitions since server start.
+type tierMoveStats struct {
+	InVersions  uint64
+	InBytes     uint64
+	OutVersions uint64
+	OutBytes    uint64
 }
 
 func (t *transitionState) queueTransitionTask(oi ObjectInfo, sc string) {
@@ -187,6 +199,7 @@ func newTransitionState(ctx context.Context, objAPI ObjectLayer) *transitionStat
 		objAPI:       objAPI,
 		killCh:       make(chan struct{}),
 		lastDayStats: make(map[string]*lastDayTierStats),
+		moveStats:    make(map[string]tierMoveStats),
 	}
 }
 
@@ -214,10 +227,18 @@ func (t *transitionState) worker(ctx context.Context, objectAPI ObjectLayer) {
 				return
 			}
 			atomic.AddInt32(&t.activeTasks, 1)
-			if err := transitionObject(ctx, objectAPI, task.objInfo, task.tier); err != nil {
+			var srcTier string
+			if task.objInfo.IsRemote() {
+				srcTier = task.objInfo.TransitionedObject.Tier
+			}
+			err := transitionObject(ctx, objectAPI, task.objInfo, task.tier)
+			switch {
+			case errors.Is(err, errTransitionRestoredObject):
+				// Moved once the restored copy expires.
+			case err != nil:
 				logger.LogIf(ctx, fmt.Errorf("Transition failed for %s/%s version:%s with %w",
 					task.objInfo.Bucket, task.objInfo.Name, task.objInfo.VersionID, err))
-			} else {
+			default:
 				ts := tierStats{
 					TotalSize:   uint64(task.objInfo.Size),
 					NumVersions: 1,
@@ -226,6 +247,7 @@ func (t *transitionState) worker(ctx context.Context, objectAPI ObjectLayer) {
 					ts.NumObjects = 1
 				}
 				t.addLastDayStats(task.tier, ts)
+				t.addMoveStats(srcTier, task.tier, task.objInfo.Size)
 			}
 			atomic.AddInt32(&t.activeTasks, -1)
 		}
@@ -242,6 +264,36 @@ func (t *transitionState) addLastDayStats(tier string, ts tierStats) {
 	t.lastDayStats[tier].addStats(ts)
 }
 
+// addMoveStats records an object version of size bytes moved from the tier
+// src, empty for this cluster, to the tier dst.
+func (t *transitionState) addMoveStats(src, dst string, size int64) {
+	t.moveMu.Lock()
+	defer t.moveMu.Unlock()
+
+	if src != "" && src != dst {
+		st := t.moveStats[src]
+		st.OutVersions++
+		st.OutBytes += uint64(size)
+		t.moveStats[src] = st
+	}
+	st := t.moveStats[dst]
+	st.InVersions++
+	st.InBytes += uint64(size)
+	t.moveStats[dst] = st
+}
+
+// getMoveStats returns the transition move stats of every tier.
+func (t *transitionState) getMoveStats() map[string]tierMoveStats {
+	t.moveMu.Lock()
+	defer t.moveMu.Unlock()
+
+	res := make(map[string]tierMoveStats, len(t.moveStats))
+	for tier, st := range t.moveStats {
+		res[tier] = st
+	}
+	return res
+}
+
 func (t *transitionState) getDailyAllTierStats() DailyAllTierStats {
 	t.lastDayMu.RLock()
 	defer t.lastDayMu.RUnlock()
@@ -276,17 +328,31 @@ func initBackgroundTransition(ctx context.Context, objectAPI ObjectLayer) {
 	globalTransitionState.UpdateWorkers(n)
 }
 
-var errInvalidStorageClass = errors.New("invalid storage class")
+var (
+	errInvalidStorageClass = errors.New("invalid storage class")
+
+	// errTransitionRestoredObject is returned when a transitioned object
+	// with a restored copy is to be moved to another tier.
+	errTransitionRestoredObject = errors.New("transitioned object has a restored copy")
+)
 
 func validateTransitionTier(lc *lifecycle.Lifecycle) error {
 	for _, rule := range lc.Rules {
-		if rule.Transition.StorageClass != "" {
-			if valid := globalTierConfigMgr.IsTierValid(rule.Transition.StorageClass); !valid {
+		remote := false
+		for _, t := range rule.AllTransitions() {
+			if valid := isValidTransitionTier(t.StorageClass); !valid {
+				return errInvalidStorageClass
+			}
+			// Content of a remote tier is not moved back to this
+			// cluster.
+			custom := globalStorageClass.IsCustom(t.StorageClass)
+			if remote && custom {
 				return errInvalidStorageClass
 			}
+			remote = remote || !custom
 		}
 		if rule.NoncurrentVersionTransition.StorageClass != "" {
-			if valid := globalTierConfigMgr.IsTierValid(rule.NoncurrentVersionTransition.StorageClass); !valid {
+			if valid := isValidTransitionTier(rule.NoncurrentVersionTransition.StorageClass); !valid {
 				return errInvalidStorageClass
 			}
 		}
@@ -294,6 +360,12 @@ func validateTransitionTier(lc *lifecycle.Lifecycle) error {
 	return nil
 }
 
+// isValidTransitionTier returns true if objects can be transitioned to
+// tier, i.e a remote tier or a custom storage class of this cluster.
+func isValidTransitionTier(tier string) bool {
+	return globalStorageClass.IsCustom(tier) || globalTierConfigMgr.IsTierValid(tier)
+}
+
 // enqueueTransitionImmediate enqueues obj for transition if eligible.
 // This is to be called after a successful upload of an object (version).
 func enqueueTransitionImmediate(obj ObjectInfo) {
@@ -812,5 +884,6 @@ func (oi ObjectInfo) ToLifecycleOpts() lifecycle.ObjectOpts {
 		RestoreOngoing:   oi.RestoreOngoing,
 		RestoreExpires:   oi.RestoreExpires,
 		TransitionStatus: oi.TransitionedObject.Status,
+		StorageClass:     oi.StorageClass,
 	}
 }
@@ -110,6 +110,18 @@ func (sys *BucketMetadataSys) updateAndParse(ctx context.Context, bucket string,
 	case bucketQuotaConfigFile:
 		meta.QuotaConfigJSON = configData
 		meta.QuotaConfigUpdatedAt = updatedAt
+	case bucketPoolAffinityConfigFile:
+		meta.PoolAffinityConfigJSON = configData
+		meta.PoolAffinityConfigUpdatedAt = updatedAt
+	case bucketReplicationConflictPolicyFile:
+		meta.ReplicationConflictPolicyJSON = configData
+		meta.ReplicationConflictPolicyUpdatedAt = updatedAt
+	case bucketBandwidthScheduleFile:
+		meta.BandwidthScheduleJSON = configData
+		meta.BandwidthScheduleUpdatedAt = updatedAt
+	case bucketGenericTargetsFile:
+		meta.GenericTargetsJSON = configData
+		meta.GenericTargetsUpdatedAt = updatedAt
 	case objectLockConfig:
 		meta.ObjectLockConfigXML = configData
 		meta.ObjectLockConfigUpdatedAt = updatedAt
@@ -304,6 +316,70 @@ func (sys *BucketMetadataSys) GetQuotaConfig(ctx context.Context, bucket string)
 	return meta.quotaConfig, meta.QuotaConfigUpdatedAt, nil
 }
 
+// GetPoolAffinityConfig returns configured bucket pool affinity
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetPoolAffinityConfig(bucket string) (*BucketPoolAffinity, error) {
+	meta, err := sys.GetConfig(GlobalContext, bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketPoolAffinityNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.poolAffinityConfig == nil {
+		return nil, BucketPoolAffinityNotFound{Bucket: bucket}
+	}
+	return meta.poolAffinityConfig, nil
+}
+
+// GetReplicationConflictPolicy returns configured bucket replication conflict policy
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetReplicationConflictPolicy(bucket string) (*BucketReplicationConflictPolicy, error) {
+	meta, err := sys.GetConfig(GlobalContext, bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketReplicationConflictPolicyNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.replicationConflictPolicy == nil {
+		return nil, BucketReplicationConflictPolicyNotFound{Bucket: bucket}
+	}
+	return meta.replicationConflictPolicy, nil
+}
+
+// GetBandwidthSchedules returns configured bandwidth schedules of the bucket targets
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetBandwidthSchedules(bucket string) (*BucketBandwidthSchedules, error) {
+	meta, err := sys.GetConfig(GlobalContext, bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketBandwidthSchedulesNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.bandwidthSchedules == nil {
+		return nil, BucketBandwidthSchedulesNotFound{Bucket: bucket}
+	}
+	return meta.bandwidthSchedules, nil
+}
+
+// GetGenericTargets returns the generic targets of the bucket
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetGenericTargets(bucket string) (*BucketGenericTargets, error) {
+	meta, err := sys.GetConfig(GlobalContext, bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketGenericTargetsNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.genericTargets == nil {
+		return nil, BucketGenericTargetsNotFound{Bucket: bucket}
+	}
+	return meta.genericTargets, nil
+}
+
 // GetReplicationConfig returns configured bucket replication config
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetReplicationConfig(ctx context.Context, bucket string) (*replication.Config, time.Time, error) {
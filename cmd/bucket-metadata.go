@@ -67,40 +67,52 @@ var (
 // bucketMetadataFormat refers to the format.
 // bucketMetadataVersion can be used to track a rolling upgrade of a field.
 type BucketMetadata struct {
-	Name                        string
-	Created                     time.Time
-	LockEnabled                 bool // legacy not used anymore.
-	PolicyConfigJSON            []byte
-	NotificationConfigXML       []byte
-	LifecycleConfigXML          []byte
-	ObjectLockConfigXML         []byte
-	VersioningConfigXML         []byte
-	EncryptionConfigXML         []byte
-	TaggingConfigXML            []byte
-	QuotaConfigJSON             []byte
-	ReplicationConfigXML        []byte
-	BucketTargetsConfigJSON     []byte
-	BucketTargetsConfigMetaJSON []byte
-	PolicyConfigUpdatedAt       time.Time
-	ObjectLockConfigUpdatedAt   time.Time
-	EncryptionConfigUpdatedAt   time.Time
-	TaggingConfigUpdatedAt      time.Time
-	QuotaConfigUpdatedAt        time.Time
-	ReplicationConfigUpdatedAt  time.Time
-	VersioningConfigUpdatedAt   time.Time
+	Name                               string
+	Created                            time.Time
+	LockEnabled                        bool // legacy not used anymore.
+	PolicyConfigJSON                   []byte
+	NotificationConfigXML              []byte
+	LifecycleConfigXML                 []byte
+	ObjectLockConfigXML                []byte
+	VersioningConfigXML                []byte
+	EncryptionConfigXML                []byte
+	TaggingConfigXML                   []byte
+	QuotaConfigJSON                    []byte
+	ReplicationConfigXML               []byte
+	BucketTargetsConfigJSON            []byte
+	BucketTargetsConfigMetaJSON        []byte
+	PolicyConfigUpdatedAt              time.Time
+	ObjectLockConfigUpdatedAt          time.Time
+	EncryptionConfigUpdatedAt          time.Time
+	TaggingConfigUpdatedAt             time.Time
+	QuotaConfigUpdatedAt               time.Time
+	ReplicationConfigUpdatedAt         time.Time
+	VersioningConfigUpdatedAt          time.Time
+	PoolAffinityConfigJSON             []byte
+	PoolAffinityConfigUpdatedAt        time.Time
+	ReplicationConflictPolicyJSON      []byte
+	ReplicationConflictPolicyUpdatedAt time.Time
+	BandwidthScheduleJSON              []byte
+	BandwidthScheduleUpdatedAt         time.Time
+	GenericTargetsJSON                 []byte
+	GenericTargetsUpdatedAt            time.Time
 
 	// Unexported fields. Must be updated atomically.
-	policyConfig           *policy.Policy
-	notificationConfig     *event.Config
-	lifecycleConfig        *lifecycle.Lifecycle
-	objectLockConfig       *objectlock.Config
-	versioningConfig       *versioning.Versioning
-	sseConfig              *bucketsse.BucketSSEConfig
-	taggingConfig          *tags.Tags
-	quotaConfig            *madmin.BucketQuota
-	replicationConfig      *replication.Config
-	bucketTargetConfig     *madmin.BucketTargets
-	bucketTargetConfigMeta map[string]string
+	policyConfig              *policy.Policy
+	notificationConfig        *event.Config
+	lifecycleConfig           *lifecycle.Lifecycle
+	objectLockConfig          *objectlock.Config
+	versioningConfig          *versioning.Versioning
+	sseConfig                 *bucketsse.BucketSSEConfig
+	taggingConfig             *tags.Tags
+	quotaConfig               *madmin.BucketQuota
+	replicationConfig         *replication.Config
+	bucketTargetConfig        *madmin.BucketTargets
+	bucketTargetConfigMeta    map[string]string
+	poolAffinityConfig        *BucketPoolAffinity
+	replicationConflictPolicy *BucketReplicationConflictPolicy
+	bandwidthSchedules        *BucketBandwidthSchedules
+	genericTargets            *BucketGenericTargets
 }
 
 // newBucketMetadata creates BucketMetadata with the supplied name and Created to Now.
@@ -292,6 +304,42 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 	} else {
 		b.bucketTargetConfig = &madmin.BucketTargets{}
 	}
+
+	if len(b.PoolAffinityConfigJSON) != 0 {
+		b.poolAffinityConfig, err = parseBucketPoolAffinity(b.Name, b.PoolAffinityConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.poolAffinityConfig = nil
+	}
+
+	if len(b.ReplicationConflictPolicyJSON) != 0 {
+		b.replicationConflictPolicy, err = parseBucketReplicationConflictPolicy(b.Name, b.ReplicationConflictPolicyJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.replicationConflictPolicy = nil
+	}
+
+	if len(b.BandwidthScheduleJSON) != 0 {
+		b.bandwidthSchedules, err = parseBucketBandwidthSchedules(b.Name, b.BandwidthScheduleJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.bandwidthSchedules = nil
+	}
+
+	if len(b.GenericTargetsJSON) != 0 {
+		b.genericTargets, err = parseBucketGenericTargets(b.Name, b.GenericTargetsJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.genericTargets = nil
+	}
 	return nil
 }
 
@@ -418,6 +466,22 @@ func (b *BucketMetadata) defaultTimestamps() {
 	if b.VersioningConfigUpdatedAt.IsZero() {
 		b.VersioningConfigUpdatedAt = b.Created
 	}
+
+	if b.PoolAffinityConfigUpdatedAt.IsZero() {
+		b.PoolAffinityConfigUpdatedAt = b.Created
+	}
+
+	if b.ReplicationConflictPolicyUpdatedAt.IsZero() {
+		b.ReplicationConflictPolicyUpdatedAt = b.Created
+	}
+
+	if b.BandwidthScheduleUpdatedAt.IsZero() {
+		b.BandwidthScheduleUpdatedAt = b.Created
+	}
+
+	if b.GenericTargetsUpdatedAt.IsZero() {
+		b.GenericTargetsUpdatedAt = b.Created
+	}
 }
 
 // Save config to supplied ObjectLayer api.
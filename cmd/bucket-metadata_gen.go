@@ -150,6 +150,54 @@ func (z *BucketMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "VersioningConfigUpdatedAt")
 				return
 			}
+		case "PoolAffinityConfigJSON":
+			z.PoolAffinityConfigJSON, err = dc.ReadBytes(z.PoolAffinityConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "PoolAffinityConfigJSON")
+				return
+			}
+		case "PoolAffinityConfigUpdatedAt":
+			z.PoolAffinityConfigUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "PoolAffinityConfigUpdatedAt")
+				return
+			}
+		case "ReplicationConflictPolicyJSON":
+			z.ReplicationConflictPolicyJSON, err = dc.ReadBytes(z.ReplicationConflictPolicyJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicationConflictPolicyJSON")
+				return
+			}
+		case "ReplicationConflictPolicyUpdatedAt":
+			z.ReplicationConflictPolicyUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicationConflictPolicyUpdatedAt")
+				return
+			}
+		case "BandwidthScheduleJSON":
+			z.BandwidthScheduleJSON, err = dc.ReadBytes(z.BandwidthScheduleJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "BandwidthScheduleJSON")
+				return
+			}
+		case "BandwidthScheduleUpdatedAt":
+			z.BandwidthScheduleUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "BandwidthScheduleUpdatedAt")
+				return
+			}
+		case "GenericTargetsJSON":
+			z.GenericTargetsJSON, err = dc.ReadBytes(z.GenericTargetsJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "GenericTargetsJSON")
+				return
+			}
+		case "GenericTargetsUpdatedAt":
+			z.GenericTargetsUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "GenericTargetsUpdatedAt")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -163,9 +211,9 @@ func (z *BucketMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *BucketMetadata) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 21
+	// map header, size 29
 	// write "Name"
-	err = en.Append(0xde, 0x0, 0x15, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	err = en.Append(0xde, 0x0, 0x1d, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
 	if err != nil {
 		return
 	}
@@ -374,15 +422,95 @@ func (z *BucketMetadata) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "VersioningConfigUpdatedAt")
 		return
 	}
+	// write "PoolAffinityConfigJSON"
+	err = en.Append(0xb6, 0x50, 0x6f, 0x6f, 0x6c, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.PoolAffinityConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "PoolAffinityConfigJSON")
+		return
+	}
+	// write "PoolAffinityConfigUpdatedAt"
+	err = en.Append(0xbb, 0x50, 0x6f, 0x6f, 0x6c, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.PoolAffinityConfigUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "PoolAffinityConfigUpdatedAt")
+		return
+	}
+	// write "ReplicationConflictPolicyJSON"
+	err = en.Append(0xbd, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.ReplicationConflictPolicyJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "ReplicationConflictPolicyJSON")
+		return
+	}
+	// write "ReplicationConflictPolicyUpdatedAt"
+	err = en.Append(0xd9, 0x22, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.ReplicationConflictPolicyUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "ReplicationConflictPolicyUpdatedAt")
+		return
+	}
+	// write "BandwidthScheduleJSON"
+	err = en.Append(0xb5, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.BandwidthScheduleJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "BandwidthScheduleJSON")
+		return
+	}
+	// write "BandwidthScheduleUpdatedAt"
+	err = en.Append(0xba, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.BandwidthScheduleUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "BandwidthScheduleUpdatedAt")
+		return
+	}
+	// write "GenericTargetsJSON"
+	err = en.Append(0xb2, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.GenericTargetsJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "GenericTargetsJSON")
+		return
+	}
+	// write "GenericTargetsUpdatedAt"
+	err = en.Append(0xb7, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.GenericTargetsUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "GenericTargetsUpdatedAt")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *BucketMetadata) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 21
+	// map header, size 29
 	// string "Name"
-	o = append(o, 0xde, 0x0, 0x15, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	o = append(o, 0xde, 0x0, 0x1d, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
 	o = msgp.AppendString(o, z.Name)
 	// string "Created"
 	o = append(o, 0xa7, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64)
@@ -444,6 +572,30 @@ func (z *BucketMetadata) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "VersioningConfigUpdatedAt"
 	o = append(o, 0xb9, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
 	o = msgp.AppendTime(o, z.VersioningConfigUpdatedAt)
+	// string "PoolAffinityConfigJSON"
+	o = append(o, 0xb6, 0x50, 0x6f, 0x6f, 0x6c, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.PoolAffinityConfigJSON)
+	// string "PoolAffinityConfigUpdatedAt"
+	o = append(o, 0xbb, 0x50, 0x6f, 0x6f, 0x6c, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.PoolAffinityConfigUpdatedAt)
+	// string "ReplicationConflictPolicyJSON"
+	o = append(o, 0xbd, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.ReplicationConflictPolicyJSON)
+	// string "ReplicationConflictPolicyUpdatedAt"
+	o = append(o, 0xd9, 0x22, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.ReplicationConflictPolicyUpdatedAt)
+	// string "BandwidthScheduleJSON"
+	o = append(o, 0xb5, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.BandwidthScheduleJSON)
+	// string "BandwidthScheduleUpdatedAt"
+	o = append(o, 0xba, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.BandwidthScheduleUpdatedAt)
+	// string "GenericTargetsJSON"
+	o = append(o, 0xb2, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.GenericTargetsJSON)
+	// string "GenericTargetsUpdatedAt"
+	o = append(o, 0xb7, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.GenericTargetsUpdatedAt)
 	return
 }
 
@@ -591,6 +743,54 @@ func (z *BucketMetadata) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "VersioningConfigUpdatedAt")
 				return
 			}
+		case "PoolAffinityConfigJSON":
+			z.PoolAffinityConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.PoolAffinityConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "PoolAffinityConfigJSON")
+				return
+			}
+		case "PoolAffinityConfigUpdatedAt":
+			z.PoolAffinityConfigUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PoolAffinityConfigUpdatedAt")
+				return
+			}
+		case "ReplicationConflictPolicyJSON":
+			z.ReplicationConflictPolicyJSON, bts, err = msgp.ReadBytesBytes(bts, z.ReplicationConflictPolicyJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicationConflictPolicyJSON")
+				return
+			}
+		case "ReplicationConflictPolicyUpdatedAt":
+			z.ReplicationConflictPolicyUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicationConflictPolicyUpdatedAt")
+				return
+			}
+		case "BandwidthScheduleJSON":
+			z.BandwidthScheduleJSON, bts, err = msgp.ReadBytesBytes(bts, z.BandwidthScheduleJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "BandwidthScheduleJSON")
+				return
+			}
+		case "BandwidthScheduleUpdatedAt":
+			z.BandwidthScheduleUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BandwidthScheduleUpdatedAt")
+				return
+			}
+		case "GenericTargetsJSON":
+			z.GenericTargetsJSON, bts, err = msgp.ReadBytesBytes(bts, z.GenericTargetsJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "GenericTargetsJSON")
+				return
+			}
+		case "GenericTargetsUpdatedAt":
+			z.GenericTargetsUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "GenericTargetsUpdatedAt")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -605,6 +805,6 @@ func (z *BucketMetadata) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *BucketMetadata) Msgsize() (s int) {
-	s = 3 + 5 + msgp.StringPrefixSize + len(z.Name) + 8 + msgp.TimeSize + 12 + msgp.BoolSize + 17 + msgp.BytesPrefixSize + len(z.PolicyConfigJSON) + 22 + msgp.BytesPrefixSize + len(z.NotificationConfigXML) + 19 + msgp.BytesPrefixSize + len(z.LifecycleConfigXML) + 20 + msgp.BytesPrefixSize + len(z.ObjectLockConfigXML) + 20 + msgp.BytesPrefixSize + len(z.VersioningConfigXML) + 20 + msgp.BytesPrefixSize + len(z.EncryptionConfigXML) + 17 + msgp.BytesPrefixSize + len(z.TaggingConfigXML) + 16 + msgp.BytesPrefixSize + len(z.QuotaConfigJSON) + 21 + msgp.BytesPrefixSize + len(z.ReplicationConfigXML) + 24 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigJSON) + 28 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigMetaJSON) + 22 + msgp.TimeSize + 26 + msgp.TimeSize + 26 + msgp.TimeSize + 23 + msgp.TimeSize + 21 + msgp.TimeSize + 27 + msgp.TimeSize + 26 + msgp.TimeSize
+	s = 3 + 5 + msgp.StringPrefixSize + len(z.Name) + 8 + msgp.TimeSize + 12 + msgp.BoolSize + 17 + msgp.BytesPrefixSize + len(z.PolicyConfigJSON) + 22 + msgp.BytesPrefixSize + len(z.NotificationConfigXML) + 19 + msgp.BytesPrefixSize + len(z.LifecycleConfigXML) + 20 + msgp.BytesPrefixSize + len(z.ObjectLockConfigXML) + 20 + msgp.BytesPrefixSize + len(z.VersioningConfigXML) + 20 + msgp.BytesPrefixSize + len(z.EncryptionConfigXML) + 17 + msgp.BytesPrefixSize + len(z.TaggingConfigXML) + 16 + msgp.BytesPrefixSize + len(z.QuotaConfigJSON) + 21 + msgp.BytesPrefixSize + len(z.ReplicationConfigXML) + 24 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigJSON) + 28 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigMetaJSON) + 22 + msgp.TimeSize + 26 + msgp.TimeSize + 26 + msgp.TimeSize + 23 + msgp.TimeSize + 21 + msgp.TimeSize + 27 + msgp.TimeSize + 26 + msgp.TimeSize + 23 + msgp.BytesPrefixSize + len(z.PoolAffinityConfigJSON) + 28 + msgp.TimeSize + 30 + msgp.BytesPrefixSize + len(z.ReplicationConflictPolicyJSON) + 36 + msgp.TimeSize + 22 + msgp.BytesPrefixSize + len(z.BandwidthScheduleJSON) + 27 + msgp.TimeSize + 19 + msgp.BytesPrefixSize + len(z.GenericTargetsJSON) + 24 + msgp.TimeSize
 	return
 }
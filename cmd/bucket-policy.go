@@ -23,6 +23,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
@@ -32,6 +33,7 @@ import (
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/pkg/bucket/policy"
+	"github.com/minio/pkg/bucket/policy/condition"
 )
 
 // PolicySys - policy subsystem.
@@ -60,8 +62,26 @@ func (sys *PolicySys) IsAllowed(args policy.Args) bool {
 	return args.IsOwner
 }
 
+// policyConditionKeys - condition keys supported by MinIO on top of the
+// ones known to the condition package.
+var policyConditionKeys = []condition.KeyName{
+	principalTagKeyName,
+}
+
+var registerPolicyConditionKeysOnce sync.Once
+
+// registerPolicyConditionKeys - policies are only accepted with condition
+// keys known to the condition package, add MinIO specific keys to them.
+func registerPolicyConditionKeys() {
+	registerPolicyConditionKeysOnce.Do(func() {
+		condition.AllSupportedKeys = append(condition.AllSupportedKeys, policyConditionKeys...)
+		condition.CommonKeys = append(condition.CommonKeys, policyConditionKeys...)
+	})
+}
+
 // NewPolicySys - creates new policy system.
 func NewPolicySys() *PolicySys {
+	registerPolicyConditionKeys()
 	return &PolicySys{}
 }
 
@@ -186,12 +206,20 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 		}
 	}
 
+	// Principal tags must only come from the session tags of temporary
+	// credentials, drop any passed in the request.
+	for key := range args {
+		if isPrincipalTagKey(key) {
+			delete(args, key)
+		}
+	}
+
 	// JWT specific values
 	//
 	// Add all string claims
 	for k, v := range claims {
 		vStr, ok := v.(string)
-		if ok {
+		if ok && !isPrincipalTagKey(k) {
 			// Special case for AD/LDAP STS users
 			switch k {
 			case ldapUser:
@@ -0,0 +1,297 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const bucketPoolAffinityConfigFile = "pool-affinity.json"
+
+// BucketPoolAffinity - pool placement policy of the new objects of a bucket.
+type BucketPoolAffinity struct {
+	Rules []PoolAffinityRule `json:"rules"`
+}
+
+// PoolAffinityRule - pools allowed for the objects under Prefix, all the
+// objects of the bucket if Prefix is empty. Either Pools lists the allowed
+// pools or Exclude lists the pools not allowed, pools are identified by
+// their index starting at 0.
+type PoolAffinityRule struct {
+	Prefix  string `json:"prefix,omitempty"`
+	Pools   []int  `json:"pools,omitempty"`
+	Exclude []int  `json:"exclude,omitempty"`
+}
+
+// allows returns true if new objects matching the rule can be placed on
+// the pool idx.
+func (r PoolAffinityRule) allows(idx int) bool {
+	if len(r.Pools) > 0 {
+		for _, pool := range r.Pools {
+			if pool == idx {
+				return true
+			}
+		}
+		return false
+	}
+	for _, pool := range r.Exclude {
+		if pool == idx {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate validates the rules against the number of pools.
+func (a *BucketPoolAffinity) Validate(pools int) error {
+	prefixes := make(map[string]struct{}, len(a.Rules))
+	for _, r := range a.Rules {
+		if _, ok := prefixes[r.Prefix]; ok {
+			return fmt.Errorf("duplicate pool affinity rule for prefix '%s'", r.Prefix)
+		}
+		prefixes[r.Prefix] = struct{}{}
+
+		switch {
+		case len(r.Pools) == 0 && len(r.Exclude) == 0:
+			return fmt.Errorf("pool affinity rule for prefix '%s' has no pools", r.Prefix)
+		case len(r.Pools) > 0 && len(r.Exclude) > 0:
+			return fmt.Errorf("pool affinity rule for prefix '%s' can either allow or exclude pools", r.Prefix)
+		}
+		for _, idx := range append(append([]int{}, r.Pools...), r.Exclude...) {
+			if idx < 0 || idx >= pools {
+				return fmt.Errorf("invalid pool %d in pool affinity rule for prefix '%s', %d pools are available", idx, r.Prefix, pools)
+			}
+		}
+		excluded := 0
+		for idx := 0; idx < pools; idx++ {
+			if !r.allows(idx) {
+				excluded++
+			}
+		}
+		if excluded == pools {
+			return fmt.Errorf("pool affinity rule for prefix '%s' excludes all pools", r.Prefix)
+		}
+	}
+	return nil
+}
+
+// Rule returns the rule matching object, the one with the longest prefix.
+func (a *BucketPoolAffinity) Rule(object string) (rule PoolAffinityRule, ok bool) {
+	if a == nil {
+		return rule, false
+	}
+	for _, r := range a.Rules {
+		if !strings.HasPrefix(object, r.Prefix) {
+			continue
+		}
+		if !ok || len(r.Prefix) > len(rule.Prefix) {
+			rule, ok = r, true
+		}
+	}
+	return rule, ok
+}
+
+// Allows returns true if new objects named object can be placed on the
+// pool idx.
+func (a *BucketPoolAffinity) Allows(object string, idx int) bool {
+	rule, ok := a.Rule(object)
+	return !ok || rule.allows(idx)
+}
+
+// pinnedToPool returns true if object, stored on the pool idx allowed by
+// its pool affinity, cannot be moved to another pool accepting new objects
+// without violating the pool affinity.
+func (z *erasureServerPools) pinnedToPool(affinity *BucketPoolAffinity, object string, idx int) bool {
+	rule, ok := affinity.Rule(object)
+	if !ok || !rule.allows(idx) {
+		return false
+	}
+	for i := range z.serverPools {
+		if i == idx || z.IsSuspended(i) || z.IsPoolRebalancing(i) {
+			continue
+		}
+		if rule.allows(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkPoolAffinity returns an error if the pool affinity of bucket leaves
+// the objects of a rule without a pool to be placed on, the pools being
+// decommissioned and the pool decommission, if not -1, excluded.
+func (z *erasureServerPools) checkPoolAffinity(bucket string, affinity *BucketPoolAffinity, decommission int) error {
+	if affinity == nil {
+		return nil
+	}
+	for _, r := range affinity.Rules {
+		var allowed bool
+		for i := range z.serverPools {
+			if i == decommission || z.IsSuspended(i) {
+				continue
+			}
+			if r.allows(i) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("pool affinity of bucket %s allows no pool for prefix '%s' once decommissioned pools are excluded", bucket, r.Prefix)
+		}
+	}
+	return nil
+}
+
+// parseBucketPoolAffinity parses BucketPoolAffinity from json
+func parseBucketPoolAffinity(bucket string, data []byte) (*BucketPoolAffinity, error) {
+	affinity := &BucketPoolAffinity{}
+	if err := json.Unmarshal(data, affinity); err != nil {
+		return nil, fmt.Errorf("invalid pool affinity config for bucket %s: %w", bucket, err)
+	}
+	return affinity, nil
+}
+
+// getPoolAffinity returns the pool affinity of the bucket, nil if none.
+func getPoolAffinity(bucket string) *BucketPoolAffinity {
+	if isMinioMetaBucketName(bucket) || globalBucketMetadataSys == nil {
+		return nil
+	}
+	affinity, err := globalBucketMetadataSys.GetPoolAffinityConfig(bucket)
+	if err != nil {
+		return nil
+	}
+	return affinity
+}
+
+// poolAffinityViolation is an object version stored on a pool not allowed
+// by the pool affinity of its bucket.
+type poolAffinityViolation struct {
+	Object    string `json:"object"`
+	VersionID string `json:"versionId,omitempty"`
+	Pool      int    `json:"pool"`
+	Prefix    string `json:"prefix"`
+}
+
+// poolAffinityReport reports the objects violating the pool affinity of
+// a bucket.
+type poolAffinityReport struct {
+	Bucket     string                  `json:"bucket"`
+	Scanned    uint64                  `json:"scanned"`
+	Violations uint64                  `json:"violations"`
+	PerPool    map[int]uint64          `json:"perPool,omitempty"`
+	Objects    []poolAffinityViolation `json:"objects,omitempty"`
+	Truncated  bool                    `json:"truncated"`
+}
+
+// poolAffinityReport lists the object versions of the bucket stored on a
+// pool not allowed by the pool affinity of the bucket, up to maxObjects of
+// them are listed. The sets of a pool are listed concurrently.
+func (z *erasureServerPools) poolAffinityReport(ctx context.Context, bucket string, affinity *BucketPoolAffinity, maxObjects int) (poolAffinityReport, error) {
+	rpt := poolAffinityReport{
+		Bucket:  bucket,
+		PerPool: make(map[int]uint64),
+	}
+
+	var mu sync.Mutex
+	visit := func(idx int, entry metaCacheEntry) {
+		if entry.isDir() || !entry.isObject() {
+			return
+		}
+		fivs, err := entry.fileInfoVersions(bucket)
+		if err != nil {
+			return
+		}
+		rule, ok := affinity.Rule(entry.name)
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, version := range fivs.Versions {
+			rpt.Scanned++
+			if !ok || rule.allows(idx) {
+				continue
+			}
+			rpt.Violations++
+			rpt.PerPool[idx]++
+			if len(rpt.Objects) >= maxObjects {
+				rpt.Truncated = true
+				continue
+			}
+			rpt.Objects = append(rpt.Objects, poolAffinityViolation{
+				Object:    decodeDirObject(version.Name),
+				VersionID: version.VersionID,
+				Pool:      idx,
+				Prefix:    rule.Prefix,
+			})
+		}
+	}
+
+	for idx, pool := range z.serverPools {
+		idx := idx
+		var wg sync.WaitGroup
+		var errMu sync.Mutex
+		var err error
+		for _, set := range pool.sets {
+			disks := set.getOnlineDisks()
+			if len(disks) == 0 {
+				continue
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				// How to resolve partial results.
+				resolver := metadataResolutionParams{
+					dirQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+					objQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+					bucket:    bucket,
+				}
+				lerr := listPathRaw(ctx, listPathRawOptions{
+					disks:          disks,
+					bucket:         bucket,
+					recursive:      true,
+					minDisks:       len(disks) / 2, // to capture all quorum ratios
+					reportNotFound: false,
+					agreed: func(entry metaCacheEntry) {
+						visit(idx, entry)
+					},
+					partial: func(entries metaCacheEntries, _ []error) {
+						if entry, ok := entries.resolve(&resolver); ok {
+							visit(idx, *entry)
+						}
+					},
+					finished: nil,
+				})
+				if lerr != nil {
+					errMu.Lock()
+					err = lerr
+					errMu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		if err != nil {
+			return rpt, err
+		}
+	}
+	return rpt, nil
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestBucketPoolAffinityValidate(t *testing.T) {
+	testCases := []struct {
+		config  string
+		success bool
+	}{
+		{`{"rules":[{"pools":[0,1]}]}`, true},
+		{`{"rules":[{"exclude":[2]},{"prefix":"logs/","pools":[2]}]}`, true},
+		{`{"rules":[]}`, true},
+		// duplicate prefix
+		{`{"rules":[{"prefix":"a/","pools":[0]},{"prefix":"a/","pools":[1]}]}`, false},
+		// no pools
+		{`{"rules":[{"prefix":"a/"}]}`, false},
+		// both allowed and excluded pools
+		{`{"rules":[{"pools":[0],"exclude":[1]}]}`, false},
+		// pool out of range
+		{`{"rules":[{"pools":[3]}]}`, false},
+		{`{"rules":[{"exclude":[-1]}]}`, false},
+		// all pools excluded
+		{`{"rules":[{"exclude":[0,1,2]}]}`, false},
+	}
+	for i, testCase := range testCases {
+		affinity, err := parseBucketPoolAffinity("bucket", []byte(testCase.config))
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		err = affinity.Validate(3)
+		if testCase.success && err != nil {
+			t.Errorf("Test %d: expected success, got %v", i+1, err)
+		}
+		if !testCase.success && err == nil {
+			t.Errorf("Test %d: expected failure", i+1)
+		}
+	}
+}
+
+func TestBucketPoolAffinityAllows(t *testing.T) {
+	affinity := &BucketPoolAffinity{
+		Rules: []PoolAffinityRule{
+			{Exclude: []int{2}},
+			{Prefix: "logs/", Pools: []int{2}},
+			{Prefix: "logs/archive/", Pools: []int{0, 1}},
+		},
+	}
+	testCases := []struct {
+		object string
+		pool   int
+		allows bool
+	}{
+		{"object", 0, true},
+		{"object", 2, false},
+		{"logs/object", 0, false},
+		{"logs/object", 2, true},
+		{"logs/archive/object", 1, true},
+		{"logs/archive/object", 2, false},
+	}
+	for i, testCase := range testCases {
+		if got := affinity.Allows(testCase.object, testCase.pool); got != testCase.allows {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.allows, got)
+		}
+	}
+
+	var none *BucketPoolAffinity
+	if !none.Allows("object", 2) {
+		t.Error("expected no pool affinity to allow all pools")
+	}
+}
+
+func TestFilterPoolAffinity(t *testing.T) {
+	affinity := &BucketPoolAffinity{
+		Rules: []PoolAffinityRule{
+			{Prefix: "logs/", Pools: []int{1}},
+		},
+	}
+	newPools := func() serverPoolsAvailableSpace {
+		return serverPoolsAvailableSpace{
+			{Index: 0, Available: 100},
+			{Index: 1, Available: 100},
+			{Index: 2, Available: 100},
+		}
+	}
+
+	pools := newPools()
+	pools.FilterPoolAffinity(affinity, "logs/object")
+	if pools.TotalAvailable() != 100 || pools[1].Available != 100 {
+		t.Errorf("expected only pool 1 to be available, got %v", pools)
+	}
+
+	pools = newPools()
+	pools.FilterPoolAffinity(affinity, "object")
+	if pools.TotalAvailable() != 300 {
+		t.Errorf("expected all pools to be available, got %v", pools)
+	}
+
+	// No pool is available if the allowed pools are full.
+	pools = newPools()
+	pools[1].Available = 0
+	pools.FilterPoolAffinity(affinity, "logs/object")
+	if pools.TotalAvailable() != 0 {
+		t.Errorf("expected no pool to be available, got %v", pools)
+	}
+}
+
+func TestCheckPoolAffinity(t *testing.T) {
+	z := &erasureServerPools{
+		serverPools: make([]*erasureSets, 3),
+		poolMeta: poolMeta{
+			Pools: []PoolStatus{
+				{ID: 0},
+				{ID: 1},
+				{ID: 2, Decommission: &PoolDecommissionInfo{}},
+			},
+		},
+	}
+	affinity := &BucketPoolAffinity{
+		Rules: []PoolAffinityRule{
+			{Exclude: []int{1}},
+			{Prefix: "logs/", Pools: []int{1, 2}},
+		},
+	}
+
+	if err := z.checkPoolAffinity("bucket", nil, 0); err != nil {
+		t.Fatalf("expected no error without pool affinity, got %v", err)
+	}
+	if err := z.checkPoolAffinity("bucket", affinity, -1); err != nil {
+		t.Fatalf("expected pool affinity to be valid, got %v", err)
+	}
+
+	// Objects under logs/ can only be placed on pool 1, which cannot be
+	// decommissioned while pool 2 is.
+	if err := z.checkPoolAffinity("bucket", affinity, 1); err == nil {
+		t.Fatal("expected decommissioning pool 1 to be refused")
+	}
+	// All the other objects can only be placed on pool 0.
+	if err := z.checkPoolAffinity("bucket", affinity, 0); err == nil {
+		t.Fatal("expected decommissioning pool 0 to be refused")
+	}
+}
@@ -0,0 +1,536 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/logger"
+)
+
+//go:generate msgp -file=$GOFILE
+
+//msgp:ignore BucketReplicationConflictPolicy replicationConflictFilter replicationConflictResult replicationConflictStore
+
+const (
+	bucketReplicationConflictPolicyFile = "replication-conflict-policy.json"
+
+	replicationConflictsFile      = "conflicts.bin"
+	replicationConflictsFormat    = 1
+	replicationConflictsVersionV1 = 1
+	replicationConflictsVersion   = replicationConflictsVersionV1
+
+	// Maximum number of conflicts kept per bucket, the oldest conflicts
+	// are dropped beyond.
+	maxReplicationConflicts = 10000
+
+	// Interval at which the conflicts recorded by a node are persisted.
+	replicationConflictsSaveInterval = time.Minute
+
+	// ReplicationSourceSite is the deployment ID of the site a replica was
+	// written at, saved in the internal metadata of the replica.
+	ReplicationSourceSite = "replication-source-site"
+)
+
+// Replication conflict resolution policies.
+const (
+	// The version with the latest mod time is the latest version, the
+	// implicit ordering of the versions of an object.
+	replicationConflictLatestMTimeWins = "latest-mtime-wins"
+	// The version written at the preferred site is the latest version,
+	// falls back to latest-mtime-wins if neither or both versions were
+	// written at the preferred site.
+	replicationConflictPreferSite = "prefer-site"
+)
+
+// Kinds of replication conflicts.
+const (
+	// The replica is older than the latest local version.
+	replicationConflictOlder = "older"
+	// The replica is newer than the latest local version, which was
+	// written locally and not replicated before the replica was written.
+	replicationConflictConcurrent = "concurrent"
+	// A local version sent to a remote target was rejected, a conflicting
+	// version is the latest version at the remote site.
+	replicationConflictRemote = "remote"
+)
+
+// Resolutions of replication conflicts.
+const (
+	// The replica is stored, versions are ordered by mod time.
+	replicationConflictAccepted = "accepted"
+	// The replica is rejected, the local version stays the latest.
+	replicationConflictRejected = "rejected"
+	// The replica is stored, the local version is moved behind it.
+	replicationConflictDemoted = "demoted"
+)
+
+// errReplicationConflictDemoted is returned when the mod time of a version
+// changed since it was queued for replication, moved behind a replica by
+// the replication conflict policy.
+var errReplicationConflictDemoted = errors.New("version was moved behind a replica by the replication conflict policy")
+
+// BucketReplicationConflictPolicy - resolution policy of the conflicts
+// between the versions written concurrently at the sites of an
+// active-active replication of a bucket. The same policy must be set on
+// the bucket at all sites.
+type BucketReplicationConflictPolicy struct {
+	Policy string `json:"policy"`
+	// Deployment ID of the preferred site of the prefer-site policy.
+	Site string `json:"site,omitempty"`
+	// Deployment IDs of the sites replicating to the bucket, besides the
+	// site replication peers. The site a replica was sent from is only
+	// trusted if known.
+	Sites []string `json:"sites,omitempty"`
+}
+
+// Validate validates the replication conflict policy.
+func (p *BucketReplicationConflictPolicy) Validate() error {
+	switch p.Policy {
+	case replicationConflictLatestMTimeWins:
+		if p.Site != "" {
+			return fmt.Errorf("replication conflict policy '%s' does not take a site", p.Policy)
+		}
+	case replicationConflictPreferSite:
+		if p.Site == "" {
+			return fmt.Errorf("replication conflict policy '%s' requires a site", p.Policy)
+		}
+	default:
+		return fmt.Errorf("unknown replication conflict policy '%s'", p.Policy)
+	}
+	return nil
+}
+
+// parseBucketReplicationConflictPolicy parses BucketReplicationConflictPolicy from json
+func parseBucketReplicationConflictPolicy(bucket string, data []byte) (*BucketReplicationConflictPolicy, error) {
+	policy := &BucketReplicationConflictPolicy{}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("invalid replication conflict policy for bucket %s: %w", bucket, err)
+	}
+	return policy, nil
+}
+
+// getReplicationConflictPolicy returns the replication conflict policy of
+// the bucket, latest-mtime-wins if none.
+func getReplicationConflictPolicy(bucket string) BucketReplicationConflictPolicy {
+	if globalBucketMetadataSys != nil {
+		if policy, err := globalBucketMetadataSys.GetReplicationConflictPolicy(bucket); err == nil {
+			return *policy
+		}
+	}
+	return BucketReplicationConflictPolicy{Policy: replicationConflictLatestMTimeWins}
+}
+
+// replicationSourceSite returns site, the deployment ID a replica of the
+// bucket was sent with, if it is a site replication peer or a site of the
+// replication conflict policy of the bucket, empty otherwise.
+func replicationSourceSite(bucket, site string) string {
+	if site == "" || site == globalDeploymentID {
+		return ""
+	}
+	if globalSiteReplicationSys.isPeer(site) {
+		return site
+	}
+	for _, s := range getReplicationConflictPolicy(bucket).Sites {
+		if s == site {
+			return site
+		}
+	}
+	return ""
+}
+
+// isReplicationConflictErr returns true if the remote target rejected a
+// replica losing a conflict against its latest version.
+func isReplicationConflictErr(err error) bool {
+	return err != nil && miniogo.ToErrorResponse(err).Code == errorCodes[ErrReplicationConflict].Code
+}
+
+// ReplicationConflict is a replica received while a conflicting version of
+// the object was the latest local version.
+type ReplicationConflict struct {
+	Bucket string `json:"bucket" msg:"b"`
+	Object string `json:"object" msg:"o"`
+	Kind   string `json:"kind" msg:"k"`
+	// ARN of the remote target which rejected the local version.
+	Target          string    `json:"target,omitempty" msg:"tg"`
+	LocalSite       string    `json:"localSite,omitempty" msg:"ls"`
+	LocalVersionID  string    `json:"localVersionId" msg:"lv"`
+	LocalMTime      time.Time `json:"localMTime" msg:"lm"`
+	RemoteSite      string    `json:"remoteSite,omitempty" msg:"rs"`
+	RemoteVersionID string    `json:"remoteVersionId" msg:"rv"`
+	RemoteMTime     time.Time `json:"remoteMTime" msg:"rm"`
+	Policy          string    `json:"policy" msg:"p"`
+	Resolution      string    `json:"resolution" msg:"r"`
+	// Version ID of the latest version once the conflict is resolved.
+	Winner string    `json:"winner" msg:"w"`
+	Time   time.Time `json:"time" msg:"t"`
+}
+
+func (c ReplicationConflict) key() string {
+	localVersionID, remoteVersionID := c.LocalVersionID, c.RemoteVersionID
+	if localVersionID == "" {
+		localVersionID = nullVersionID
+	}
+	if remoteVersionID == "" {
+		remoteVersionID = nullVersionID
+	}
+	return pathJoin(c.Object, localVersionID, remoteVersionID)
+}
+
+// ReplicationConflicts are the replication conflicts of a bucket persisted
+// on drives.
+type ReplicationConflicts struct {
+	Entries map[string]ReplicationConflict `json:"entries" msg:"e"`
+	Version int                            `json:"version" msg:"v"`
+}
+
+func (l *ReplicationConflicts) reset() {
+	*l = ReplicationConflicts{
+		Entries: make(map[string]ReplicationConflict),
+		Version: replicationConflictsVersion,
+	}
+}
+
+// replicationVersionSite returns the deployment ID of the site the version
+// oi was written at, empty if unknown.
+func replicationVersionSite(oi ObjectInfo) string {
+	if oi.ReplicationStatus == replication.Replica {
+		return oi.UserDefined[ReservedMetadataPrefixLower+ReplicationSourceSite]
+	}
+	return globalDeploymentID
+}
+
+// replicationConflictWith returns the conflict between the latest local
+// version of an object and the replica of the remote site described by
+// opts, if any, resolved with the policy.
+func replicationConflictWith(latest ObjectInfo, opts ObjectOptions, remoteSite string, policy BucketReplicationConflictPolicy) (c ReplicationConflict, ok bool) {
+	if latest.VersionID == opts.VersionID || latest.DeleteMarker || opts.MTime.IsZero() {
+		return c, false
+	}
+	c = ReplicationConflict{
+		Bucket:          latest.Bucket,
+		Object:          latest.Name,
+		LocalSite:       replicationVersionSite(latest),
+		LocalVersionID:  latest.VersionID,
+		LocalMTime:      latest.ModTime,
+		RemoteSite:      remoteSite,
+		RemoteVersionID: opts.VersionID,
+		RemoteMTime:     opts.MTime,
+		Policy:          policy.Policy,
+		Resolution:      replicationConflictAccepted,
+		Time:            UTCNow(),
+	}
+	switch {
+	case opts.MTime.Before(latest.ModTime) && c.LocalSite != remoteSite:
+		// An older replica of the site the local version was written at
+		// is only late.
+		c.Kind = replicationConflictOlder
+	case opts.MTime.After(latest.ModTime) && latest.ReplicationStatus != replication.Replica:
+		// The local version was not replicated when the replica was
+		// written, the remote site did not know about it.
+		switch latest.ReplicationStatus {
+		case replication.Pending, replication.Failed:
+		default:
+			replicatedAt, err := time.Parse(time.RFC3339Nano, latest.UserDefined[ReservedMetadataPrefixLower+ReplicationTimestamp])
+			if err != nil || !opts.MTime.Before(replicatedAt) {
+				return c, false
+			}
+		}
+		c.Kind = replicationConflictConcurrent
+	default:
+		return c, false
+	}
+
+	localWins := c.Kind == replicationConflictOlder
+	if policy.Policy == replicationConflictPreferSite {
+		switch {
+		case c.LocalSite == policy.Site && c.RemoteSite != policy.Site:
+			localWins = true
+		case c.RemoteSite == policy.Site && c.LocalSite != policy.Site:
+			localWins = false
+		}
+	}
+	switch {
+	case localWins && c.Kind == replicationConflictConcurrent:
+		c.Resolution = replicationConflictRejected
+	case !localWins && c.Kind == replicationConflictOlder:
+		c.Resolution = replicationConflictDemoted
+	}
+	c.Winner = c.LocalVersionID
+	if !localWins {
+		c.Winner = c.RemoteVersionID
+	}
+	return c, true
+}
+
+// resolveReplicationConflict checks the replica of object described by opts,
+// sent by the remote site, against the latest local version of the object.
+// A conflict is recorded and resolved with the replication conflict policy
+// of the bucket: the replica is rejected with ReplicationConflictRejected or
+// the local version is moved behind the replica if it loses.
+func resolveReplicationConflict(ctx context.Context, objAPI ObjectLayer, bucket, object string, opts ObjectOptions, remoteSite string) error {
+	if !opts.ReplicationRequest || opts.VersionID == "" {
+		return nil
+	}
+	latest, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		// No conflict with a delete marker or a missing object.
+		return nil
+	}
+	c, ok := replicationConflictWith(latest, opts, remoteSite, getReplicationConflictPolicy(bucket))
+	if !ok {
+		return nil
+	}
+	switch c.Resolution {
+	case replicationConflictRejected:
+		globalReplicationConflicts.add(c)
+		return ReplicationConflictRejected{Bucket: bucket, Object: object, VersionID: opts.VersionID}
+	case replicationConflictDemoted:
+		if err = demoteReplicationConflictLoser(ctx, objAPI, latest, opts.MTime); err != nil {
+			return err
+		}
+	}
+	globalReplicationConflicts.add(c)
+	return nil
+}
+
+// recordRemoteReplicationConflicts records the replication of objInfo to
+// the targets which rejected it losing a conflict at their site, it returns
+// true if any.
+func recordRemoteReplicationConflicts(objInfo ObjectInfo, rinfos replicatedInfos) (conflict bool) {
+	for _, rinfo := range rinfos.Targets {
+		if !rinfo.Conflict {
+			continue
+		}
+		conflict = true
+		globalReplicationConflicts.add(ReplicationConflict{
+			Bucket:         objInfo.Bucket,
+			Object:         objInfo.Name,
+			Kind:           replicationConflictRemote,
+			Target:         rinfo.Arn,
+			LocalSite:      globalDeploymentID,
+			LocalVersionID: objInfo.VersionID,
+			LocalMTime:     objInfo.ModTime,
+			Policy:         getReplicationConflictPolicy(objInfo.Bucket).Policy,
+			Resolution:     replicationConflictRejected,
+			Time:           UTCNow(),
+		})
+	}
+	return conflict
+}
+
+// demoteReplicationConflictLoser moves the version loser behind the version
+// with mod time mtime, by setting its mod time just before.
+func demoteReplicationConflictLoser(ctx context.Context, objAPI ObjectLayer, loser ObjectInfo, mtime time.Time) error {
+	// Serialize with the replication of the version.
+	lk := objAPI.NewNSLock(loser.Bucket, "/[replicate]/"+loser.Name)
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	_, err = objAPI.PutObjectMetadata(ctx, loser.Bucket, loser.Name, ObjectOptions{
+		VersionID: loser.VersionID,
+		MTime:     mtime.Add(-time.Millisecond),
+		EvalMetadataFn: func(oi ObjectInfo) error {
+			if !oi.ModTime.Equal(loser.ModTime) {
+				return errReplicationConflictDemoted
+			}
+			return nil
+		},
+	})
+	return err
+}
+
+// replicationConflictFilter selects replication conflicts.
+type replicationConflictFilter struct {
+	Prefix string
+	Object string
+	Kind   string
+}
+
+func extractReplicationConflictFilter(q url.Values) replicationConflictFilter {
+	return replicationConflictFilter{
+		Prefix: q.Get("prefix"),
+		Object: q.Get("object"),
+		Kind:   q.Get("kind"),
+	}
+}
+
+func (f replicationConflictFilter) match(c ReplicationConflict) bool {
+	if !strings.HasPrefix(c.Object, f.Prefix) {
+		return false
+	}
+	if f.Object != "" && c.Object != f.Object {
+		return false
+	}
+	return f.Kind == "" || c.Kind == f.Kind
+}
+
+// replicationConflictResult is the response of the replication conflicts
+// clear admin API.
+type replicationConflictResult struct {
+	Count int `json:"count"`
+}
+
+func replicationConflictsPath(bucket string) string {
+	return path.Join(bucketMetaPrefix, bucket, replicationDir, replicationConflictsFile)
+}
+
+// replicationConflictStore keeps the replication conflicts of the buckets.
+// Conflicts are recorded in memory and merged in the lists persisted on
+// drives periodically, under a cluster wide lock.
+type replicationConflictStore struct {
+	mu      sync.Mutex
+	updates map[string]map[string]ReplicationConflict
+}
+
+var globalReplicationConflicts = newReplicationConflictStore()
+
+var replicationConflictRecords = replicationRecords{
+	name:     "replication-conflicts",
+	format:   replicationConflictsFormat,
+	version:  replicationConflictsVersion,
+	interval: replicationConflictsSaveInterval,
+}
+
+func newReplicationConflictStore() *replicationConflictStore {
+	return &replicationConflictStore{
+		updates: make(map[string]map[string]ReplicationConflict),
+	}
+}
+
+// add records the replication conflict c.
+func (s *replicationConflictStore) add(c ReplicationConflict) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.updates[c.Bucket]
+	if !ok {
+		u = make(map[string]ReplicationConflict)
+		s.updates[c.Bucket] = u
+	}
+	u[c.key()] = c
+}
+
+// update loads the replication conflicts of the bucket, applies fn and
+// saves them if fn returns true, under the conflicts lock.
+func (s *replicationConflictStore) update(ctx context.Context, objAPI ObjectLayer, bucket string, fn func(l *ReplicationConflicts) bool) error {
+	var l ReplicationConflicts
+	return replicationConflictRecords.update(ctx, objAPI, replicationConflictsPath(bucket), &l, func() bool {
+		return fn(&l)
+	})
+}
+
+// flush persists the replication conflicts recorded by this node.
+func (s *replicationConflictStore) flush(ctx context.Context, objAPI ObjectLayer) {
+	s.mu.Lock()
+	updates := s.updates
+	s.updates = make(map[string]map[string]ReplicationConflict)
+	s.mu.Unlock()
+
+	for bucket, u := range updates {
+		err := s.update(ctx, objAPI, bucket, func(l *ReplicationConflicts) bool {
+			for key, c := range u {
+				l.Entries[key] = c
+			}
+			l.trim(maxReplicationConflicts)
+			return len(u) > 0
+		})
+		if err != nil {
+			logger.LogOnceIf(ctx, fmt.Errorf("Unable to persist replication conflicts of %s: %w", bucket, err), "replication-conflicts-"+bucket)
+		}
+	}
+}
+
+// persist flushes the replication conflicts periodically.
+func (s *replicationConflictStore) persist(ctx context.Context, objAPI ObjectLayer) {
+	replicationConflictRecords.persist(ctx, objAPI, s.flush)
+}
+
+// List returns the replication conflicts of the bucket matching the
+// filter, the most recent first. The conflicts not persisted yet are not
+// listed, see flushClusterReplicationRecords.
+func (s *replicationConflictStore) List(ctx context.Context, objAPI ObjectLayer, bucket string, f replicationConflictFilter) ([]ReplicationConflict, error) {
+	l, err := loadReplicationConflicts(ctx, objAPI, bucket)
+	if err != nil {
+		return nil, err
+	}
+	conflicts := make([]ReplicationConflict, 0, len(l.Entries))
+	for _, c := range l.Entries {
+		if f.match(c) {
+			conflicts = append(conflicts, c)
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Time.After(conflicts[j].Time)
+	})
+	return conflicts, nil
+}
+
+// Clear removes the replication conflicts of the bucket matching the
+// filter.
+func (s *replicationConflictStore) Clear(ctx context.Context, objAPI ObjectLayer, bucket string, f replicationConflictFilter) (n int, err error) {
+	err = s.update(ctx, objAPI, bucket, func(l *ReplicationConflicts) bool {
+		for key, c := range l.Entries {
+			if f.match(c) {
+				delete(l.Entries, key)
+				n++
+			}
+		}
+		return n > 0
+	})
+	return n, err
+}
+
+// trim drops the oldest conflicts beyond max entries.
+func (l *ReplicationConflicts) trim(max int) {
+	if len(l.Entries) <= max {
+		return
+	}
+	keys := make([]string, 0, len(l.Entries))
+	for key := range l.Entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return l.Entries[keys[i]].Time.After(l.Entries[keys[j]].Time)
+	})
+	for _, key := range keys[max:] {
+		delete(l.Entries, key)
+	}
+}
+
+// loadReplicationConflicts loads the replication conflicts of the bucket
+// from drives.
+func loadReplicationConflicts(ctx context.Context, objAPI ObjectLayer, bucket string) (l ReplicationConflicts, e error) {
+	e = replicationConflictRecords.load(ctx, objAPI, replicationConflictsPath(bucket), &l)
+	return l, e
+}
@@ -0,0 +1,638 @@
+package cmd
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// DecodeMsg implements msgp.Decodable
+func (z *ReplicationConflict) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "b":
+			z.Bucket, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Bucket")
+				return
+			}
+		case "o":
+			z.Object, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Object")
+				return
+			}
+		case "k":
+			z.Kind, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Kind")
+				return
+			}
+		case "tg":
+			z.Target, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Target")
+				return
+			}
+		case "ls":
+			z.LocalSite, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "LocalSite")
+				return
+			}
+		case "lv":
+			z.LocalVersionID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "LocalVersionID")
+				return
+			}
+		case "lm":
+			z.LocalMTime, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "LocalMTime")
+				return
+			}
+		case "rs":
+			z.RemoteSite, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "RemoteSite")
+				return
+			}
+		case "rv":
+			z.RemoteVersionID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "RemoteVersionID")
+				return
+			}
+		case "rm":
+			z.RemoteMTime, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "RemoteMTime")
+				return
+			}
+		case "p":
+			z.Policy, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Policy")
+				return
+			}
+		case "r":
+			z.Resolution, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Resolution")
+				return
+			}
+		case "w":
+			z.Winner, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Winner")
+				return
+			}
+		case "t":
+			z.Time, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "Time")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ReplicationConflict) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 14
+	// write "b"
+	err = en.Append(0x8e, 0xa1, 0x62)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Bucket)
+	if err != nil {
+		err = msgp.WrapError(err, "Bucket")
+		return
+	}
+	// write "o"
+	err = en.Append(0xa1, 0x6f)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Object)
+	if err != nil {
+		err = msgp.WrapError(err, "Object")
+		return
+	}
+	// write "k"
+	err = en.Append(0xa1, 0x6b)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Kind)
+	if err != nil {
+		err = msgp.WrapError(err, "Kind")
+		return
+	}
+	// write "tg"
+	err = en.Append(0xa2, 0x74, 0x67)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Target)
+	if err != nil {
+		err = msgp.WrapError(err, "Target")
+		return
+	}
+	// write "ls"
+	err = en.Append(0xa2, 0x6c, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.LocalSite)
+	if err != nil {
+		err = msgp.WrapError(err, "LocalSite")
+		return
+	}
+	// write "lv"
+	err = en.Append(0xa2, 0x6c, 0x76)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.LocalVersionID)
+	if err != nil {
+		err = msgp.WrapError(err, "LocalVersionID")
+		return
+	}
+	// write "lm"
+	err = en.Append(0xa2, 0x6c, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.LocalMTime)
+	if err != nil {
+		err = msgp.WrapError(err, "LocalMTime")
+		return
+	}
+	// write "rs"
+	err = en.Append(0xa2, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.RemoteSite)
+	if err != nil {
+		err = msgp.WrapError(err, "RemoteSite")
+		return
+	}
+	// write "rv"
+	err = en.Append(0xa2, 0x72, 0x76)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.RemoteVersionID)
+	if err != nil {
+		err = msgp.WrapError(err, "RemoteVersionID")
+		return
+	}
+	// write "rm"
+	err = en.Append(0xa2, 0x72, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.RemoteMTime)
+	if err != nil {
+		err = msgp.WrapError(err, "RemoteMTime")
+		return
+	}
+	// write "p"
+	err = en.Append(0xa1, 0x70)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Policy)
+	if err != nil {
+		err = msgp.WrapError(err, "Policy")
+		return
+	}
+	// write "r"
+	err = en.Append(0xa1, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Resolution)
+	if err != nil {
+		err = msgp.WrapError(err, "Resolution")
+		return
+	}
+	// write "w"
+	err = en.Append(0xa1, 0x77)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Winner)
+	if err != nil {
+		err = msgp.WrapError(err, "Winner")
+		return
+	}
+	// write "t"
+	err = en.Append(0xa1, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.Time)
+	if err != nil {
+		err = msgp.WrapError(err, "Time")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ReplicationConflict) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 14
+	// string "b"
+	o = append(o, 0x8e, 0xa1, 0x62)
+	o = msgp.AppendString(o, z.Bucket)
+	// string "o"
+	o = append(o, 0xa1, 0x6f)
+	o = msgp.AppendString(o, z.Object)
+	// string "k"
+	o = append(o, 0xa1, 0x6b)
+	o = msgp.AppendString(o, z.Kind)
+	// string "tg"
+	o = append(o, 0xa2, 0x74, 0x67)
+	o = msgp.AppendString(o, z.Target)
+	// string "ls"
+	o = append(o, 0xa2, 0x6c, 0x73)
+	o = msgp.AppendString(o, z.LocalSite)
+	// string "lv"
+	o = append(o, 0xa2, 0x6c, 0x76)
+	o = msgp.AppendString(o, z.LocalVersionID)
+	// string "lm"
+	o = append(o, 0xa2, 0x6c, 0x6d)
+	o = msgp.AppendTime(o, z.LocalMTime)
+	// string "rs"
+	o = append(o, 0xa2, 0x72, 0x73)
+	o = msgp.AppendString(o, z.RemoteSite)
+	// string "rv"
+	o = append(o, 0xa2, 0x72, 0x76)
+	o = msgp.AppendString(o, z.RemoteVersionID)
+	// string "rm"
+	o = append(o, 0xa2, 0x72, 0x6d)
+	o = msgp.AppendTime(o, z.RemoteMTime)
+	// string "p"
+	o = append(o, 0xa1, 0x70)
+	o = msgp.AppendString(o, z.Policy)
+	// string "r"
+	o = append(o, 0xa1, 0x72)
+	o = msgp.AppendString(o, z.Resolution)
+	// string "w"
+	o = append(o, 0xa1, 0x77)
+	o = msgp.AppendString(o, z.Winner)
+	// string "t"
+	o = append(o, 0xa1, 0x74)
+	o = msgp.AppendTime(o, z.Time)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ReplicationConflict) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "b":
+			z.Bucket, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Bucket")
+				return
+			}
+		case "o":
+			z.Object, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Object")
+				return
+			}
+		case "k":
+			z.Kind, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Kind")
+				return
+			}
+		case "tg":
+			z.Target, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Target")
+				return
+			}
+		case "ls":
+			z.LocalSite, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LocalSite")
+				return
+			}
+		case "lv":
+			z.LocalVersionID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LocalVersionID")
+				return
+			}
+		case "lm":
+			z.LocalMTime, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LocalMTime")
+				return
+			}
+		case "rs":
+			z.RemoteSite, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RemoteSite")
+				return
+			}
+		case "rv":
+			z.RemoteVersionID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RemoteVersionID")
+				return
+			}
+		case "rm":
+			z.RemoteMTime, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RemoteMTime")
+				return
+			}
+		case "p":
+			z.Policy, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Policy")
+				return
+			}
+		case "r":
+			z.Resolution, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Resolution")
+				return
+			}
+		case "w":
+			z.Winner, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Winner")
+				return
+			}
+		case "t":
+			z.Time, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Time")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ReplicationConflict) Msgsize() (s int) {
+	s = 1 + 2 + msgp.StringPrefixSize + len(z.Bucket) + 2 + msgp.StringPrefixSize + len(z.Object) + 2 + msgp.StringPrefixSize + len(z.Kind) + 3 + msgp.StringPrefixSize + len(z.Target) + 3 + msgp.StringPrefixSize + len(z.LocalSite) + 3 + msgp.StringPrefixSize + len(z.LocalVersionID) + 3 + msgp.TimeSize + 3 + msgp.StringPrefixSize + len(z.RemoteSite) + 3 + msgp.StringPrefixSize + len(z.RemoteVersionID) + 3 + msgp.TimeSize + 2 + msgp.StringPrefixSize + len(z.Policy) + 2 + msgp.StringPrefixSize + len(z.Resolution) + 2 + msgp.StringPrefixSize + len(z.Winner) + 2 + msgp.TimeSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *ReplicationConflicts) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "e":
+			var zb0002 uint32
+			zb0002, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Entries")
+				return
+			}
+			if z.Entries == nil {
+				z.Entries = make(map[string]ReplicationConflict, zb0002)
+			} else if len(z.Entries) > 0 {
+				for key := range z.Entries {
+					delete(z.Entries, key)
+				}
+			}
+			for zb0002 > 0 {
+				zb0002--
+				var za0001 string
+				var za0002 ReplicationConflict
+				za0001, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Entries")
+					return
+				}
+				err = za0002.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Entries", za0001)
+					return
+				}
+				z.Entries[za0001] = za0002
+			}
+		case "v":
+			z.Version, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "Version")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ReplicationConflicts) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "e"
+	err = en.Append(0x82, 0xa1, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteMapHeader(uint32(len(z.Entries)))
+	if err != nil {
+		err = msgp.WrapError(err, "Entries")
+		return
+	}
+	for za0001, za0002 := range z.Entries {
+		err = en.WriteString(za0001)
+		if err != nil {
+			err = msgp.WrapError(err, "Entries")
+			return
+		}
+		err = za0002.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Entries", za0001)
+			return
+		}
+	}
+	// write "v"
+	err = en.Append(0xa1, 0x76)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.Version)
+	if err != nil {
+		err = msgp.WrapError(err, "Version")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ReplicationConflicts) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "e"
+	o = append(o, 0x82, 0xa1, 0x65)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Entries)))
+	for za0001, za0002 := range z.Entries {
+		o = msgp.AppendString(o, za0001)
+		o, err = za0002.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Entries", za0001)
+			return
+		}
+	}
+	// string "v"
+	o = append(o, 0xa1, 0x76)
+	o = msgp.AppendInt(o, z.Version)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ReplicationConflicts) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "e":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Entries")
+				return
+			}
+			if z.Entries == nil {
+				z.Entries = make(map[string]ReplicationConflict, zb0002)
+			} else if len(z.Entries) > 0 {
+				for key := range z.Entries {
+					delete(z.Entries, key)
+				}
+			}
+			for zb0002 > 0 {
+				var za0001 string
+				var za0002 ReplicationConflict
+				zb0002--
+				za0001, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Entries")
+					return
+				}
+				bts, err = za0002.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Entries", za0001)
+					return
+				}
+				z.Entries[za0001] = za0002
+			}
+		case "v":
+			z.Version, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Version")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ReplicationConflicts) Msgsize() (s int) {
+	s = 1 + 2 + msgp.MapHeaderSize
+	if z.Entries != nil {
+		for za0001, za0002 := range z.Entries {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + za0002.Msgsize()
+		}
+	}
+	s += 2 + msgp.IntSize
+	return
+}
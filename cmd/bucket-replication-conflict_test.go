@@ -0,0 +1,281 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/replication"
+)
+
+func TestBucketReplicationConflictPolicyValidate(t *testing.T) {
+	testCases := []struct {
+		policy BucketReplicationConflictPolicy
+		valid  bool
+	}{
+		{BucketReplicationConflictPolicy{Policy: replicationConflictLatestMTimeWins}, true},
+		{BucketReplicationConflictPolicy{Policy: replicationConflictLatestMTimeWins, Site: "site-a"}, false},
+		{BucketReplicationConflictPolicy{Policy: replicationConflictPreferSite, Site: "site-a"}, true},
+		{BucketReplicationConflictPolicy{Policy: replicationConflictPreferSite}, false},
+		{BucketReplicationConflictPolicy{Policy: "first-write-wins"}, false},
+	}
+	for i, tc := range testCases {
+		if err := tc.policy.Validate(); (err == nil) != tc.valid {
+			t.Errorf("Test %d: expected valid %v, got %v", i+1, tc.valid, err)
+		}
+	}
+}
+
+func TestReplicationConflictWith(t *testing.T) {
+	defer func(id string) { globalDeploymentID = id }(globalDeploymentID)
+	globalDeploymentID = "site-a"
+
+	now := UTCNow()
+	replicatedAt := now.Add(time.Minute).Format(time.RFC3339Nano)
+	local := func(status replication.StatusType) ObjectInfo {
+		return ObjectInfo{
+			Bucket:            "bucket",
+			Name:              "object",
+			VersionID:         "local",
+			ModTime:           now,
+			ReplicationStatus: status,
+			UserDefined: map[string]string{
+				ReservedMetadataPrefixLower + ReplicationTimestamp: replicatedAt,
+			},
+		}
+	}
+	replica := ObjectInfo{
+		Bucket:            "bucket",
+		Name:              "object",
+		VersionID:         "local",
+		ModTime:           now,
+		ReplicationStatus: replication.Replica,
+		UserDefined: map[string]string{
+			ReservedMetadataPrefixLower + ReplicationSourceSite: "site-c",
+		},
+	}
+	replicaB := replica.Clone()
+	replicaB.UserDefined[ReservedMetadataPrefixLower+ReplicationSourceSite] = "site-b"
+	older := ObjectOptions{VersionID: "remote", MTime: now.Add(-time.Second)}
+	newer := ObjectOptions{VersionID: "remote", MTime: now.Add(time.Second)}
+	latest := BucketReplicationConflictPolicy{Policy: replicationConflictLatestMTimeWins}
+	preferA := BucketReplicationConflictPolicy{Policy: replicationConflictPreferSite, Site: "site-a"}
+	preferB := BucketReplicationConflictPolicy{Policy: replicationConflictPreferSite, Site: "site-b"}
+
+	testCases := []struct {
+		latest     ObjectInfo
+		opts       ObjectOptions
+		policy     BucketReplicationConflictPolicy
+		conflict   bool
+		kind       string
+		resolution string
+		winner     string
+	}{
+		// Same version replicated again.
+		{local(replication.Pending), ObjectOptions{VersionID: "local", MTime: now}, latest, false, "", "", ""},
+		// Written after the local version was replicated.
+		{local(replication.Completed), ObjectOptions{VersionID: "remote", MTime: now.Add(2 * time.Minute)}, latest, false, "", "", ""},
+		// Newer than a replica.
+		{replica, newer, latest, false, "", "", ""},
+		{local(replication.Pending), older, latest, true, replicationConflictOlder, replicationConflictAccepted, "local"},
+		{local(replication.Pending), newer, latest, true, replicationConflictConcurrent, replicationConflictAccepted, "remote"},
+		{local(replication.Completed), newer, latest, true, replicationConflictConcurrent, replicationConflictAccepted, "remote"},
+		{local(replication.Pending), older, preferA, true, replicationConflictOlder, replicationConflictAccepted, "local"},
+		{local(replication.Pending), newer, preferA, true, replicationConflictConcurrent, replicationConflictRejected, "local"},
+		{local(replication.Pending), older, preferB, true, replicationConflictOlder, replicationConflictDemoted, "remote"},
+		{local(replication.Pending), newer, preferB, true, replicationConflictConcurrent, replicationConflictAccepted, "remote"},
+		// Replicas of the same site received out of order.
+		{replicaB, older, latest, false, "", "", ""},
+		// Neither version was written at the preferred site.
+		{replica, older, preferA, true, replicationConflictOlder, replicationConflictAccepted, "local"},
+	}
+	for i, tc := range testCases {
+		c, ok := replicationConflictWith(tc.latest, tc.opts, "site-b", tc.policy)
+		if ok != tc.conflict {
+			t.Errorf("Test %d: expected conflict %v, got %v", i+1, tc.conflict, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if c.Kind != tc.kind || c.Resolution != tc.resolution || c.Winner != tc.winner {
+			t.Errorf("Test %d: expected %s conflict %s with winner %s, got %s conflict %s with winner %s",
+				i+1, tc.kind, tc.resolution, tc.winner, c.Kind, c.Resolution, c.Winner)
+		}
+		if c.LocalSite != replicationVersionSite(tc.latest) || c.RemoteSite != "site-b" {
+			t.Errorf("Test %d: unexpected sites %s and %s", i+1, c.LocalSite, c.RemoteSite)
+		}
+	}
+}
+
+func TestReplicationSourceSite(t *testing.T) {
+	defer func(id string) { globalDeploymentID = id }(globalDeploymentID)
+	globalDeploymentID = "site-a"
+
+	testCases := []struct {
+		site, want string
+	}{
+		{"", ""},
+		// Replicas sent from this site.
+		{"site-a", ""},
+		// Unknown site, no site replication nor policy.
+		{"site-b", ""},
+	}
+	for i, tc := range testCases {
+		if got := replicationSourceSite("bucket", tc.site); got != tc.want {
+			t.Errorf("Test %d: expected site %q, got %q", i+1, tc.want, got)
+		}
+	}
+}
+
+func TestRecordRemoteReplicationConflicts(t *testing.T) {
+	defer func(s *replicationConflictStore) { globalReplicationConflicts = s }(globalReplicationConflicts)
+	globalReplicationConflicts = newReplicationConflictStore()
+
+	objInfo := ObjectInfo{Bucket: "bucket", Name: "object", VersionID: "local", ModTime: UTCNow()}
+	rinfos := replicatedInfos{Targets: []replicatedTargetInfo{
+		{Arn: "arn-1", ReplicationStatus: replication.Completed},
+		{Arn: "arn-2", ReplicationStatus: replication.Completed, Conflict: true},
+	}}
+	if !recordRemoteReplicationConflicts(objInfo, rinfos) {
+		t.Fatal("expected a conflict")
+	}
+	u := globalReplicationConflicts.updates["bucket"]
+	if len(u) != 1 {
+		t.Fatalf("expected one conflict, got %d", len(u))
+	}
+	for _, c := range u {
+		if c.Kind != replicationConflictRemote || c.Target != "arn-2" || c.Resolution != replicationConflictRejected {
+			t.Errorf("unexpected conflict %+v", c)
+		}
+	}
+	rinfos.Targets[1].Conflict = false
+	if recordRemoteReplicationConflicts(objInfo, rinfos) {
+		t.Fatal("expected no conflict")
+	}
+}
+
+func TestReplicationConflictStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	now := UTCNow()
+	s := newReplicationConflictStore()
+	s.add(ReplicationConflict{Bucket: "bucket", Object: "a/1", Kind: replicationConflictOlder, LocalVersionID: "l1", RemoteVersionID: "r1", Time: now})
+	s.add(ReplicationConflict{Bucket: "bucket", Object: "b/2", Kind: replicationConflictConcurrent, LocalVersionID: "l2", RemoteVersionID: "r2", Time: now.Add(time.Second)})
+	s.flush(ctx, obj)
+
+	// Recorded by another node.
+	s2 := newReplicationConflictStore()
+	s2.add(ReplicationConflict{Bucket: "bucket", Object: "a/1", Kind: replicationConflictConcurrent, LocalVersionID: "l1", RemoteVersionID: "r3", Time: now.Add(2 * time.Second)})
+	s2.flush(ctx, obj)
+
+	conflicts, err := s2.List(ctx, obj, "bucket", replicationConflictFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 3 || conflicts[0].RemoteVersionID != "r3" {
+		t.Fatalf("expected 3 conflicts, the latest first, got %v", conflicts)
+	}
+
+	conflicts, err = s.List(ctx, obj, "bucket", replicationConflictFilter{Kind: replicationConflictConcurrent, Prefix: "a/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || conflicts[0].RemoteVersionID != "r3" {
+		t.Errorf("expected the concurrent conflict of a/1 only, got %v", conflicts)
+	}
+
+	n, err := s.Clear(ctx, obj, "bucket", replicationConflictFilter{Object: "a/1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 cleared conflicts, got %d", n)
+	}
+	conflicts, err = s.List(ctx, obj, "bucket", replicationConflictFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Object != "b/2" {
+		t.Errorf("expected b/2 only, got %v", conflicts)
+	}
+}
+
+func TestDemoteReplicationConflictLoser(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+	setObjectLayer(obj)
+	defer setObjectLayer(nil)
+
+	if err = obj.MakeBucketWithLocation(ctx, "bucket", MakeBucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	loser, err := obj.PutObject(ctx, "bucket", "object",
+		mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), int64(len("abcd")), "", ""), ObjectOptions{
+			Versioned: true,
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Replica of a version written before the loser at the preferred site.
+	mtime := loser.ModTime.Add(-time.Second)
+	winner, err := obj.PutObject(ctx, "bucket", "object",
+		mustGetPutObjReader(t, bytes.NewReader([]byte("efgh")), int64(len("efgh")), "", ""), ObjectOptions{
+			Versioned: true,
+			VersionID: mustGetUUID(),
+			MTime:     mtime,
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = demoteReplicationConflictLoser(ctx, obj, loser, mtime); err != nil {
+		t.Fatal(err)
+	}
+	latest, err := obj.GetObjectInfo(ctx, "bucket", "object", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.VersionID != winner.VersionID {
+		t.Errorf("expected version %s to be the latest, got %s", winner.VersionID, latest.VersionID)
+	}
+
+	// Already moved.
+	if err = demoteReplicationConflictLoser(ctx, obj, loser, mtime); err != errReplicationConflictDemoted {
+		t.Errorf("expected %v, got %v", errReplicationConflictDemoted, err)
+	}
+}
@@ -0,0 +1,477 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/logger"
+)
+
+//go:generate msgp -file=$GOFILE
+
+//msgp:ignore replicationDLQFilter replicationDLQTarget replicationDLQUpdates replicationDLQDepth replicationDLQ replicationDLQResult
+
+const (
+	replicationDLQDir       = "dlq"
+	replicationDLQFormat    = 1
+	replicationDLQVersionV1 = 1
+	replicationDLQVersion   = replicationDLQVersionV1
+
+	// Maximum number of entries kept per target, the oldest failures
+	// are dropped beyond.
+	maxReplicationDLQEntries = 10000
+
+	// Interval at which the failures recorded by a node are persisted.
+	replicationDLQSaveInterval = time.Minute
+
+	// Time the number of entries of a target is cached for metrics.
+	replicationDLQDepthExpiry = 5 * time.Minute
+)
+
+// Error codes of the remote targets on which replication fails until the
+// target or the object is fixed, retrying does not help.
+var permanentReplicationErrCodes = map[string]struct{}{
+	"AccessDenied":             {},
+	"EntityTooLarge":           {},
+	"InvalidArgument":          {},
+	"InvalidBucketName":        {},
+	"InvalidRequest":           {},
+	"InvalidStorageClass":      {},
+	"KeyTooLongError":          {},
+	"MetadataTooLarge":         {},
+	"MethodNotAllowed":         {},
+	"NoSuchBucket":             {},
+	"NotImplemented":           {},
+	"XMinioInvalidObjectName":  {},
+	"XMinioObjectTamperedWith": {},
+}
+
+// isPermanentReplicationErr returns true if replication failed with an
+// error retrying does not fix.
+func isPermanentReplicationErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := permanentReplicationErrCodes[miniogo.ToErrorResponse(err).Code]
+	return ok
+}
+
+// ReplicationDLQEntry is an object version which failed to replicate to a
+// target with a permanent error.
+type ReplicationDLQEntry struct {
+	Bucket       string    `json:"bucket" msg:"b"`
+	Object       string    `json:"object" msg:"o"`
+	VersionID    string    `json:"versionId" msg:"v"`
+	Arn          string    `json:"targetArn" msg:"a"`
+	Error        string    `json:"error" msg:"e"`
+	Attempts     int       `json:"attempts" msg:"n"`
+	FirstFailure time.Time `json:"firstFailure" msg:"ff"`
+	LastFailure  time.Time `json:"lastFailure" msg:"lf"`
+}
+
+// merge merges the entry with a later failure of the same object version.
+func (e ReplicationDLQEntry) merge(o ReplicationDLQEntry) ReplicationDLQEntry {
+	if e.Attempts == 0 {
+		return o
+	}
+	e.Attempts += o.Attempts
+	if o.LastFailure.After(e.LastFailure) {
+		e.LastFailure = o.LastFailure
+		e.Error = o.Error
+	}
+	return e
+}
+
+// ReplicationDLQEntries is the dead-letter list of a target persisted on
+// drives.
+type ReplicationDLQEntries struct {
+	Arn     string                         `json:"targetArn" msg:"a"`
+	Entries map[string]ReplicationDLQEntry `json:"entries" msg:"e"`
+	Version int                            `json:"version" msg:"v"`
+}
+
+func (l *ReplicationDLQEntries) reset() {
+	*l = ReplicationDLQEntries{
+		Arn:     l.Arn,
+		Entries: make(map[string]ReplicationDLQEntry),
+		Version: replicationDLQVersion,
+	}
+}
+
+// replicationDLQKey returns the key of an object version in the
+// dead-letter list.
+func replicationDLQKey(object, versionID string) string {
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	return pathJoin(object, versionID)
+}
+
+// replicationDLQFilter selects entries of the dead-letter lists.
+type replicationDLQFilter struct {
+	Prefix    string
+	Object    string
+	VersionID string
+	Error     string // matches the entries whose error contains it
+}
+
+func extractReplicationDLQFilter(q url.Values) replicationDLQFilter {
+	return replicationDLQFilter{
+		Prefix:    q.Get("prefix"),
+		Object:    q.Get("object"),
+		VersionID: q.Get("versionId"),
+		Error:     q.Get("error"),
+	}
+}
+
+func (f replicationDLQFilter) match(e ReplicationDLQEntry) bool {
+	if !strings.HasPrefix(e.Object, f.Prefix) {
+		return false
+	}
+	if f.Object != "" && e.Object != f.Object {
+		return false
+	}
+	if f.VersionID != "" && e.VersionID != f.VersionID {
+		return false
+	}
+	return f.Error == "" || strings.Contains(e.Error, f.Error)
+}
+
+// replicationDLQResult is the response of the dead-letter list retry and
+// purge admin APIs.
+type replicationDLQResult struct {
+	Count int `json:"count"`
+}
+
+// replicationDLQTarget identifies the dead-letter list of a target.
+type replicationDLQTarget struct {
+	bucket, arn string
+}
+
+func (t replicationDLQTarget) path() string {
+	return path.Join(bucketMetaPrefix, t.bucket, replicationDir, replicationDLQDir, getSHA256Hash([]byte(t.arn))+".bin")
+}
+
+// replicationDLQUpdates are the changes of a dead-letter list recorded by
+// this node and not persisted yet.
+type replicationDLQUpdates struct {
+	failures map[string]ReplicationDLQEntry
+	removals map[string]struct{}
+}
+
+type replicationDLQDepth struct {
+	n       int
+	updated time.Time
+}
+
+// replicationDLQ keeps the dead-letter lists of the replication targets,
+// the object versions which failed to replicate with permanent errors.
+// Failures are recorded in memory and merged in the lists persisted on
+// drives periodically, under a cluster wide lock.
+type replicationDLQ struct {
+	mu      sync.Mutex
+	updates map[replicationDLQTarget]*replicationDLQUpdates
+	depth   map[replicationDLQTarget]replicationDLQDepth
+}
+
+var globalReplicationDLQ = newReplicationDLQ()
+
+var replicationDLQRecords = replicationRecords{
+	name:     "replication-dlq",
+	format:   replicationDLQFormat,
+	version:  replicationDLQVersion,
+	interval: replicationDLQSaveInterval,
+}
+
+func newReplicationDLQ() *replicationDLQ {
+	return &replicationDLQ{
+		updates: make(map[replicationDLQTarget]*replicationDLQUpdates),
+		depth:   make(map[replicationDLQTarget]replicationDLQDepth),
+	}
+}
+
+func (q *replicationDLQ) targetUpdates(t replicationDLQTarget) *replicationDLQUpdates {
+	u, ok := q.updates[t]
+	if !ok {
+		u = &replicationDLQUpdates{
+			failures: make(map[string]ReplicationDLQEntry),
+			removals: make(map[string]struct{}),
+		}
+		q.updates[t] = u
+	}
+	return u
+}
+
+// add records the permanent failure to replicate objInfo to the target arn.
+func (q *replicationDLQ) add(objInfo ObjectInfo, arn string, err error) {
+	now := UTCNow()
+	e := ReplicationDLQEntry{
+		Bucket:       objInfo.Bucket,
+		Object:       objInfo.Name,
+		VersionID:    objInfo.VersionID,
+		Arn:          arn,
+		Error:        err.Error(),
+		Attempts:     1,
+		FirstFailure: now,
+		LastFailure:  now,
+	}
+	key := replicationDLQKey(e.Object, e.VersionID)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	u := q.targetUpdates(replicationDLQTarget{bucket: e.Bucket, arn: arn})
+	delete(u.removals, key)
+	u.failures[key] = u.failures[key].merge(e)
+}
+
+// remove removes the object version replicated to the target arn from its
+// dead-letter list.
+func (q *replicationDLQ) remove(bucket, arn, object, versionID string) {
+	key := replicationDLQKey(object, versionID)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	u := q.targetUpdates(replicationDLQTarget{bucket: bucket, arn: arn})
+	delete(u.failures, key)
+	u.removals[key] = struct{}{}
+}
+
+// updateTargets records the failures and successes of a replication of
+// objInfo.
+func (q *replicationDLQ) updateTargets(objInfo ObjectInfo, rinfos replicatedInfos) {
+	for _, rinfo := range rinfos.Targets {
+		if rinfo.Empty() {
+			continue
+		}
+		switch {
+		case isPermanentReplicationErr(rinfo.Err):
+			q.add(objInfo, rinfo.Arn, rinfo.Err)
+		case rinfo.Err == nil && rinfo.PrevReplicationStatus == replication.Failed && rinfo.ReplicationStatus == replication.Completed:
+			q.remove(objInfo.Bucket, rinfo.Arn, objInfo.Name, objInfo.VersionID)
+		}
+	}
+}
+
+// updateDeleteTargets records the failures and successes of a replication
+// of the delete marker or versioned delete dobj.
+func (q *replicationDLQ) updateDeleteTargets(dobj DeletedObjectReplicationInfo, rinfos replicatedInfos) {
+	objInfo := ObjectInfo{
+		Bucket:       dobj.Bucket,
+		Name:         dobj.ObjectName,
+		VersionID:    dobj.DeleteMarkerVersionID,
+		DeleteMarker: dobj.DeleteMarker,
+	}
+	if dobj.VersionID != "" {
+		objInfo.VersionID = dobj.VersionID
+	}
+	for _, rinfo := range rinfos.Targets {
+		if rinfo.Empty() {
+			continue
+		}
+		var replicated bool
+		if dobj.VersionID == "" {
+			replicated = rinfo.PrevReplicationStatus == replication.Failed && rinfo.ReplicationStatus == replication.Completed
+		} else {
+			replicated = dobj.ReplicationState.PurgeTargets[rinfo.Arn] == Failed && rinfo.VersionPurgeStatus == Complete
+		}
+		switch {
+		case isPermanentReplicationErr(rinfo.Err):
+			q.add(objInfo, rinfo.Arn, rinfo.Err)
+		case rinfo.Err == nil && replicated:
+			q.remove(objInfo.Bucket, rinfo.Arn, objInfo.Name, objInfo.VersionID)
+		}
+	}
+}
+
+// update loads the dead-letter list of the target, applies fn and saves it
+// if fn returns true, under the list lock.
+func (q *replicationDLQ) update(ctx context.Context, objAPI ObjectLayer, t replicationDLQTarget, fn func(l *ReplicationDLQEntries) bool) error {
+	l := ReplicationDLQEntries{Arn: t.arn}
+	err := replicationDLQRecords.update(ctx, objAPI, t.path(), &l, func() bool {
+		return fn(&l)
+	})
+	if err != nil {
+		return err
+	}
+	q.setDepth(t, len(l.Entries))
+	return nil
+}
+
+func (q *replicationDLQ) setDepth(t replicationDLQTarget, n int) {
+	q.mu.Lock()
+	q.depth[t] = replicationDLQDepth{n: n, updated: time.Now()}
+	q.mu.Unlock()
+}
+
+// flush persists the updates of the dead-letter lists recorded by this node.
+func (q *replicationDLQ) flush(ctx context.Context, objAPI ObjectLayer) {
+	q.mu.Lock()
+	updates := q.updates
+	q.updates = make(map[replicationDLQTarget]*replicationDLQUpdates)
+	q.mu.Unlock()
+
+	for t, u := range updates {
+		err := q.update(ctx, objAPI, t, func(l *ReplicationDLQEntries) bool {
+			for key := range u.removals {
+				delete(l.Entries, key)
+			}
+			for key, e := range u.failures {
+				l.Entries[key] = l.Entries[key].merge(e)
+			}
+			l.trim(maxReplicationDLQEntries)
+			return len(u.removals) > 0 || len(u.failures) > 0
+		})
+		if err != nil {
+			logger.LogOnceIf(ctx, fmt.Errorf("Unable to persist replication dead-letter list of %s for %s: %w", t.bucket, t.arn, err), "replication-dlq-"+t.bucket)
+		}
+	}
+}
+
+// persist flushes the updates of the dead-letter lists periodically.
+func (q *replicationDLQ) persist(ctx context.Context, objAPI ObjectLayer) {
+	replicationDLQRecords.persist(ctx, objAPI, q.flush)
+}
+
+// List returns the entries of the dead-letter list of the target matching
+// the filter, the most recent failures first. The failures not persisted
+// yet are not listed, see flushClusterReplicationRecords.
+func (q *replicationDLQ) List(ctx context.Context, objAPI ObjectLayer, bucket, arn string, f replicationDLQFilter) ([]ReplicationDLQEntry, error) {
+	t := replicationDLQTarget{bucket: bucket, arn: arn}
+	l, err := loadReplicationDLQ(ctx, objAPI, t)
+	if err != nil {
+		return nil, err
+	}
+	q.setDepth(t, len(l.Entries))
+	entries := make([]ReplicationDLQEntry, 0, len(l.Entries))
+	for _, e := range l.Entries {
+		if f.match(e) {
+			entries = append(entries, e)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastFailure.After(entries[j].LastFailure)
+	})
+	return entries, nil
+}
+
+// Retry queues the replication of the entries of the dead-letter list of
+// the target matching the filter, including delete markers and versioned
+// deletes. Entries stay listed until replicated, entries of object versions
+// which no longer exist are removed.
+func (q *replicationDLQ) Retry(ctx context.Context, objAPI ObjectLayer, bucket, arn string, f replicationDLQFilter) (n int, err error) {
+	entries, err := q.List(ctx, objAPI, bucket, arn, f)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range entries {
+		oi, err := objAPI.GetObjectInfo(ctx, e.Bucket, e.Object, ObjectOptions{VersionID: e.VersionID})
+		// Delete markers and versions pending deletion are returned
+		// with MethodNotAllowed.
+		if isErrMethodNotAllowed(err) && (oi.DeleteMarker || !oi.VersionPurgeStatus.Empty()) {
+			err = nil
+		}
+		if err != nil {
+			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+				q.remove(e.Bucket, e.Arn, e.Object, e.VersionID)
+			}
+			continue
+		}
+		if oi.TargetReplicationStatus(arn) == replication.Completed {
+			q.remove(e.Bucket, e.Arn, e.Object, e.VersionID)
+			continue
+		}
+		QueueReplicationHeal(ctx, bucket, oi)
+		n++
+	}
+	q.flush(ctx, objAPI)
+	return n, nil
+}
+
+// Purge removes the entries of the dead-letter list of the target matching
+// the filter.
+func (q *replicationDLQ) Purge(ctx context.Context, objAPI ObjectLayer, bucket, arn string, f replicationDLQFilter) (n int, err error) {
+	err = q.update(ctx, objAPI, replicationDLQTarget{bucket: bucket, arn: arn}, func(l *ReplicationDLQEntries) bool {
+		for key, e := range l.Entries {
+			if f.match(e) {
+				delete(l.Entries, key)
+				n++
+			}
+		}
+		return n > 0
+	})
+	return n, err
+}
+
+// Depth returns the number of entries of the dead-letter list of the
+// target, cached for replicationDLQDepthExpiry.
+func (q *replicationDLQ) Depth(ctx context.Context, objAPI ObjectLayer, bucket, arn string) int {
+	t := replicationDLQTarget{bucket: bucket, arn: arn}
+	q.mu.Lock()
+	d, ok := q.depth[t]
+	q.mu.Unlock()
+	if ok && time.Since(d.updated) < replicationDLQDepthExpiry {
+		return d.n
+	}
+	l, err := loadReplicationDLQ(ctx, objAPI, t)
+	if err != nil {
+		return d.n
+	}
+	q.setDepth(t, len(l.Entries))
+	return len(l.Entries)
+}
+
+// trim drops the oldest failures beyond max entries.
+func (l *ReplicationDLQEntries) trim(max int) {
+	if len(l.Entries) <= max {
+		return
+	}
+	keys := make([]string, 0, len(l.Entries))
+	for key := range l.Entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return l.Entries[keys[i]].LastFailure.After(l.Entries[keys[j]].LastFailure)
+	})
+	for _, key := range keys[max:] {
+		delete(l.Entries, key)
+	}
+}
+
+// loadReplicationDLQ loads the dead-letter list of the target from drives.
+func loadReplicationDLQ(ctx context.Context, objAPI ObjectLayer, t replicationDLQTarget) (l ReplicationDLQEntries, e error) {
+	l.Arn = t.arn
+	e = replicationDLQRecords.load(ctx, objAPI, t.path(), &l)
+	return l, e
+}
+
+// saveReplicationDLQ saves the dead-letter list of the target to drives.
+func saveReplicationDLQ(ctx context.Context, objAPI ObjectLayer, t replicationDLQTarget, l ReplicationDLQEntries) error {
+	return replicationDLQRecords.save(ctx, objAPI, t.path(), &l)
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio/internal/bucket/replication"
+)
+
+func TestIsPermanentReplicationErr(t *testing.T) {
+	testCases := []struct {
+		err       error
+		permanent bool
+	}{
+		{nil, false},
+		{errors.New("connection reset"), false},
+		{miniogo.ErrorResponse{Code: "AccessDenied"}, true},
+		{miniogo.ErrorResponse{Code: "EntityTooLarge"}, true},
+		{miniogo.ErrorResponse{Code: "SlowDown"}, false},
+	}
+	for i, tc := range testCases {
+		if got := isPermanentReplicationErr(tc.err); got != tc.permanent {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.permanent, got)
+		}
+	}
+}
+
+func TestReplicationDLQ(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	const (
+		bucket = "bucket"
+		arn    = "arn:minio:replication::target:bucket"
+	)
+	accessDenied := miniogo.ErrorResponse{Code: "AccessDenied", Message: "Access Denied."}
+	tooLarge := miniogo.ErrorResponse{Code: "EntityTooLarge", Message: "Your proposed upload exceeds the maximum allowed object size."}
+
+	q := newReplicationDLQ()
+	q.updateTargets(ObjectInfo{Bucket: bucket, Name: "a/1", VersionID: "v1"}, replicatedInfos{
+		Targets: []replicatedTargetInfo{{Arn: arn, ReplicationStatus: replication.Failed, Err: accessDenied}},
+	})
+	q.updateTargets(ObjectInfo{Bucket: bucket, Name: "b/2", VersionID: "v2"}, replicatedInfos{
+		Targets: []replicatedTargetInfo{{Arn: arn, ReplicationStatus: replication.Failed, Err: tooLarge}},
+	})
+	// Transient failures are retried by the scanner.
+	q.updateTargets(ObjectInfo{Bucket: bucket, Name: "c/3", VersionID: "v3"}, replicatedInfos{
+		Targets: []replicatedTargetInfo{{Arn: arn, ReplicationStatus: replication.Failed, Err: errors.New("connection reset")}},
+	})
+	q.flush(ctx, obj)
+
+	// A second failure of the same version, recorded by another node.
+	q2 := newReplicationDLQ()
+	q2.add(ObjectInfo{Bucket: bucket, Name: "a/1", VersionID: "v1"}, arn, accessDenied)
+	q2.flush(ctx, obj)
+
+	entries, err := q.List(ctx, obj, bucket, arn, replicationDLQFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %v", entries)
+	}
+	if entries[0].Object != "a/1" || entries[0].Attempts != 2 {
+		t.Errorf("expected 2 attempts for a/1 listed first, got %v", entries[0])
+	}
+	if n := q.Depth(ctx, obj, bucket, arn); n != 2 {
+		t.Errorf("expected depth 2, got %d", n)
+	}
+
+	entries, err = q.List(ctx, obj, bucket, arn, replicationDLQFilter{Error: "maximum allowed object size"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Object != "b/2" {
+		t.Errorf("expected b/2 only, got %v", entries)
+	}
+
+	// Replicated after the failure.
+	q.updateTargets(ObjectInfo{Bucket: bucket, Name: "a/1", VersionID: "v1"}, replicatedInfos{
+		Targets: []replicatedTargetInfo{{Arn: arn, PrevReplicationStatus: replication.Failed, ReplicationStatus: replication.Completed}},
+	})
+	q.flush(ctx, obj)
+	entries, err = q.List(ctx, obj, bucket, arn, replicationDLQFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Object != "b/2" {
+		t.Errorf("expected b/2 only, got %v", entries)
+	}
+
+	n, err := q.Purge(ctx, obj, bucket, arn, replicationDLQFilter{Prefix: "b/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 purged entry, got %d", n)
+	}
+	if n := q.Depth(ctx, obj, bucket, arn); n != 0 {
+		t.Errorf("expected depth 0, got %d", n)
+	}
+}
+
+func TestReplicationDLQDeletes(t *testing.T) {
+	const (
+		bucket = "bucket"
+		arn    = "arn:minio:replication::target:bucket"
+	)
+	accessDenied := miniogo.ErrorResponse{Code: "AccessDenied", Message: "Access Denied."}
+	deleteMarker := DeletedObjectReplicationInfo{
+		DeletedObject: DeletedObject{ObjectName: "a/1", DeleteMarkerVersionID: "dm1", DeleteMarker: true},
+		Bucket:        bucket,
+	}
+	versionDelete := DeletedObjectReplicationInfo{
+		DeletedObject: DeletedObject{ObjectName: "b/2", VersionID: "v2"},
+		Bucket:        bucket,
+	}
+
+	q := newReplicationDLQ()
+	q.updateDeleteTargets(deleteMarker, replicatedInfos{
+		Targets: []replicatedTargetInfo{{Arn: arn, ReplicationStatus: replication.Failed, Err: accessDenied}},
+	})
+	q.updateDeleteTargets(versionDelete, replicatedInfos{
+		Targets: []replicatedTargetInfo{{Arn: arn, VersionPurgeStatus: Failed, Err: accessDenied}},
+	})
+	u := q.updates[replicationDLQTarget{bucket: bucket, arn: arn}]
+	for _, key := range []string{replicationDLQKey("a/1", "dm1"), replicationDLQKey("b/2", "v2")} {
+		if _, ok := u.failures[key]; !ok {
+			t.Errorf("expected failure of %s to be recorded, got %v", key, u.failures)
+		}
+	}
+
+	// Replicated after the failure.
+	versionDelete.ReplicationState.PurgeTargets = map[string]VersionPurgeStatusType{arn: Failed}
+	q.updateDeleteTargets(versionDelete, replicatedInfos{
+		Targets: []replicatedTargetInfo{{Arn: arn, VersionPurgeStatus: Complete}},
+	})
+	key := replicationDLQKey("b/2", "v2")
+	if _, ok := u.failures[key]; ok {
+		t.Errorf("expected failure of %s to be removed", key)
+	}
+	if _, ok := u.removals[key]; !ok {
+		t.Errorf("expected removal of %s to be recorded", key)
+	}
+}
+
+func TestReplicationDLQTrim(t *testing.T) {
+	l := ReplicationDLQEntries{Entries: make(map[string]ReplicationDLQEntry)}
+	now := UTCNow()
+	for i, name := range []string{"a", "b", "c"} {
+		l.Entries[name] = ReplicationDLQEntry{Object: name, LastFailure: now.Add(time.Duration(i) * time.Second)}
+	}
+	l.trim(2)
+	if _, ok := l.Entries["a"]; ok || len(l.Entries) != 2 {
+		t.Errorf("expected the oldest entry to be dropped, got %v", l.Entries)
+	}
+}
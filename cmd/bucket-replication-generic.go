@@ -0,0 +1,226 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio/internal/bucket/replication"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// bucketGenericTargetsFile lists the remote targets of a bucket which are
+// not MinIO deployments.
+const bucketGenericTargetsFile = "generic-targets.json"
+
+// User metadata of the objects replicated to generic targets, identifying
+// the source object version.
+const (
+	genericSourceVersionID = "X-Amz-Meta-Minio-Source-Version-Id"
+	genericSourceMTime     = "X-Amz-Meta-Minio-Source-Mtime"
+	genericSourceETag      = "X-Amz-Meta-Minio-Source-Etag"
+)
+
+// BucketGenericTargets - ARNs of the remote targets of a bucket which are
+// not MinIO deployments, such as AWS S3 or Ceph RGW. Objects are replicated
+// to them with standard S3 calls only, as new versions of the target
+// objects.
+type BucketGenericTargets struct {
+	Targets []string `json:"targets"`
+}
+
+func parseBucketGenericTargets(bucket string, data []byte) (*BucketGenericTargets, error) {
+	targets := &BucketGenericTargets{}
+	if err := json.Unmarshal(data, targets); err != nil {
+		return nil, fmt.Errorf("invalid generic targets for bucket %s: %w", bucket, err)
+	}
+	return targets, nil
+}
+
+// Contains returns true if the target arn is a generic target.
+func (g *BucketGenericTargets) Contains(arn string) bool {
+	if g == nil {
+		return false
+	}
+	for _, t := range g.Targets {
+		if t == arn {
+			return true
+		}
+	}
+	return false
+}
+
+// getGenericTargets returns the generic targets of the bucket, nil if none.
+func getGenericTargets(bucket string) *BucketGenericTargets {
+	if globalBucketMetadataSys == nil {
+		return nil
+	}
+	targets, err := globalBucketMetadataSys.GetGenericTargets(bucket)
+	if err != nil {
+		return nil
+	}
+	return targets
+}
+
+// updateGenericTarget adds the target arn to the generic targets of the
+// bucket, or removes it if generic is false.
+func updateGenericTarget(ctx context.Context, bucket, arn string, generic bool) error {
+	current := getGenericTargets(bucket)
+	if current.Contains(arn) == generic {
+		return nil
+	}
+	var targets []string
+	if current != nil {
+		for _, t := range current.Targets {
+			if t != arn {
+				targets = append(targets, t)
+			}
+		}
+	}
+	if generic {
+		targets = append(targets, arn)
+	}
+	if len(targets) == 0 {
+		_, err := globalBucketMetadataSys.Delete(ctx, bucket, bucketGenericTargetsFile)
+		return err
+	}
+	data, err := json.Marshal(BucketGenericTargets{Targets: targets})
+	if err != nil {
+		return err
+	}
+	_, err = globalBucketMetadataSys.Update(ctx, bucket, bucketGenericTargetsFile, data)
+	return err
+}
+
+// genericSourceMetadata returns the user metadata identifying the source
+// object version on generic targets.
+func genericSourceMetadata(objInfo ObjectInfo) map[string]string {
+	versionID := objInfo.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	return map[string]string{
+		genericSourceVersionID: versionID,
+		genericSourceMTime:     objInfo.ModTime.UTC().Format(time.RFC3339Nano),
+		genericSourceETag:      objInfo.ETag,
+	}
+}
+
+// genericPutReplicationOpts returns the options of putReplicationOpts
+// without MinIO extensions, keeping the source object version in user
+// metadata.
+func genericPutReplicationOpts(putOpts miniogo.PutObjectOptions, objInfo ObjectInfo) miniogo.PutObjectOptions {
+	putOpts.Internal = miniogo.AdvancedPutOptions{}
+	meta := make(map[string]string, len(putOpts.UserMetadata)+3)
+	for k, v := range putOpts.UserMetadata {
+		meta[k] = v
+	}
+	for k, v := range genericSourceMetadata(objInfo) {
+		meta[k] = v
+	}
+	putOpts.UserMetadata = meta
+	return putOpts
+}
+
+// genericCopyObjMetadata returns the metadata of getCopyObjMetadata without
+// MinIO extensions, keeping the source object version in user metadata.
+func genericCopyObjMetadata(oi ObjectInfo, sc string) map[string]string {
+	meta := getCopyObjMetadata(oi, sc)
+	delete(meta, xhttp.MinIOSourceETag)
+	delete(meta, xhttp.MinIOSourceMTime)
+	delete(meta, xhttp.AmzBucketReplicationStatus)
+	for k, v := range genericSourceMetadata(oi) {
+		meta[k] = v
+	}
+	return meta
+}
+
+// statGenericTarget returns the info of the latest version of object on
+// a generic target, with its tags.
+func statGenericTarget(ctx context.Context, tgt *TargetClient, object string) (oi miniogo.ObjectInfo, err error) {
+	oi, err = tgt.StatObject(ctx, tgt.Bucket, object, miniogo.StatObjectOptions{})
+	if err != nil {
+		return oi, err
+	}
+	// S3 only returns the number of tags with the object.
+	if oi.UserTagCount > 0 {
+		t, err := tgt.GetObjectTagging(ctx, tgt.Bucket, object, miniogo.GetObjectTaggingOptions{VersionID: oi.VersionID})
+		if err != nil {
+			return oi, err
+		}
+		oi.UserTags = t.ToMap()
+	} else {
+		oi.UserTags = map[string]string{}
+	}
+	return oi, nil
+}
+
+// getGenericReplicationAction returns the replicationAction of oi1 given
+// the latest version oi2 of the object on a generic target. Versions older
+// than the one on the target are not replicated, generic targets only hold
+// the versions replicated in order.
+func getGenericReplicationAction(oi1 ObjectInfo, oi2 miniogo.ObjectInfo, opType replication.Type) replicationAction {
+	mtime, err := time.Parse(time.RFC3339Nano, oi2.Metadata.Get(genericSourceMTime))
+	if err != nil {
+		// Not replicated from this deployment.
+		return replicateAll
+	}
+	versionID := oi1.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	if oi2.Metadata.Get(genericSourceVersionID) != versionID {
+		if mtime.After(oi1.ModTime) {
+			return replicateNone
+		}
+		return replicateAll
+	}
+
+	// Compare as if the target held the source version.
+	oi2.VersionID = oi1.VersionID
+	oi2.LastModified = mtime
+	oi2.ETag = oi2.Metadata.Get(genericSourceETag)
+	meta := oi2.Metadata.Clone()
+	for _, k := range []string{genericSourceVersionID, genericSourceMTime, genericSourceETag} {
+		meta.Del(k)
+	}
+	oi2.Metadata = meta
+	return getReplicationAction(oi1, oi2, opType)
+}
+
+// genericTargetAlive returns true if the generic target endpoint ep
+// answers HTTP requests, generic targets have no health check API.
+func genericTargetAlive(ctx context.Context, ep epHealth) bool {
+	u := url.URL{Scheme: ep.Scheme, Host: ep.Endpoint, Path: "/"}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.String(), nil)
+	if err != nil {
+		return false
+	}
+	resp, err := (&http.Client{Transport: globalRemoteTargetTransport}).Do(req)
+	if err != nil {
+		return false
+	}
+	xhttp.DrainBody(resp.Body)
+	return true
+}
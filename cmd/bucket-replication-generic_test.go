@@ -0,0 +1,149 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio/internal/bucket/replication"
+)
+
+func TestGenericPutReplicationOpts(t *testing.T) {
+	objInfo := ObjectInfo{
+		Bucket:      "bucket",
+		Name:        "object",
+		VersionID:   "2c3f7e1d-2ee4-4a4b-a3b0-1f4b5e9e3b6d",
+		ModTime:     time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC),
+		ETag:        "5d41402abc4b2a76b9719d911017c592",
+		ContentType: "text/plain",
+		UserDefined: map[string]string{"X-Amz-Meta-Project": "tax"},
+		UserTags:    "k=v",
+	}
+	putOpts, err := putReplicationOpts(context.Background(), "", objInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := genericPutReplicationOpts(putOpts, objInfo).Header()
+	for k := range h {
+		if strings.HasPrefix(strings.ToLower(k), "x-minio-") || http.CanonicalHeaderKey(k) == "X-Amz-Replication-Status" {
+			t.Errorf("unexpected MinIO extension header %s", k)
+		}
+	}
+	for k, v := range map[string]string{
+		genericSourceVersionID: objInfo.VersionID,
+		genericSourceMTime:     "2022-06-01T10:00:00Z",
+		genericSourceETag:      objInfo.ETag,
+		"X-Amz-Meta-Project":   "tax",
+		"X-Amz-Tagging":        "k=v",
+	} {
+		if h.Get(k) != v {
+			t.Errorf("expected %s to be %s, got %s", k, v, h.Get(k))
+		}
+	}
+}
+
+func TestGetGenericReplicationAction(t *testing.T) {
+	mtime := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	src := ObjectInfo{
+		Bucket:      "bucket",
+		Name:        "object",
+		VersionID:   "2c3f7e1d-2ee4-4a4b-a3b0-1f4b5e9e3b6d",
+		ModTime:     mtime,
+		ETag:        "5d41402abc4b2a76b9719d911017c592",
+		Size:        5,
+		ContentType: "text/plain",
+		UserDefined: map[string]string{"X-Amz-Meta-Project": "tax"},
+	}
+	target := func(versionID string, mtime time.Time, project string) miniogo.ObjectInfo {
+		return miniogo.ObjectInfo{
+			VersionID:    "target-version",
+			LastModified: time.Now(),
+			ETag:         "target-etag",
+			Size:         5,
+			ContentType:  "text/plain",
+			UserTags:     map[string]string{},
+			Metadata: http.Header{
+				genericSourceVersionID: []string{versionID},
+				genericSourceMTime:     []string{mtime.Format(time.RFC3339Nano)},
+				genericSourceETag:      []string{src.ETag},
+				"X-Amz-Meta-Project":   []string{project},
+			},
+		}
+	}
+	testCases := []struct {
+		target miniogo.ObjectInfo
+		action replicationAction
+	}{
+		// 1. source version replicated
+		{target(src.VersionID, mtime, "tax"), replicateNone},
+		// 2. source version replicated with other metadata
+		{target(src.VersionID, mtime, "audit"), replicateMetadata},
+		// 3. older version on the target
+		{target("older", mtime.Add(-time.Hour), "tax"), replicateAll},
+		// 4. newer version on the target
+		{target("newer", mtime.Add(time.Hour), "tax"), replicateNone},
+		// 5. object not replicated from this deployment
+		{miniogo.ObjectInfo{Metadata: http.Header{}}, replicateAll},
+	}
+	for i, tc := range testCases {
+		if action := getGenericReplicationAction(src, tc.target, replication.HealReplicationType); action != tc.action {
+			t.Errorf("Test %d: expected action %s, got %s", i+1, tc.action, action)
+		}
+	}
+}
+
+func TestIsGenericEndpoint(t *testing.T) {
+	sys := &BucketTargetSys{
+		targetsMap: map[string][]madmin.BucketTarget{
+			"bucket1": {
+				{Endpoint: "s3.amazonaws.com", Arn: "arn1"},
+				{Endpoint: "minio:9000", Arn: "arn2"},
+			},
+			"bucket2": {
+				{Endpoint: "minio:9000", Arn: "arn3"},
+			},
+		},
+		arnRemotesMap: map[string]*TargetClient{
+			"arn1": {generic: true},
+			"arn2": {generic: true},
+			"arn3": {},
+		},
+	}
+	testCases := []struct {
+		endpoint string
+		generic  bool
+	}{
+		// 1. generic targets only
+		{"s3.amazonaws.com", true},
+		// 2. generic and MinIO targets
+		{"minio:9000", false},
+		// 3. no target
+		{"rgw:7480", false},
+	}
+	for i, tc := range testCases {
+		if generic := sys.isGenericEndpoint(tc.endpoint); generic != tc.generic {
+			t.Errorf("Test %d: expected generic %v, got %v", i+1, tc.generic, generic)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+// replicationRecordList is a list of replication records persisted on
+// drives, e.g the dead-letter list of a target.
+type replicationRecordList interface {
+	msgp.Marshaler
+	msgp.Unmarshaler
+	msgp.Sizer
+	// reset empties the list.
+	reset()
+}
+
+// replicationRecords describes a kind of replication records. Each node
+// records them in memory and merges them periodically in lists persisted
+// on drives, under a cluster wide lock.
+type replicationRecords struct {
+	name     string // names the lock and the errors of the lists
+	format   uint16
+	version  uint16
+	interval time.Duration // interval of the merges
+}
+
+// load loads the list l from configPath, l is empty if not found.
+func (r replicationRecords) load(ctx context.Context, objAPI ObjectLayer, configPath string, l replicationRecordList) error {
+	l.reset()
+	data, err := readConfig(ctx, objAPI, configPath)
+	if err != nil && err != errConfigNotFound {
+		return err
+	}
+	if len(data) == 0 {
+		// Seems to be empty.
+		return nil
+	}
+	if len(data) <= 4 {
+		return fmt.Errorf("%s: no data", r.name)
+	}
+	// Read meta header
+	switch format := binary.LittleEndian.Uint16(data[0:2]); format {
+	case r.format:
+	default:
+		return fmt.Errorf("%s: unknown format: %d", r.name, format)
+	}
+	switch version := binary.LittleEndian.Uint16(data[2:4]); version {
+	case r.version:
+	default:
+		return fmt.Errorf("%s: unknown version: %d", r.name, version)
+	}
+	// OK, parse data.
+	_, err = l.UnmarshalMsg(data[4:])
+	return err
+}
+
+// save saves the list l to configPath.
+func (r replicationRecords) save(ctx context.Context, objAPI ObjectLayer, configPath string, l replicationRecordList) error {
+	data := make([]byte, 4, l.Msgsize()+4)
+
+	// Initialize the meta header.
+	binary.LittleEndian.PutUint16(data[0:2], r.format)
+	binary.LittleEndian.PutUint16(data[2:4], r.version)
+
+	buf, err := l.MarshalMsg(data)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, configPath, buf)
+}
+
+// update loads the list l from configPath, applies fn and saves l if fn
+// returns true, under the lock of the list.
+func (r replicationRecords) update(ctx context.Context, objAPI ObjectLayer, configPath string, l replicationRecordList, fn func() bool) error {
+	// Use separate lock that doesn't collide with reading and saving the list.
+	lk := objAPI.NewNSLock(minioMetaBucket, "/["+r.name+"]/"+configPath)
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	if err = r.load(ctx, objAPI, configPath, l); err != nil {
+		return err
+	}
+	if fn() {
+		return r.save(ctx, objAPI, configPath, l)
+	}
+	return nil
+}
+
+// persist calls flush periodically, and once more when ctx is done.
+func (r replicationRecords) persist(ctx context.Context, objAPI ObjectLayer, flush func(ctx context.Context, objAPI ObjectLayer)) {
+	t := time.NewTimer(r.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flush(context.Background(), objAPI)
+			return
+		case <-t.C:
+			flush(ctx, objAPI)
+			t.Reset(r.interval)
+		}
+	}
+}
+
+// flushReplicationRecords persists the replication records of this node.
+func flushReplicationRecords(ctx context.Context, objAPI ObjectLayer) {
+	globalReplicationDLQ.flush(ctx, objAPI)
+	globalReplicationConflicts.flush(ctx, objAPI)
+}
+
+// flushClusterReplicationRecords persists the replication records of all
+// the nodes, before they are listed or changed.
+func flushClusterReplicationRecords(ctx context.Context, objAPI ObjectLayer) {
+	if globalNotificationSys != nil {
+		globalNotificationSys.FlushReplicationRecords(ctx)
+	}
+	flushReplicationRecords(ctx, objAPI)
+}
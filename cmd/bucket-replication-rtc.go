@@ -0,0 +1,164 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/event"
+)
+
+const (
+	// Maximum number of object versions tracked for replication time
+	// control, objects above the limit are not notified until replicated.
+	maxRTCTracked = 100000
+
+	// Time a missed object version stays tracked, for its retries not to
+	// be notified again.
+	rtcMissedExpiry = 24 * time.Hour
+)
+
+// rtcPending is an object version pending replication to a target with
+// replication time control.
+type rtcPending struct {
+	timer    *time.Timer
+	deadline time.Time
+	missed   bool
+}
+
+// replicationRTC tracks the object versions pending replication to targets
+// with replication time control, to notify the ones missing the event
+// threshold.
+type replicationRTC struct {
+	mu      sync.Mutex
+	pending map[string]*rtcPending
+}
+
+var globalReplicationRTC = &replicationRTC{
+	pending: make(map[string]*rtcPending),
+}
+
+func rtcKey(objInfo ObjectInfo, arn string) string {
+	return pathJoin(objInfo.Bucket, objInfo.Name, objInfo.VersionID, arn)
+}
+
+// track starts tracking the replication of objInfo to the target arn, the
+// missed threshold event is sent if it is not replicated within
+// eventThreshold of its creation.
+func (r *replicationRTC) track(objInfo ObjectInfo, arn string, eventThreshold time.Duration) {
+	key := rtcKey(objInfo, arn)
+	deadline := objInfo.ModTime.Add(eventThreshold)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.pending[key]; ok {
+		return
+	}
+	if len(r.pending) >= maxRTCTracked {
+		r.expire()
+		if len(r.pending) >= maxRTCTracked {
+			return
+		}
+	}
+	p := &rtcPending{deadline: deadline}
+	r.pending[key] = p
+	p.timer = time.AfterFunc(time.Until(deadline), func() {
+		r.mu.Lock()
+		if r.pending[key] != p {
+			r.mu.Unlock()
+			return
+		}
+		p.missed = true
+		r.mu.Unlock()
+
+		globalReplicationStats.MissedRTC(objInfo.Bucket, arn)
+		sendEvent(eventArgs{
+			EventName:  event.ObjectReplicationMissedThreshold,
+			BucketName: objInfo.Bucket,
+			Object:     objInfo,
+			Host:       "Internal: [Replication]",
+		})
+	})
+}
+
+// done stops tracking the replication of objInfo to the target arn.
+func (r *replicationRTC) done(objInfo ObjectInfo, arn string) {
+	key := rtcKey(objInfo, arn)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p, ok := r.pending[key]; ok {
+		p.timer.Stop()
+		delete(r.pending, key)
+	}
+}
+
+// expire stops tracking the object versions notified as missed for long,
+// the caller must hold the lock.
+func (r *replicationRTC) expire() {
+	now := time.Now()
+	for key, p := range r.pending {
+		if p.missed && now.Sub(p.deadline) > rtcMissedExpiry {
+			delete(r.pending, key)
+		}
+	}
+}
+
+// updateRTC updates the replication time control statistics of the targets
+// objInfo was replicated to, and sends the replicated after threshold
+// events.
+func (ri replicatedInfos) updateRTC(cfg *replication.Config, objInfo ObjectInfo) {
+	var after bool
+	for _, rinfo := range ri.Targets {
+		if rinfo.Empty() {
+			continue
+		}
+		threshold, eventThreshold := cfg.ReplicationTimeThreshold(rinfo.Arn)
+		if threshold == 0 {
+			continue
+		}
+		if rinfo.ReplicationStatus != replication.Completed {
+			// Still tracked, until replicated.
+			continue
+		}
+		globalReplicationRTC.done(objInfo, rinfo.Arn)
+		if rinfo.PrevReplicationStatus == replication.Completed || rinfo.ReplicationAction != replicateAll {
+			continue
+		}
+		if rinfo.OpType != replication.ObjectReplicationType {
+			continue
+		}
+		latency := UTCNow().Sub(objInfo.ModTime)
+		globalReplicationStats.UpdateRTC(objInfo.Bucket, rinfo.Arn, latency, threshold)
+		if latency > eventThreshold {
+			after = true
+		}
+	}
+	if after {
+		sendEvent(eventArgs{
+			EventName:  event.ObjectReplicationReplicatedAfterThreshold,
+			BucketName: objInfo.Bucket,
+			Object:     objInfo,
+			Host:       "Internal: [Replication]",
+		})
+	}
+}
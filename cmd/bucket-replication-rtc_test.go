@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReplicationRTCStats(t *testing.T) {
+	var a, b ReplicationRTCStats
+	for i := 0; i < 90; i++ {
+		a.update(3*time.Second, 15*time.Minute)
+	}
+	for i := 0; i < 9; i++ {
+		b.update(8*time.Minute, 15*time.Minute)
+	}
+	b.update(20*time.Minute, 15*time.Minute)
+	b.MissedThreshold++
+
+	s := a.merge(b)
+	if s.WithinThreshold != 99 || s.AfterThreshold != 1 || s.MissedThreshold != 1 {
+		t.Fatalf("unexpected counts %+v", s)
+	}
+	if s.MaxLatency != 20*time.Minute {
+		t.Fatalf("expected the highest latency to be 20m, got %v", s.MaxLatency)
+	}
+	testCases := []struct {
+		p       float64
+		latency time.Duration
+	}{
+		{0.5, 5 * time.Second},
+		{0.9, 5 * time.Second},
+		{0.95, 10 * time.Minute},
+		{0.99, 10 * time.Minute},
+		{1, 20 * time.Minute},
+	}
+	for _, tc := range testCases {
+		if l := s.percentile(tc.p); l != tc.latency {
+			t.Errorf("expected percentile %v to be %v, got %v", tc.p, tc.latency, l)
+		}
+	}
+	if (ReplicationRTCStats{}).percentile(0.5) != 0 {
+		t.Error("expected no latency without replicated objects")
+	}
+}
+
+func TestReplicationRTCMissed(t *testing.T) {
+	if globalEventNotifier == nil {
+		globalEventNotifier = NewEventNotifier()
+	}
+	r := &replicationRTC{pending: make(map[string]*rtcPending)}
+	arn := "arn:minio:replication::target:bucket"
+
+	// Replicated within the threshold.
+	replicated := ObjectInfo{Bucket: "bucket", Name: "replicated", ModTime: time.Now()}
+	r.track(replicated, arn, time.Hour)
+	r.done(replicated, arn)
+
+	// Pending past the threshold.
+	pending := ObjectInfo{Bucket: "bucket", Name: "pending", ModTime: time.Now().Add(-time.Hour)}
+	r.track(pending, arn, time.Minute)
+	r.track(pending, arn, time.Minute)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		r.mu.Lock()
+		p := r.pending[rtcKey(pending, arn)]
+		missed := p != nil && p.missed
+		n := len(r.pending)
+		r.mu.Unlock()
+		if missed {
+			if n != 1 {
+				t.Fatalf("expected one object version tracked, got %d", n)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the pending object version to miss the threshold")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -136,6 +136,46 @@ func (r *ReplicationStats) Update(bucket string, arn string, n int64, duration t
 	}
 }
 
+// UpdateRTC updates in-memory replication time control statistics with the
+// latency of an object replicated to the target arn.
+func (r *ReplicationStats) UpdateRTC(bucket, arn string, latency, threshold time.Duration) {
+	if r == nil {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+
+	r.targetStat(bucket, arn).RTC.update(latency, threshold)
+}
+
+// MissedRTC counts an object still pending replication to the target arn
+// past the replication time control event threshold.
+func (r *ReplicationStats) MissedRTC(bucket, arn string) {
+	if r == nil {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+
+	r.targetStat(bucket, arn).RTC.MissedThreshold++
+}
+
+// targetStat returns the in-memory statistics of the target arn, the
+// caller must hold the lock.
+func (r *ReplicationStats) targetStat(bucket, arn string) *BucketReplicationStat {
+	bs, ok := r.Cache[bucket]
+	if !ok {
+		bs = &BucketReplicationStats{Stats: make(map[string]*BucketReplicationStat)}
+		r.Cache[bucket] = bs
+	}
+	b, ok := bs.Stats[arn]
+	if !ok {
+		b = &BucketReplicationStat{}
+		bs.Stats[arn] = b
+	}
+	return b
+}
+
 // GetInitialUsage get replication metrics available at the time of cluster initialization
 func (r *ReplicationStats) GetInitialUsage(bucket string) BucketReplicationStats {
 	if r == nil {
@@ -307,6 +347,7 @@ func (r *ReplicationStats) calculateBucketReplicationStats(bucket string, u Buck
 				FailedSize:     stat.FailedSize + oldst.FailedSize,
 				ReplicatedSize: stat.ReplicatedSize + oldst.ReplicatedSize,
 				Latency:        stat.Latency.merge(oldst.Latency),
+				RTC:            stat.RTC.merge(oldst.RTC),
 				PendingCount:   stat.PendingCount + oldst.PendingCount,
 				PendingSize:    stat.PendingSize + oldst.PendingSize,
 			}
@@ -358,6 +399,7 @@ func (r *ReplicationStats) calculateBucketReplicationStats(bucket string, u Buck
 		st.PendingSize = int64(math.Max(float64(tgtstat.PendingSize), 0))
 		st.PendingCount = int64(math.Max(float64(tgtstat.PendingCount), 0))
 		st.Latency = tgtstat.Latency
+		st.RTC = tgtstat.RTC
 
 		s.Stats[arn] = &st
 		s.FailedSize += st.FailedSize
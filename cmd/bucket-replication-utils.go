@@ -46,7 +46,11 @@ type replicatedTargetInfo struct {
 	PrevReplicationStatus replication.StatusType
 	VersionPurgeStatus    VersionPurgeStatusType
 	ResyncTimestamp       string
-	ReplicationResynced   bool // true only if resync attempted for this target
+	ReplicationResynced   bool  // true only if resync attempted for this target
+	Err                   error // replication error, if failed
+	// Conflict is true if the target rejected the version, losing a
+	// replication conflict at the remote site.
+	Conflict bool
 }
 
 // Empty returns true for a target if arn is empty
@@ -517,6 +521,7 @@ func getHealReplicateObjectInfo(objInfo ObjectInfo, rcfg replicationConfig) Repl
 	} else {
 		dsc = mustReplicate(GlobalContext, oi.Bucket, oi.Name, getMustReplicateOptions(ObjectInfo{
 			UserDefined: oi.UserDefined,
+			Size:        oi.Size,
 		}, replication.HealReplicationType, ObjectOptions{}))
 	}
 	tgtStatuses = replicationStatusesMap(oi.ReplicationStatusInternal)
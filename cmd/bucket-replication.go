@@ -153,7 +153,8 @@ type mustReplicateOptions struct {
 	meta               map[string]string
 	status             replication.StatusType
 	opType             replication.Type
-	replicationRequest bool // incoming request is a replication request
+	replicationRequest bool  // incoming request is a replication request
+	size               int64 // actual object size, negative if unknown
 }
 
 func (o mustReplicateOptions) ReplicationStatus() (s replication.StatusType) {
@@ -163,6 +164,14 @@ func (o mustReplicateOptions) ReplicationStatus() (s replication.StatusType) {
 	return s
 }
 
+// StorageClass returns the storage class of the object.
+func (o mustReplicateOptions) StorageClass() string {
+	if sc, ok := o.meta[xhttp.AmzStorageClass]; ok && sc != "" {
+		return sc
+	}
+	return globalMinioDefaultStorageClass
+}
+
 func (o mustReplicateOptions) isExistingObjectReplication() bool {
 	return o.opType == replication.ExistingObjectReplicationType
 }
@@ -182,12 +191,17 @@ func getMustReplicateOptions(o ObjectInfo, op replication.Type, opts ObjectOptio
 	if o.UserTags != "" {
 		meta[xhttp.AmzObjectTagging] = o.UserTags
 	}
+	size, err := o.GetActualSize()
+	if err != nil {
+		size = -1
+	}
 
 	return mustReplicateOptions{
 		meta:               meta,
 		status:             o.ReplicationStatus,
 		opType:             op,
 		replicationRequest: opts.ReplicationRequest,
+		size:               size,
 	}
 }
 
@@ -222,6 +236,8 @@ func mustReplicate(ctx context.Context, bucket, object string, mopts mustReplica
 		SSEC:           crypto.SSEC.IsEncrypted(mopts.meta),
 		Replica:        replStatus == replication.Replica,
 		ExistingObject: mopts.isExistingObjectReplication(),
+		Size:           mopts.size,
+		StorageClass:   mopts.StorageClass(),
 	}
 	tagStr, ok := mopts.meta[xhttp.AmzObjectTagging]
 	if ok {
@@ -243,6 +259,31 @@ func mustReplicate(ctx context.Context, bucket, object string, mopts mustReplica
 	return dsc
 }
 
+// replicationStorageClass returns the storage class of objInfo replicated to
+// tgt, the storage class mapped by the replication rules takes precedence
+// over the storage class of the remote target.
+func replicationStorageClass(ctx context.Context, tgt *TargetClient, objInfo ObjectInfo) string {
+	cfg, err := getReplicationConfig(ctx, objInfo.Bucket)
+	if err != nil || cfg == nil {
+		return tgt.StorageClass
+	}
+	size, err := objInfo.GetActualSize()
+	if err != nil {
+		size = -1
+	}
+	sc := cfg.DestinationStorageClass(replication.ObjectOpts{
+		Name:         objInfo.Name,
+		UserTags:     objInfo.UserTags,
+		TargetArn:    tgt.ARN,
+		Size:         size,
+		StorageClass: objInfo.StorageClass,
+	})
+	if sc == "" {
+		return tgt.StorageClass
+	}
+	return sc
+}
+
 // Standard headers that needs to be extracted from User metadata.
 var standardHeaders = []string{
 	xhttp.ContentType,
@@ -464,6 +505,7 @@ func replicateDelete(ctx context.Context, dobj DeletedObjectReplicationInfo, obj
 		}(idx, tgt)
 	}
 	wg.Wait()
+	globalReplicationDLQ.updateDeleteTargets(dobj, rinfos)
 
 	replicationStatus = rinfos.ReplicationStatus()
 	prevStatus := dobj.DeleteMarkerReplicationStatus()
@@ -566,8 +608,14 @@ func replicateDeleteToTarget(ctx context.Context, dobj DeletedObjectReplicationI
 		}
 		return
 	}
+	// generic targets cannot tell which of their versions replicate a
+	// source version, versioned deletes are not replicated to them.
+	if tgt.generic && dobj.VersionID != "" {
+		rinfo.VersionPurgeStatus = Complete
+		return
+	}
 	// early return if already replicated delete marker for existing object replication/ healing delete markers
-	if dobj.DeleteMarkerVersionID != "" {
+	if dobj.DeleteMarkerVersionID != "" && !tgt.generic {
 		toi, err := tgt.StatObject(ctx, tgt.Bucket, dobj.ObjectName, miniogo.StatObjectOptions{
 			VersionID: versionID,
 			Internal: miniogo.AdvancedGetOptions{
@@ -589,7 +637,7 @@ func replicateDeleteToTarget(ctx context.Context, dobj DeletedObjectReplicationI
 		}
 	}
 
-	rmErr := tgt.RemoveObject(ctx, tgt.Bucket, dobj.ObjectName, miniogo.RemoveObjectOptions{
+	rmOpts := miniogo.RemoveObjectOptions{
 		VersionID: versionID,
 		Internal: miniogo.AdvancedRemoveOptions{
 			ReplicationDeleteMarker: dobj.DeleteMarkerVersionID != "",
@@ -597,8 +645,14 @@ func replicateDeleteToTarget(ctx context.Context, dobj DeletedObjectReplicationI
 			ReplicationStatus:       miniogo.ReplicationStatusReplica,
 			ReplicationRequest:      true, // always set this to distinguish between `mc mirror` replication and serverside
 		},
-	})
+	}
+	if tgt.generic {
+		// a plain delete creates a new delete marker on the target
+		rmOpts = miniogo.RemoveObjectOptions{}
+	}
+	rmErr := tgt.RemoveObject(ctx, tgt.Bucket, dobj.ObjectName, rmOpts)
 	if rmErr != nil {
+		rinfo.Err = rmErr
 		if dobj.VersionID == "" {
 			rinfo.ReplicationStatus = replication.Failed
 		} else {
@@ -919,11 +973,26 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 		})
 		return
 	}
+	size, err := objInfo.GetActualSize()
+	if err != nil {
+		size = -1
+	}
 	tgtArns := cfg.FilterTargetArns(replication.ObjectOpts{
-		Name:     object,
-		SSEC:     crypto.SSEC.IsEncrypted(objInfo.UserDefined),
-		UserTags: objInfo.UserTags,
+		Name:         object,
+		SSEC:         crypto.SSEC.IsEncrypted(objInfo.UserDefined),
+		UserTags:     objInfo.UserTags,
+		Size:         size,
+		StorageClass: objInfo.StorageClass,
 	})
+	// Objects to a target paused by its bandwidth schedule are deferred to
+	// MRF, before any lock is taken.
+	for _, tgtArn := range tgtArns {
+		if globalBucketMonitor.Paused(bucket, tgtArn) {
+			globalReplicationPool.queueMRFSave(ri.ToMRFEntry())
+			return
+		}
+	}
+
 	// Lock the object name before starting replication.
 	// Use separate lock that doesn't collide with regular objects.
 	lk := objectAPI.NewNSLock(bucket, "/[replicate]/"+object)
@@ -957,6 +1026,13 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 			})
 			continue
 		}
+		// Only incoming writes are tracked for replication time control,
+		// not objects replicated by the scanner, MRF retries or resync.
+		if ri.OpType == replication.ObjectReplicationType {
+			if _, eventThreshold := cfg.ReplicationTimeThreshold(tgtArn); eventThreshold > 0 {
+				globalReplicationRTC.track(objInfo, tgtArn, eventThreshold)
+			}
+		}
 		wg.Add(1)
 		go func(index int, tgt *TargetClient) {
 			defer wg.Done()
@@ -969,11 +1045,22 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 		}(i, tgt)
 	}
 	wg.Wait()
-	// FIXME: add support for missing replication events
-	// - event.ObjectReplicationMissedThreshold
-	// - event.ObjectReplicationReplicatedAfterThreshold
+	for i := range rinfos.Targets {
+		// Transfers stopped by a pause of the bandwidth schedule are
+		// retried, they are not dead letters.
+		if rinfos.Targets[i].Err != nil && globalBucketMonitor.Paused(bucket, rinfos.Targets[i].Arn) {
+			rinfos.Targets[i].Err = nil
+		}
+	}
+	rinfos.updateRTC(cfg, objInfo)
+	globalReplicationDLQ.updateTargets(objInfo, rinfos)
+
+	// Versions rejected by a target losing a conflict are not retried,
+	// they are reported as conflicts instead of replicated.
+	conflict := recordRemoteReplicationConflicts(objInfo, rinfos)
+
 	eventName := event.ObjectReplicationComplete
-	if rinfos.ReplicationStatus() == replication.Failed {
+	if rinfos.ReplicationStatus() == replication.Failed || conflict {
 		eventName = event.ObjectReplicationFailed
 	}
 	newReplStatusInternal := rinfos.ReplicationStatusInternal()
@@ -984,6 +1071,11 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 			MTime:     objInfo.ModTime,
 			VersionID: objInfo.VersionID,
 			EvalMetadataFn: func(oi ObjectInfo) error {
+				if !oi.ModTime.Equal(objInfo.ModTime) {
+					// Leave it to the scanner to update the replication status
+					// of the version moved since it was queued.
+					return errReplicationConflictDemoted
+				}
 				oi.UserDefined[ReservedMetadataPrefixLower+ReplicationStatus] = newReplStatusInternal
 				oi.UserDefined[ReservedMetadataPrefixLower+ReplicationTimestamp] = UTCNow().Format(time.RFC3339Nano)
 				oi.UserDefined[xhttp.AmzBucketReplicationStatus] = string(rinfos.ReplicationStatus())
@@ -999,7 +1091,7 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 			},
 		}
 
-		if _, err = objectAPI.PutObjectMetadata(ctx, bucket, object, popts); err != nil {
+		if _, err = objectAPI.PutObjectMetadata(ctx, bucket, object, popts); err != nil && !errors.Is(err, errReplicationConflictDemoted) {
 			logger.LogIf(ctx, fmt.Errorf("Unable to update replication metadata for %s/%s(%s): %w",
 				bucket, objInfo.Name, objInfo.VersionID, err))
 		}
@@ -1008,7 +1100,7 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 			opType = replication.ObjectReplicationType
 		}
 		for _, rinfo := range rinfos.Targets {
-			if rinfo.ReplicationStatus != rinfo.PrevReplicationStatus {
+			if rinfo.ReplicationStatus != rinfo.PrevReplicationStatus && !rinfo.Conflict {
 				globalReplicationStats.Update(bucket, rinfo.Arn, rinfo.Size, rinfo.Duration, rinfo.ReplicationStatus, rinfo.PrevReplicationStatus, opType)
 			}
 		}
@@ -1128,7 +1220,7 @@ func (ri ReplicateObjectInfo) replicateObject(ctx context.Context, objectAPI Obj
 	// use core client to avoid doing multipart on PUT
 	c := &miniogo.Core{Client: tgt.Client}
 
-	putOpts, err := putReplicationOpts(ctx, tgt.StorageClass, objInfo)
+	putOpts, err := putReplicationOpts(ctx, replicationStorageClass(ctx, tgt, objInfo), objInfo)
 	if err != nil {
 		logger.LogIf(ctx, fmt.Errorf("failed to get target for replication bucket:%s err:%w", bucket, err))
 		sendEvent(eventArgs{
@@ -1139,6 +1231,9 @@ func (ri ReplicateObjectInfo) replicateObject(ctx context.Context, objectAPI Obj
 		})
 		return
 	}
+	if tgt.generic {
+		putOpts = genericPutReplicationOpts(putOpts, objInfo)
+	}
 
 	var headerSize int
 	for k, v := range putOpts.Header() {
@@ -1147,10 +1242,11 @@ func (ri ReplicateObjectInfo) replicateObject(ctx context.Context, objectAPI Obj
 
 	opts := &bandwidth.MonitorReaderOptions{
 		Bucket:     objInfo.Bucket,
+		ARN:        tgt.ARN,
 		HeaderSize: headerSize,
 	}
 	newCtx := ctx
-	if globalBucketMonitor.IsThrottled(bucket) {
+	if globalBucketMonitor.IsThrottled(bucket, tgt.ARN) {
 		var cancel context.CancelFunc
 		newCtx, cancel = context.WithTimeout(ctx, throttleDeadline)
 		defer cancel()
@@ -1159,15 +1255,21 @@ func (ri ReplicateObjectInfo) replicateObject(ctx context.Context, objectAPI Obj
 	if objInfo.isMultipart() {
 		if err := replicateObjectWithMultipart(ctx, c, tgt.Bucket, object,
 			r, objInfo, putOpts); err != nil {
-			if minio.ToErrorResponse(err).Code != "PreConditionFailed" {
+			if isReplicationConflictErr(err) {
+				rinfo.Conflict = true
+			} else if minio.ToErrorResponse(err).Code != "PreConditionFailed" {
 				rinfo.ReplicationStatus = replication.Failed
+				rinfo.Err = err
 				logger.LogIf(ctx, fmt.Errorf("Unable to replicate for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
 			}
 		}
 	} else {
 		if _, err = c.PutObject(ctx, tgt.Bucket, object, r, size, "", "", putOpts); err != nil {
-			if minio.ToErrorResponse(err).Code != "PreConditionFailed" {
+			if isReplicationConflictErr(err) {
+				rinfo.Conflict = true
+			} else if minio.ToErrorResponse(err).Code != "PreConditionFailed" {
 				rinfo.ReplicationStatus = replication.Failed
+				rinfo.Err = err
 				logger.LogIf(ctx, fmt.Errorf("Unable to replicate for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
 			}
 		}
@@ -1271,14 +1373,26 @@ func (ri ReplicateObjectInfo) replicateAll(ctx context.Context, objectAPI Object
 	}()
 
 	rAction = replicateAll
-	oi, cerr := tgt.StatObject(ctx, tgt.Bucket, object, miniogo.StatObjectOptions{
-		VersionID: objInfo.VersionID,
-		Internal: miniogo.AdvancedGetOptions{
-			ReplicationProxyRequest: "false",
-		},
-	})
+	var (
+		oi   miniogo.ObjectInfo
+		cerr error
+	)
+	if tgt.generic {
+		oi, cerr = statGenericTarget(ctx, tgt, object)
+	} else {
+		oi, cerr = tgt.StatObject(ctx, tgt.Bucket, object, miniogo.StatObjectOptions{
+			VersionID: objInfo.VersionID,
+			Internal: miniogo.AdvancedGetOptions{
+				ReplicationProxyRequest: "false",
+			},
+		})
+	}
 	if cerr == nil {
-		rAction = getReplicationAction(objInfo, oi, ri.OpType)
+		if tgt.generic {
+			rAction = getGenericReplicationAction(objInfo, oi, ri.OpType)
+		} else {
+			rAction = getReplicationAction(objInfo, oi, ri.OpType)
+		}
 		rinfo.ReplicationStatus = replication.Completed
 		if rAction == replicateNone {
 			if ri.OpType == replication.ExistingObjectReplicationType &&
@@ -1323,13 +1437,22 @@ func (ri ReplicateObjectInfo) replicateAll(ctx context.Context, objectAPI Object
 				ReplicationRequest: true, // always set this to distinguish between `mc mirror` replication and serverside
 			},
 		}
-		if _, err = c.CopyObject(ctx, tgt.Bucket, object, tgt.Bucket, object, getCopyObjMetadata(objInfo, tgt.StorageClass), srcOpts, dstOpts); err != nil {
+		sc := replicationStorageClass(ctx, tgt, objInfo)
+		meta := getCopyObjMetadata(objInfo, sc)
+		if tgt.generic {
+			// Copy the latest version of the target over itself.
+			srcOpts.VersionID = oi.VersionID
+			dstOpts = miniogo.PutObjectOptions{}
+			meta = genericCopyObjMetadata(objInfo, sc)
+		}
+		if _, err = c.CopyObject(ctx, tgt.Bucket, object, tgt.Bucket, object, meta, srcOpts, dstOpts); err != nil {
 			rinfo.ReplicationStatus = replication.Failed
+			rinfo.Err = err
 			logger.LogIf(ctx, fmt.Errorf("Unable to replicate metadata for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
 		}
 	} else {
 		var putOpts minio.PutObjectOptions
-		putOpts, err = putReplicationOpts(ctx, tgt.StorageClass, objInfo)
+		putOpts, err = putReplicationOpts(ctx, replicationStorageClass(ctx, tgt, objInfo), objInfo)
 		if err != nil {
 			logger.LogIf(ctx, fmt.Errorf("failed to get target for replication bucket:%s err:%w", bucket, err))
 			sendEvent(eventArgs{
@@ -1340,6 +1463,9 @@ func (ri ReplicateObjectInfo) replicateAll(ctx context.Context, objectAPI Object
 			})
 			return
 		}
+		if tgt.generic {
+			putOpts = genericPutReplicationOpts(putOpts, objInfo)
+		}
 		var headerSize int
 		for k, v := range putOpts.Header() {
 			headerSize += len(k) + len(v)
@@ -1347,10 +1473,11 @@ func (ri ReplicateObjectInfo) replicateAll(ctx context.Context, objectAPI Object
 
 		opts := &bandwidth.MonitorReaderOptions{
 			Bucket:     objInfo.Bucket,
+			ARN:        tgt.ARN,
 			HeaderSize: headerSize,
 		}
 		newCtx := ctx
-		if globalBucketMonitor.IsThrottled(bucket) {
+		if globalBucketMonitor.IsThrottled(bucket, tgt.ARN) {
 			var cancel context.CancelFunc
 			newCtx, cancel = context.WithTimeout(ctx, throttleDeadline)
 			defer cancel()
@@ -1358,13 +1485,19 @@ func (ri ReplicateObjectInfo) replicateAll(ctx context.Context, objectAPI Object
 		r := bandwidth.NewMonitoredReader(newCtx, globalBucketMonitor, gr, opts)
 		if objInfo.isMultipart() {
 			if err := replicateObjectWithMultipart(ctx, c, tgt.Bucket, object,
-				r, objInfo, putOpts); err != nil {
+				r, objInfo, putOpts); isReplicationConflictErr(err) {
+				rinfo.Conflict = true
+			} else if err != nil {
 				rinfo.ReplicationStatus = replication.Failed
+				rinfo.Err = err
 				logger.LogIf(ctx, fmt.Errorf("Unable to replicate for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
 			}
 		} else {
-			if _, err = c.PutObject(ctx, tgt.Bucket, object, r, size, "", "", putOpts); err != nil {
+			if _, err = c.PutObject(ctx, tgt.Bucket, object, r, size, "", "", putOpts); isReplicationConflictErr(err) {
+				rinfo.Conflict = true
+			} else if err != nil {
 				rinfo.ReplicationStatus = replication.Failed
+				rinfo.Err = err
 				logger.LogIf(ctx, fmt.Errorf("Unable to replicate for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
 			}
 		}
@@ -1419,13 +1552,16 @@ func replicateObjectWithMultipart(ctx context.Context, c *miniogo.Core, bucket,
 			ETag:       pInfo.ETag,
 		})
 	}
-	_, err = c.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, miniogo.PutObjectOptions{
-		Internal: miniogo.AdvancedPutOptions{
+	var completeOpts miniogo.PutObjectOptions
+	// generic targets are sent no replication request, see genericPutReplicationOpts
+	if opts.Internal.ReplicationRequest {
+		completeOpts.Internal = miniogo.AdvancedPutOptions{
 			SourceMTime: objInfo.ModTime,
 			// always set this to distinguish between `mc mirror` replication and serverside
 			ReplicationRequest: true,
-		},
-	})
+		}
+	}
+	_, err = c.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, completeOpts)
 	return err
 }
 
@@ -1599,6 +1735,8 @@ func NewReplicationPool(ctx context.Context, o ObjectLayer, opts replicationPool
 	go pool.processMRF()
 	go pool.persistMRF()
 	go pool.saveStatsToDisk()
+	go globalReplicationDLQ.persist(ctx, o)
+	go globalReplicationConflicts.persist(ctx, o)
 	return pool
 }
 
@@ -1936,7 +2074,7 @@ func getProxyTargets(ctx context.Context, bucket, object string, opts ObjectOpti
 	if err != nil || cfg == nil {
 		return &madmin.BucketTargets{}
 	}
-	topts := replication.ObjectOpts{Name: object}
+	topts := replication.ObjectOpts{Name: object, Size: -1}
 	tgtArns := cfg.FilterTargetArns(topts)
 	tgts = &madmin.BucketTargets{Targets: make([]madmin.BucketTarget, len(tgtArns))}
 	for i, tgtArn := range tgtArns {
@@ -288,3 +288,31 @@ func TestReplicationResyncwrapper(t *testing.T) {
 		}
 	}
 }
+
+func TestGetMustReplicateOptionsSize(t *testing.T) {
+	testCases := []struct {
+		oi           ObjectInfo
+		expectedSize int64
+		expectedSC   string
+	}{
+		{ObjectInfo{Size: 1024}, 1024, "STANDARD"},
+		{ObjectInfo{Size: -1}, -1, "STANDARD"},
+		{ObjectInfo{Size: 100, UserDefined: map[string]string{
+			ReservedMetadataPrefix + "compression": compressionAlgorithmV2,
+			ReservedMetadataPrefix + "actual-size": "2048",
+			xhttp.AmzStorageClass:                  "REDUCED_REDUNDANCY",
+		}}, 2048, "REDUCED_REDUNDANCY"},
+		{ObjectInfo{Size: -1, UserDefined: map[string]string{
+			ReservedMetadataPrefix + "compression": compressionAlgorithmV2,
+		}}, -1, "STANDARD"},
+	}
+	for i, tc := range testCases {
+		mopts := getMustReplicateOptions(tc.oi, replication.ObjectReplicationType, ObjectOptions{})
+		if mopts.size != tc.expectedSize {
+			t.Errorf("Test %d: expected size %d, got %d", i+1, tc.expectedSize, mopts.size)
+		}
+		if sc := mopts.StorageClass(); sc != tc.expectedSC {
+			t.Errorf("Test %d: expected storage class %s, got %s", i+1, tc.expectedSC, sc)
+		}
+	}
+}
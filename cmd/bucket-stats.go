@@ -19,6 +19,8 @@ package cmd
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"time"
 )
 
@@ -52,6 +54,117 @@ func (rl *ReplicationLatency) update(size int64, duration time.Duration) {
 	rl.UploadHistogram.Add(size, duration)
 }
 
+// rtcLatencyBounds are the upper bounds of the buckets of replication time
+// control latencies.
+var rtcLatencyBounds = []time.Duration{
+	time.Second,
+	5 * time.Second,
+	15 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	15 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+	2 * time.Hour,
+	6 * time.Hour,
+	12 * time.Hour,
+	24 * time.Hour,
+}
+
+// ReplicationRTCStats holds replication time control statistics of a target,
+// latencies are measured from the creation of objects to their replication.
+type ReplicationRTCStats struct {
+	// Objects replicated within the replication time threshold
+	WithinThreshold int64 `json:"replicatedWithinThreshold"`
+	// Objects replicated after the replication time threshold
+	AfterThreshold int64 `json:"replicatedAfterThreshold"`
+	// Objects which were still pending replication past the event threshold
+	MissedThreshold int64 `json:"missedThreshold"`
+	// Number of latencies per bucket of rtcLatencyBounds, the last bucket
+	// counts the latencies above all the bounds.
+	Latency []uint64 `json:"latency"`
+	// Highest latency
+	MaxLatency time.Duration `json:"maxLatency"`
+}
+
+// Update replication time control statistics with the latency of an
+// object replicated.
+func (rs *ReplicationRTCStats) update(latency, threshold time.Duration) {
+	if latency > threshold {
+		rs.AfterThreshold++
+	} else {
+		rs.WithinThreshold++
+	}
+	if len(rs.Latency) != len(rtcLatencyBounds)+1 {
+		rs.Latency = make([]uint64, len(rtcLatencyBounds)+1)
+	}
+	i := sort.Search(len(rtcLatencyBounds), func(i int) bool { return latency <= rtcLatencyBounds[i] })
+	rs.Latency[i]++
+	if latency > rs.MaxLatency {
+		rs.MaxLatency = latency
+	}
+}
+
+// Merge two replication time control statistics into new ones.
+func (rs ReplicationRTCStats) merge(other ReplicationRTCStats) (n ReplicationRTCStats) {
+	n.WithinThreshold = rs.WithinThreshold + other.WithinThreshold
+	n.AfterThreshold = rs.AfterThreshold + other.AfterThreshold
+	n.MissedThreshold = rs.MissedThreshold + other.MissedThreshold
+	if len(rs.Latency) > 0 || len(other.Latency) > 0 {
+		n.Latency = make([]uint64, len(rtcLatencyBounds)+1)
+		for _, l := range [][]uint64{rs.Latency, other.Latency} {
+			for i := 0; i < len(l) && i < len(n.Latency); i++ {
+				n.Latency[i] += l[i]
+			}
+		}
+	}
+	n.MaxLatency = rs.MaxLatency
+	if other.MaxLatency > n.MaxLatency {
+		n.MaxLatency = other.MaxLatency
+	}
+	return n
+}
+
+// percentile returns the upper bound of the bucket the p-th percentile of
+// replication latencies falls in, the highest latency for the last bucket.
+func (rs ReplicationRTCStats) percentile(p float64) time.Duration {
+	var total uint64
+	for _, n := range rs.Latency {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(p * float64(total)))
+	var count uint64
+	for i, n := range rs.Latency {
+		count += n
+		if count >= rank && i < len(rtcLatencyBounds) {
+			if rtcLatencyBounds[i] > rs.MaxLatency {
+				return rs.MaxLatency
+			}
+			return rtcLatencyBounds[i]
+		}
+	}
+	return rs.MaxLatency
+}
+
+// Get replication latency percentiles in milliseconds
+func (rs ReplicationRTCStats) getLatencyPercentiles() map[string]uint64 {
+	return map[string]uint64{
+		"0.5":  uint64(rs.percentile(0.5) / time.Millisecond),
+		"0.9":  uint64(rs.percentile(0.9) / time.Millisecond),
+		"0.99": uint64(rs.percentile(0.99) / time.Millisecond),
+	}
+}
+
+func (rs ReplicationRTCStats) isEmpty() bool {
+	return rs.WithinThreshold == 0 && rs.AfterThreshold == 0 && rs.MissedThreshold == 0
+}
+
 // BucketStatsMap captures bucket statistics for all buckets
 type BucketStatsMap struct {
 	Stats     map[string]BucketStats
@@ -97,6 +210,7 @@ func (brs BucketReplicationStats) Clone() (c BucketReplicationStats) {
 	for arn, st := range brs.Stats {
 		// make a copy of `*st`
 		s := *st
+		s.RTC.Latency = append([]uint64(nil), st.RTC.Latency...)
 		c.Stats[arn] = &s
 	}
 	return c
@@ -120,6 +234,8 @@ type BucketReplicationStat struct {
 	FailedCount int64 `json:"failedReplicationCount"`
 	// Replication latency information
 	Latency ReplicationLatency `json:"replicationLatency"`
+	// Replication time control information
+	RTC ReplicationRTCStats `json:"replicationTimeControl"`
 }
 
 func (bs *BucketReplicationStat) hasReplicationUsage() bool {
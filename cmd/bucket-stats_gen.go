@@ -89,6 +89,12 @@ func (z *BucketReplicationStat) DecodeMsg(dc *msgp.Reader) (err error) {
 					}
 				}
 			}
+		case "RTC":
+			err = z.RTC.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "RTC")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -102,9 +108,9 @@ func (z *BucketReplicationStat) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *BucketReplicationStat) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 7
+	// map header, size 8
 	// write "PendingSize"
-	err = en.Append(0x87, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
+	err = en.Append(0x88, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
 	if err != nil {
 		return
 	}
@@ -179,15 +185,25 @@ func (z *BucketReplicationStat) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Latency", "UploadHistogram")
 		return
 	}
+	// write "RTC"
+	err = en.Append(0xa3, 0x52, 0x54, 0x43)
+	if err != nil {
+		return
+	}
+	err = z.RTC.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "RTC")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *BucketReplicationStat) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 7
+	// map header, size 8
 	// string "PendingSize"
-	o = append(o, 0x87, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
+	o = append(o, 0x88, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
 	o = msgp.AppendInt64(o, z.PendingSize)
 	// string "ReplicatedSize"
 	o = append(o, 0xae, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65)
@@ -214,6 +230,13 @@ func (z *BucketReplicationStat) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "Latency", "UploadHistogram")
 		return
 	}
+	// string "RTC"
+	o = append(o, 0xa3, 0x52, 0x54, 0x43)
+	o, err = z.RTC.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "RTC")
+		return
+	}
 	return
 }
 
@@ -300,6 +323,12 @@ func (z *BucketReplicationStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					}
 				}
 			}
+		case "RTC":
+			bts, err = z.RTC.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RTC")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -314,7 +343,7 @@ func (z *BucketReplicationStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *BucketReplicationStat) Msgsize() (s int) {
-	s = 1 + 12 + msgp.Int64Size + 15 + msgp.Int64Size + 12 + msgp.Int64Size + 11 + msgp.Int64Size + 13 + msgp.Int64Size + 12 + msgp.Int64Size + 8 + 1 + 16 + z.Latency.UploadHistogram.Msgsize()
+	s = 1 + 12 + msgp.Int64Size + 15 + msgp.Int64Size + 12 + msgp.Int64Size + 11 + msgp.Int64Size + 13 + msgp.Int64Size + 12 + msgp.Int64Size + 8 + 1 + 16 + z.Latency.UploadHistogram.Msgsize() + 4 + z.RTC.Msgsize()
 	return
 }
 
@@ -1156,3 +1185,242 @@ func (z *ReplicationLatency) Msgsize() (s int) {
 	s = 1 + 16 + z.UploadHistogram.Msgsize()
 	return
 }
+
+// DecodeMsg implements msgp.Decodable
+func (z *ReplicationRTCStats) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "WithinThreshold":
+			z.WithinThreshold, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "WithinThreshold")
+				return
+			}
+		case "AfterThreshold":
+			z.AfterThreshold, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "AfterThreshold")
+				return
+			}
+		case "MissedThreshold":
+			z.MissedThreshold, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "MissedThreshold")
+				return
+			}
+		case "Latency":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Latency")
+				return
+			}
+			if cap(z.Latency) >= int(zb0002) {
+				z.Latency = (z.Latency)[:zb0002]
+			} else {
+				z.Latency = make([]uint64, zb0002)
+			}
+			for za0001 := range z.Latency {
+				z.Latency[za0001], err = dc.ReadUint64()
+				if err != nil {
+					err = msgp.WrapError(err, "Latency", za0001)
+					return
+				}
+			}
+		case "MaxLatency":
+			z.MaxLatency, err = dc.ReadDuration()
+			if err != nil {
+				err = msgp.WrapError(err, "MaxLatency")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ReplicationRTCStats) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 5
+	// write "WithinThreshold"
+	err = en.Append(0x85, 0xaf, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.WithinThreshold)
+	if err != nil {
+		err = msgp.WrapError(err, "WithinThreshold")
+		return
+	}
+	// write "AfterThreshold"
+	err = en.Append(0xae, 0x41, 0x66, 0x74, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.AfterThreshold)
+	if err != nil {
+		err = msgp.WrapError(err, "AfterThreshold")
+		return
+	}
+	// write "MissedThreshold"
+	err = en.Append(0xaf, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.MissedThreshold)
+	if err != nil {
+		err = msgp.WrapError(err, "MissedThreshold")
+		return
+	}
+	// write "Latency"
+	err = en.Append(0xa7, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Latency)))
+	if err != nil {
+		err = msgp.WrapError(err, "Latency")
+		return
+	}
+	for za0001 := range z.Latency {
+		err = en.WriteUint64(z.Latency[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "Latency", za0001)
+			return
+		}
+	}
+	// write "MaxLatency"
+	err = en.Append(0xaa, 0x4d, 0x61, 0x78, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteDuration(z.MaxLatency)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxLatency")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ReplicationRTCStats) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 5
+	// string "WithinThreshold"
+	o = append(o, 0x85, 0xaf, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64)
+	o = msgp.AppendInt64(o, z.WithinThreshold)
+	// string "AfterThreshold"
+	o = append(o, 0xae, 0x41, 0x66, 0x74, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64)
+	o = msgp.AppendInt64(o, z.AfterThreshold)
+	// string "MissedThreshold"
+	o = append(o, 0xaf, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64)
+	o = msgp.AppendInt64(o, z.MissedThreshold)
+	// string "Latency"
+	o = append(o, 0xa7, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Latency)))
+	for za0001 := range z.Latency {
+		o = msgp.AppendUint64(o, z.Latency[za0001])
+	}
+	// string "MaxLatency"
+	o = append(o, 0xaa, 0x4d, 0x61, 0x78, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79)
+	o = msgp.AppendDuration(o, z.MaxLatency)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ReplicationRTCStats) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "WithinThreshold":
+			z.WithinThreshold, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WithinThreshold")
+				return
+			}
+		case "AfterThreshold":
+			z.AfterThreshold, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AfterThreshold")
+				return
+			}
+		case "MissedThreshold":
+			z.MissedThreshold, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MissedThreshold")
+				return
+			}
+		case "Latency":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Latency")
+				return
+			}
+			if cap(z.Latency) >= int(zb0002) {
+				z.Latency = (z.Latency)[:zb0002]
+			} else {
+				z.Latency = make([]uint64, zb0002)
+			}
+			for za0001 := range z.Latency {
+				z.Latency[za0001], bts, err = msgp.ReadUint64Bytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Latency", za0001)
+					return
+				}
+			}
+		case "MaxLatency":
+			z.MaxLatency, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxLatency")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ReplicationRTCStats) Msgsize() (s int) {
+	s = 1 + 16 + msgp.Int64Size + 15 + msgp.Int64Size + 16 + msgp.Int64Size + 8 + msgp.ArrayHeaderSize + (len(z.Latency) * (msgp.Uint64Size)) + 11 + msgp.DurationSize
+	return
+}
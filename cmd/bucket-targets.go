@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"sync"
 	"time"
@@ -29,8 +30,10 @@ import (
 	"github.com/minio/minio-go/v7"
 	miniogo "github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio/internal/bucket/bandwidth"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/crypto"
+	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
 )
@@ -56,6 +59,7 @@ type epHealth struct {
 	Endpoint string
 	Scheme   string
 	Online   bool
+	Generic  bool // not a MinIO deployment
 }
 
 // isOffline returns current liveness result of remote target. Add endpoint to
@@ -71,15 +75,42 @@ func (sys *BucketTargetSys) isOffline(ep *url.URL) bool {
 }
 
 func (sys *BucketTargetSys) initHC(ep *url.URL) {
+	generic := sys.isGenericEndpoint(ep.Host)
 	sys.hMutex.Lock()
 	sys.hc[ep.Host] = epHealth{
 		Endpoint: ep.Host,
 		Scheme:   ep.Scheme,
 		Online:   true,
+		Generic:  generic,
 	}
 	sys.hMutex.Unlock()
 }
 
+// isGenericEndpoint returns true if the remote targets at endpoint are not
+// MinIO deployments. An endpoint with both generic and MinIO targets is a
+// MinIO deployment.
+func (sys *BucketTargetSys) isGenericEndpoint(endpoint string) bool {
+	sys.RLock()
+	defer sys.RUnlock()
+	generic := false
+	for _, tgts := range sys.targetsMap {
+		for _, tgt := range tgts {
+			if tgt.Endpoint != endpoint {
+				continue
+			}
+			clnt, ok := sys.arnRemotesMap[tgt.Arn]
+			if !ok {
+				continue
+			}
+			if !clnt.generic {
+				return false
+			}
+			generic = true
+		}
+	}
+	return generic
+}
+
 // newHCClient initializes an anonymous client for performing health check on the remote endpoints
 func newHCClient() *madmin.AnonymousClient {
 	clnt, e := madmin.NewAnonymousClientNoEndpoint()
@@ -100,14 +131,23 @@ func (sys *BucketTargetSys) heartBeat(ctx context.Context) {
 		case <-hcTimer.C:
 			sys.hMutex.RLock()
 			var eps []madmin.ServerProperties
+			var generic []epHealth
 			for _, ep := range sys.hc {
+				if ep.Generic {
+					generic = append(generic, ep)
+					continue
+				}
 				eps = append(eps, madmin.ServerProperties{Endpoint: ep.Endpoint, Scheme: ep.Scheme})
 			}
 			sys.hMutex.RUnlock()
 
-			if len(eps) > 0 {
+			if len(eps) > 0 || len(generic) > 0 {
 				cctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 				m := map[string]epHealth{}
+				for _, ep := range generic {
+					ep.Online = genericTargetAlive(cctx, ep)
+					m[ep.Endpoint] = ep
+				}
 				for result := range sys.hcClient.Alive(cctx, madmin.AliveOpts{}, eps...) {
 					var online bool
 					if result.Error == nil {
@@ -146,6 +186,7 @@ func (sys *BucketTargetSys) reloadHealthCheckers(ctx context.Context) {
 				Online:   true,
 				Endpoint: t.Endpoint,
 				Scheme:   scheme,
+				Generic:  sys.isGenericEndpoint(t.Endpoint),
 			}
 		}
 	}
@@ -208,10 +249,20 @@ func (sys *BucketTargetSys) Delete(bucket string) {
 
 // SetTarget - sets a new minio-go client target for this bucket.
 func (sys *BucketTargetSys) SetTarget(ctx context.Context, bucket string, tgt *madmin.BucketTarget, update bool) error {
+	return sys.setTarget(ctx, bucket, tgt, update, getGenericTargets(bucket).Contains(tgt.Arn))
+}
+
+// SetGenericTarget - sets a new remote target for this bucket which is not
+// a MinIO deployment, see BucketGenericTargets.
+func (sys *BucketTargetSys) SetGenericTarget(ctx context.Context, bucket string, tgt *madmin.BucketTarget) error {
+	return sys.setTarget(ctx, bucket, tgt, false, true)
+}
+
+func (sys *BucketTargetSys) setTarget(ctx context.Context, bucket string, tgt *madmin.BucketTarget, update, generic bool) error {
 	if !tgt.Type.IsValid() && !update {
 		return BucketRemoteArnTypeInvalid{Bucket: bucket}
 	}
-	clnt, err := sys.getRemoteTargetClient(tgt)
+	clnt, err := sys.getRemoteTargetClient(tgt, generic)
 	if err != nil {
 		return BucketRemoteTargetNotFound{Bucket: tgt.TargetBucket}
 	}
@@ -230,12 +281,16 @@ func (sys *BucketTargetSys) SetTarget(ctx context.Context, bucket string, tgt *m
 		if !globalBucketVersioningSys.Enabled(bucket) {
 			return BucketReplicationSourceNotVersioned{Bucket: bucket}
 		}
-		vcfg, err := clnt.GetBucketVersioning(ctx, tgt.TargetBucket)
-		if err != nil {
-			return RemoteTargetConnectionErr{Bucket: tgt.TargetBucket, Err: err}
-		}
-		if !vcfg.Enabled() {
-			return BucketRemoteTargetNotVersioned{Bucket: tgt.TargetBucket}
+		// Generic targets need not be versioned, the objects replicated
+		// then overwrite each other.
+		if !generic {
+			vcfg, err := clnt.GetBucketVersioning(ctx, tgt.TargetBucket)
+			if err != nil {
+				return RemoteTargetConnectionErr{Bucket: tgt.TargetBucket, Err: err}
+			}
+			if !vcfg.Enabled() {
+				return BucketRemoteTargetNotVersioned{Bucket: tgt.TargetBucket}
+			}
 		}
 	}
 	sys.Lock()
@@ -263,18 +318,15 @@ func (sys *BucketTargetSys) SetTarget(ctx context.Context, bucket string, tgt *m
 
 	sys.targetsMap[bucket] = newtgts
 	sys.arnRemotesMap[tgt.Arn] = clnt
-	sys.updateBandwidthLimit(bucket, tgt.BandwidthLimit)
+	sys.updateBandwidthLimit(bucket, *tgt, getBandwidthSchedules(bucket).Schedule(tgt.Arn))
 	return nil
 }
 
-func (sys *BucketTargetSys) updateBandwidthLimit(bucket string, limit int64) {
-	if limit == 0 {
-		globalBucketMonitor.DeleteBucket(bucket)
-		return
-	}
-	// Setup bandwidth throttling
-
-	globalBucketMonitor.SetBandwidthLimit(bucket, limit)
+func (sys *BucketTargetSys) updateBandwidthLimit(bucket string, tgt madmin.BucketTarget, sched bandwidth.Schedule) {
+	// Setup bandwidth throttling, a target with neither a limit nor a
+	// schedule is not throttled.
+	globalBucketMonitor.SetBandwidthLimit(bucket, tgt.Arn, tgt.BandwidthLimit)
+	globalBucketMonitor.SetBandwidthSchedule(bucket, tgt.Arn, sched)
 }
 
 // RemoveTarget - removes a remote bucket target for this source bucket.
@@ -326,7 +378,7 @@ func (sys *BucketTargetSys) RemoveTarget(ctx context.Context, bucket, arnStr str
 	}
 	sys.targetsMap[bucket] = targets
 	delete(sys.arnRemotesMap, arnStr)
-	sys.updateBandwidthLimit(bucket, 0)
+	globalBucketMonitor.DeleteBucketThrottle(bucket, arnStr)
 	return nil
 }
 
@@ -390,26 +442,29 @@ func (sys *BucketTargetSys) UpdateAllTargets(bucket string, tgts *madmin.BucketT
 	if tgts, ok := sys.targetsMap[bucket]; ok {
 		for _, t := range tgts {
 			delete(sys.arnRemotesMap, t.Arn)
+			globalBucketMonitor.DeleteBucketThrottle(bucket, t.Arn)
 		}
 		delete(sys.targetsMap, bucket)
 	}
 
 	// No need for more if not adding anything
 	if tgts == nil || tgts.Empty() {
-		sys.updateBandwidthLimit(bucket, 0)
 		return
 	}
 
+	schedules := getBandwidthSchedules(bucket)
+	generic := getGenericTargets(bucket)
+
 	if len(tgts.Targets) > 0 {
 		sys.targetsMap[bucket] = tgts.Targets
 	}
 	for _, tgt := range tgts.Targets {
-		tgtClient, err := sys.getRemoteTargetClient(&tgt)
+		tgtClient, err := sys.getRemoteTargetClient(&tgt, generic.Contains(tgt.Arn))
 		if err != nil {
 			continue
 		}
 		sys.arnRemotesMap[tgt.Arn] = tgtClient
-		sys.updateBandwidthLimit(bucket, tgt.BandwidthLimit)
+		sys.updateBandwidthLimit(bucket, tgt, schedules.Schedule(tgt.Arn))
 	}
 	sys.targetsMap[bucket] = tgts.Targets
 }
@@ -426,27 +481,32 @@ func (sys *BucketTargetSys) set(bucket BucketInfo, meta BucketMetadata) {
 		sys.targetsMap[bucket.Name] = cfg.Targets
 	}
 	for _, tgt := range cfg.Targets {
-		tgtClient, err := sys.getRemoteTargetClient(&tgt)
+		tgtClient, err := sys.getRemoteTargetClient(&tgt, meta.genericTargets.Contains(tgt.Arn))
 		if err != nil {
 			logger.LogIf(GlobalContext, err)
 			continue
 		}
 		sys.arnRemotesMap[tgt.Arn] = tgtClient
-		sys.updateBandwidthLimit(bucket.Name, tgt.BandwidthLimit)
+		sys.updateBandwidthLimit(bucket.Name, tgt, meta.bandwidthSchedules.Schedule(tgt.Arn))
 	}
 	sys.targetsMap[bucket.Name] = cfg.Targets
 }
 
-// Returns a minio-go Client configured to access remote host described in replication target config.
-func (sys *BucketTargetSys) getRemoteTargetClient(tcfg *madmin.BucketTarget) (*TargetClient, error) {
+// Returns a minio-go Client configured to access remote host described in replication target config,
+// generic if the remote host is not a MinIO deployment.
+func (sys *BucketTargetSys) getRemoteTargetClient(tcfg *madmin.BucketTarget, generic bool) (*TargetClient, error) {
 	config := tcfg.Credentials
 	creds := credentials.NewStaticV4(config.AccessKey, config.SecretKey, "")
 
+	transport := globalRemoteTargetTransport
+	if transport != nil && !generic {
+		transport = deploymentIDTransport{transport}
+	}
 	api, err := minio.New(tcfg.Endpoint, &miniogo.Options{
 		Creds:     creds,
 		Secure:    tcfg.Secure,
 		Region:    tcfg.Region,
-		Transport: globalRemoteTargetTransport,
+		Transport: transport,
 	})
 	if err != nil {
 		return nil, err
@@ -461,7 +521,8 @@ func (sys *BucketTargetSys) getRemoteTargetClient(tcfg *madmin.BucketTarget) (*T
 		replicateSync:       tcfg.ReplicationSync,
 		Bucket:              tcfg.TargetBucket,
 		StorageClass:        tcfg.StorageClass,
-		disableProxy:        tcfg.DisableProxy,
+		disableProxy:        tcfg.DisableProxy || generic,
+		generic:             generic,
 		ARN:                 tcfg.Arn,
 		ResetID:             tcfg.ResetID,
 		Endpoint:            tcfg.Endpoint,
@@ -470,6 +531,19 @@ func (sys *BucketTargetSys) getRemoteTargetClient(tcfg *madmin.BucketTarget) (*T
 	return tc, nil
 }
 
+// deploymentIDTransport sets the deployment ID of this site on the requests
+// to the remote targets, the remote sites record it as the site the
+// replicas were written at.
+type deploymentIDTransport struct {
+	http.RoundTripper
+}
+
+func (t deploymentIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(xhttp.MinioDeploymentID, globalDeploymentID)
+	return t.RoundTripper.RoundTrip(req)
+}
+
 // getRemoteARN gets existing ARN for an endpoint or generates a new one.
 func (sys *BucketTargetSys) getRemoteARN(bucket string, target *madmin.BucketTarget) string {
 	if target == nil {
@@ -555,6 +629,7 @@ type TargetClient struct {
 	replicateSync       bool
 	StorageClass        string // storage class on remote
 	disableProxy        bool
+	generic             bool   // not a MinIO deployment, see BucketGenericTargets
 	ARN                 string // ARN to uniquely identify remote target
 	ResetID             string
 	Endpoint            string
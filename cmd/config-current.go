@@ -37,6 +37,7 @@ import (
 	xldap "github.com/minio/minio/internal/config/identity/ldap"
 	"github.com/minio/minio/internal/config/identity/openid"
 	idplugin "github.com/minio/minio/internal/config/identity/plugin"
+	"github.com/minio/minio/internal/config/identity/saml"
 	xtls "github.com/minio/minio/internal/config/identity/tls"
 	"github.com/minio/minio/internal/config/notify"
 	"github.com/minio/minio/internal/config/policy/opa"
@@ -60,6 +61,7 @@ func initHelp() {
 		config.IdentityOpenIDSubSys: openid.DefaultKVS,
 		config.IdentityTLSSubSys:    xtls.DefaultKVS,
 		config.IdentityPluginSubSys: idplugin.DefaultKVS,
+		config.IdentitySAMLSubSys:   saml.DefaultKVS,
 		config.PolicyOPASubSys:      opa.DefaultKVS,
 		config.PolicyPluginSubSys:   polplugin.DefaultKVS,
 		config.SiteSubSys:           config.DefaultSiteKVS,
@@ -106,13 +108,18 @@ func initHelp() {
 			MultipleTargets: true,
 		},
 		config.HelpKV{
-			Key:         config.IdentityLDAPSubSys,
-			Description: "enable LDAP SSO support",
+			Key:             config.IdentityLDAPSubSys,
+			Description:     "enable LDAP SSO support",
+			MultipleTargets: true,
 		},
 		config.HelpKV{
 			Key:         config.IdentityTLSSubSys,
 			Description: "enable X.509 TLS certificate SSO support",
 		},
+		config.HelpKV{
+			Key:         config.IdentitySAMLSubSys,
+			Description: "enable SAML 2.0 SSO support",
+		},
 		config.HelpKV{
 			Key:         config.IdentityPluginSubSys,
 			Description: "enable Identity Plugin via external hook",
@@ -233,6 +240,7 @@ func initHelp() {
 		config.IdentityLDAPSubSys:   xldap.Help,
 		config.IdentityTLSSubSys:    xtls.Help,
 		config.IdentityPluginSubSys: idplugin.Help,
+		config.IdentitySAMLSubSys:   saml.Help,
 		config.PolicyOPASubSys:      opa.Help,
 		config.PolicyPluginSubSys:   polplugin.Help,
 		config.LoggerWebhookSubSys:  logger.Help,
@@ -289,10 +297,20 @@ func validateSubSysConfig(s config.Config, subSys string, objAPI ObjectLayer) er
 		if objAPI == nil {
 			return errServerNotInitialized
 		}
-		for _, setDriveCount := range objAPI.SetDriveCounts() {
-			if _, err := storageclass.LookupConfig(s[config.StorageClassSubSys][config.Default], setDriveCount); err != nil {
+		setDriveCounts := objAPI.SetDriveCounts()
+		for _, setDriveCount := range setDriveCounts {
+			sc, err := storageclass.LookupConfig(s[config.StorageClassSubSys][config.Default], setDriveCount)
+			if err != nil {
+				return err
+			}
+			if err = sc.ValidatePools(len(setDriveCounts)); err != nil {
 				return err
 			}
+			if globalTierConfigMgr != nil {
+				if err = sc.ValidateTiers(globalTierConfigMgr.IsTierValid); err != nil {
+					return err
+				}
+			}
 		}
 	case config.CacheSubSys:
 		if _, err := cache.LookupConfig(s[config.CacheSubSys][config.Default]); err != nil {
@@ -340,16 +358,19 @@ func validateSubSysConfig(s config.Config, subSys string, objAPI ObjectLayer) er
 			return err
 		}
 		if cfg.Enabled() {
-			conn, cerr := cfg.LDAP.Connect()
-			if cerr != nil {
-				return cerr
+			if err = cfg.CheckConnectivity(); err != nil {
+				return err
 			}
-			conn.Close()
 		}
 	case config.IdentityTLSSubSys:
 		if _, err := xtls.Lookup(s[config.IdentityTLSSubSys][config.Default]); err != nil {
 			return err
 		}
+	case config.IdentitySAMLSubSys:
+		if _, err := saml.LookupConfig(s[config.IdentitySAMLSubSys][config.Default],
+			NewHTTPTransport(), xhttp.DrainBody, globalSite.Region); err != nil {
+			return err
+		}
 	case config.IdentityPluginSubSys:
 		if _, err := idplugin.LookupConfig(s[config.IdentityPluginSubSys][config.Default],
 			NewHTTPTransport(), xhttp.DrainBody, globalSite.Region); err != nil {
@@ -101,11 +101,13 @@ func (dui DataUsageInfo) tierStats() []madmin.TierInfo {
 	}
 
 	cfgs := globalTierConfigMgr.ListTiers()
-	if len(cfgs) == 0 {
+	fileCfgs := globalTierConfigMgr.ListFileTiers()
+	classes := globalStorageClass.CustomClasses()
+	if len(cfgs) == 0 && len(fileCfgs) == 0 && len(classes) == 0 {
 		return nil
 	}
 
-	ts := make(map[string]madmin.TierStats, len(cfgs)+1)
+	ts := make(map[string]madmin.TierStats, len(cfgs)+len(fileCfgs)+len(classes)+1)
 	infos := make([]madmin.TierInfo, 0, len(ts))
 
 	// Add STANDARD (hot-tier)
@@ -114,6 +116,14 @@ func (dui DataUsageInfo) tierStats() []madmin.TierInfo {
 		Name: minioHotTier,
 		Type: "internal",
 	})
+	// Add custom storage classes
+	for _, sc := range classes {
+		ts[sc] = madmin.TierStats{}
+		infos = append(infos, madmin.TierInfo{
+			Name: sc,
+			Type: "internal",
+		})
+	}
 	// Add configured remote tiers
 	for _, cfg := range cfgs {
 		ts[cfg.Name] = madmin.TierStats{}
@@ -122,6 +132,13 @@ func (dui DataUsageInfo) tierStats() []madmin.TierInfo {
 			Type: cfg.Type.String(),
 		})
 	}
+	for _, cfg := range fileCfgs {
+		ts[cfg.Name] = madmin.TierStats{}
+		infos = append(infos, madmin.TierInfo{
+			Name: cfg.Name,
+			Type: cfg.Type,
+		})
+	}
 
 	ts = dui.TierStats.adminStats(ts)
 	for i := range infos {
@@ -130,11 +147,12 @@ func (dui DataUsageInfo) tierStats() []madmin.TierInfo {
 	}
 
 	sort.Slice(infos, func(i, j int) bool {
-		if infos[i].Type == "internal" {
-			return true
+		// STANDARD first, followed by custom storage classes and remote tiers
+		if infos[i].Name == minioHotTier || infos[j].Name == minioHotTier {
+			return infos[i].Name == minioHotTier
 		}
-		if infos[j].Type == "internal" {
-			return false
+		if infos[i].Type != infos[j].Type && (infos[i].Type == "internal" || infos[j].Type == "internal") {
+			return infos[i].Type == "internal"
 		}
 		return infos[i].Name < infos[j].Name
 	})
@@ -0,0 +1,667 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+)
+
+const evacuationTrackerFilename = ".evacuation.bin"
+
+// Drive evacuation states.
+const (
+	driveEvacuating int32 = iota + 1
+	driveEvacuated
+)
+
+// Drive evacuation status reported by the admin API.
+const (
+	driveEvacuationRunning  = "evacuating"
+	driveEvacuationComplete = "evacuated"
+	driveEvacuationFailed   = "failed"
+	driveEvacuationCanceled = "canceled"
+)
+
+var (
+	errDriveEvacuationRunning = AdminError{
+		Code:       "XMinioAdminDriveEvacuationRunning",
+		Message:    "drive is already being evacuated",
+		StatusCode: http.StatusConflict,
+	}
+	errDriveEvacuationNotFound = AdminError{
+		Code:       "XMinioAdminDriveEvacuationNotFound",
+		Message:    "drive is not being evacuated",
+		StatusCode: http.StatusNotFound,
+	}
+)
+
+// invalidDriveEvacuation returns the error of a drive evacuation that
+// cannot be started.
+func invalidDriveEvacuation(format string, a ...interface{}) error {
+	return AdminError{
+		Code:       "XMinioAdminInvalidDriveEvacuation",
+		Message:    fmt.Sprintf(format, a...),
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
+//go:generate msgp -file $GOFILE -unexported
+//msgp:ignore driveEvacuation driveEvacuations
+
+// driveEvacuationInfo is persisted on the evacuating drive, to resume the
+// evacuation or keep the drive offline once evacuated across restarts.
+type driveEvacuationInfo struct {
+	ID          string    `json:"id"`
+	Endpoint    string    `json:"endpoint"`
+	Replacement string    `json:"replacement"`
+	Status      string    `json:"status"`
+	Started     time.Time `json:"started"`
+	LastUpdate  time.Time `json:"lastUpdate"`
+	Error       string    `json:"error,omitempty"`
+
+	ObjectsCopied uint64 `json:"objectsCopied"`
+	ObjectsFailed uint64 `json:"objectsFailed"`
+	BytesCopied   uint64 `json:"bytesCopied"`
+
+	// Last object copied.
+	Bucket string `json:"bucket,omitempty"`
+	Object string `json:"object,omitempty"`
+
+	// Filled on start, buckets are copied in order.
+	QueuedBuckets []string `json:"queuedBuckets"`
+
+	// Filled during the evacuation.
+	CopiedBuckets []string `json:"copiedBuckets"`
+}
+
+func (i driveEvacuationInfo) isCopied(bucket string) bool {
+	for _, b := range i.CopiedBuckets {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+// driveEvacuation is the evacuation of a local drive, its shards are
+// copied onto a replacement drive while the drive takes no new data.
+type driveEvacuation struct {
+	state  int32      // atomic evacuation state
+	disk   StorageAPI // drive being evacuated
+	path   string     // drive path, shared by all the storage layers of the drive
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu   sync.Mutex
+	info driveEvacuationInfo
+}
+
+// status returns a copy of the evacuation info.
+func (ev *driveEvacuation) status() driveEvacuationInfo {
+	ev.mu.Lock()
+	defer ev.mu.Unlock()
+	info := ev.info
+	info.QueuedBuckets = append([]string{}, ev.info.QueuedBuckets...)
+	info.CopiedBuckets = append([]string{}, ev.info.CopiedBuckets...)
+	return info
+}
+
+// save persists the evacuation info on the evacuating drive.
+func (ev *driveEvacuation) save(ctx context.Context) error {
+	ev.mu.Lock()
+	ev.info.LastUpdate = time.Now().UTC()
+	b, err := ev.info.MarshalMsg(nil)
+	ev.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return ev.disk.WriteAll(ctx, minioMetaBucket,
+		pathJoin(bucketMetaPrefix, evacuationTrackerFilename), b)
+}
+
+// driveEvacuations tracks the evacuations of the local drives, by drive
+// path since a drive has a storage layer per erasure set and per storage
+// REST server.
+type driveEvacuations struct {
+	active int32 // atomic number of drives evacuating or evacuated
+
+	mu       sync.RWMutex
+	drives   map[string]*driveEvacuation
+	finished map[string]driveEvacuationInfo // last failed or canceled evacuations
+}
+
+var globalDriveEvacuations = &driveEvacuations{
+	drives:   make(map[string]*driveEvacuation),
+	finished: make(map[string]driveEvacuationInfo),
+}
+
+func (d *driveEvacuations) get(path string) *driveEvacuation {
+	if atomic.LoadInt32(&d.active) == 0 {
+		return nil
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.drives[path]
+}
+
+// add adds ev unless the drive is already being evacuated.
+func (d *driveEvacuations) add(ev *driveEvacuation) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.drives[ev.path]; ok {
+		return false
+	}
+	d.drives[ev.path] = ev
+	delete(d.finished, ev.path)
+	atomic.StoreInt32(&d.active, int32(len(d.drives)))
+	return true
+}
+
+// remove stops tracking ev, keeping its final status.
+func (d *driveEvacuations) remove(ev *driveEvacuation, status string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.drives[ev.path] != ev {
+		return
+	}
+	delete(d.drives, ev.path)
+	atomic.StoreInt32(&d.active, int32(len(d.drives)))
+	if status != "" {
+		info := ev.status()
+		info.Status = status
+		d.finished[ev.path] = info
+	}
+}
+
+// isEvacuated returns true if the drive at path has been evacuated.
+func (d *driveEvacuations) isEvacuated(path string) bool {
+	ev := d.get(path)
+	return ev != nil && atomic.LoadInt32(&ev.state) == driveEvacuated
+}
+
+// isEvacuating returns true if the drive at path is being evacuated,
+// writers treat such a drive as offline so that no new shards land on it.
+func (d *driveEvacuations) isEvacuating(path string) bool {
+	ev := d.get(path)
+	return ev != nil && atomic.LoadInt32(&ev.state) == driveEvacuating
+}
+
+// check returns the error of the storage call s on the drive at path,
+// evacuated drives are offline and evacuating drives take no new data.
+func (d *driveEvacuations) check(path string, s storageMetric) error {
+	ev := d.get(path)
+	if ev == nil {
+		return nil
+	}
+	switch atomic.LoadInt32(&ev.state) {
+	case driveEvacuated:
+		return errDiskNotFound
+	case driveEvacuating:
+		switch s {
+		case storageMetricCreateFile, storageMetricAppendFile, storageMetricRenameFile,
+			storageMetricRenameData, storageMetricWriteMetadata:
+			return errDiskEvacuating
+		}
+	}
+	return nil
+}
+
+// status returns the status of the evacuation of the drive at path.
+func (d *driveEvacuations) status(path string) (driveEvacuationInfo, bool) {
+	if ev := d.get(path); ev != nil {
+		return ev.status(), true
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	info, ok := d.finished[path]
+	return info, ok
+}
+
+// findLocalDrive returns the local drive with the endpoint drive, and
+// the erasure set it belongs to.
+func (z *erasureServerPools) findLocalDrive(drive string) (*xlStorageDiskIDCheck, *erasureObjects) {
+	for _, pool := range z.serverPools {
+		for _, set := range pool.sets {
+			for _, disk := range set.getDisks() {
+				if disk == nil || !disk.IsLocal() || disk.Endpoint().String() != drive {
+					continue
+				}
+				if d, ok := disk.(*xlStorageDiskIDCheck); ok {
+					return d, set
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// validateEvacuationReplacement validates that replacement is an empty,
+// unformatted drive mounted on this node which is not part of the cluster.
+func validateEvacuationReplacement(replacement string) error {
+	if !filepath.IsAbs(replacement) {
+		return invalidDriveEvacuation("replacement drive '%s' must be an absolute path", replacement)
+	}
+	for _, path := range globalEndpoints.LocalDisksPaths() {
+		if filepath.Clean(path) == filepath.Clean(replacement) {
+			return invalidDriveEvacuation("replacement drive '%s' is already in use", replacement)
+		}
+	}
+	fi, err := os.Stat(replacement)
+	if err != nil {
+		return invalidDriveEvacuation("replacement drive '%s' is not accessible: %v", replacement, err)
+	}
+	if !fi.IsDir() {
+		return invalidDriveEvacuation("replacement drive '%s' is not a directory", replacement)
+	}
+	if _, err = os.Stat(pathJoin(replacement, minioMetaBucket, formatConfigFile)); err == nil {
+		return invalidDriveEvacuation("replacement drive '%s' is already formatted", replacement)
+	}
+	return nil
+}
+
+// EvacuateDrive starts evacuating the local drive with the endpoint drive:
+// the drive takes no new data while its shards are copied onto the
+// replacement drive, once done the drive is taken offline until it is
+// replaced by the replacement drive, which then heals the data written
+// during the evacuation.
+func (z *erasureServerPools) EvacuateDrive(ctx context.Context, drive, replacement string) error {
+	disk, set := z.findLocalDrive(drive)
+	if disk == nil {
+		return invalidDriveEvacuation("drive '%s' not found on this node", drive)
+	}
+	if ev := globalDriveEvacuations.get(disk.storage.diskPath); ev != nil {
+		return errDriveEvacuationRunning
+	}
+	if err := validateEvacuationReplacement(replacement); err != nil {
+		return err
+	}
+
+	// The other drives of the set must be able to serve the new data.
+	for _, d := range set.getDisks() {
+		if d == nil || !d.IsOnline() {
+			return invalidDriveEvacuation("all the drives of the erasure set of '%s' must be online", drive)
+		}
+		if d.Healing() != nil {
+			return invalidDriveEvacuation("drive '%s' of the erasure set of '%s' is healing", d, drive)
+		}
+	}
+
+	id, err := disk.GetDiskID()
+	if err != nil {
+		return err
+	}
+	vols, err := disk.ListVols(ctx)
+	if err != nil {
+		return err
+	}
+	ev := &driveEvacuation{
+		state: driveEvacuating,
+		disk:  disk,
+		path:  disk.storage.diskPath,
+		info: driveEvacuationInfo{
+			ID:            id,
+			Endpoint:      drive,
+			Replacement:   filepath.Clean(replacement),
+			Status:        driveEvacuationRunning,
+			Started:       time.Now().UTC(),
+			QueuedBuckets: []string{minioMetaBucket},
+		},
+	}
+	for _, vol := range vols {
+		if !isMinioMetaBucketName(vol.Name) {
+			ev.info.QueuedBuckets = append(ev.info.QueuedBuckets, vol.Name)
+		}
+	}
+	return z.startDriveEvacuation(ev)
+}
+
+// startDriveEvacuation starts copying the shards of the evacuating drive
+// in the background.
+func (z *erasureServerPools) startDriveEvacuation(ev *driveEvacuation) error {
+	poolIdx, setIdx, _ := ev.disk.GetDiskLoc()
+	if poolIdx < 0 || setIdx < 0 {
+		return invalidDriveEvacuation("drive '%s' is not part of an erasure set", ev.info.Endpoint)
+	}
+
+	// Prevent healing a new drive or evacuating another drive of the set
+	// in parallel.
+	locker := z.NewNSLock(minioMetaBucket, fmt.Sprintf("new-drive-healing/%d/%d", poolIdx, setIdx))
+	lkctx, err := locker.GetLock(GlobalContext, newDiskHealingTimeout)
+	if err != nil {
+		return invalidDriveEvacuation("a drive of the erasure set of '%s' is healing or being evacuated", ev.info.Endpoint)
+	}
+	if !globalDriveEvacuations.add(ev) {
+		locker.Unlock(lkctx.Cancel)
+		return errDriveEvacuationRunning
+	}
+	if err = ev.save(lkctx.Context()); err != nil {
+		globalDriveEvacuations.remove(ev, "")
+		locker.Unlock(lkctx.Cancel)
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(lkctx.Context())
+	ev.cancel = cancel
+	ev.done = make(chan struct{})
+	go func() {
+		defer close(ev.done)
+		defer locker.Unlock(lkctx.Cancel)
+		defer cancel()
+
+		err := ev.run(ctx)
+		switch {
+		case err == nil:
+			logger.Info("Drive %s evacuated to %s, replace it with %s to bring it back online", ev.info.Endpoint, ev.info.Replacement, ev.info.Replacement)
+			go ev.waitReplaced(GlobalContext)
+		case ctx.Err() == nil:
+			logger.LogIf(GlobalContext, fmt.Errorf("Evacuating drive %s failed: %w", ev.info.Endpoint, err))
+			ev.mu.Lock()
+			ev.info.Error = err.Error()
+			ev.mu.Unlock()
+			ev.restore(GlobalContext, driveEvacuationFailed)
+		}
+	}()
+	return nil
+}
+
+// run copies the shards of the evacuating drive onto the replacement
+// drive, then takes the drive offline.
+func (ev *driveEvacuation) run(ctx context.Context) error {
+	target, err := newLocalXLStorage(ev.info.Replacement)
+	if err != nil {
+		return fmt.Errorf("replacement drive %s: %w", ev.info.Replacement, err)
+	}
+	defer target.Close()
+
+	lastSave := time.Now()
+	for _, bucket := range ev.status().QueuedBuckets {
+		if ev.status().isCopied(bucket) {
+			continue
+		}
+		if err = ev.copyBucket(ctx, target, bucket, &lastSave); err != nil {
+			return err
+		}
+		ev.mu.Lock()
+		ev.info.CopiedBuckets = append(ev.info.CopiedBuckets, bucket)
+		ev.info.Bucket, ev.info.Object = "", ""
+		ev.mu.Unlock()
+		logger.LogIf(ctx, ev.save(ctx))
+	}
+
+	// The replacement drive takes the identity of the evacuated drive, and
+	// heals the data written during the evacuation once swapped.
+	format, err := ev.disk.ReadAll(ctx, minioMetaBucket, formatConfigFile)
+	if err != nil {
+		return err
+	}
+	h := newHealingTracker(ev.disk)
+	h.Started = time.Now().UTC()
+	h.LastUpdate = h.Started
+	tracker, err := h.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+	if err = target.WriteAll(ctx, minioMetaBucket, pathJoin(bucketMetaPrefix, healingTrackerFilename), tracker); err != nil {
+		return err
+	}
+	if err = target.WriteAll(ctx, minioMetaBucket, formatConfigFile, format); err != nil {
+		return err
+	}
+
+	ev.mu.Lock()
+	ev.info.Status = driveEvacuationComplete
+	ev.mu.Unlock()
+	if err = ev.save(ctx); err != nil {
+		return err
+	}
+	atomic.StoreInt32(&ev.state, driveEvacuated)
+	return nil
+}
+
+// copyBucket copies the objects of bucket onto target, resuming after the
+// last object copied.
+func (ev *driveEvacuation) copyBucket(ctx context.Context, target *xlStorage, bucket string, lastSave *time.Time) error {
+	if err := target.MakeVol(ctx, bucket); err != nil && !errors.Is(err, errVolumeExists) {
+		return err
+	}
+
+	var forwardTo string
+	if info := ev.status(); info.Bucket == bucket {
+		forwardTo = info.Object
+	}
+	return listPathRaw(ctx, listPathRawOptions{
+		disks:          []StorageAPI{ev.disk},
+		bucket:         bucket,
+		recursive:      true,
+		forwardTo:      forwardTo,
+		minDisks:       1,
+		reportNotFound: false,
+		agreed: func(entry metaCacheEntry) {
+			if entry.isDir() || !entry.isObject() {
+				return
+			}
+			// Temporary data is not evacuated.
+			if bucket == minioMetaBucket && strings.HasPrefix(entry.name, "tmp/") {
+				return
+			}
+			size, err := copyEvacuatedEntry(ctx, ev.disk, target, bucket, entry)
+
+			ev.mu.Lock()
+			if err != nil {
+				// Left to heal once the replacement drive is in place.
+				ev.info.ObjectsFailed++
+			} else {
+				ev.info.ObjectsCopied++
+				ev.info.BytesCopied += uint64(size)
+			}
+			ev.info.Bucket, ev.info.Object = bucket, entry.name
+			ev.mu.Unlock()
+			if err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to evacuate %s/%s: %w", bucket, entry.name, err))
+			}
+
+			if time.Since(*lastSave) > time.Minute {
+				logger.LogIf(ctx, ev.save(ctx))
+				*lastSave = time.Now()
+			}
+		},
+		finished: nil,
+	})
+}
+
+// copyEvacuatedEntry copies the data and metadata of entry from disk to
+// target, returning the number of bytes copied.
+func copyEvacuatedEntry(ctx context.Context, disk StorageAPI, target *xlStorage, bucket string, entry metaCacheEntry) (size int64, err error) {
+	fivs, err := entry.fileInfoVersions(bucket)
+	if err != nil {
+		return 0, err
+	}
+
+	// Remove any partial copy left by an interrupted evacuation.
+	err = target.Delete(ctx, bucket, entry.name, DeleteOptions{Recursive: true})
+	if err != nil && !errors.Is(err, errFileNotFound) {
+		return 0, err
+	}
+
+	dataDirs := make(map[string]struct{})
+	for _, version := range fivs.Versions {
+		if version.DataDir == "" || version.InlineData() || version.IsRemote() {
+			continue
+		}
+		if _, ok := dataDirs[version.DataDir]; ok {
+			continue
+		}
+		dataDirs[version.DataDir] = struct{}{}
+
+		dir := pathJoin(entry.name, version.DataDir)
+		names, err := disk.ListDir(ctx, bucket, dir, -1)
+		if err != nil {
+			if errors.Is(err, errFileNotFound) {
+				// Missing shards are healed on the replacement drive.
+				continue
+			}
+			return size, err
+		}
+		for _, name := range names {
+			if strings.HasSuffix(name, SlashSeparator) {
+				continue
+			}
+			n, err := copyEvacuatedFile(ctx, disk, target, bucket, pathJoin(dir, name))
+			if err != nil {
+				return size, err
+			}
+			size += n
+		}
+	}
+	return size, target.WriteAll(ctx, bucket, pathJoin(entry.name, xlStorageFormatFile), entry.metadata)
+}
+
+// copyEvacuatedFile copies the file at path from disk to target.
+func copyEvacuatedFile(ctx context.Context, disk StorageAPI, target *xlStorage, bucket, path string) (int64, error) {
+	st, err := disk.StatInfoFile(ctx, bucket, path, false)
+	if err != nil {
+		return 0, err
+	}
+	if len(st) != 1 {
+		return 0, errFileNotFound
+	}
+	rc, err := disk.ReadFileStream(ctx, bucket, path, 0, st[0].Size)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	return st[0].Size, target.CreateFile(ctx, bucket, path, st[0].Size, rc)
+}
+
+// waitReplaced waits for the evacuated drive to be replaced by its
+// replacement drive, which has no evacuation tracker, and heals it.
+func (ev *driveEvacuation) waitReplaced(ctx context.Context) {
+	tracker := pathJoin(ev.path, minioMetaBucket, bucketMetaPrefix, evacuationTrackerFilename)
+	t := time.NewTicker(defaultMonitorNewDiskInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		if globalDriveEvacuations.get(ev.path) != ev {
+			// Canceled.
+			return
+		}
+		if _, err := os.Stat(tracker); !osIsNotExist(err) {
+			continue
+		}
+		logger.Info("Evacuated drive %s was replaced, healing it", ev.info.Endpoint)
+		globalDriveEvacuations.remove(ev, "")
+		globalBackgroundHealState.pushHealLocalDisks(ev.disk.Endpoint())
+		return
+	}
+}
+
+// restore brings the drive back online, healing the data it did not take
+// during the evacuation.
+func (ev *driveEvacuation) restore(ctx context.Context, status string) {
+	atomic.StoreInt32(&ev.state, 0)
+	globalDriveEvacuations.remove(ev, status)
+
+	logger.LogIf(ctx, ev.disk.Delete(ctx, minioMetaBucket,
+		pathJoin(bucketMetaPrefix, evacuationTrackerFilename), DeleteOptions{}))
+	if err := newHealingTracker(ev.disk).save(ctx); err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	globalBackgroundHealState.pushHealLocalDisks(ev.disk.Endpoint())
+}
+
+// CancelDriveEvacuation cancels the evacuation of the local drive with the
+// endpoint drive, bringing it back online. The data already copied onto
+// the replacement drive is left as is.
+func (z *erasureServerPools) CancelDriveEvacuation(ctx context.Context, drive string) error {
+	disk, _ := z.findLocalDrive(drive)
+	if disk == nil {
+		return invalidDriveEvacuation("drive '%s' not found on this node", drive)
+	}
+	ev := globalDriveEvacuations.get(disk.storage.diskPath)
+	if ev == nil {
+		return errDriveEvacuationNotFound
+	}
+	if ev.cancel != nil {
+		ev.cancel()
+		<-ev.done
+	}
+	ev.restore(ctx, driveEvacuationCanceled)
+	return nil
+}
+
+// DriveEvacuationStatus returns the status of the evacuation of the local
+// drive with the endpoint drive.
+func (z *erasureServerPools) DriveEvacuationStatus(drive string) (driveEvacuationInfo, error) {
+	disk, _ := z.findLocalDrive(drive)
+	if disk == nil {
+		return driveEvacuationInfo{}, invalidDriveEvacuation("drive '%s' not found on this node", drive)
+	}
+	info, ok := globalDriveEvacuations.status(disk.storage.diskPath)
+	if !ok {
+		return info, errDriveEvacuationNotFound
+	}
+	return info, nil
+}
+
+// resumeDriveEvacuations loads the evacuations of the local drives on
+// startup, resuming the ones in progress.
+func resumeDriveEvacuations(ctx context.Context, z *erasureServerPools) {
+	for _, disk := range globalLocalDrives {
+		d, ok := disk.(*xlStorageDiskIDCheck)
+		if !ok {
+			continue
+		}
+		b, err := d.ReadAll(ctx, minioMetaBucket, pathJoin(bucketMetaPrefix, evacuationTrackerFilename))
+		if err != nil {
+			continue
+		}
+		ev := &driveEvacuation{
+			disk: d,
+			path: d.storage.diskPath,
+		}
+		if _, err = ev.info.UnmarshalMsg(b); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to load the evacuation of drive %s: %w", d, err))
+			continue
+		}
+		if ev.info.Status == driveEvacuationComplete {
+			ev.state = driveEvacuated
+			if globalDriveEvacuations.add(ev) {
+				go ev.waitReplaced(ctx)
+			}
+			continue
+		}
+		ev.state = driveEvacuating
+		logger.LogIf(ctx, z.startDriveEvacuation(ev))
+	}
+}
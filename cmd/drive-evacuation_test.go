@@ -0,0 +1,239 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDriveEvacuationsCheck(t *testing.T) {
+	evacuations := &driveEvacuations{
+		drives:   make(map[string]*driveEvacuation),
+		finished: make(map[string]driveEvacuationInfo),
+	}
+	ev := &driveEvacuation{state: driveEvacuating, path: "/drive1"}
+	if !evacuations.add(ev) {
+		t.Fatal("expected the evacuation to be added")
+	}
+	if evacuations.add(&driveEvacuation{state: driveEvacuating, path: "/drive1"}) {
+		t.Fatal("expected a second evacuation of the drive to be refused")
+	}
+
+	testCases := []struct {
+		path   string
+		state  int32
+		metric storageMetric
+		err    error
+	}{
+		{"/drive2", driveEvacuating, storageMetricCreateFile, nil},
+		{"/drive1", driveEvacuating, storageMetricCreateFile, errDiskEvacuating},
+		{"/drive1", driveEvacuating, storageMetricRenameData, errDiskEvacuating},
+		{"/drive1", driveEvacuating, storageMetricWriteMetadata, errDiskEvacuating},
+		{"/drive1", driveEvacuating, storageMetricReadFileStream, nil},
+		{"/drive1", driveEvacuating, storageMetricDeleteVersion, nil},
+		{"/drive1", driveEvacuated, storageMetricReadFileStream, errDiskNotFound},
+	}
+	for i, tc := range testCases {
+		ev.state = tc.state
+		if err := evacuations.check(tc.path, tc.metric); err != tc.err {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.err, err)
+		}
+	}
+
+	evacuations.remove(ev, driveEvacuationCanceled)
+	if err := evacuations.check("/drive1", storageMetricCreateFile); err != nil {
+		t.Fatalf("expected no error once removed, got %v", err)
+	}
+	if info, ok := evacuations.status("/drive1"); !ok || info.Status != driveEvacuationCanceled {
+		t.Fatalf("expected the canceled status to be kept, got %v", info)
+	}
+}
+
+func TestEvacuateDrive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fsDirs, err := getRandomDisks(17)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+	replacement := fsDirs[16]
+
+	obj, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(fsDirs[:16]...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+
+	setObjectLayer(obj)
+	if globalBackgroundHealState == nil {
+		globalBackgroundHealState = newHealState(GlobalContext, false)
+	}
+	z := obj.(*erasureServerPools)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	objects := map[string][]byte{
+		"small":        []byte("small object"),
+		"prefix/large": bytes.Repeat([]byte("a"), 4<<20),
+	}
+	for object, data := range objects {
+		_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	disk := z.serverPools[0].sets[0].getDisks()[0]
+	drive := disk.Endpoint().String()
+	if err = z.EvacuateDrive(ctx, drive, fsDirs[0]); err == nil {
+		t.Fatal("expected evacuating onto a drive in use to fail")
+	}
+	if err = z.EvacuateDrive(ctx, drive, replacement); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Minute)
+	for {
+		status, err := z.DriveEvacuationStatus(drive)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Status == driveEvacuationComplete {
+			if status.ObjectsFailed != 0 || status.BytesCopied < 4<<20/16 {
+				t.Fatalf("unexpected evacuation status %#v", status)
+			}
+			break
+		}
+		if status.Status != driveEvacuationRunning || time.Now().After(deadline) {
+			t.Fatalf("unexpected evacuation status %#v", status)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// The replacement drive holds the shards and the identity of the drive.
+	for _, file := range []string{
+		pathJoin(minioMetaBucket, formatConfigFile),
+		pathJoin(minioMetaBucket, bucketMetaPrefix, healingTrackerFilename),
+		pathJoin(bucket, "small", xlStorageFormatFile),
+		pathJoin(bucket, "prefix/large", xlStorageFormatFile),
+		pathJoin(bucket, "prefix/large", "*", "part.1"),
+	} {
+		if matches, _ := filepath.Glob(pathJoin(replacement, file)); len(matches) != 1 {
+			t.Fatalf("expected %s on the replacement drive", file)
+		}
+	}
+
+	// The evacuated drive is offline, the set takes new objects without it.
+	if disk.IsOnline() {
+		t.Fatal("expected the evacuated drive to be offline")
+	}
+	if _, err = disk.ReadAll(ctx, minioMetaBucket, formatConfigFile); !errors.Is(err, errDiskNotFound) {
+		t.Fatalf("expected %v, got %v", errDiskNotFound, err)
+	}
+	data := []byte("new object")
+	_, err = obj.PutObject(ctx, bucket, "new", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = z.CancelDriveEvacuation(ctx, drive); err != nil {
+		t.Fatal(err)
+	}
+	if !disk.IsOnline() {
+		t.Fatal("expected the drive to be back online")
+	}
+	if disk.Healing() == nil {
+		t.Fatal("expected the drive to be marked for healing")
+	}
+	if status, err := z.DriveEvacuationStatus(drive); err != nil || status.Status != driveEvacuationCanceled {
+		t.Fatalf("expected the evacuation to be canceled, got %v, %v", status, err)
+	}
+}
+
+func TestPutObjectEvacuatingDrive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	setObjectLayer(obj)
+
+	set := obj.(*erasureServerPools).serverPools[0].sets[0]
+	disk := set.getDisks()[0].(*xlStorageDiskIDCheck)
+	ev := &driveEvacuation{state: driveEvacuating, path: disk.storage.diskPath}
+	if !globalDriveEvacuations.add(ev) {
+		t.Fatal("expected the evacuation to be added")
+	}
+	defer globalDriveEvacuations.remove(ev, driveEvacuationCanceled)
+
+	if di, err := disk.DiskInfo(ctx); err != nil || !di.Evacuating {
+		t.Fatalf("expected the drive to be reported as evacuating, got %v, %v", di.Evacuating, err)
+	}
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	parity := globalStorageClass.GetParityForSC("")
+	if parity < 0 {
+		parity = set.defaultParityCount
+	}
+	data := bytes.Repeat([]byte("a"), 1<<20)
+	if _, err = obj.PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	res, err := obj.NewMultipartUpload(ctx, bucket, "multipart", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.AbortMultipartUpload(ctx, bucket, "multipart", res.UploadID, ObjectOptions{})
+
+	// The evacuating drive counts as offline, the parity of new objects
+	// is upgraded and the drive holds none of their shards.
+	fi, _, _, err := set.getObjectFileInfo(ctx, bucket, "object", ObjectOptions{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Erasure.ParityBlocks != parity+1 {
+		t.Fatalf("expected parity %d, got %d", parity+1, fi.Erasure.ParityBlocks)
+	}
+	if _, err = disk.ReadVersion(ctx, bucket, "object", "", false); err == nil {
+		t.Fatal("expected no shard of the object on the evacuating drive")
+	}
+	mfi, _, err := set.checkUploadIDExists(ctx, bucket, "multipart", res.UploadID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mfi.Erasure.ParityBlocks != parity+1 {
+		t.Fatalf("expected multipart parity %d, got %d", parity+1, mfi.Erasure.ParityBlocks)
+	}
+}
@@ -205,6 +205,18 @@ type PoolEndpoints struct {
 	DrivesPerSet int
 	Endpoints    Endpoints
 	CmdLine      string
+
+	// Spare drives, formatted but not part of any erasure set until
+	// promoted to replace a failed drive of the pool.
+	Spares Endpoints
+}
+
+// EndpointsWithSpares returns the endpoints of the pool followed by its
+// spare drives.
+func (p PoolEndpoints) EndpointsWithSpares() Endpoints {
+	endpoints := make(Endpoints, 0, len(p.Endpoints)+len(p.Spares))
+	endpoints = append(endpoints, p.Endpoints...)
+	return append(endpoints, p.Spares...)
 }
 
 // EndpointServerPools - list of list of endpoints
@@ -225,7 +237,7 @@ func (l EndpointServerPools) GetPoolIdx(pool string) int {
 // if ep is remote this code will return -1 poolIndex
 func (l EndpointServerPools) GetLocalPoolIdx(ep Endpoint) int {
 	for i, zep := range l {
-		for _, cep := range zep.Endpoints {
+		for _, cep := range zep.EndpointsWithSpares() {
 			if cep.IsLocal && ep.IsLocal {
 				if reflect.DeepEqual(cep, ep) {
 					return i
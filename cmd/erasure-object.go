@@ -1016,7 +1016,8 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 				defer wg.Done()
 				di, err := disk.DiskInfo(ctx)
 				//查询不到磁盘也作为校验磁盘计数.
-				if err != nil || di.ID == "" {
+				// Evacuating drives take no new shards, count them as offline.
+				if err != nil || di.ID == "" || di.Evacuating {
 					atomicParityDrives.Inc()
 				}
 			}(disk)
@@ -1933,6 +1934,10 @@ func (er erasureObjects) GetObjectTags(ctx context.Context, bucket, object strin
 
 // TransitionObject - transition object content to target tier.
 func (er erasureObjects) TransitionObject(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	if globalStorageClass.IsCustom(opts.Transition.Tier) {
+		return er.transitionStorageClass(ctx, bucket, object, opts)
+	}
+
 	tgtClient, err := globalTierConfigMgr.getDriver(opts.Transition.Tier)
 	if err != nil {
 		return err
@@ -1962,12 +1967,16 @@ func (er erasureObjects) TransitionObject(ctx context.Context, bucket, object st
 	if !opts.MTime.Equal(fi.ModTime) || !strings.EqualFold(opts.Transition.ETag, extractETag(fi.Metadata)) {
 		return toObjectErr(errFileNotFound, bucket, object)
 	}
-	// if object already transitioned, return
-	if fi.TransitionStatus == lifecycle.TransitionComplete {
+	// if object already transitioned to the tier, return
+	if fi.TransitionStatus == lifecycle.TransitionComplete && fi.TransitionTier == opts.Transition.Tier {
 		return nil
 	}
 	defer NSUpdated(bucket, object)
 
+	if fi.TransitionStatus == lifecycle.TransitionComplete {
+		return er.moveTransitionedObject(ctx, bucket, object, fi, tgtClient, opts)
+	}
+
 	if fi.XLV1 {
 		if _, err = er.HealObject(ctx, bucket, object, "", madmin.HealOpts{NoLock: true}); err != nil {
 			return err
@@ -2030,6 +2039,143 @@ func (er erasureObjects) TransitionObject(ctx context.Context, bucket, object st
 	return err
 }
 
+// moveTransitionedObject moves the content of an object already transitioned
+// to a remote tier to the tier in opts, e.g from a warm tier to an archive
+// tier. The content on the previous tier is journaled for deletion only once
+// the object metadata refers to the new tier, the new copy is journaled
+// instead when the metadata update fails.
+func (er erasureObjects) moveTransitionedObject(ctx context.Context, bucket, object string, fi FileInfo, tgtClient WarmBackend, opts ObjectOptions) error {
+	// A restored copy of the object shares its metadata, the object is
+	// moved once the copy expires.
+	if fi.Metadata[xhttp.AmzRestore] != "" {
+		return errTransitionRestoredObject
+	}
+
+	srcClient, err := globalTierConfigMgr.getDriver(fi.TransitionTier)
+	if err != nil {
+		return err
+	}
+	rd, err := srcClient.Get(ctx, fi.TransitionedObjName, remoteVersionID(fi.TransitionVersionID), WarmBackendGetOpts{})
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+
+	destObj, err := genTransitionObjName(bucket)
+	if err != nil {
+		return err
+	}
+	rv, err := tgtClient.Put(ctx, destObj, rd, fi.Size)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to transition %s/%s(%s) from %s to %s tier: %w", bucket, object, opts.VersionID, fi.TransitionTier, opts.Transition.Tier, err))
+		return err
+	}
+
+	prev := jentry{
+		ObjName:   fi.TransitionedObjName,
+		VersionID: fi.TransitionVersionID,
+		TierName:  fi.TransitionTier,
+	}
+	fi.TransitionedObjName = destObj
+	fi.TransitionTier = opts.Transition.Tier
+	fi.TransitionVersionID = string(rv)
+	if err = er.deleteObjectVersion(ctx, bucket, object, fi, false); err != nil {
+		// The metadata still refers to the previous tier, the new copy
+		// is not referenced.
+		logger.LogIf(ctx, globalTierJournal.addEntryDurable(jentry{
+			ObjName:   destObj,
+			VersionID: string(rv),
+			TierName:  opts.Transition.Tier,
+		}))
+		objInfo := fi.ToObjectInfo(bucket, object, opts.Versioned || opts.VersionSuspended)
+		sendEvent(eventArgs{
+			EventName:  event.ObjectTransitionFailed,
+			BucketName: bucket,
+			Object:     objInfo,
+			Host:       "Internal: [ILM-Transition]",
+		})
+		return toObjectErr(err, bucket, object)
+	}
+	logger.LogIf(ctx, globalTierJournal.addEntryDurable(prev))
+
+	for _, disk := range er.getDisks() {
+		if disk != nil && disk.IsOnline() {
+			continue
+		}
+		er.addPartial(bucket, object, opts.VersionID, -1)
+		break
+	}
+
+	objInfo := fi.ToObjectInfo(bucket, object, opts.Versioned || opts.VersionSuspended)
+	sendEvent(eventArgs{
+		EventName:  event.ObjectTransitionComplete,
+		BucketName: bucket,
+		Object:     objInfo,
+		Host:       "Internal: [ILM-Transition]",
+	})
+	auditLogLifecycle(ctx, objInfo, ILMTransition)
+	return nil
+}
+
+// transitionStorageClass transitions the object to a custom storage class of
+// this cluster, its data is re-encoded in place with the parity of the storage
+// class.
+func (er erasureObjects) transitionStorageClass(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	sc := opts.Transition.Tier
+
+	// Acquire write lock before starting to transition the object.
+	lk := er.NewNSLock(bucket, object)
+	lkctx, err := lk.GetLock(ctx, globalDeleteOperationTimeout)
+	if err != nil {
+		return err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	opts.NoLock = true
+	fi, _, _, err := er.getObjectFileInfo(ctx, bucket, object, opts, false)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+	if fi.Deleted {
+		if opts.VersionID == "" {
+			return toObjectErr(errFileNotFound, bucket, object)
+		}
+		// Make sure to return object info to provide extra information.
+		return toObjectErr(errMethodNotAllowed, bucket, object)
+	}
+	// verify that the object queued for transition is identical to that on disk.
+	if !opts.MTime.Equal(fi.ModTime) || !strings.EqualFold(opts.Transition.ETag, extractETag(fi.Metadata)) {
+		return toObjectErr(errFileNotFound, bucket, object)
+	}
+	// if object already transitioned, return
+	if fi.IsRemote() || fi.Metadata[xhttp.AmzStorageClass] == sc {
+		return nil
+	}
+	defer NSUpdated(bucket, object)
+
+	eventName := event.ObjectTransitionComplete
+	err = er.reencodeObject(ctx, bucket, object, fi, func(userDefined map[string]string) {
+		userDefined[xhttp.AmzStorageClass] = sc
+	})
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to transition %s/%s(%s) to %s storage class: %w", bucket, object, opts.VersionID, sc, err))
+		eventName = event.ObjectTransitionFailed
+	} else {
+		fi.Metadata[xhttp.AmzStorageClass] = sc
+	}
+
+	objInfo := fi.ToObjectInfo(bucket, object, opts.Versioned || opts.VersionSuspended)
+	sendEvent(eventArgs{
+		EventName:  eventName,
+		BucketName: bucket,
+		Object:     objInfo,
+		Host:       "Internal: [ILM-Transition]",
+	})
+	auditLogLifecycle(ctx, objInfo, ILMTransition)
+	return err
+}
+
 // RestoreTransitionedObject - restore transitioned object content locally on this cluster.
 // This is similar to PostObjectRestore from AWS GLACIER
 // storage class. When PostObjectRestore API is called, a temporary copy of the object
@@ -32,6 +32,8 @@ import (
 	"testing"
 
 	"github.com/dustin/go-humanize"
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/bucket/lifecycle"
 	"github.com/minio/minio/internal/config/storageclass"
 )
 
@@ -1219,3 +1221,191 @@ func TestGetObjectWithOutdatedDisks(t *testing.T) {
 		}
 	}
 }
+
+func TestTransitionStorageClass(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	setObjectLayer(obj)
+
+	z := obj.(*erasureServerPools)
+	xl := z.serverPools[0].sets[0]
+
+	defer func(sc storageclass.Config) {
+		globalStorageClass = sc
+	}(globalStorageClass)
+	globalStorageClass = storageclass.Config{
+		Standard: storageclass.StorageClass{
+			Parity: 2,
+		},
+		Custom: []storageclass.CustomClass{
+			{Name: "ARCHIVE_EC6", Parity: 6, Pool: -1},
+		},
+	}
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 1024*1024)
+	oi, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = obj.TransitionObject(ctx, bucket, object, ObjectOptions{
+		Transition: TransitionOptions{
+			Status: lifecycle.TransitionPending,
+			Tier:   "ARCHIVE_EC6",
+			ETag:   oi.ETag,
+		},
+		MTime: oi.ModTime,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, _, _, err := xl.getObjectFileInfo(ctx, bucket, object, ObjectOptions{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Erasure.ParityBlocks != 6 {
+		t.Errorf("Expected parity 6, got %d", fi.Erasure.ParityBlocks)
+	}
+	if fi.IsRemote() {
+		t.Error("Expected object data to remain in this cluster")
+	}
+
+	gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(gr)
+	gr.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Error("Expected object content to be preserved")
+	}
+	if gr.ObjInfo.StorageClass != "ARCHIVE_EC6" || gr.ObjInfo.ETag != oi.ETag || !gr.ObjInfo.ModTime.Equal(oi.ModTime) {
+		t.Errorf("Unexpected object info after transition %s %s %s", gr.ObjInfo.StorageClass, gr.ObjInfo.ETag, gr.ObjInfo.ModTime)
+	}
+}
+
+func TestTransitionObjectBetweenTiers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	setObjectLayer(obj)
+	defer setObjectLayer(nil)
+
+	z := obj.(*erasureServerPools)
+	xl := z.serverPools[0].sets[0]
+
+	defer func(mgr *TierConfigMgr, j *tierJournal) {
+		globalTierConfigMgr = mgr
+		globalTierJournal = j
+	}(globalTierConfigMgr, globalTierJournal)
+	globalTierConfigMgr = NewTierConfigMgr()
+	globalTierJournal = &tierJournal{
+		tierMemJournal:  newTierMemJoural(10),
+		tierDiskJournal: newTierDiskJournal(),
+	}
+	for _, tier := range []string{"WARM", "ARCHIVE"} {
+		root := t.TempDir()
+		if err = os.Mkdir(filepath.Join(root, "tier"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		d, err := newFileWarmBackend(ctx, FileTierConfig{
+			Version: madmin.TierConfigVer,
+			Type:    tierTypeFS,
+			Name:    tier,
+			FS: &TierFS{
+				Path:   root,
+				Bucket: "tier",
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		globalTierConfigMgr.drivercache[tier] = d
+	}
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 1024*1024)
+	oi, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var prev FileInfo
+	for _, tier := range []string{"WARM", "ARCHIVE"} {
+		err = obj.TransitionObject(ctx, bucket, object, ObjectOptions{
+			Transition: TransitionOptions{
+				Status: lifecycle.TransitionPending,
+				Tier:   tier,
+				ETag:   oi.ETag,
+			},
+			MTime: oi.ModTime,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fi, _, _, err := xl.getObjectFileInfo(ctx, bucket, object, ObjectOptions{}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fi.IsRemote() || fi.TransitionTier != tier {
+			t.Fatalf("Expected object to be transitioned to %s, got %s", tier, fi.TransitionTier)
+		}
+
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("Expected object content to be preserved in %s", tier)
+		}
+		if gr.ObjInfo.StorageClass != tier || gr.ObjInfo.ETag != oi.ETag {
+			t.Errorf("Unexpected object info after transition %s %s", gr.ObjInfo.StorageClass, gr.ObjInfo.ETag)
+		}
+
+		if prev.TransitionTier == "" {
+			prev = fi
+			continue
+		}
+		// The content on the previous tier must be journaled for deletion.
+		select {
+		case je := <-globalTierJournal.entries:
+			if je.TierName != prev.TransitionTier || je.ObjName != prev.TransitionedObjName {
+				t.Errorf("Expected %s in %s to be journaled, got %s in %s", prev.TransitionedObjName, prev.TransitionTier, je.ObjName, je.TierName)
+			}
+		default:
+			t.Error("Expected the previous tier content to be journaled for deletion")
+		}
+	}
+}
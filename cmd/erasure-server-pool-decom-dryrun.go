@@ -0,0 +1,284 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/minio/minio/internal/bucket/lifecycle"
+)
+
+// maxDecomDryRunUnmovable is the maximum number of unmovable object
+// versions listed by a decommission dry-run.
+const maxDecomDryRunUnmovable = 1000
+
+// decomDryRunBucket reports what decommission would move out of a bucket.
+type decomDryRunBucket struct {
+	Bucket    string `json:"bucket"`
+	Objects   uint64 `json:"objects"`   // Number of objects to be moved
+	Versions  uint64 `json:"versions"`  // Number of versions to be moved
+	Bytes     uint64 `json:"bytes"`     // Number of bytes to be moved
+	Skipped   uint64 `json:"skipped"`   // Number of versions skipped, expired or single delete markers
+	Unmovable uint64 `json:"unmovable"` // Number of versions that cannot be moved
+}
+
+// decomDryRunObject is an object version decommission cannot move.
+type decomDryRunObject struct {
+	Bucket    string `json:"bucket"`
+	Object    string `json:"object"`
+	VersionID string `json:"versionId,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// decomDryRunPool reports the usable capacity of a pool that would
+// receive the decommissioned objects.
+type decomDryRunPool struct {
+	Pool      int   `json:"pool"`
+	Total     int64 `json:"total"`     // Usable capacity, parity excluded
+	Free      int64 `json:"free"`      // Usable free space, parity excluded
+	Available int64 `json:"available"` // Usable free space new objects can be placed in
+}
+
+// decomDryRunReport reports what decommissioning a pool would do,
+// without modifying anything.
+type decomDryRunReport struct {
+	Pool       int                 `json:"pool"`
+	Buckets    []decomDryRunBucket `json:"buckets"`
+	Objects    uint64              `json:"objects"`
+	Versions   uint64              `json:"versions"`
+	Bytes      uint64              `json:"bytes"`
+	Unmovable  []decomDryRunObject `json:"unmovable,omitempty"`
+	Truncated  bool                `json:"truncated"` // Set if more unmovable versions exist than listed
+	Pools      []decomDryRunPool   `json:"pools"`     // Pools receiving the objects
+	Available  int64               `json:"available"`
+	Sufficient bool                `json:"sufficient"` // Set if the remaining pools can hold the moved bytes
+	Blockers   []string            `json:"blockers,omitempty"`
+}
+
+func (rpt *decomDryRunReport) addUnmovable(o decomDryRunObject) {
+	if len(rpt.Unmovable) >= maxDecomDryRunUnmovable {
+		rpt.Truncated = true
+		return
+	}
+	rpt.Unmovable = append(rpt.Unmovable, o)
+}
+
+// decommissionDryRun walks the pool idx and reports the objects
+// decommission would move, the objects it cannot move and whether the
+// remaining pools have enough capacity, without modifying anything.
+func (z *erasureServerPools) decommissionDryRun(ctx context.Context, idx int) (rpt decomDryRunReport, err error) {
+	if idx < 0 || idx >= len(z.serverPools) || z.SinglePool() {
+		return rpt, errInvalidArgument
+	}
+
+	rpt.Pool = idx
+	if z.IsRebalanceStarted() {
+		rpt.Blockers = append(rpt.Blockers, errDecommissionRebalanceAlreadyRunning.Error())
+	}
+	z.poolMetaMutex.RLock()
+	err = z.poolMeta.canDecommission(idx)
+	z.poolMetaMutex.RUnlock()
+	if err != nil {
+		rpt.Blockers = append(rpt.Blockers, err.Error())
+	}
+
+	buckets, err := z.ListBuckets(ctx, BucketOptions{})
+	if err != nil {
+		return rpt, err
+	}
+
+	for _, bucket := range buckets {
+		// TODO: Support decommissioning transition tiers.
+		if lc, err := globalLifecycleSys.Get(bucket.Name); err == nil && lc.HasTransition() {
+			rpt.Blockers = append(rpt.Blockers, fmt.Sprintf("Bucket is part of transitioned tier %s: decommission is not allowed in Tier'd setups", bucket.Name))
+		}
+		if err := z.checkPoolAffinity(bucket.Name, getPoolAffinity(bucket.Name), idx); err != nil {
+			rpt.Blockers = append(rpt.Blockers, err.Error())
+		}
+
+		brpt, err := z.decommissionDryRunBucket(ctx, idx, bucket.Name, &rpt)
+		if err != nil {
+			return rpt, err
+		}
+		rpt.Buckets = append(rpt.Buckets, brpt)
+		rpt.Objects += brpt.Objects
+		rpt.Versions += brpt.Versions
+		rpt.Bytes += brpt.Bytes
+	}
+
+	for i := range z.serverPools {
+		if i == idx || z.IsSuspended(i) {
+			continue
+		}
+		pi, err := z.getDecommissionPoolSpaceInfo(i)
+		if err != nil {
+			return rpt, err
+		}
+		// New objects are not placed on drives above the reserved space.
+		available := pi.Free - int64(float64(pi.Total)*diskReserveFraction)
+		if available < 0 {
+			available = 0
+		}
+		rpt.Pools = append(rpt.Pools, decomDryRunPool{
+			Pool:      i,
+			Total:     pi.Total,
+			Free:      pi.Free,
+			Available: available,
+		})
+		rpt.Available += available
+	}
+	rpt.Sufficient = uint64(rpt.Available) >= rpt.Bytes
+
+	return rpt, nil
+}
+
+// decommissionDryRunBucket walks bucket on the pool idx, as per
+// decommissionPool, adding the versions that cannot be moved to rpt.
+func (z *erasureServerPools) decommissionDryRunBucket(ctx context.Context, idx int, bucket string, rpt *decomDryRunReport) (brpt decomDryRunBucket, err error) {
+	brpt.Bucket = bucket
+
+	vc, _ := globalBucketVersioningSys.Get(bucket)
+	lc, _ := globalLifecycleSys.Get(bucket)
+	lr, _ := globalBucketObjectLockSys.Get(bucket)
+
+	expired := func(object string, fi FileInfo) bool {
+		if lc == nil {
+			return false
+		}
+		versioned := vc != nil && vc.Versioned(object)
+		evt := evalActionFromLifecycle(ctx, *lc, lr, fi.ToObjectInfo(bucket, object, versioned))
+		switch evt.Action {
+		case lifecycle.DeleteVersionAction, lifecycle.DeleteAction,
+			lifecycle.DeleteRestoredAction, lifecycle.DeleteRestoredVersionAction:
+			return true
+		}
+		return false
+	}
+
+	var mu sync.Mutex
+	unmovable := func(version FileInfo, reason string) {
+		brpt.Unmovable++
+		rpt.addUnmovable(decomDryRunObject{
+			Bucket:    bucket,
+			Object:    version.Name,
+			VersionID: version.VersionID,
+			Reason:    reason,
+		})
+	}
+
+	// visit accounts the entry found on drives of its erasure set.
+	visit := func(entry metaCacheEntry, drives int) {
+		if entry.isDir() {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		fivs, err := entry.fileInfoVersions(bucket)
+		if err != nil {
+			brpt.Unmovable++
+			rpt.addUnmovable(decomDryRunObject{
+				Bucket: bucket,
+				Object: decodeDirObject(entry.name),
+				Reason: fmt.Sprintf("unreadable metadata: %v", err),
+			})
+			return
+		}
+
+		var latest bool
+		for _, version := range fivs.Versions {
+			switch {
+			case version.IsRemote():
+				unmovable(version, "transitioned object")
+			case expired(version.Name, version):
+				brpt.Skipped++
+			case version.Deleted && len(fivs.Versions) == 1:
+				if lr.LockEnabled {
+					unmovable(version, "delete marker with no other versions in an object locked bucket")
+				} else {
+					brpt.Skipped++
+				}
+			case !version.Deleted && drives < version.Erasure.DataBlocks:
+				unmovable(version, fmt.Sprintf("read quorum not available, found on %d drives, %d needed", drives, version.Erasure.DataBlocks))
+			default:
+				brpt.Versions++
+				brpt.Bytes += uint64(version.Size)
+				latest = latest || version.IsLatest
+			}
+		}
+		if latest {
+			brpt.Objects++
+		}
+	}
+
+	var wg sync.WaitGroup
+	var errMu sync.Mutex
+	for _, set := range z.serverPools[idx].sets {
+		set := set
+		disks := set.getOnlineDisks()
+		if len(disks) == 0 {
+			return brpt, fmt.Errorf("no online drives found for set with endpoints %s", set.getEndpoints())
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// How to resolve partial results.
+			resolver := metadataResolutionParams{
+				dirQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+				objQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+				bucket:    bucket,
+			}
+			lerr := listPathRaw(ctx, listPathRawOptions{
+				disks:          disks,
+				bucket:         bucket,
+				recursive:      true,
+				minDisks:       len(disks) / 2, // to capture all quorum ratios
+				reportNotFound: false,
+				agreed: func(entry metaCacheEntry) {
+					visit(entry, len(disks))
+				},
+				partial: func(entries metaCacheEntries, _ []error) {
+					entry, ok := entries.resolve(&resolver)
+					if !ok {
+						return
+					}
+					var drives int
+					for _, e := range entries {
+						if e.name == entry.name {
+							drives++
+						}
+					}
+					visit(*entry, drives)
+				},
+				finished: nil,
+			})
+			if lerr != nil {
+				errMu.Lock()
+				err = lerr
+				errMu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return brpt, err
+}
@@ -251,6 +251,24 @@ var (
 )
 
 func (p *poolMeta) Decommission(idx int, pi poolSpaceInfo) error {
+	if err := p.canDecommission(idx); err != nil {
+		return err
+	}
+
+	now := UTCNow()
+	p.Pools[idx].LastUpdate = now
+	p.Pools[idx].Decommission = &PoolDecommissionInfo{
+		StartTime:   now,
+		StartSize:   pi.Free,
+		CurrentSize: pi.Free,
+		TotalSize:   pi.Total,
+	}
+	return nil
+}
+
+// canDecommission returns an error if the pool idx cannot be
+// decommissioned now.
+func (p poolMeta) canDecommission(idx int) error {
 	for i, pool := range p.Pools {
 		if idx == i {
 			continue
@@ -271,15 +289,6 @@ func (p *poolMeta) Decommission(idx int, pi poolSpaceInfo) error {
 		!p.Pools[idx].Decommission.Canceled {
 		return errDecommissionAlreadyRunning
 	}
-
-	now := UTCNow()
-	p.Pools[idx].LastUpdate = now
-	p.Pools[idx].Decommission = &PoolDecommissionInfo{
-		StartTime:   now,
-		StartSize:   pi.Free,
-		CurrentSize: pi.Free,
-		TotalSize:   pi.Total,
-	}
 	return nil
 }
 
@@ -511,6 +520,12 @@ func (z *erasureServerPools) Init(ctx context.Context) error {
 	// Start rebalance routine
 	z.StartRebalance()
 
+	// Resume the parity upgrade in progress, if any.
+	if err = z.loadParityUpgradeMeta(ctx); err != nil {
+		return fmt.Errorf("failed to load parity upgrade data: %w", err)
+	}
+	z.StartParityUpgrade()
+
 	meta := poolMeta{}
 
 	if err := meta.load(ctx, z.serverPools[0], z.serverPools); err != nil {
@@ -1234,6 +1249,14 @@ func (z *erasureServerPools) StartDecommission(ctx context.Context, idx int) (er
 		}
 	}
 
+	// Objects are only moved to the pools allowed by the pool affinity
+	// of their bucket.
+	for _, bucket := range decomBuckets {
+		if err := z.checkPoolAffinity(bucket.Name, getPoolAffinity(bucket.Name), idx); err != nil {
+			return decomError{Err: err.Error()}
+		}
+	}
+
 	// Create .minio.sys/conifg, .minio.sys/buckets paths if missing,
 	// this code is present to avoid any missing meta buckets on other
 	// pools.
@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"testing"
 )
@@ -194,3 +195,71 @@ func TestPoolMetaValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestDecommissionDryRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fsDirs, err := getRandomDisks(32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	pools := mustGetPoolEndpoints(fsDirs[:16]...)
+	pools = append(pools, mustGetPoolEndpoints(fsDirs[16:]...)...)
+	obj, _, err := initObjectLayer(ctx, pools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+
+	setObjectLayer(obj)
+	z := obj.(*erasureServerPools)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("a"), 1024)
+	for _, object := range []string{"object1", "object2", "prefix/object3"} {
+		_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Objects are spread over both pools, all of them are reported.
+	var versions, size uint64
+	for idx := range z.serverPools {
+		rpt, err := z.decommissionDryRun(ctx, idx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rpt.Blockers) != 0 || len(rpt.Unmovable) != 0 {
+			t.Fatalf("pool %d: unexpected blockers %v or unmovable objects %v", idx, rpt.Blockers, rpt.Unmovable)
+		}
+		if len(rpt.Pools) != 1 || rpt.Pools[0].Pool == idx {
+			t.Fatalf("pool %d: expected the other pool to receive the objects, got %v", idx, rpt.Pools)
+		}
+		if !rpt.Sufficient {
+			t.Fatalf("pool %d: expected sufficient capacity, got %v", idx, rpt)
+		}
+		versions += rpt.Versions
+		size += rpt.Bytes
+	}
+	if versions != 3 || size != 3*uint64(len(data)) {
+		t.Fatalf("expected 3 versions and %d bytes, got %d versions and %d bytes", 3*len(data), versions, size)
+	}
+
+	// Nothing was moved.
+	if z.IsSuspended(0) || z.IsSuspended(1) {
+		t.Fatal("expected no pool to be suspended")
+	}
+	for _, object := range []string{"object1", "object2", "prefix/object3"} {
+		if _, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
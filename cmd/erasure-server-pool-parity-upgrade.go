@@ -0,0 +1,607 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/env"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+
+// parityUpgradeStatus is the state of a parity upgrade.
+type parityUpgradeStatus string
+
+const (
+	parityUpgradeStarted   parityUpgradeStatus = "started"
+	parityUpgradeCompleted parityUpgradeStatus = "completed"
+	parityUpgradeStopped   parityUpgradeStatus = "stopped"
+	parityUpgradeFailed    parityUpgradeStatus = "failed"
+)
+
+// parityUpgradeMeta contains information pertaining to a parity upgrade,
+// which re-encodes the objects with a parity below the parity of their
+// storage class.
+type parityUpgradeMeta struct {
+	cancel context.CancelFunc `msg:"-"` // to be invoked on parity-upgrade-stop
+
+	ID        string              `json:"id" msg:"id"`
+	Status    parityUpgradeStatus `json:"status" msg:"s"`
+	StartTime time.Time           `json:"startTime" msg:"st"`
+	EndTime   time.Time           `json:"endTime,omitempty" msg:"et"`
+
+	Buckets         []string `json:"buckets" msg:"bus"`         // buckets to be upgraded
+	UpgradedBuckets []string `json:"upgradedBuckets" msg:"ubs"` // buckets upgraded
+	Bucket          string   `json:"bucket" msg:"bu"`           // Last upgraded bucket
+	Object          string   `json:"object" msg:"ob"`           // Last upgraded object
+
+	Scanned  uint64 `json:"scanned" msg:"sc"`  // Number of versions checked
+	Upgraded uint64 `json:"upgraded" msg:"up"` // Number of versions re-encoded
+	Failed   uint64 `json:"failed" msg:"fa"`   // Number of versions failed to be re-encoded
+	Bytes    uint64 `json:"bytes" msg:"bs"`    // Number of bytes re-encoded
+}
+
+const (
+	parityUpgradeMetaName = "parity-upgrade.bin"
+	parityUpgradeMetaFmt  = 1
+	parityUpgradeMetaVer  = 1
+
+	parityUpgradeSaveInterval = 10 * time.Second
+)
+
+var (
+	errParityUpgradeNotStarted     = errors.New("parity upgrade not started")
+	errParityUpgradeAlreadyRunning = errors.New("parity upgrade is already in progress")
+	errParityUpgradeNotAllowed     = errors.New("parity upgrade cannot be started, decommission or rebalance is in progress")
+)
+
+func (p *parityUpgradeMeta) load(ctx context.Context, store objectIO) error {
+	data, err := readConfig(ctx, store, parityUpgradeMetaName)
+	if err != nil {
+		return err
+	}
+	if len(data) <= 4 {
+		return fmt.Errorf("parityUpgradeMeta: no data")
+	}
+
+	// Read header
+	switch binary.LittleEndian.Uint16(data[0:2]) {
+	case parityUpgradeMetaFmt:
+	default:
+		return fmt.Errorf("parityUpgradeMeta: unknown format: %d", binary.LittleEndian.Uint16(data[0:2]))
+	}
+	switch binary.LittleEndian.Uint16(data[2:4]) {
+	case parityUpgradeMetaVer:
+	default:
+		return fmt.Errorf("parityUpgradeMeta: unknown version: %d", binary.LittleEndian.Uint16(data[2:4]))
+	}
+
+	// OK, parse data.
+	_, err = p.UnmarshalMsg(data[4:])
+	return err
+}
+
+func (p *parityUpgradeMeta) save(ctx context.Context, store objectIO) error {
+	data := make([]byte, 4, p.Msgsize()+4)
+
+	// Initialize the header.
+	binary.LittleEndian.PutUint16(data[0:2], parityUpgradeMetaFmt)
+	binary.LittleEndian.PutUint16(data[2:4], parityUpgradeMetaVer)
+
+	buf, err := p.MarshalMsg(data)
+	if err != nil {
+		return err
+	}
+
+	return saveConfig(ctx, store, parityUpgradeMetaName, buf)
+}
+
+func (p *parityUpgradeMeta) clone() parityUpgradeMeta {
+	c := *p
+	c.cancel = nil
+	c.Buckets = append([]string(nil), p.Buckets...)
+	c.UpgradedBuckets = append([]string(nil), p.UpgradedBuckets...)
+	return c
+}
+
+// parityUpgradeLeader returns whether this node runs the parity upgrades,
+// i.e the first node of the first pool.
+func parityUpgradeLeader() bool {
+	return len(globalEndpoints) > 0 && globalEndpoints[0].Endpoints[0].IsLocal
+}
+
+// loadParityUpgradeMeta loads the last parity upgrade, if any.
+func (z *erasureServerPools) loadParityUpgradeMeta(ctx context.Context) error {
+	p := &parityUpgradeMeta{}
+	if err := p.load(ctx, z.serverPools[0]); err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	z.parityMu.Lock()
+	z.parityMeta = p
+	z.parityMu.Unlock()
+	return nil
+}
+
+// IsParityUpgradeRunning returns whether a parity upgrade is in progress.
+func (z *erasureServerPools) IsParityUpgradeRunning() bool {
+	z.parityMu.RLock()
+	defer z.parityMu.RUnlock()
+	return z.parityMeta != nil && z.parityMeta.Status == parityUpgradeStarted
+}
+
+// initParityUpgradeMeta initializes the metadata of a new parity upgrade of
+// the buckets and saves it in the object store.
+func (z *erasureServerPools) initParityUpgradeMeta(ctx context.Context, buckets []string) (id string, err error) {
+	if z.IsParityUpgradeRunning() {
+		return "", errParityUpgradeAlreadyRunning
+	}
+	if z.IsDecommissionRunning() || z.IsRebalanceStarted() {
+		return "", errParityUpgradeNotAllowed
+	}
+
+	p := &parityUpgradeMeta{
+		ID:              shortuuid.New(),
+		Status:          parityUpgradeStarted,
+		StartTime:       UTCNow(),
+		Buckets:         buckets,
+		UpgradedBuckets: make([]string, 0, len(buckets)),
+	}
+	if err = p.save(ctx, z.serverPools[0]); err != nil {
+		return "", err
+	}
+
+	z.parityMu.Lock()
+	z.parityMeta = p
+	z.parityMu.Unlock()
+	return p.ID, nil
+}
+
+// parityUpgradeStatus returns the progress of the last parity upgrade.
+func (z *erasureServerPools) parityUpgradeStatus() (parityUpgradeMeta, error) {
+	z.parityMu.RLock()
+	defer z.parityMu.RUnlock()
+	if z.parityMeta == nil {
+		return parityUpgradeMeta{}, errParityUpgradeNotStarted
+	}
+	return z.parityMeta.clone(), nil
+}
+
+func (z *erasureServerPools) saveParityUpgradeMeta(ctx context.Context) error {
+	z.parityMu.RLock()
+	p := z.parityMeta.clone()
+	z.parityMu.RUnlock()
+	return p.save(ctx, z.serverPools[0])
+}
+
+func (z *erasureServerPools) nextParityUpgradeBucket() (string, bool) {
+	z.parityMu.RLock()
+	defer z.parityMu.RUnlock()
+
+	p := z.parityMeta
+	if p == nil || len(p.Buckets) == 0 {
+		return "", false
+	}
+	return p.Buckets[0], true
+}
+
+func (z *erasureServerPools) bucketParityUpgradeDone(bucket string) {
+	z.parityMu.Lock()
+	defer z.parityMu.Unlock()
+
+	p := z.parityMeta
+	for i, b := range p.Buckets {
+		if b == bucket {
+			p.Buckets = append(p.Buckets[:i], p.Buckets[i+1:]...)
+			p.UpgradedBuckets = append(p.UpgradedBuckets, bucket)
+			break
+		}
+	}
+}
+
+func (z *erasureServerPools) updateParityUpgradeStats(bucket string, fi FileInfo, upgraded bool, err error) {
+	z.parityMu.Lock()
+	defer z.parityMu.Unlock()
+
+	p := z.parityMeta
+	p.Scanned++
+	switch {
+	case err != nil:
+		p.Failed++
+	case upgraded:
+		p.Upgraded++
+		p.Bytes += uint64(fi.Size)
+		p.Bucket = bucket
+		p.Object = fi.Name
+	}
+}
+
+func (z *erasureServerPools) setParityUpgradeStatus(status parityUpgradeStatus) {
+	z.parityMu.Lock()
+	defer z.parityMu.Unlock()
+
+	z.parityMeta.Status = status
+	z.parityMeta.EndTime = UTCNow()
+	z.parityMeta.cancel = nil
+}
+
+// StartParityUpgrade starts the parity upgrade in progress, on the leader
+// node only. It is resumed from the first bucket not yet upgraded, objects
+// already upgraded are skipped.
+func (z *erasureServerPools) StartParityUpgrade() {
+	if !parityUpgradeLeader() {
+		return
+	}
+
+	z.parityMu.Lock()
+	p := z.parityMeta
+	if p == nil || p.Status != parityUpgradeStarted || p.cancel != nil {
+		z.parityMu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(GlobalContext)
+	p.cancel = cancel // to be used when parity-upgrade-stop is called
+	z.parityMu.Unlock()
+
+	go z.upgradeParityBuckets(ctx)
+}
+
+// StopParityUpgrade stops the parity upgrade in progress.
+func (z *erasureServerPools) StopParityUpgrade() error {
+	z.parityMu.Lock()
+	p := z.parityMeta
+	if p == nil || p.Status != parityUpgradeStarted {
+		z.parityMu.Unlock()
+		return errParityUpgradeNotStarted
+	}
+	cancel := p.cancel
+	z.parityMu.Unlock()
+
+	if cancel != nil {
+		// The upgrade routine saves its state when canceled.
+		cancel()
+		return nil
+	}
+	z.setParityUpgradeStatus(parityUpgradeStopped)
+	return z.saveParityUpgradeMeta(GlobalContext)
+}
+
+func (z *erasureServerPools) upgradeParityBuckets(ctx context.Context) {
+	ctx = logger.SetReqInfo(ctx, &logger.ReqInfo{})
+
+	doneCh := make(chan error, 1)
+	go func() {
+		doneCh <- z.upgradeParityAllBuckets(ctx)
+	}()
+
+	ticker := time.NewTicker(parityUpgradeSaveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			logger.LogIf(ctx, z.saveParityUpgradeMeta(ctx))
+			continue
+		case err := <-doneCh:
+			switch {
+			case ctx.Err() != nil:
+				z.setParityUpgradeStatus(parityUpgradeStopped)
+			case err != nil:
+				logger.LogIf(ctx, err)
+				z.setParityUpgradeStatus(parityUpgradeFailed)
+			default:
+				z.setParityUpgradeStatus(parityUpgradeCompleted)
+			}
+		}
+		// ctx may be canceled.
+		logger.LogIf(GlobalContext, z.saveParityUpgradeMeta(GlobalContext))
+		return
+	}
+}
+
+func (z *erasureServerPools) upgradeParityAllBuckets(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		bucket, ok := z.nextParityUpgradeBucket()
+		if !ok {
+			return nil
+		}
+		if err := z.upgradeParityBucket(ctx, bucket); err != nil {
+			return err
+		}
+		if ctx.Err() == nil {
+			z.bucketParityUpgradeDone(bucket)
+		}
+	}
+}
+
+// upgradeParityBucket re-encodes the objects of the bucket with a parity
+// below the parity of their storage class.
+func (z *erasureServerPools) upgradeParityBucket(ctx context.Context, bucket string) error {
+	for _, pool := range z.serverPools {
+		const envParityUpgradeWorkers = "_MINIO_PARITY_UPGRADE_WORKERS"
+		wStr := env.Get(envParityUpgradeWorkers, strconv.Itoa(len(pool.sets)))
+		workerSize, err := strconv.Atoi(wStr)
+		if err != nil || workerSize <= 0 {
+			logger.LogIf(ctx, fmt.Errorf("invalid %s value: %s err: %v, defaulting to %d", envParityUpgradeWorkers, wStr, err, len(pool.sets)))
+			workerSize = len(pool.sets)
+		}
+		workers := make(chan struct{}, workerSize)
+		var wg sync.WaitGroup
+		for _, set := range pool.sets {
+			set := set
+			disks := set.getOnlineDisks()
+			if len(disks) == 0 {
+				logger.LogIf(ctx, fmt.Errorf("no online disks found for set with endpoints %s",
+					set.getEndpoints()))
+				continue
+			}
+
+			upgradeEntry := func(entry metaCacheEntry) {
+				defer func() {
+					<-workers
+					wg.Done()
+				}()
+
+				if entry.isDir() {
+					return
+				}
+				fivs, err := entry.fileInfoVersions(bucket)
+				if err != nil {
+					return
+				}
+				for _, version := range fivs.Versions {
+					if ctx.Err() != nil {
+						return
+					}
+					// Delete markers have no data, transitioned
+					// objects have no data on the drives.
+					if version.Deleted || version.IsRemote() {
+						continue
+					}
+					var upgraded bool
+					var err error
+					if version.Erasure.ParityBlocks < set.storageClassParity(version.Metadata[xhttp.AmzStorageClass]) {
+						// Wait and proceed if there are active requests
+						waitForLowHTTPReq()
+						upgraded, err = set.upgradeObjectParity(ctx, bucket, version)
+						logger.LogIf(ctx, err)
+					}
+					z.updateParityUpgradeStats(bucket, version, upgraded, err)
+				}
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				// How to resolve partial results.
+				resolver := metadataResolutionParams{
+					dirQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+					objQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+					bucket:    bucket,
+				}
+				err := listPathRaw(ctx, listPathRawOptions{
+					disks:          disks,
+					bucket:         bucket,
+					recursive:      true,
+					minDisks:       len(disks) / 2, // to capture all quorum ratios
+					reportNotFound: false,
+					agreed: func(entry metaCacheEntry) {
+						workers <- struct{}{}
+						wg.Add(1)
+						go upgradeEntry(entry)
+					},
+					partial: func(entries metaCacheEntries, _ []error) {
+						entry, ok := entries.resolve(&resolver)
+						if ok {
+							workers <- struct{}{}
+							wg.Add(1)
+							go upgradeEntry(*entry)
+						}
+					},
+					finished: nil,
+				})
+				if err != nil && ctx.Err() == nil {
+					logger.LogIf(ctx, err)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+	return nil
+}
+
+// storageClassParity returns the parity of the objects of the storage
+// class written to this erasure set.
+func (er erasureObjects) storageClassParity(sc string) int {
+	parity := globalStorageClass.GetParityForSC(sc)
+	if parity < 0 {
+		parity = er.defaultParityCount
+	}
+	if parity > er.setDriveCount/2 {
+		parity = er.setDriveCount / 2
+	}
+	return parity
+}
+
+// upgradeObjectParity re-encodes the object version with the parity of its
+// storage class. The object is locked while it is re-encoded, it is skipped
+// if it was modified meanwhile.
+func (er erasureObjects) upgradeObjectParity(ctx context.Context, bucket string, version FileInfo) (upgraded bool, err error) {
+	object := encodeDirObject(version.Name)
+	lk := er.NewNSLock(bucket, object)
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return false, err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	versionID := version.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	fi, _, _, err := er.getObjectFileInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID, NoLock: true}, false)
+	if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+		// object deleted by the application, nothing to do here we move on.
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !fi.ModTime.Equal(version.ModTime) || fi.Deleted || fi.IsRemote() ||
+		fi.Erasure.ParityBlocks >= er.storageClassParity(fi.Metadata[xhttp.AmzStorageClass]) {
+		return false, nil
+	}
+
+	if err = er.reencodeObject(ctx, bucket, object, fi, nil); err != nil {
+		return false, fmt.Errorf("upgradeObjectParity: %w", err)
+	}
+	return true, nil
+}
+
+// reencodeObject re-encodes the locked object version fi through the regular
+// write path, with its metadata updated by updateFn if any: the new data dir
+// replaces the previous one of the version in `xl.meta`. The data is erasure
+// coded with the parity of the storage class of the updated metadata, the
+// ETag, modification time and version ID are preserved.
+func (er erasureObjects) reencodeObject(ctx context.Context, bucket, object string, fi FileInfo, updateFn func(userDefined map[string]string)) error {
+	versionID := fi.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	gr, err := er.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, noLock, ObjectOptions{
+		VersionID:    versionID,
+		NoDecryption: true,
+		NoLock:       true,
+	})
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+	oi := gr.ObjInfo
+
+	userDefined := cloneMSS(oi.UserDefined)
+	// set again if the parity is upgraded because of offline drives.
+	delete(userDefined, minIOErasureUpgraded)
+	if oi.ReplicationStatusInternal != "" {
+		userDefined[ReservedMetadataPrefixLower+ReplicationStatus] = oi.ReplicationStatusInternal
+	}
+	if updateFn != nil {
+		updateFn(userDefined)
+	}
+
+	if oi.isMultipart() {
+		res, err := er.NewMultipartUpload(ctx, bucket, object, ObjectOptions{
+			VersionID:   fi.VersionID,
+			MTime:       oi.ModTime,
+			UserDefined: userDefined,
+		})
+		if err != nil {
+			return fmt.Errorf("NewMultipartUpload() %w", err)
+		}
+		defer er.AbortMultipartUpload(ctx, bucket, object, res.UploadID, ObjectOptions{})
+
+		parts := make([]CompletePart, len(oi.Parts))
+		for i, part := range oi.Parts {
+			hr, err := hash.NewReader(gr, part.Size, "", "", part.ActualSize)
+			if err != nil {
+				return fmt.Errorf("hash.NewReader() %w", err)
+			}
+			pi, err := er.PutObjectPart(ctx, bucket, object, res.UploadID,
+				part.Number,
+				NewPutObjReader(hr),
+				ObjectOptions{
+					PreserveETag: part.ETag, // Preserve original ETag to ensure same metadata.
+					IndexCB: func() []byte {
+						return part.Index // Preserve part Index to ensure decompression works.
+					},
+				})
+			if err != nil {
+				return fmt.Errorf("PutObjectPart() %w", err)
+			}
+			parts[i] = CompletePart{
+				ETag:       pi.ETag,
+				PartNumber: pi.PartNumber,
+			}
+		}
+		_, err = er.CompleteMultipartUpload(ctx, bucket, object, res.UploadID, parts, ObjectOptions{
+			MTime:  oi.ModTime,
+			NoLock: true, // already locked
+		})
+		if err != nil {
+			return fmt.Errorf("CompleteMultipartUpload() %w", err)
+		}
+		return nil
+	}
+
+	actualSize, err := oi.GetActualSize()
+	if err != nil {
+		return err
+	}
+	hr, err := hash.NewReader(gr, oi.Size, "", "", actualSize)
+	if err != nil {
+		return fmt.Errorf("hash.NewReader() %w", err)
+	}
+	_, err = er.PutObject(ctx, bucket, object, NewPutObjReader(hr), ObjectOptions{
+		VersionID:    fi.VersionID,
+		MTime:        oi.ModTime,
+		UserDefined:  userDefined,
+		PreserveETag: oi.ETag, // Preserve original ETag to ensure same metadata.
+		IndexCB: func() []byte {
+			return oi.Parts[0].Index // Preserve part Index to ensure decompression works.
+		},
+		NoLock: true, // already locked
+	})
+	if err != nil {
+		return fmt.Errorf("PutObject() %w", err)
+	}
+	return nil
+}
+
+// parityUpgradeBuckets returns the buckets to be upgraded.
+func parityUpgradeBuckets(ctx context.Context, objAPI ObjectLayer) ([]string, error) {
+	bucketInfos, err := objAPI.ListBuckets(ctx, BucketOptions{})
+	if err != nil {
+		return nil, err
+	}
+	buckets := make([]string, 0, len(bucketInfos))
+	for _, bi := range bucketInfos {
+		buckets = append(buckets, bi.Name)
+	}
+	sort.Strings(buckets)
+	return buckets, nil
+}
@@ -0,0 +1,525 @@
+package cmd
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// DecodeMsg implements msgp.Decodable
+func (z *parityUpgradeMeta) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "id":
+			z.ID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "s":
+			{
+				var zb0002 string
+				zb0002, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Status")
+					return
+				}
+				z.Status = parityUpgradeStatus(zb0002)
+			}
+		case "st":
+			z.StartTime, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "StartTime")
+				return
+			}
+		case "et":
+			z.EndTime, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "EndTime")
+				return
+			}
+		case "bus":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Buckets")
+				return
+			}
+			if cap(z.Buckets) >= int(zb0003) {
+				z.Buckets = (z.Buckets)[:zb0003]
+			} else {
+				z.Buckets = make([]string, zb0003)
+			}
+			for za0001 := range z.Buckets {
+				z.Buckets[za0001], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Buckets", za0001)
+					return
+				}
+			}
+		case "ubs":
+			var zb0004 uint32
+			zb0004, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "UpgradedBuckets")
+				return
+			}
+			if cap(z.UpgradedBuckets) >= int(zb0004) {
+				z.UpgradedBuckets = (z.UpgradedBuckets)[:zb0004]
+			} else {
+				z.UpgradedBuckets = make([]string, zb0004)
+			}
+			for za0002 := range z.UpgradedBuckets {
+				z.UpgradedBuckets[za0002], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "UpgradedBuckets", za0002)
+					return
+				}
+			}
+		case "bu":
+			z.Bucket, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Bucket")
+				return
+			}
+		case "ob":
+			z.Object, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Object")
+				return
+			}
+		case "sc":
+			z.Scanned, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Scanned")
+				return
+			}
+		case "up":
+			z.Upgraded, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Upgraded")
+				return
+			}
+		case "fa":
+			z.Failed, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Failed")
+				return
+			}
+		case "bs":
+			z.Bytes, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Bytes")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *parityUpgradeMeta) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 12
+	// write "id"
+	err = en.Append(0x8c, 0xa2, 0x69, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.ID)
+	if err != nil {
+		err = msgp.WrapError(err, "ID")
+		return
+	}
+	// write "s"
+	err = en.Append(0xa1, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(string(z.Status))
+	if err != nil {
+		err = msgp.WrapError(err, "Status")
+		return
+	}
+	// write "st"
+	err = en.Append(0xa2, 0x73, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.StartTime)
+	if err != nil {
+		err = msgp.WrapError(err, "StartTime")
+		return
+	}
+	// write "et"
+	err = en.Append(0xa2, 0x65, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.EndTime)
+	if err != nil {
+		err = msgp.WrapError(err, "EndTime")
+		return
+	}
+	// write "bus"
+	err = en.Append(0xa3, 0x62, 0x75, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Buckets)))
+	if err != nil {
+		err = msgp.WrapError(err, "Buckets")
+		return
+	}
+	for za0001 := range z.Buckets {
+		err = en.WriteString(z.Buckets[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "Buckets", za0001)
+			return
+		}
+	}
+	// write "ubs"
+	err = en.Append(0xa3, 0x75, 0x62, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.UpgradedBuckets)))
+	if err != nil {
+		err = msgp.WrapError(err, "UpgradedBuckets")
+		return
+	}
+	for za0002 := range z.UpgradedBuckets {
+		err = en.WriteString(z.UpgradedBuckets[za0002])
+		if err != nil {
+			err = msgp.WrapError(err, "UpgradedBuckets", za0002)
+			return
+		}
+	}
+	// write "bu"
+	err = en.Append(0xa2, 0x62, 0x75)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Bucket)
+	if err != nil {
+		err = msgp.WrapError(err, "Bucket")
+		return
+	}
+	// write "ob"
+	err = en.Append(0xa2, 0x6f, 0x62)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Object)
+	if err != nil {
+		err = msgp.WrapError(err, "Object")
+		return
+	}
+	// write "sc"
+	err = en.Append(0xa2, 0x73, 0x63)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Scanned)
+	if err != nil {
+		err = msgp.WrapError(err, "Scanned")
+		return
+	}
+	// write "up"
+	err = en.Append(0xa2, 0x75, 0x70)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Upgraded)
+	if err != nil {
+		err = msgp.WrapError(err, "Upgraded")
+		return
+	}
+	// write "fa"
+	err = en.Append(0xa2, 0x66, 0x61)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Failed)
+	if err != nil {
+		err = msgp.WrapError(err, "Failed")
+		return
+	}
+	// write "bs"
+	err = en.Append(0xa2, 0x62, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Bytes)
+	if err != nil {
+		err = msgp.WrapError(err, "Bytes")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *parityUpgradeMeta) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 12
+	// string "id"
+	o = append(o, 0x8c, 0xa2, 0x69, 0x64)
+	o = msgp.AppendString(o, z.ID)
+	// string "s"
+	o = append(o, 0xa1, 0x73)
+	o = msgp.AppendString(o, string(z.Status))
+	// string "st"
+	o = append(o, 0xa2, 0x73, 0x74)
+	o = msgp.AppendTime(o, z.StartTime)
+	// string "et"
+	o = append(o, 0xa2, 0x65, 0x74)
+	o = msgp.AppendTime(o, z.EndTime)
+	// string "bus"
+	o = append(o, 0xa3, 0x62, 0x75, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Buckets)))
+	for za0001 := range z.Buckets {
+		o = msgp.AppendString(o, z.Buckets[za0001])
+	}
+	// string "ubs"
+	o = append(o, 0xa3, 0x75, 0x62, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.UpgradedBuckets)))
+	for za0002 := range z.UpgradedBuckets {
+		o = msgp.AppendString(o, z.UpgradedBuckets[za0002])
+	}
+	// string "bu"
+	o = append(o, 0xa2, 0x62, 0x75)
+	o = msgp.AppendString(o, z.Bucket)
+	// string "ob"
+	o = append(o, 0xa2, 0x6f, 0x62)
+	o = msgp.AppendString(o, z.Object)
+	// string "sc"
+	o = append(o, 0xa2, 0x73, 0x63)
+	o = msgp.AppendUint64(o, z.Scanned)
+	// string "up"
+	o = append(o, 0xa2, 0x75, 0x70)
+	o = msgp.AppendUint64(o, z.Upgraded)
+	// string "fa"
+	o = append(o, 0xa2, 0x66, 0x61)
+	o = msgp.AppendUint64(o, z.Failed)
+	// string "bs"
+	o = append(o, 0xa2, 0x62, 0x73)
+	o = msgp.AppendUint64(o, z.Bytes)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *parityUpgradeMeta) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "id":
+			z.ID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "s":
+			{
+				var zb0002 string
+				zb0002, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Status")
+					return
+				}
+				z.Status = parityUpgradeStatus(zb0002)
+			}
+		case "st":
+			z.StartTime, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "StartTime")
+				return
+			}
+		case "et":
+			z.EndTime, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EndTime")
+				return
+			}
+		case "bus":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Buckets")
+				return
+			}
+			if cap(z.Buckets) >= int(zb0003) {
+				z.Buckets = (z.Buckets)[:zb0003]
+			} else {
+				z.Buckets = make([]string, zb0003)
+			}
+			for za0001 := range z.Buckets {
+				z.Buckets[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Buckets", za0001)
+					return
+				}
+			}
+		case "ubs":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UpgradedBuckets")
+				return
+			}
+			if cap(z.UpgradedBuckets) >= int(zb0004) {
+				z.UpgradedBuckets = (z.UpgradedBuckets)[:zb0004]
+			} else {
+				z.UpgradedBuckets = make([]string, zb0004)
+			}
+			for za0002 := range z.UpgradedBuckets {
+				z.UpgradedBuckets[za0002], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "UpgradedBuckets", za0002)
+					return
+				}
+			}
+		case "bu":
+			z.Bucket, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Bucket")
+				return
+			}
+		case "ob":
+			z.Object, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Object")
+				return
+			}
+		case "sc":
+			z.Scanned, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Scanned")
+				return
+			}
+		case "up":
+			z.Upgraded, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Upgraded")
+				return
+			}
+		case "fa":
+			z.Failed, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Failed")
+				return
+			}
+		case "bs":
+			z.Bytes, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Bytes")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *parityUpgradeMeta) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 2 + msgp.StringPrefixSize + len(string(z.Status)) + 3 + msgp.TimeSize + 3 + msgp.TimeSize + 4 + msgp.ArrayHeaderSize
+	for za0001 := range z.Buckets {
+		s += msgp.StringPrefixSize + len(z.Buckets[za0001])
+	}
+	s += 4 + msgp.ArrayHeaderSize
+	for za0002 := range z.UpgradedBuckets {
+		s += msgp.StringPrefixSize + len(z.UpgradedBuckets[za0002])
+	}
+	s += 3 + msgp.StringPrefixSize + len(z.Bucket) + 3 + msgp.StringPrefixSize + len(z.Object) + 3 + msgp.Uint64Size + 3 + msgp.Uint64Size + 3 + msgp.Uint64Size + 3 + msgp.Uint64Size
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *parityUpgradeStatus) DecodeMsg(dc *msgp.Reader) (err error) {
+	{
+		var zb0001 string
+		zb0001, err = dc.ReadString()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		(*z) = parityUpgradeStatus(zb0001)
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z parityUpgradeStatus) EncodeMsg(en *msgp.Writer) (err error) {
+	err = en.WriteString(string(z))
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z parityUpgradeStatus) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	o = msgp.AppendString(o, string(z))
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *parityUpgradeStatus) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	{
+		var zb0001 string
+		zb0001, bts, err = msgp.ReadStringBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		(*z) = parityUpgradeStatus(zb0001)
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z parityUpgradeStatus) Msgsize() (s int) {
+	s = msgp.StringPrefixSize + len(string(z))
+	return
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/minio/minio/internal/config/storageclass"
+)
+
+func TestParityUpgrade(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	setObjectLayer(obj)
+
+	z := obj.(*erasureServerPools)
+	set := z.serverPools[0].sets[0]
+
+	defer func(sc storageclass.Config) {
+		globalStorageClass = sc
+	}(globalStorageClass)
+	globalStorageClass = storageclass.Config{
+		Standard: storageclass.StorageClass{
+			Parity: 2,
+		},
+	}
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("a"), 6*1024*1024)
+	objects := map[string]ObjectInfo{}
+	oi, err := obj.PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects[oi.Name] = oi
+
+	res, err := obj.NewMultipartUpload(ctx, bucket, "multipart", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parts []CompletePart
+	for i := 1; i <= 2; i++ {
+		pi, err := obj.PutObjectPart(ctx, bucket, "multipart", res.UploadID, i, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, CompletePart{PartNumber: pi.PartNumber, ETag: pi.ETag})
+	}
+	if oi, err = obj.CompleteMultipartUpload(ctx, bucket, "multipart", res.UploadID, parts, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	objects[oi.Name] = oi
+
+	// Parity is raised after the objects were written.
+	globalStorageClass = storageclass.Config{
+		Standard: storageclass.StorageClass{
+			Parity: 6,
+		},
+	}
+
+	if _, err = z.initParityUpgradeMeta(ctx, []string{bucket}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.initParityUpgradeMeta(ctx, []string{bucket}); err != errParityUpgradeAlreadyRunning {
+		t.Fatalf("Expected %v, got %v", errParityUpgradeAlreadyRunning, err)
+	}
+	if err = z.upgradeParityAllBuckets(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := z.parityUpgradeStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Scanned != 2 || status.Upgraded != 2 || status.Failed != 0 ||
+		len(status.Buckets) != 0 || len(status.UpgradedBuckets) != 1 {
+		t.Fatalf("Unexpected parity upgrade status %#v", status)
+	}
+
+	for name, oi := range objects {
+		fi, _, _, err := set.getObjectFileInfo(ctx, bucket, name, ObjectOptions{}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Erasure.ParityBlocks != 6 {
+			t.Errorf("%s: expected parity 6, got %d", name, fi.Erasure.ParityBlocks)
+		}
+		if !fi.ModTime.Equal(oi.ModTime) || fi.Size != oi.Size {
+			t.Errorf("%s: object changed by parity upgrade", name)
+		}
+
+		gr, err := obj.GetObjectNInfo(ctx, bucket, name, nil, http.Header{}, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gr.ObjInfo.ETag != oi.ETag || int64(len(got)) != oi.Size || !bytes.Equal(got[:len(data)], data) {
+			t.Errorf("%s: content changed by parity upgrade", name)
+		}
+	}
+
+	// Upgraded objects are skipped when the upgrade is resumed.
+	z.parityMeta.Buckets = []string{bucket}
+	if err = z.upgradeParityAllBuckets(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if status, _ = z.parityUpgradeStatus(); status.Upgraded != 2 || status.Scanned != 4 {
+		t.Fatalf("Unexpected parity upgrade status after resume %#v", status)
+	}
+}
@@ -22,12 +22,14 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/rand"
 	"net/http"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/lithammer/shortuuid/v4"
@@ -35,7 +37,9 @@ import (
 	"github.com/minio/minio/internal/bucket/lifecycle"
 	"github.com/minio/minio/internal/hash"
 	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/schedule"
 	"github.com/minio/pkg/env"
+	"golang.org/x/time/rate"
 )
 
 //go:generate msgp -file $GOFILE -unexported
@@ -87,17 +91,68 @@ type rebalanceInfo struct {
 	Status    rebalStatus `msg:"status"`  // Current state of rebalance operation. One of Started|Stopped|Completed|Failed.
 }
 
+// rebalanceOpts contains the options of a rebalance operation.
+type rebalanceOpts struct {
+	Buckets   []string `json:"buckets,omitempty" msg:"bu"`   // Buckets to rebalance, all buckets if empty
+	Pools     []int    `json:"pools,omitempty" msg:"po"`     // Pools to move data out of, all pools below the free space goal if empty
+	Threshold float64  `json:"threshold,omitempty" msg:"th"` // Distance from the free space goal at which a pool is rebalanced, 0.05 if zero
+	Windows   string   `json:"windows,omitempty" msg:"win"`  // Maintenance windows rebalance runs in, e.g. "mon-fri 22:00-06:00,sat-sun", always if empty
+	Bandwidth uint64   `json:"bandwidth,omitempty" msg:"bw"` // Bytes per second read by each rebalancing pool, unlimited if zero
+	IOPS      uint64   `json:"iops,omitempty" msg:"iops"`    // Object versions per second moved by each rebalancing pool, unlimited if zero
+}
+
+// validate validates the options against the number of pools.
+func (o rebalanceOpts) validate(pools int) error {
+	for _, idx := range o.Pools {
+		if idx < 0 || idx >= pools {
+			return fmt.Errorf("invalid pool %d, %d pools are available", idx, pools)
+		}
+	}
+	if o.Threshold < 0 || o.Threshold >= 1 {
+		return fmt.Errorf("invalid threshold %v, must be between 0 and 1", o.Threshold)
+	}
+	_, err := schedule.Parse(o.Windows)
+	return err
+}
+
+// includesPool returns true if data can be moved out of the pool idx.
+func (o rebalanceOpts) includesPool(idx int) bool {
+	if len(o.Pools) == 0 {
+		return true
+	}
+	for _, pool := range o.Pools {
+		if pool == idx {
+			return true
+		}
+	}
+	return false
+}
+
 // rebalanceMeta contains information pertaining to an ongoing rebalance operation.
 type rebalanceMeta struct {
 	cancel          context.CancelFunc `msg:"-"` // to be invoked on rebalance-stop
 	lastRefreshedAt time.Time          `msg:"-"`
+	windows         schedule.Windows   `msg:"-"`      // Parsed from Opts.Windows
 	StoppedAt       time.Time          `msg:"stopTs"` // Time when rebalance-stop was issued.
 	ID              string             `msg:"id"`     // ID of the ongoing rebalance operation
 	PercentFreeGoal float64            `msg:"pf"`     // Computed from total free space and capacity at the start of rebalance
 	PoolStats       []*rebalanceStats  `msg:"rss"`    // Per-pool rebalance stats keyed by pool index
+	Opts            rebalanceOpts      `msg:"opts"`   // Options of the rebalance operation
 }
 
-var errRebalanceNotStarted = errors.New("rebalance not started")
+// threshold returns the distance from the free space goal at which a pool
+// is considered rebalanced.
+func (r *rebalanceMeta) threshold() float64 {
+	if r.Opts.Threshold > 0 {
+		return r.Opts.Threshold
+	}
+	return 0.05
+}
+
+var (
+	errRebalanceNotStarted   = errors.New("rebalance not started")
+	errRebalanceWindowClosed = errors.New("rebalance maintenance window closed")
+)
 
 //加载reblance信息, 从rebalance.bin配置中.
 func (z *erasureServerPools) loadRebalanceMeta(ctx context.Context) error {
@@ -119,10 +174,16 @@ func (z *erasureServerPools) loadRebalanceMeta(ctx context.Context) error {
 
 // initRebalanceMeta initializes rebalance metadata for a new rebalance
 // operation and saves it in the object store.
-func (z *erasureServerPools) initRebalanceMeta(ctx context.Context, buckets []string) (arn string, err error) {
+func (z *erasureServerPools) initRebalanceMeta(ctx context.Context, buckets []string, opts rebalanceOpts) (arn string, err error) {
+	windows, err := schedule.Parse(opts.Windows)
+	if err != nil {
+		return arn, err
+	}
 	r := &rebalanceMeta{
 		ID:        shortuuid.New(),
 		PoolStats: make([]*rebalanceStats, len(z.serverPools)),
+		Opts:      opts,
+		windows:   windows,
 	}
 
 	// Fetch disk capacity and available space.
@@ -151,7 +212,7 @@ func (z *erasureServerPools) initRebalanceMeta(ctx context.Context, buckets []st
 		}
 		copy(r.PoolStats[idx].Buckets, buckets)
 
-		if pfi := float64(diskStats[idx].AvailableSpace) / float64(diskStats[idx].TotalSpace); pfi < r.PercentFreeGoal {
+		if pfi := float64(diskStats[idx].AvailableSpace) / float64(diskStats[idx].TotalSpace); pfi < r.PercentFreeGoal && opts.includesPool(idx) {
 			r.PoolStats[idx].Participating = true
 			r.PoolStats[idx].Info = rebalanceInfo{
 				StartTime: now,
@@ -265,6 +326,10 @@ func (r *rebalanceMeta) loadWithOpts(ctx context.Context, store objectIO, opts O
 		return err
 	}
 
+	if r.windows, err = schedule.Parse(r.Opts.Windows); err != nil {
+		return err
+	}
+
 	r.lastRefreshedAt = time.Now()
 
 	return nil
@@ -315,6 +380,99 @@ func (z *erasureServerPools) IsPoolRebalancing(poolIndex int) bool {
 	return false
 }
 
+// inRebalanceWindow returns true if rebalance can run now as per its
+// maintenance windows.
+func (z *erasureServerPools) inRebalanceWindow() bool {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+
+	r := z.rebalMeta
+	return r == nil || r.windows.Contains(time.Now())
+}
+
+// waitRebalanceWindow waits for the next maintenance window of rebalance
+// to open, returns immediately if rebalance can run now.
+func (z *erasureServerPools) waitRebalanceWindow(ctx context.Context, poolIdx int) error {
+	z.rebalMu.RLock()
+	var next time.Time
+	if r := z.rebalMeta; r != nil {
+		next = r.windows.Next(time.Now())
+	}
+	z.rebalMu.RUnlock()
+
+	wait := time.Until(next)
+	if wait <= 0 {
+		return nil
+	}
+
+	stopFn := globalRebalanceMetrics.log(rebalanceMetricWaitWindow, poolIdx, next.String())
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		stopFn(ctx.Err())
+		return ctx.Err()
+	case <-timer.C:
+		stopFn(nil)
+		return nil
+	}
+}
+
+//msgp:ignore rebalanceThrottle rebalanceThrottledReader
+
+// rebalanceThrottle limits the bandwidth and the rate of object versions
+// moved by the rebalance of a pool.
+type rebalanceThrottle struct {
+	bw  *rate.Limiter
+	ops *rate.Limiter
+}
+
+func newRebalanceThrottle(opts rebalanceOpts) *rebalanceThrottle {
+	t := &rebalanceThrottle{}
+	if opts.Bandwidth > 0 {
+		t.bw = rate.NewLimiter(rate.Limit(opts.Bandwidth), int(opts.Bandwidth))
+	}
+	if opts.IOPS > 0 {
+		t.ops = rate.NewLimiter(rate.Limit(opts.IOPS), int(opts.IOPS))
+	}
+	return t
+}
+
+// wait waits until one more object version can be moved.
+func (t *rebalanceThrottle) wait(ctx context.Context) error {
+	if t == nil || t.ops == nil {
+		return nil
+	}
+	return t.ops.Wait(ctx)
+}
+
+// reader returns r limited to the configured bandwidth.
+func (t *rebalanceThrottle) reader(ctx context.Context, r io.Reader) io.Reader {
+	if t == nil || t.bw == nil {
+		return r
+	}
+	return &rebalanceThrottledReader{ctx: ctx, r: r, bw: t.bw}
+}
+
+type rebalanceThrottledReader struct {
+	ctx context.Context
+	r   io.Reader
+	bw  *rate.Limiter
+}
+
+func (tr *rebalanceThrottledReader) Read(p []byte) (n int, err error) {
+	if burst := tr.bw.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+	n, err = tr.r.Read(p)
+	if n > 0 {
+		if werr := tr.bw.WaitN(tr.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
 func (z *erasureServerPools) rebalanceBuckets(ctx context.Context, poolIdx int) (err error) {
 	doneCh := make(chan struct{})
 	defer close(doneCh)
@@ -375,6 +533,10 @@ func (z *erasureServerPools) rebalanceBuckets(ctx context.Context, poolIdx int)
 		}
 	}()
 
+	z.rebalMu.RLock()
+	throttle := newRebalanceThrottle(z.rebalMeta.Opts)
+	z.rebalMu.RUnlock()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -388,8 +550,17 @@ func (z *erasureServerPools) rebalanceBuckets(ctx context.Context, poolIdx int)
 			break
 		}
 
+		if err = z.waitRebalanceWindow(ctx, poolIdx); err != nil {
+			return
+		}
+
 		stopFn := globalRebalanceMetrics.log(rebalanceMetricRebalanceBucket, poolIdx, bucket)
-		err = z.rebalanceBucket(ctx, bucket, poolIdx)
+		err = z.rebalanceBucket(ctx, bucket, poolIdx, throttle)
+		if errors.Is(err, errRebalanceWindowClosed) {
+			// resume the bucket in the next maintenance window
+			stopFn(err)
+			continue
+		}
 		if err != nil {
 			stopFn(err)
 			logger.LogIf(ctx, err)
@@ -414,8 +585,8 @@ func (z *erasureServerPools) checkIfRebalanceDone(poolIdx int) bool {
 	}
 
 	pfi := float64(poolStats.InitFreeSpace+poolStats.Bytes) / float64(poolStats.InitCapacity)
-	// Mark pool rebalance as done if within 5% from PercentFreeGoal.
-	if diff := math.Abs(pfi - r.PercentFreeGoal); diff <= 0.05 {
+	// Mark pool rebalance as done if within the threshold from PercentFreeGoal.
+	if diff := math.Abs(pfi - r.PercentFreeGoal); diff <= r.threshold() {
 		r.PoolStats[poolIdx].Info.Status = rebalCompleted
 		r.PoolStats[poolIdx].Info.EndTime = time.Now()
 		return true
@@ -424,14 +595,18 @@ func (z *erasureServerPools) checkIfRebalanceDone(poolIdx int) bool {
 	return false
 }
 
-// rebalanceBucket rebalances objects under bucket in poolIdx pool
-func (z *erasureServerPools) rebalanceBucket(ctx context.Context, bucket string, poolIdx int) error {
+// rebalanceBucket rebalances objects under bucket in poolIdx pool, it
+// returns errRebalanceWindowClosed if the maintenance window of rebalance
+// closed before all the objects were visited.
+func (z *erasureServerPools) rebalanceBucket(ctx context.Context, bucket string, poolIdx int, throttle *rebalanceThrottle) error {
 	ctx = logger.SetReqInfo(ctx, &logger.ReqInfo{})
 	vc, _ := globalBucketVersioningSys.Get(bucket)
 	// Check if the current bucket has a configured lifecycle policy
 	lc, _ := globalLifecycleSys.Get(bucket)
 	// Check if bucket is object locked.
 	lr, _ := globalBucketObjectLockSys.Get(bucket)
+	// Check if the current bucket has a configured pool affinity
+	affinity := getPoolAffinity(bucket)
 
 	pool := z.serverPools[poolIdx]
 	const envRebalanceWorkers = "_MINIO_REBALANCE_WORKERS"
@@ -443,6 +618,13 @@ func (z *erasureServerPools) rebalanceBucket(ctx context.Context, bucket string,
 	}
 	workers := make(chan struct{}, workerSize)
 	var wg sync.WaitGroup
+
+	// Listing stops when the maintenance window closes, the objects
+	// being moved are completed.
+	listCtx, cancelList := context.WithCancel(ctx)
+	defer cancelList()
+	var windowClosed int32
+
 	for _, set := range pool.sets {
 		set := set
 		disks := set.getOnlineDisks()
@@ -482,6 +664,19 @@ func (z *erasureServerPools) rebalanceBucket(ctx context.Context, bucket string,
 				return
 			}
 
+			if !z.inRebalanceWindow() {
+				if atomic.CompareAndSwapInt32(&windowClosed, 0, 1) {
+					cancelList()
+				}
+				return
+			}
+
+			// Skip objects which cannot leave this pool as per
+			// the pool affinity of the bucket.
+			if z.pinnedToPool(affinity, entry.name, poolIdx) {
+				return
+			}
+
 			// rebalance on poolIdx has reached its goal
 			if z.checkIfRebalanceDone(poolIdx) {
 				return
@@ -517,6 +712,10 @@ func (z *erasureServerPools) rebalanceBucket(ctx context.Context, bucket string,
 					continue
 				}
 
+				if err := throttle.wait(ctx); err != nil {
+					return
+				}
+
 				if version.Deleted {
 					_, err := z.DeleteObject(ctx,
 						bucket,
@@ -567,7 +766,7 @@ func (z *erasureServerPools) rebalanceBucket(ctx context.Context, bucket string,
 					}
 
 					stopFn := globalRebalanceMetrics.log(rebalanceMetricRebalanceObject, poolIdx, bucket, version.Name)
-					if err = z.rebalanceObject(ctx, bucket, gr); err != nil {
+					if err = z.rebalanceObject(ctx, bucket, gr, throttle); err != nil {
 						stopFn(err)
 						failure = true
 						logger.LogIf(ctx, err)
@@ -615,7 +814,7 @@ func (z *erasureServerPools) rebalanceBucket(ctx context.Context, bucket string,
 				objQuorum: len(disks) / 2, // make sure to capture all quorum ratios
 				bucket:    bucket,
 			}
-			err := listPathRaw(ctx, listPathRawOptions{
+			err := listPathRaw(listCtx, listPathRawOptions{
 				disks:          disks,
 				bucket:         bucket,
 				recursive:      true,
@@ -637,10 +836,15 @@ func (z *erasureServerPools) rebalanceBucket(ctx context.Context, bucket string,
 				},
 				finished: nil,
 			})
-			logger.LogIf(ctx, err)
+			if atomic.LoadInt32(&windowClosed) == 0 {
+				logger.LogIf(ctx, err)
+			}
 		}()
 	}
 	wg.Wait()
+	if atomic.LoadInt32(&windowClosed) == 1 {
+		return errRebalanceWindowClosed
+	}
 	return nil
 }
 
@@ -697,8 +901,9 @@ func auditLogRebalance(ctx context.Context, apiName, bucket, object, versionID s
 	})
 }
 
-func (z *erasureServerPools) rebalanceObject(ctx context.Context, bucket string, gr *GetObjectReader) (err error) {
+func (z *erasureServerPools) rebalanceObject(ctx context.Context, bucket string, gr *GetObjectReader, throttle *rebalanceThrottle) (err error) {
 	oi := gr.ObjInfo
+	rd := throttle.reader(ctx, gr)
 
 	defer func() {
 		gr.Close()
@@ -723,7 +928,7 @@ func (z *erasureServerPools) rebalanceObject(ctx context.Context, bucket string,
 
 		parts := make([]CompletePart, len(oi.Parts))
 		for i, part := range oi.Parts {
-			hr, err := hash.NewReader(gr, part.Size, "", "", part.ActualSize)
+			hr, err := hash.NewReader(rd, part.Size, "", "", part.ActualSize)
 			if err != nil {
 				return fmt.Errorf("rebalanceObject: hash.NewReader() %w", err)
 			}
@@ -753,7 +958,7 @@ func (z *erasureServerPools) rebalanceObject(ctx context.Context, bucket string,
 		return err
 	}
 
-	hr, err := hash.NewReader(gr, oi.Size, "", "", actualSize)
+	hr, err := hash.NewReader(rd, oi.Size, "", "", actualSize)
 	if err != nil {
 		return fmt.Errorf("rebalanceObject: hash.NewReader() %w", err)
 	}
@@ -849,6 +1054,7 @@ const (
 	rebalanceMetricRebalanceObject
 	rebalanceMetricRebalanceRemoveObject
 	rebalanceMetricSaveMetadata
+	rebalanceMetricWaitWindow
 )
 
 func rebalanceTrace(r rebalanceMetric, poolIdx int, startTime time.Time, duration time.Duration, err error, path string) madmin.TraceInfo {
@@ -338,6 +338,12 @@ func (z *rebalanceMeta) DecodeMsg(dc *msgp.Reader) (err error) {
 					}
 				}
 			}
+		case "opts":
+			err = z.Opts.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Opts")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -351,9 +357,9 @@ func (z *rebalanceMeta) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *rebalanceMeta) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 4
+	// map header, size 5
 	// write "stopTs"
-	err = en.Append(0x84, 0xa6, 0x73, 0x74, 0x6f, 0x70, 0x54, 0x73)
+	err = en.Append(0x85, 0xa6, 0x73, 0x74, 0x6f, 0x70, 0x54, 0x73)
 	if err != nil {
 		return
 	}
@@ -406,15 +412,25 @@ func (z *rebalanceMeta) EncodeMsg(en *msgp.Writer) (err error) {
 			}
 		}
 	}
+	// write "opts"
+	err = en.Append(0xa4, 0x6f, 0x70, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = z.Opts.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Opts")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *rebalanceMeta) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 4
+	// map header, size 5
 	// string "stopTs"
-	o = append(o, 0x84, 0xa6, 0x73, 0x74, 0x6f, 0x70, 0x54, 0x73)
+	o = append(o, 0x85, 0xa6, 0x73, 0x74, 0x6f, 0x70, 0x54, 0x73)
 	o = msgp.AppendTime(o, z.StoppedAt)
 	// string "id"
 	o = append(o, 0xa2, 0x69, 0x64)
@@ -436,6 +452,13 @@ func (z *rebalanceMeta) MarshalMsg(b []byte) (o []byte, err error) {
 			}
 		}
 	}
+	// string "opts"
+	o = append(o, 0xa4, 0x6f, 0x70, 0x74, 0x73)
+	o, err = z.Opts.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Opts")
+		return
+	}
 	return
 }
 
@@ -505,6 +528,12 @@ func (z *rebalanceMeta) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					}
 				}
 			}
+		case "opts":
+			bts, err = z.Opts.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Opts")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -527,6 +556,7 @@ func (z *rebalanceMeta) Msgsize() (s int) {
 			s += z.PoolStats[za0001].Msgsize()
 		}
 	}
+	s += 5 + z.Opts.Msgsize()
 	return
 }
 
@@ -665,6 +695,310 @@ func (z rebalanceMetrics) Msgsize() (s int) {
 	return
 }
 
+// DecodeMsg implements msgp.Decodable
+func (z *rebalanceOpts) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "bu":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Buckets")
+				return
+			}
+			if cap(z.Buckets) >= int(zb0002) {
+				z.Buckets = (z.Buckets)[:zb0002]
+			} else {
+				z.Buckets = make([]string, zb0002)
+			}
+			for za0001 := range z.Buckets {
+				z.Buckets[za0001], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Buckets", za0001)
+					return
+				}
+			}
+		case "po":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Pools")
+				return
+			}
+			if cap(z.Pools) >= int(zb0003) {
+				z.Pools = (z.Pools)[:zb0003]
+			} else {
+				z.Pools = make([]int, zb0003)
+			}
+			for za0002 := range z.Pools {
+				z.Pools[za0002], err = dc.ReadInt()
+				if err != nil {
+					err = msgp.WrapError(err, "Pools", za0002)
+					return
+				}
+			}
+		case "th":
+			z.Threshold, err = dc.ReadFloat64()
+			if err != nil {
+				err = msgp.WrapError(err, "Threshold")
+				return
+			}
+		case "win":
+			z.Windows, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Windows")
+				return
+			}
+		case "bw":
+			z.Bandwidth, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Bandwidth")
+				return
+			}
+		case "iops":
+			z.IOPS, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "IOPS")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *rebalanceOpts) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 6
+	// write "bu"
+	err = en.Append(0x86, 0xa2, 0x62, 0x75)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Buckets)))
+	if err != nil {
+		err = msgp.WrapError(err, "Buckets")
+		return
+	}
+	for za0001 := range z.Buckets {
+		err = en.WriteString(z.Buckets[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "Buckets", za0001)
+			return
+		}
+	}
+	// write "po"
+	err = en.Append(0xa2, 0x70, 0x6f)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Pools)))
+	if err != nil {
+		err = msgp.WrapError(err, "Pools")
+		return
+	}
+	for za0002 := range z.Pools {
+		err = en.WriteInt(z.Pools[za0002])
+		if err != nil {
+			err = msgp.WrapError(err, "Pools", za0002)
+			return
+		}
+	}
+	// write "th"
+	err = en.Append(0xa2, 0x74, 0x68)
+	if err != nil {
+		return
+	}
+	err = en.WriteFloat64(z.Threshold)
+	if err != nil {
+		err = msgp.WrapError(err, "Threshold")
+		return
+	}
+	// write "win"
+	err = en.Append(0xa3, 0x77, 0x69, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Windows)
+	if err != nil {
+		err = msgp.WrapError(err, "Windows")
+		return
+	}
+	// write "bw"
+	err = en.Append(0xa2, 0x62, 0x77)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Bandwidth)
+	if err != nil {
+		err = msgp.WrapError(err, "Bandwidth")
+		return
+	}
+	// write "iops"
+	err = en.Append(0xa4, 0x69, 0x6f, 0x70, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.IOPS)
+	if err != nil {
+		err = msgp.WrapError(err, "IOPS")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *rebalanceOpts) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 6
+	// string "bu"
+	o = append(o, 0x86, 0xa2, 0x62, 0x75)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Buckets)))
+	for za0001 := range z.Buckets {
+		o = msgp.AppendString(o, z.Buckets[za0001])
+	}
+	// string "po"
+	o = append(o, 0xa2, 0x70, 0x6f)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Pools)))
+	for za0002 := range z.Pools {
+		o = msgp.AppendInt(o, z.Pools[za0002])
+	}
+	// string "th"
+	o = append(o, 0xa2, 0x74, 0x68)
+	o = msgp.AppendFloat64(o, z.Threshold)
+	// string "win"
+	o = append(o, 0xa3, 0x77, 0x69, 0x6e)
+	o = msgp.AppendString(o, z.Windows)
+	// string "bw"
+	o = append(o, 0xa2, 0x62, 0x77)
+	o = msgp.AppendUint64(o, z.Bandwidth)
+	// string "iops"
+	o = append(o, 0xa4, 0x69, 0x6f, 0x70, 0x73)
+	o = msgp.AppendUint64(o, z.IOPS)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *rebalanceOpts) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "bu":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Buckets")
+				return
+			}
+			if cap(z.Buckets) >= int(zb0002) {
+				z.Buckets = (z.Buckets)[:zb0002]
+			} else {
+				z.Buckets = make([]string, zb0002)
+			}
+			for za0001 := range z.Buckets {
+				z.Buckets[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Buckets", za0001)
+					return
+				}
+			}
+		case "po":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Pools")
+				return
+			}
+			if cap(z.Pools) >= int(zb0003) {
+				z.Pools = (z.Pools)[:zb0003]
+			} else {
+				z.Pools = make([]int, zb0003)
+			}
+			for za0002 := range z.Pools {
+				z.Pools[za0002], bts, err = msgp.ReadIntBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Pools", za0002)
+					return
+				}
+			}
+		case "th":
+			z.Threshold, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Threshold")
+				return
+			}
+		case "win":
+			z.Windows, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Windows")
+				return
+			}
+		case "bw":
+			z.Bandwidth, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Bandwidth")
+				return
+			}
+		case "iops":
+			z.IOPS, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "IOPS")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *rebalanceOpts) Msgsize() (s int) {
+	s = 1 + 3 + msgp.ArrayHeaderSize
+	for za0001 := range z.Buckets {
+		s += msgp.StringPrefixSize + len(z.Buckets[za0001])
+	}
+	s += 3 + msgp.ArrayHeaderSize + (len(z.Pools) * (msgp.IntSize)) + 3 + msgp.Float64Size + 4 + msgp.StringPrefixSize + len(z.Windows) + 3 + msgp.Uint64Size + 5 + msgp.Uint64Size
+	return
+}
+
 // DecodeMsg implements msgp.Decodable
 func (z *rebalanceStats) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
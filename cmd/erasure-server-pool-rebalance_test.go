@@ -0,0 +1,82 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestRebalanceOptsValidate(t *testing.T) {
+	testCases := []struct {
+		opts    rebalanceOpts
+		success bool
+	}{
+		{rebalanceOpts{}, true},
+		{rebalanceOpts{Pools: []int{0, 2}, Threshold: 0.02, Windows: "mon-fri 22:00-06:00"}, true},
+		{rebalanceOpts{Pools: []int{3}}, false},
+		{rebalanceOpts{Threshold: 1}, false},
+		{rebalanceOpts{Threshold: -0.1}, false},
+		{rebalanceOpts{Windows: "mon-fri 22:00"}, false},
+	}
+	for i, testCase := range testCases {
+		err := testCase.opts.validate(3)
+		if testCase.success && err != nil {
+			t.Errorf("Test %d: expected success, got %v", i+1, err)
+		}
+		if !testCase.success && err == nil {
+			t.Errorf("Test %d: expected failure", i+1)
+		}
+	}
+
+	r := &rebalanceMeta{}
+	if r.threshold() != 0.05 {
+		t.Errorf("expected default threshold 0.05, got %v", r.threshold())
+	}
+}
+
+func TestRebalanceThrottle(t *testing.T) {
+	ctx := context.Background()
+
+	// No limits.
+	var throttle *rebalanceThrottle
+	data := bytes.Repeat([]byte("a"), 1024)
+	if rd := throttle.reader(ctx, bytes.NewReader(data)); rd == nil {
+		t.Fatal("expected a reader")
+	}
+	if err := throttle.wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// 512 bytes per second, the first 512 bytes are read at once.
+	throttle = newRebalanceThrottle(rebalanceOpts{Bandwidth: 512})
+	start := time.Now()
+	n, err := io.Copy(io.Discard, throttle.reader(ctx, bytes.NewReader(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(data)) {
+		t.Fatalf("expected %d bytes, got %d", len(data), n)
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("expected reading to be throttled, took %v", elapsed)
+	}
+}
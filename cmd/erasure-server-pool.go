@@ -37,6 +37,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/internal/bucket/lifecycle"
 	"github.com/minio/minio/internal/config/storageclass"
+	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/minio/internal/sync/errgroup"
 	"github.com/minio/pkg/wildcard"
@@ -49,6 +50,9 @@ type erasureServerPools struct {
 	rebalMu   sync.RWMutex
 	rebalMeta *rebalanceMeta
 
+	parityMu   sync.RWMutex
+	parityMeta *parityUpgradeMeta
+
 	serverPools []*erasureSets
 
 	// Shut down async operations
@@ -301,13 +305,36 @@ func (p serverPoolsAvailableSpace) FilterMaxUsed(max int) {
 	}
 }
 
+// FilterPoolAffinity will filter out the pools not allowed for new objects
+// named object by the pool affinity of their bucket, even if none of the
+// allowed pools has space available.
+func (p serverPoolsAvailableSpace) FilterPoolAffinity(affinity *BucketPoolAffinity, object string) {
+	rule, ok := affinity.Rule(object)
+	if !ok {
+		return
+	}
+
+	// Remove entries that are not allowed.
+	for i, z := range p {
+		if !rule.allows(z.Index) {
+			p[i].Available = 0
+		}
+	}
+}
+
 // getAvailablePoolIdx will return an index that can hold size bytes.
 // -1 is returned if no serverPools have available space for the size given.
 func (z *erasureServerPools) getAvailablePoolIdx(ctx context.Context, bucket, object string, size int64) int {
 	serverPools := z.getServerPoolsAvailableSpace(ctx, bucket, object, size)
 	serverPools.FilterMaxUsed(100 - (100 * diskReserveFraction))
+	affinity := getPoolAffinity(bucket)
+	serverPools.FilterPoolAffinity(affinity, object)
 	total := serverPools.TotalAvailable()
 	if total == 0 {
+		if rule, ok := affinity.Rule(object); ok {
+			logger.LogOnceIf(ctx, fmt.Errorf("no pool allowed by the pool affinity of bucket %s has space for new objects under prefix '%s'", bucket, rule.Prefix),
+				"pool-affinity-full-"+bucket+"/"+rule.Prefix)
+		}
 		return -1
 	}
 	// choose when we reach this many
@@ -324,6 +351,22 @@ func (z *erasureServerPools) getAvailablePoolIdx(ctx context.Context, bucket, ob
 	return -1
 }
 
+// getStorageClassPoolIdx returns the pool preferred for new objects of the
+// storage class sc if it can hold size bytes, -1 otherwise.
+func (z *erasureServerPools) getStorageClassPoolIdx(ctx context.Context, bucket, object, sc string, size int64) int {
+	idx := globalStorageClass.GetPoolForSC(sc)
+	if idx < 0 || idx >= len(z.serverPools) || isMinioMetaBucketName(bucket) {
+		return -1
+	}
+	if z.IsSuspended(idx) || z.IsPoolRebalancing(idx) || !getPoolAffinity(bucket).Allows(object, idx) {
+		return -1
+	}
+	if !hasSpaceFor(getDiskInfos(ctx, z.serverPools[idx].getHashedSet(object).getDisks()...), size) {
+		return -1
+	}
+	return idx
+}
+
 // getServerPoolsAvailableSpace will return the available space of each pool after storing the content.
 // If there is not enough space the pool will return 0 bytes available.
 // The size of each will be multiplied by the number of sets.
@@ -484,13 +527,16 @@ func (z *erasureServerPools) getPoolIdxExistingNoLock(ctx context.Context, bucke
 	})
 }
 
-func (z *erasureServerPools) getPoolIdxNoLock(ctx context.Context, bucket, object string, size int64) (idx int, err error) {
+func (z *erasureServerPools) getPoolIdxNoLock(ctx context.Context, bucket, object, sc string, size int64) (idx int, err error) {
 	idx, err = z.getPoolIdxExistingNoLock(ctx, bucket, object)
 	if err != nil && !isErrObjectNotFound(err) {
 		return idx, err
 	}
 
 	if isErrObjectNotFound(err) {
+		if idx = z.getStorageClassPoolIdx(ctx, bucket, object, sc, size); idx >= 0 {
+			return idx, nil
+		}
 		idx = z.getAvailablePoolIdx(ctx, bucket, object, size)
 		if idx < 0 {
 			return -1, toObjectErr(errDiskFull)
@@ -501,9 +547,10 @@ func (z *erasureServerPools) getPoolIdxNoLock(ctx context.Context, bucket, objec
 }
 
 // getPoolIdx returns the found previous object and its corresponding pool idx,
-// if none are found falls back to most available space pool, this function is
-// designed to be only used by PutObject, CopyObject (newObject creation) and NewMultipartUpload.
-func (z *erasureServerPools) getPoolIdx(ctx context.Context, bucket, object string, size int64) (idx int, err error) {
+// if none are found falls back to the pool preferred by the storage class sc,
+// then to most available space pool, this function is designed to be only
+// used by PutObject, CopyObject (newObject creation) and NewMultipartUpload.
+func (z *erasureServerPools) getPoolIdx(ctx context.Context, bucket, object, sc string, size int64) (idx int, err error) {
 	idx, err = z.getPoolIdxExistingWithOpts(ctx, bucket, object, ObjectOptions{
 		SkipDecommissioned: true,
 		SkipRebalancing:    true,
@@ -513,6 +560,9 @@ func (z *erasureServerPools) getPoolIdx(ctx context.Context, bucket, object stri
 	}
 
 	if isErrObjectNotFound(err) {
+		if idx = z.getStorageClassPoolIdx(ctx, bucket, object, sc, size); idx >= 0 {
+			return idx, nil
+		}
 		idx = z.getAvailablePoolIdx(ctx, bucket, object, size)
 		if idx < 0 {
 			return -1, toObjectErr(errDiskFull)
@@ -992,7 +1042,7 @@ func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, objec
 		opts.NoLock = true
 	}
 
-	idx, err := z.getPoolIdxNoLock(ctx, bucket, object, data.Size())
+	idx, err := z.getPoolIdxNoLock(ctx, bucket, object, opts.UserDefined[xhttp.AmzStorageClass], data.Size())
 	if err != nil {
 		return ObjectInfo{}, err
 	}
@@ -1178,7 +1228,7 @@ func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObjec
 		dstOpts.NoLock = true
 	}
 
-	poolIdx, err := z.getPoolIdxNoLock(ctx, dstBucket, dstObject, srcInfo.Size)
+	poolIdx, err := z.getPoolIdxNoLock(ctx, dstBucket, dstObject, srcInfo.UserDefined[xhttp.AmzStorageClass], srcInfo.Size)
 	if err != nil {
 		return objInfo, err
 	}
@@ -1454,7 +1504,7 @@ func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, obj
 
 	// any parallel writes on the object will block for this poolIdx
 	// to return since this holds a read lock on the namespace.
-	idx, err := z.getPoolIdx(ctx, bucket, object, -1)
+	idx, err := z.getPoolIdx(ctx, bucket, object, opts.UserDefined[xhttp.AmzStorageClass], -1)
 	if err != nil {
 		return nil, err
 	}
@@ -2289,7 +2339,7 @@ func (z *erasureServerPools) Health(ctx context.Context, opts HealthOptions) Hea
 		}
 	}
 
-	var aggHealStateResult madmin.BgHealState
+	var aggHealStateResult bgHealState
 	if opts.Maintenance {
 		// check if local disks are being healed, if they are being healed
 		// we need to tell healthy status as 'false' so that this server
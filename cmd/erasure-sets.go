@@ -193,7 +193,8 @@ func (s *erasureSets) connectDisks() {
 	var wg sync.WaitGroup
 	diskMap := s.getDiskMap()
 	setsJustConnected := make([]bool, s.setCount)
-	for _, endpoint := range s.endpoints.Endpoints {
+	for i, endpoint := range s.endpoints.EndpointsWithSpares() {
+		spare := i >= len(s.endpoints.Endpoints)
 		cdisk := diskMap[endpoint]
 		if cdisk != nil && cdisk.IsOnline() {
 			if s.lastConnectDisksOpTime.IsZero() {
@@ -212,32 +213,49 @@ func (s *erasureSets) connectDisks() {
 		}
 
 		wg.Add(1)
-		go func(endpoint Endpoint) {
+		go func(i int, endpoint Endpoint, spare bool) {
 			defer wg.Done()
+			// A drive replaced by a spare drive is a spare drive in turn
+			// once re-provisioned.
+			replaced := !spare && s.isReplacedEndpoint(i)
+			spare = spare || replaced
 			disk, format, err := connectEndpoint(endpoint)
 			if err != nil {
-				if endpoint.IsLocal && errors.Is(err, errUnformattedDisk) {
+				switch {
+				case replaced && endpoint.IsLocal && errors.Is(err, errUnformattedDisk):
+					s.formatSpare(GlobalContext, endpoint)
+				case spare:
+					// Unused spare drives are not healed.
+				case endpoint.IsLocal && errors.Is(err, errUnformattedDisk):
 					globalBackgroundHealState.pushHealLocalDisks(endpoint)
-				} else {
+				default:
 					printEndpointError(endpoint, err, true)
 				}
 				return
 			}
-			if disk.IsLocal() && disk.Healing() != nil {
-				globalBackgroundHealState.pushHealLocalDisks(disk.Endpoint())
-			}
 			s.erasureDisksMu.RLock()
 			setIndex, diskIndex, err := findDiskIndex(s.format, format)
 			s.erasureDisksMu.RUnlock()
+			if err != nil && s.reconcileFormat(disk, format) {
+				s.erasureDisksMu.RLock()
+				setIndex, diskIndex, err = findDiskIndex(s.format, format)
+				s.erasureDisksMu.RUnlock()
+			}
 			if err != nil {
-				printEndpointError(endpoint, err, false)
+				if !spare {
+					printEndpointError(endpoint, err, false)
+				}
 				disk.Close()
 				return
 			}
+			if disk.IsLocal() && disk.Healing() != nil {
+				globalBackgroundHealState.pushHealLocalDisks(disk.Endpoint())
+			}
 
 			s.erasureDisksMu.Lock()
 			if currentDisk := s.erasureDisks[setIndex][diskIndex]; currentDisk != nil {
-				if !reflect.DeepEqual(currentDisk.Endpoint(), disk.Endpoint()) {
+				// A promoted spare drive takes the place of the failed drive.
+				if !spare && !reflect.DeepEqual(currentDisk.Endpoint(), disk.Endpoint()) {
 					err = fmt.Errorf("Detected unexpected drive ordering refusing to use the drive: expecting %s, found %s, refusing to use the drive",
 						currentDisk.Endpoint(), disk.Endpoint())
 					printEndpointError(endpoint, err, false)
@@ -264,7 +282,7 @@ func (s *erasureSets) connectDisks() {
 			disk.SetDiskLoc(s.poolIndex, setIndex, diskIndex)
 			setsJustConnected[setIndex] = true // disk just went online we treat it is as MRF event
 			s.erasureDisksMu.Unlock()
-		}(endpoint)
+		}(i, endpoint, spare)
 	}
 
 	wg.Wait()
@@ -504,6 +522,15 @@ func newErasureSets(ctx context.Context, endpoints PoolEndpoints, storageDisks [
 	//清理删除的objects
 	go s.cleanupDeletedObjects(ctx)
 
+	// Format the new spare drives, and replace the failed drives by spare
+	// drives if enabled.
+	if len(endpoints.Spares) > 0 {
+		s.formatSpares(ctx)
+		if promoteAfter := sparePromoteAfter(); promoteAfter > 0 && !globalIsTesting {
+			go s.monitorSpares(ctx, promoteAfter)
+		}
+	}
+
 	// Start the disk monitoring and connect routine.
 	if !globalIsTesting {
 		go s.monitorAndConnectEndpoints(ctx, defaultMonitorConnectEndpointInterval)
@@ -0,0 +1,601 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/sync/errgroup"
+	"github.com/minio/pkg/ellipses"
+	"github.com/minio/pkg/env"
+)
+
+// Spare drives configuration.
+const (
+	// EnvSpareDrives lists the spare drives of each pool, space separated
+	// in the order of the pools, ellipses are supported.
+	EnvSpareDrives = "MINIO_SPARE_DRIVES"
+
+	// EnvSparePromoteAfter is the duration after which an offline drive is
+	// declared failed and replaced by a spare drive, disabled by default.
+	EnvSparePromoteAfter = "MINIO_SPARE_PROMOTE_AFTER"
+)
+
+// Spare drive states reported by the admin API.
+const (
+	spareDriveAvailable   = "available"
+	spareDrivePromoted    = "promoted"
+	spareDriveUnformatted = "unformatted"
+	spareDriveOffline     = "offline"
+	spareDriveInvalid     = "invalid"
+)
+
+var errNoSpareDrive = AdminError{
+	Code:       "XMinioAdminNoSpareDrive",
+	Message:    "no spare drive available",
+	StatusCode: http.StatusConflict,
+}
+
+// invalidSparePromotion returns the error of a spare promotion that cannot
+// be done.
+func invalidSparePromotion(format string, a ...interface{}) error {
+	return AdminError{
+		Code:       "XMinioAdminInvalidSparePromotion",
+		Message:    fmt.Sprintf(format, a...),
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
+// spareDriveInfo reports a spare drive of a pool.
+type spareDriveInfo struct {
+	Pool     int    `json:"pool"`
+	Endpoint string `json:"endpoint"`
+	State    string `json:"state"`
+	Replaces string `json:"replaces,omitempty"` // Endpoint of the drive replaced by a promoted spare drive
+}
+
+// addSpares adds the spare drives args to the pools, one arg per pool.
+func (l EndpointServerPools) addSpares(serverAddr string, setupType SetupType, args []string) error {
+	if setupType == ErasureSDSetupType || setupType == FSSetupType {
+		return fmt.Errorf("spare drives require an erasure setup with multiple drives")
+	}
+	if len(args) > len(l) {
+		return fmt.Errorf("spare drives given for %d pools, only %d pools found", len(args), len(l))
+	}
+
+	_, serverAddrPort := mustSplitHostPort(serverAddr)
+	localHosts := set.NewStringSet()
+	inUse := set.NewStringSet()
+	for _, pool := range l {
+		for _, ep := range pool.Endpoints {
+			if ep.IsLocal && ep.Type() == URLEndpointType {
+				localHosts.Add(ep.Host)
+			}
+			inUse.Add(ep.String())
+		}
+	}
+
+	for i, arg := range args {
+		var drives []string
+		if ellipses.HasEllipses(arg) {
+			patterns, err := ellipses.FindEllipsesPatterns(arg)
+			if err != nil {
+				return fmt.Errorf("'%s': %w", arg, err)
+			}
+			for _, lbls := range patterns.Expand() {
+				drives = append(drives, strings.Join(lbls, ""))
+			}
+		} else {
+			drives = append(drives, arg)
+		}
+
+		spares, err := NewEndpoints(drives...)
+		if err != nil {
+			return err
+		}
+		hosts := set.NewStringSet()
+		for _, ep := range l[i].Endpoints {
+			hosts.Add(ep.Host)
+		}
+		for j := range spares {
+			if spares[j].Type() != l[i].Endpoints[0].Type() {
+				return fmt.Errorf("'%s': mixed style endpoints are not supported", spares[j])
+			}
+			if spares[j].Type() == URLEndpointType {
+				if _, _, err := net.SplitHostPort(spares[j].Host); err != nil {
+					spares[j].Host = net.JoinHostPort(spares[j].Host, serverAddrPort)
+				}
+				// Spare drives are served by the nodes of the pool.
+				if !hosts.Contains(spares[j].Host) {
+					return fmt.Errorf("'%s': spare drives must be on the nodes of the %s pool", spares[j], humanize.Ordinal(i+1))
+				}
+				spares[j].IsLocal = localHosts.Contains(spares[j].Host)
+			}
+			if inUse.Contains(spares[j].String()) {
+				return fmt.Errorf("'%s': spare drive is already in use", spares[j])
+			}
+			inUse.Add(spares[j].String())
+		}
+		l[i].Spares = spares
+	}
+	return nil
+}
+
+// sparePromoteAfter returns the duration after which an offline drive is
+// replaced by a spare drive, 0 if disabled.
+func sparePromoteAfter() time.Duration {
+	v := env.Get(EnvSparePromoteAfter, "")
+	if v == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		logger.LogIf(GlobalContext, fmt.Errorf("Invalid %s value %q: %w", EnvSparePromoteAfter, v, err))
+		return 0
+	}
+	return d
+}
+
+// spareEndpoints returns the spare drives of the pool followed by the
+// drives replaced by a spare drive, which are spare drives in turn once
+// re-provisioned.
+func (s *erasureSets) spareEndpoints() Endpoints {
+	spares := append(Endpoints{}, s.endpoints.Spares...)
+	for k, ep := range s.endpoints.Endpoints {
+		if s.isReplacedEndpoint(k) {
+			spares = append(spares, ep)
+		}
+	}
+	return spares
+}
+
+// formatSpares formats the local spare drives of the pool with the format
+// of the pool, their uuid is not part of any erasure set until promoted.
+func (s *erasureSets) formatSpares(ctx context.Context) {
+	for _, ep := range s.spareEndpoints() {
+		if ep.IsLocal {
+			s.formatSpare(ctx, ep)
+		}
+	}
+}
+
+// formatSpare formats the local spare drive ep if unformatted.
+func (s *erasureSets) formatSpare(ctx context.Context, ep Endpoint) {
+	disk, err := newStorageAPI(ep, false)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to use the spare drive %s: %w", ep, err))
+		return
+	}
+	defer disk.Close()
+
+	format, err := loadFormatErasure(disk)
+	switch {
+	case err == nil:
+		if format.ID != s.format.ID {
+			logger.LogIf(ctx, fmt.Errorf("Spare drive %s belongs to another deployment %s", ep, format.ID))
+		}
+	case errors.Is(err, errUnformattedDisk):
+		s.erasureDisksMu.RLock()
+		format = s.format.Clone()
+		s.erasureDisksMu.RUnlock()
+		format.Erasure.This = mustGetUUID()
+		logger.LogIf(ctx, saveFormatErasure(disk, format, false))
+	default:
+		logger.LogIf(ctx, fmt.Errorf("Unable to use the spare drive %s: %w", ep, err))
+	}
+}
+
+// isPromoted returns true if the drive id is a promoted spare drive,
+// the caller holds erasureDisksMu.
+func (s *erasureSets) isPromoted(id string) bool {
+	for _, spare := range s.format.Replaced {
+		if spare == id {
+			return true
+		}
+	}
+	return false
+}
+
+// isReplacedEndpoint returns true if the drive of the endpoint at index k
+// of the pool endpoints was replaced by a spare drive.
+func (s *erasureSets) isReplacedEndpoint(k int) bool {
+	s.erasureDisksMu.RLock()
+	defer s.erasureDisksMu.RUnlock()
+	return s.isPromoted(s.format.Erasure.Sets[k/s.setDriveCount][k%s.setDriveCount])
+}
+
+// reconcileFormat reconciles the format of the connecting disk with the
+// format of the pool when they differ by promoted spare drives. It returns
+// false if the disk is not to be used.
+func (s *erasureSets) reconcileFormat(disk StorageAPI, format *formatErasureV3) bool {
+	s.erasureDisksMu.Lock()
+	defer s.erasureDisksMu.Unlock()
+
+	switch {
+	case formatErasureReplaces(s.format, format):
+		// The disk missed a promotion, drives replaced by a spare drive
+		// are left out.
+		if _, ok := s.format.Replaced[format.Erasure.This]; ok {
+			return false
+		}
+		f := s.format.Clone()
+		f.Erasure.This = format.Erasure.This
+		if err := saveFormatErasure(disk, f, false); err != nil {
+			logger.LogIf(GlobalContext, fmt.Errorf("Unable to update the format of %s: %w", disk, err))
+			return false
+		}
+		*format = *f
+		return true
+	case formatErasureReplaces(format, s.format):
+		// A spare drive was promoted by another node.
+		s.format.Erasure.Sets = format.Erasure.Sets
+		s.format.Replaced = format.Replaced
+		return true
+	}
+	return false
+}
+
+// findDrive returns the position in the erasure sets of the drive with the
+// endpoint drive and its uuid, -1 if not found.
+func (s *erasureSets) findDrive(drive string) (setIdx, diskIdx int, id string) {
+	s.erasureDisksMu.RLock()
+	defer s.erasureDisksMu.RUnlock()
+
+	for i := range s.erasureDisks {
+		for j, disk := range s.erasureDisks[i] {
+			if disk != nil && disk.Endpoint().String() == drive {
+				return i, j, s.format.Erasure.Sets[i][j]
+			}
+		}
+	}
+	// Drives not connected since startup are at the position of their
+	// endpoint, unless replaced by a spare drive.
+	for k, ep := range s.endpoints.Endpoints {
+		i, j := k/s.setDriveCount, k%s.setDriveCount
+		if ep.String() == drive && s.erasureDisks[i][j] == nil && !s.isPromoted(s.format.Erasure.Sets[i][j]) {
+			return i, j, s.format.Erasure.Sets[i][j]
+		}
+	}
+	return -1, -1, ""
+}
+
+// pickSpare connects an available spare drive of the pool, preferring the
+// spare drives on host.
+func (s *erasureSets) pickSpare(format *formatErasureV3, host string) (StorageAPI, *formatErasureV3, error) {
+	spares := s.spareEndpoints()
+	sort.SliceStable(spares, func(i, j int) bool {
+		return spares[i].Host == host && spares[j].Host != host
+	})
+	for _, ep := range spares {
+		disk, spareFormat, err := connectEndpoint(ep)
+		if err != nil {
+			continue
+		}
+		if spareFormat.ID == format.ID {
+			_, replaced := format.Replaced[spareFormat.Erasure.This]
+			if _, _, err = findDiskIndexByDiskID(format, spareFormat.Erasure.This); err != nil && !replaced {
+				if !ep.IsLocal {
+					// Enable healthcheck disk for remote endpoint.
+					disk.Close()
+					if disk, err = newStorageAPI(ep, true); err != nil {
+						continue
+					}
+				}
+				disk.SetDiskID(spareFormat.Erasure.This)
+				return disk, spareFormat, nil
+			}
+		}
+		disk.Close()
+	}
+	return nil, nil, errNoSpareDrive
+}
+
+// promoteSpare replaces the failed drive id at setIdx, diskIdx by a spare
+// drive of the pool, then heals the spare drive as a new drive.
+func (s *erasureSets) promoteSpare(ctx context.Context, setIdx, diskIdx int, id string) (string, error) {
+	// Serialize the promotions of the pool across the nodes.
+	locker := s.NewNSLock(minioMetaBucket, fmt.Sprintf("spare-promotion/%d", s.poolIndex))
+	lkctx, err := locker.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return "", err
+	}
+	ctx = lkctx.Context()
+	defer locker.Unlock(lkctx.Cancel)
+
+	disks := s.GetDisks(setIdx)()
+	failed := s.endpoints.Endpoints[setIdx*s.setDriveCount+diskIdx]
+	if disk := disks[diskIdx]; disk != nil {
+		failed = disk.Endpoint()
+		if disk.IsOnline() {
+			return "", invalidSparePromotion("drive '%s' is online, evacuate it instead", failed)
+		}
+		if d, ok := disk.(*xlStorageDiskIDCheck); ok && globalDriveEvacuations.get(d.storage.diskPath) != nil {
+			return "", invalidSparePromotion("drive '%s' is being evacuated", failed)
+		}
+	}
+
+	// Load the format of the pool in quorum, another node may have
+	// promoted a spare drive already.
+	var poolDisks []StorageAPI
+	for i := 0; i < s.setCount; i++ {
+		for _, disk := range s.GetDisks(i)() {
+			if disk != nil && disk.IsOnline() {
+				poolDisks = append(poolDisks, disk)
+			}
+		}
+	}
+	if len(poolDisks) <= len(s.endpoints.Endpoints)/2 {
+		return "", errErasureWriteQuorum
+	}
+	formats, _ := loadFormatErasureAll(poolDisks, false)
+	format, err := getFormatErasureInQuorum(formats)
+	if err != nil {
+		return "", err
+	}
+
+	s.erasureDisksMu.RLock()
+	current := s.format
+	s.erasureDisksMu.RUnlock()
+	if !reflect.DeepEqual(format.Erasure.Sets, current.Erasure.Sets) && !formatErasureReplaces(format, current) {
+		return "", fmt.Errorf("format of the %s pool not in quorum: %w", humanize.Ordinal(s.poolIndex+1), errInconsistentDisk)
+	}
+	if format.Erasure.Sets[setIdx][diskIdx] != id {
+		return "", invalidSparePromotion("drive '%s' is already replaced by a spare drive", failed)
+	}
+
+	spare, spareFormat, err := s.pickSpare(format, failed.Host)
+	if err != nil {
+		return "", err
+	}
+
+	prevFormat := format.Clone()
+	format.Erasure.Sets[setIdx][diskIdx] = spareFormat.Erasure.This
+	if format.Replaced == nil {
+		format.Replaced = make(map[string]string)
+	}
+	format.Replaced[id] = spareFormat.Erasure.This
+
+	// The spare drive is marked for healing before it takes its place in
+	// the erasure set.
+	spare.SetDiskLoc(s.poolIndex, setIdx, diskIdx)
+	f := format.Clone()
+	f.Erasure.This = spareFormat.Erasure.This
+	if err = saveFormatErasure(spare, f, true); err != nil {
+		spare.Close()
+		return "", err
+	}
+
+	// The promotion takes effect once the new format is in write quorum,
+	// the drives missing the update are reconciled when connecting.
+	saveFormats := func(format *formatErasureV3, disks []StorageAPI) []error {
+		g := errgroup.WithNErrs(len(disks))
+		for index := range disks {
+			index := index
+			g.Go(func() error {
+				diskID, err := disks[index].GetDiskID()
+				if err != nil {
+					return err
+				}
+				f := format.Clone()
+				f.Erasure.This = diskID
+				return saveFormatErasure(disks[index], f, false)
+			}, index)
+		}
+		return g.Wait()
+	}
+	var saved []StorageAPI
+	for index, err := range saveFormats(format, poolDisks) {
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to update the format of %s: %w", poolDisks[index], err))
+			continue
+		}
+		saved = append(saved, poolDisks[index])
+	}
+	// The spare drive counts in the quorum.
+	if writeQuorum := len(s.endpoints.Endpoints)/2 + 1; len(saved)+1 < writeQuorum {
+		for index, err := range saveFormats(prevFormat, saved) {
+			if err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to restore the format of %s: %w", saved[index], err))
+			}
+		}
+		logger.LogIf(ctx, saveFormatErasure(spare, spareFormat, false))
+		spare.Close()
+		return "", errErasureWriteQuorum
+	}
+
+	// Create the buckets on the spare drive so that it takes new objects
+	// right away, the existing objects are healed.
+	for _, disk := range disks {
+		if disk == nil || !disk.IsOnline() {
+			continue
+		}
+		vols, err := disk.ListVols(ctx)
+		if err != nil {
+			continue
+		}
+		volumes := make([]string, 0, len(vols))
+		for _, vol := range vols {
+			volumes = append(volumes, vol.Name)
+		}
+		logger.LogIf(ctx, spare.MakeVolBulk(ctx, volumes...))
+		break
+	}
+
+	s.erasureDisksMu.Lock()
+	s.format.Erasure.Sets = format.Erasure.Sets
+	s.format.Replaced = format.Replaced
+	if disk := s.erasureDisks[setIdx][diskIdx]; disk != nil {
+		disk.Close()
+	}
+	s.erasureDisks[setIdx][diskIdx] = spare
+	s.endpointStrings[setIdx*s.setDriveCount+diskIdx] = spare.String()
+	s.erasureDisksMu.Unlock()
+
+	if spare.IsLocal() {
+		globalBackgroundHealState.pushHealLocalDisks(spare.Endpoint())
+	}
+	logger.Info("Drive %s replaced by the spare drive %s", failed, spare)
+	return spare.Endpoint().String(), nil
+}
+
+// driveSlot is the position of a drive in the erasure sets.
+type driveSlot struct {
+	setIdx, diskIdx int
+	id              string // uuid of the drive in the format
+}
+
+// offlineLocalDrives returns the positions of the local drives of the
+// erasure sets which are offline, by endpoint.
+func (s *erasureSets) offlineLocalDrives() map[string]driveSlot {
+	type slot struct {
+		disk     StorageAPI
+		endpoint Endpoint
+		id       string
+	}
+	slots := make([]slot, 0, len(s.endpoints.Endpoints))
+
+	s.erasureDisksMu.RLock()
+	for i := range s.erasureDisks {
+		for j, disk := range s.erasureDisks[i] {
+			id := s.format.Erasure.Sets[i][j]
+			switch {
+			case disk != nil:
+				slots = append(slots, slot{disk, disk.Endpoint(), id})
+			case !s.isPromoted(id):
+				slots = append(slots, slot{nil, s.endpoints.Endpoints[i*s.setDriveCount+j], id})
+			default:
+				slots = append(slots, slot{})
+			}
+		}
+	}
+	s.erasureDisksMu.RUnlock()
+
+	offline := make(map[string]driveSlot)
+	for k, sl := range slots {
+		if !sl.endpoint.IsLocal || (sl.disk != nil && sl.disk.IsOnline()) {
+			continue
+		}
+		// Evacuated drives are offline until replaced.
+		if d, ok := sl.disk.(*xlStorageDiskIDCheck); ok && globalDriveEvacuations.get(d.storage.diskPath) != nil {
+			continue
+		}
+		offline[sl.endpoint.String()] = driveSlot{k / s.setDriveCount, k % s.setDriveCount, sl.id}
+	}
+	return offline
+}
+
+// monitorSpares replaces the local drives offline for longer than
+// promoteAfter by spare drives.
+func (s *erasureSets) monitorSpares(ctx context.Context, promoteAfter time.Duration) {
+	offlineSince := make(map[string]time.Time)
+
+	t := time.NewTicker(defaultMonitorConnectEndpointInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			now := time.Now()
+			offline := s.offlineLocalDrives()
+			for drive := range offlineSince {
+				if _, ok := offline[drive]; !ok {
+					delete(offlineSince, drive)
+				}
+			}
+			for drive, slot := range offline {
+				since, ok := offlineSince[drive]
+				if !ok {
+					offlineSince[drive] = now
+					continue
+				}
+				if now.Sub(since) < promoteAfter {
+					continue
+				}
+				if _, err := s.promoteSpare(ctx, slot.setIdx, slot.diskIdx, slot.id); err != nil {
+					logger.LogOnceIf(ctx, fmt.Errorf("Unable to replace the drive %s offline since %s by a spare drive: %w", drive, since, err), drive)
+					continue
+				}
+				delete(offlineSince, drive)
+			}
+		}
+	}
+}
+
+// PromoteSpare replaces the offline drive with the endpoint drive by a spare
+// drive of its pool, returning the endpoint of the spare drive.
+func (z *erasureServerPools) PromoteSpare(ctx context.Context, drive string) (string, error) {
+	for _, pool := range z.serverPools {
+		if len(pool.endpoints.Spares) == 0 {
+			continue
+		}
+		if setIdx, diskIdx, id := pool.findDrive(drive); setIdx >= 0 {
+			return pool.promoteSpare(ctx, setIdx, diskIdx, id)
+		}
+	}
+	return "", invalidSparePromotion("drive '%s' not found in a pool with spare drives", drive)
+}
+
+// ListSpares returns the spare drives of all the pools.
+func (z *erasureServerPools) ListSpares() []spareDriveInfo {
+	var spares []spareDriveInfo
+	for poolIdx, pool := range z.serverPools {
+		pool.erasureDisksMu.RLock()
+		format := pool.format.Clone()
+		pool.erasureDisksMu.RUnlock()
+
+		for _, ep := range pool.spareEndpoints() {
+			info := spareDriveInfo{
+				Pool:     poolIdx,
+				Endpoint: ep.String(),
+				State:    spareDriveAvailable,
+			}
+			disk, spareFormat, err := connectEndpoint(ep)
+			switch {
+			case errors.Is(err, errUnformattedDisk):
+				info.State = spareDriveUnformatted
+			case err != nil:
+				info.State = spareDriveOffline
+			case spareFormat.ID != format.ID:
+				info.State = spareDriveInvalid
+			default:
+				if i, j, err := findDiskIndexByDiskID(format, spareFormat.Erasure.This); err == nil {
+					info.State = spareDrivePromoted
+					info.Replaces = pool.endpoints.Endpoints[i*pool.setDriveCount+j].String()
+				}
+			}
+			if disk != nil {
+				disk.Close()
+			}
+			spares = append(spares, info)
+		}
+	}
+	return spares
+}
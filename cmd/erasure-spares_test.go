@@ -0,0 +1,182 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAddSpares(t *testing.T) {
+	testCases := []struct {
+		setupType SetupType
+		args      []string
+		spares    []int
+		success   bool
+	}{
+		{ErasureSetupType, []string{"/spare{1...2}"}, []int{2, 0}, true},
+		{ErasureSetupType, []string{"/spare1", "/spare{2...4}"}, []int{1, 3}, true},
+		// More spare drives args than pools.
+		{ErasureSetupType, []string{"/spare1", "/spare2", "/spare3"}, nil, false},
+		// Spare drive already used by a pool.
+		{ErasureSetupType, []string{"/export{3...4}"}, nil, false},
+		// Duplicate spare drives.
+		{ErasureSetupType, []string{"/spare1", "/spare1"}, nil, false},
+		// Mixed style endpoints.
+		{ErasureSetupType, []string{"http://localhost:9000/spare1"}, nil, false},
+		// Single drive setup.
+		{ErasureSDSetupType, []string{"/spare1"}, nil, false},
+	}
+
+	for i, tc := range testCases {
+		pools := append(mustGetPoolEndpoints("/export1", "/export2", "/export3", "/export4"),
+			mustGetPoolEndpoints("/export5", "/export6", "/export7", "/export8")...)
+		err := pools.addSpares(":9000", tc.setupType, tc.args)
+		if (err == nil) != tc.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, tc.success, err)
+			continue
+		}
+		for j, n := range tc.spares {
+			if len(pools[j].Spares) != n {
+				t.Errorf("Test %d: expected %d spare drives in pool %d, got %d", i+1, n, j, len(pools[j].Spares))
+			}
+		}
+	}
+}
+
+func TestPromoteSpare(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fsDirs, err := getRandomDisks(17)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	pools := mustGetPoolEndpoints(fsDirs[:16]...)
+	pools[0].Spares = mustGetNewEndpoints(fsDirs[16])
+	obj, _, err := initObjectLayer(ctx, pools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+
+	setObjectLayer(obj)
+	if globalBackgroundHealState == nil {
+		globalBackgroundHealState = newHealState(GlobalContext, false)
+	}
+	z := obj.(*erasureServerPools)
+	spare := pools[0].Spares[0].String()
+
+	spares := z.ListSpares()
+	if len(spares) != 1 || spares[0].State != spareDriveAvailable {
+		t.Fatalf("expected an available spare drive, got %v", spares)
+	}
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 1<<20)
+	_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = z.PromoteSpare(ctx, pools[0].Endpoints[1].String()); err == nil {
+		t.Fatal("expected replacing an online drive to fail")
+	}
+
+	// Take the first drive offline.
+	failed := z.serverPools[0].sets[0].getDisks()[0]
+	if err = os.RemoveAll(pathJoin(fsDirs[0], minioMetaBucket, formatConfigFile)); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for failed.IsOnline() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the drive to go offline")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	promoted, err := z.PromoteSpare(ctx, failed.Endpoint().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if promoted != spare {
+		t.Fatalf("expected %s to be promoted, got %s", spare, promoted)
+	}
+
+	disk := z.serverPools[0].sets[0].getDisks()[0]
+	if disk.Endpoint().String() != spare {
+		t.Fatalf("expected %s in place of the failed drive, got %s", spare, disk)
+	}
+	if disk.Healing() == nil {
+		t.Fatal("expected the spare drive to be marked for healing")
+	}
+	spareID, err := disk.GetDiskID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// All the online drives of the pool know about the promotion.
+	for _, dir := range fsDirs[1:16] {
+		b, err := os.ReadFile(pathJoin(dir, minioMetaBucket, formatConfigFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		format := &formatErasureV3{}
+		if err = json.Unmarshal(b, format); err != nil {
+			t.Fatal(err)
+		}
+		if format.Erasure.Sets[0][0] != spareID || len(format.Replaced) != 1 {
+			t.Fatalf("expected the spare drive in the format of %s", dir)
+		}
+	}
+
+	if _, err = z.PromoteSpare(ctx, failed.Endpoint().String()); err == nil {
+		t.Fatal("expected replacing a replaced drive to fail")
+	}
+	spares = z.ListSpares()
+	if len(spares) != 2 || spares[0].State != spareDrivePromoted || spares[0].Replaces != failed.Endpoint().String() {
+		t.Fatalf("expected a promoted spare drive, got %v", spares)
+	}
+
+	// The replaced drive, once re-provisioned, is a spare drive in turn.
+	if spares[1].Endpoint != failed.Endpoint().String() {
+		t.Fatalf("expected the replaced drive to be listed, got %v", spares)
+	}
+	z.serverPools[0].formatSpares(ctx)
+	if spares = z.ListSpares(); spares[1].State != spareDriveAvailable {
+		t.Fatalf("expected the re-provisioned drive to be available, got %v", spares)
+	}
+
+	if _, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = obj.PutObject(ctx, bucket, "new", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"sync"
 
 	"github.com/dustin/go-humanize"
+	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/internal/color"
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/minio/internal/config/storageclass"
@@ -123,6 +124,9 @@ type formatErasureV3 struct {
 		// to pick the right set index for an object.
 		DistributionAlgo string `json:"distributionAlgo"`
 	} `json:"xl"`
+	// Replaced maps the uuid of each drive replaced by a spare drive in
+	// Erasure.Sets to the uuid of the spare drive.
+	Replaced map[string]string `json:"replaced,omitempty"`
 }
 
 func (f *formatErasureV3) Drives() (drives int) {
@@ -604,19 +608,87 @@ func getFormatErasureInQuorum(formats []*formatErasureV3) (*formatErasureV3, err
 		return nil, errErasureReadQuorum
 	}
 
-	for i, format := range formats {
-		if format == nil {
+	// The formats differ by the spare drives promoted, the formats which
+	// missed a promotion are reconciled later. The spare drives promoted
+	// are only taken from the formats agreeing in quorum.
+	type formatCount struct {
+		format *formatErasureV3
+		count  int
+	}
+	var counts []formatCount
+	for _, format := range formats {
+		if format == nil || format.Drives() != maxDrives {
 			continue
 		}
-		//返回当前配置相同最多的那个配置.
-		if format.Drives() == maxDrives {
-			format := formats[i].Clone()
-			format.Erasure.This = ""
-			return format, nil
+		found := false
+		for i := range counts {
+			if formatErasureSameSets(counts[i].format, format) {
+				counts[i].count++
+				found = true
+				break
+			}
+		}
+		if !found {
+			counts = append(counts, formatCount{format, 1})
+		}
+	}
+	var quorum *formatErasureV3
+	maxCount = 0
+	for _, c := range counts {
+		switch {
+		case c.count > maxCount:
+		case c.count == maxCount && formatErasureReplaces(c.format, quorum):
+			// Prefer the promotion on a tie.
+		default:
+			continue
 		}
+		quorum, maxCount = c.format, c.count
+	}
+	if quorum == nil || maxCount < len(formats)/2 {
+		return nil, errErasureReadQuorum
 	}
 
-	return nil, errErasureReadQuorum
+	format := quorum.Clone()
+	format.Erasure.This = ""
+	return format, nil
+}
+
+// formatErasureSameSets returns true if the formats have the same erasure
+// sets and the same spare drives promoted.
+func formatErasureSameSets(format, reference *formatErasureV3) bool {
+	if len(format.Replaced) != len(reference.Replaced) {
+		return false
+	}
+	for id, spare := range reference.Replaced {
+		if format.Replaced[id] != spare {
+			return false
+		}
+	}
+	return reflect.DeepEqual(format.Erasure.Sets, reference.Erasure.Sets)
+}
+
+// formatErasureReplaces returns true if format only differs from reference
+// by drives of reference replaced with spare drives in format.
+func formatErasureReplaces(format, reference *formatErasureV3) bool {
+	if format.ID != reference.ID || len(format.Erasure.Sets) != len(reference.Erasure.Sets) {
+		return false
+	}
+	var replaced bool
+	for i := range reference.Erasure.Sets {
+		if len(reference.Erasure.Sets[i]) != len(format.Erasure.Sets[i]) {
+			return false
+		}
+		for j, id := range reference.Erasure.Sets[i] {
+			if id == format.Erasure.Sets[i][j] {
+				continue
+			}
+			if format.Replaced[id] != format.Erasure.Sets[i][j] {
+				return false
+			}
+			replaced = true
+		}
+	}
+	return replaced
 }
 
 func formatErasureV3Check(reference *formatErasureV3, format *formatErasureV3) error {
@@ -860,9 +932,14 @@ func newHealFormatSets(refFormat *formatErasureV3, setCount, setDriveCount int,
 	for i := range refFormat.Erasure.Sets {
 		newFormats[i] = make([]*formatErasureV3, setDriveCount)
 	}
+	// Drives replaced by a spare drive are not formatted again.
+	promoted := set.NewStringSet()
+	for _, spare := range refFormat.Replaced {
+		promoted.Add(spare)
+	}
 	for i := range refFormat.Erasure.Sets {
 		for j := range refFormat.Erasure.Sets[i] {
-			if errors.Is(errs[i*setDriveCount+j], errUnformattedDisk) {
+			if errors.Is(errs[i*setDriveCount+j], errUnformattedDisk) && !promoted.Contains(refFormat.Erasure.Sets[i][j]) {
 				newFormats[i][j] = &formatErasureV3{}
 				newFormats[i][j].ID = refFormat.ID
 				newFormats[i][j].Format = refFormat.Format
@@ -871,6 +948,7 @@ func newHealFormatSets(refFormat *formatErasureV3, setCount, setDriveCount int,
 				newFormats[i][j].Erasure.Sets = refFormat.Erasure.Sets
 				newFormats[i][j].Erasure.Version = refFormat.Erasure.Version
 				newFormats[i][j].Erasure.DistributionAlgo = refFormat.Erasure.DistributionAlgo
+				newFormats[i][j].Replaced = refFormat.Replaced
 			}
 		}
 	}
@@ -563,3 +563,75 @@ func benchmarkInitStorageDisksN(b *testing.B, nDisks int) {
 		}
 	})
 }
+
+// Tests formatErasureReplaces and the quorum format preferring promoted spare drives.
+func TestFormatErasureReplaces(t *testing.T) {
+	format := newFormatErasureV3(2, 4)
+	spare := mustGetUUID()
+
+	promoted := format.Clone()
+	promoted.Erasure.Sets[1][2] = spare
+	promoted.Replaced = map[string]string{format.Erasure.Sets[1][2]: spare}
+
+	unknown := format.Clone()
+	unknown.Erasure.Sets[0][0] = mustGetUUID()
+	unknown.Replaced = map[string]string{format.Erasure.Sets[1][2]: spare}
+
+	otherDeployment := promoted.Clone()
+	otherDeployment.ID = mustGetUUID()
+
+	testCases := []struct {
+		format, reference *formatErasureV3
+		replaces          bool
+	}{
+		{format, format, false},
+		{promoted, format, true},
+		{format, promoted, false},
+		{unknown, format, false},
+		{otherDeployment, format, false},
+	}
+	for i, tc := range testCases {
+		if got := formatErasureReplaces(tc.format, tc.reference); got != tc.replaces {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.replaces, got)
+		}
+	}
+
+	newFormats := func(promotedDrives int, f *formatErasureV3) []*formatErasureV3 {
+		formats := make([]*formatErasureV3, 8)
+		for i := range formats {
+			formats[i] = format.Clone()
+			if i < promotedDrives {
+				formats[i] = f.Clone()
+			}
+			formats[i].Erasure.This = format.Erasure.Sets[i/4][i%4]
+		}
+		return formats
+	}
+	quorumTestCases := []struct {
+		formats []*formatErasureV3
+		quorum  *formatErasureV3
+	}{
+		{newFormats(0, promoted), format},
+		{newFormats(3, promoted), format},
+		{newFormats(4, promoted), promoted},
+		{newFormats(5, promoted), promoted},
+		// A lone format with a promotion unknown to the others.
+		{newFormats(1, unknown), format},
+	}
+	for i, tc := range quorumTestCases {
+		quorumFormat, err := getFormatErasureInQuorum(tc.formats)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(quorumFormat.Erasure.Sets, tc.quorum.Erasure.Sets) || len(quorumFormat.Replaced) != len(tc.quorum.Replaced) {
+			t.Errorf("Test %d: unexpected format in quorum", i+1)
+		}
+	}
+
+	// No format in quorum.
+	formats := newFormats(3, promoted)
+	formats[7], formats[6] = nil, nil
+	if _, err := getFormatErasureInQuorum(formats); err != errErasureReadQuorum {
+		t.Errorf("expected %v, got %v", errErasureReadQuorum, err)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/dustin/go-humanize"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/color"
+	"github.com/minio/minio/internal/config/heal"
 	"github.com/minio/minio/internal/config/storageclass"
 	"github.com/minio/minio/internal/jobtokens"
 	"github.com/minio/minio/internal/logger"
@@ -70,25 +71,65 @@ func newBgHealSequence() *healSequence {
 	}
 }
 
+// bgHealState - the background heal status, with the queues of partial
+// operations waiting to be healed.
+type bgHealState struct {
+	madmin.BgHealState
+
+	// Endpoint -> MRF queue
+	MRFQueue map[string]mrfQueueStatus `json:"mrf_queue,omitempty"`
+
+	// Healing drive endpoint -> progress per priority class
+	HealingClasses map[string][]healClassProgress `json:"healing_classes,omitempty"`
+}
+
+// Merge - merges the heal status of other nodes.
+func (s *bgHealState) Merge(others ...bgHealState) {
+	for _, other := range others {
+		s.BgHealState.Merge(other.BgHealState)
+		for node, q := range other.MRFQueue {
+			if s.MRFQueue == nil {
+				s.MRFQueue = make(map[string]mrfQueueStatus)
+			}
+			s.MRFQueue[node] = q
+		}
+		for ep, classes := range other.HealingClasses {
+			if s.HealingClasses == nil {
+				s.HealingClasses = make(map[string][]healClassProgress)
+			}
+			s.HealingClasses[ep] = classes
+		}
+	}
+}
+
 // getBackgroundHealStatus will return the
-func getBackgroundHealStatus(ctx context.Context, o ObjectLayer) (madmin.BgHealState, bool) {
+func getBackgroundHealStatus(ctx context.Context, o ObjectLayer) (bgHealState, bool) {
 	if globalBackgroundHealState == nil {
-		return madmin.BgHealState{}, false
+		return bgHealState{}, false
 	}
 
 	bgSeq, ok := globalBackgroundHealState.getHealSequenceByToken(bgHealingUUID)
 	if !ok {
-		return madmin.BgHealState{}, false
+		return bgHealState{}, false
 	}
 
-	status := madmin.BgHealState{
-		ScannedItemsCount: bgSeq.getScannedItemsCount(),
+	status := bgHealState{
+		BgHealState: madmin.BgHealState{
+			ScannedItemsCount: bgSeq.getScannedItemsCount(),
+		},
 	}
 
 	if globalMRFState.initialized() {
 		status.MRF = map[string]madmin.MRFStatus{
 			globalLocalNodeName: globalMRFState.getCurrentMRFRoundInfo(),
 		}
+		status.MRFQueue = map[string]mrfQueueStatus{
+			globalLocalNodeName: globalMRFState.getQueueStatus(),
+		}
+	}
+
+	if classes := globalBackgroundHealState.getLocalHealingClasses(); len(classes) > 0 {
+		status.HealingClasses = classes
 	}
 
 	healDisksMap := map[string]struct{}{}
@@ -196,6 +237,7 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 	}
 	// jt will never be nil since we ensure that numHealers > 0
 	jt, _ := jobtokens.New(numHealers)
+	priority := globalHealConfig.PriorityRules()
 	var retErr error
 	// Heal all buckets with all objects
 	for _, bucket := range healBuckets {
@@ -243,194 +285,280 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 			disks = disks[:3]
 		}
 
-		type healEntryResult struct {
-			bytes     uint64
-			success   bool
-			entryDone bool
-			name      string
+		if err := er.healBucketObjects(ctx, bgSeq, jt, disks, bucket, forwardTo, priority, tracker); err != nil {
+			// Set this such that when we return this function
+			// we let the caller retry this disk again for the
+			// buckets it failed to list.
+			retErr = err
+			logger.LogIf(ctx, err)
+			continue
 		}
-		healEntryDone := func(name string) healEntryResult {
-			return healEntryResult{
-				entryDone: true,
-				name:      name,
-			}
+
+		select {
+		// If context is canceled don't mark as done...
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			tracker.bucketDone(bucket)
+			logger.LogIf(ctx, tracker.update(ctx))
 		}
-		healEntrySuccess := func(sz uint64) healEntryResult {
-			return healEntryResult{
-				bytes:   sz,
-				success: true,
-			}
+	}
+	tracker.Object = ""
+	tracker.Bucket = ""
+
+	return retErr
+}
+
+// healBatchSize is the number of listed objects ordered by priority
+// before they are healed.
+const healBatchSize = 1000
+
+// healEntryPriority is the order of a listed object in its batch.
+type healEntryPriority struct {
+	class   string
+	weight  int
+	missing int
+}
+
+// healPriorityOf returns the priority class of the object, and the number
+// of listed drives it is missing from.
+func healPriorityOf(rules heal.PriorityRules, bucket, object string, missing int) healEntryPriority {
+	if r, ok := rules.Match(bucket, object); ok {
+		return healEntryPriority{class: r.Class(), weight: r.Weight, missing: missing}
+	}
+	return healEntryPriority{class: heal.DefaultClass, missing: missing}
+}
+
+// sortHealBatch returns the order in which the objects of a batch are
+// healed, by decreasing weight of their class and then by decreasing
+// number of drives they are missing from.
+func sortHealBatch(batch []healEntryPriority) []int {
+	idx := make([]int, len(batch))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		a, b := batch[idx[i]], batch[idx[j]]
+		if a.weight != b.weight {
+			return a.weight > b.weight
 		}
-		healEntryFailure := func(sz uint64) healEntryResult {
-			return healEntryResult{
-				bytes: sz,
-			}
+		return a.missing > b.missing
+	})
+	return idx
+}
+
+// healBucketObjects lists the objects of the bucket in a single pass and
+// heals them, by batches ordered by priority.
+func (er *erasureObjects) healBucketObjects(ctx context.Context, bgSeq *healSequence, jt *jobtokens.JobTokens,
+	disks []StorageAPI, bucket string, forwardTo string, priority heal.PriorityRules, tracker *healingTracker,
+) error {
+	scanMode := madmin.HealNormalScan
+
+	type healEntryResult struct {
+		bytes     uint64
+		success   bool
+		entryDone bool
+		name      string
+		priority  healEntryPriority
+	}
+	healEntryDone := func(name string) healEntryResult {
+		return healEntryResult{
+			entryDone: true,
+			name:      name,
 		}
+	}
+	healEntrySuccess := func(sz uint64) healEntryResult {
+		return healEntryResult{
+			bytes:   sz,
+			success: true,
+		}
+	}
+	healEntryFailure := func(sz uint64) healEntryResult {
+		return healEntryResult{
+			bytes: sz,
+		}
+	}
 
-		// Collect updates to tracker from concurrent healEntry calls
-		results := make(chan healEntryResult)
-		go func() {
-			for res := range results {
-				if res.entryDone {
-					tracker.Object = res.name
-					if time.Since(tracker.LastUpdate) > time.Minute {
-						logger.LogIf(ctx, tracker.update(ctx))
-					}
-					continue
+	// Collect updates to tracker from concurrent healEntry calls
+	results := make(chan healEntryResult)
+	resultsDone := make(chan struct{})
+	go func() {
+		defer close(resultsDone)
+		for res := range results {
+			if res.entryDone {
+				tracker.Object = res.name
+				if time.Since(tracker.LastUpdate) > time.Minute {
+					logger.LogIf(ctx, tracker.update(ctx))
 				}
+				continue
+			}
 
-				if res.success {
-					tracker.ItemsHealed++
-					tracker.BytesDone += res.bytes
-				} else {
-					tracker.ItemsFailed++
-					tracker.BytesFailed += res.bytes
-				}
+			class := tracker.classProgress(res.priority.class, res.priority.weight)
+			if res.success {
+				tracker.ItemsHealed++
+				tracker.BytesDone += res.bytes
+				class.ItemsHealed++
+				class.BytesDone += res.bytes
+			} else {
+				tracker.ItemsFailed++
+				tracker.BytesFailed += res.bytes
+				class.ItemsFailed++
+				class.BytesFailed += res.bytes
 			}
-		}()
+		}
+	}()
 
-		// Note: updates from healEntry to tracker must be sent on results channel.
-		healEntry := func(entry metaCacheEntry) {
-			defer jt.Give()
+	// Note: updates from healEntry to tracker must be sent on results channel.
+	healEntry := func(entry metaCacheEntry, p healEntryPriority) {
+		defer jt.Give()
 
-			if entry.name == "" && len(entry.metadata) == 0 {
-				// ignore entries that don't have metadata.
+		if entry.name == "" && len(entry.metadata) == 0 {
+			// ignore entries that don't have metadata.
+			return
+		}
+		if entry.isDir() {
+			// ignore healing entry.name's with `/` suffix.
+			return
+		}
+		// We might land at .metacache, .trash, .multipart
+		// no need to heal them skip, only when bucket
+		// is '.minio.sys'
+		if bucket == minioMetaBucket {
+			if wildcard.Match("buckets/*/.metacache/*", entry.name) {
 				return
 			}
-			if entry.isDir() {
-				// ignore healing entry.name's with `/` suffix.
+			if wildcard.Match("tmp/.trash/*", entry.name) {
 				return
 			}
-			// We might land at .metacache, .trash, .multipart
-			// no need to heal them skip, only when bucket
-			// is '.minio.sys'
-			if bucket == minioMetaBucket {
-				if wildcard.Match("buckets/*/.metacache/*", entry.name) {
-					return
-				}
-				if wildcard.Match("tmp/.trash/*", entry.name) {
-					return
-				}
-				if wildcard.Match("multipart/*", entry.name) {
-					return
-				}
+			if wildcard.Match("multipart/*", entry.name) {
+				return
 			}
+		}
 
-			var result healEntryResult
-			fivs, err := entry.fileInfoVersions(bucket)
+		var result healEntryResult
+		fivs, err := entry.fileInfoVersions(bucket)
+		if err != nil {
+			err := bgSeq.queueHealTask(healSource{
+				bucket:    bucket,
+				object:    entry.name,
+				versionID: "",
+			}, madmin.HealItemObject)
 			if err != nil {
-				err := bgSeq.queueHealTask(healSource{
-					bucket:    bucket,
-					object:    entry.name,
-					versionID: "",
-				}, madmin.HealItemObject)
-				if err != nil {
-					result = healEntryFailure(0)
-					logger.LogIf(ctx, fmt.Errorf("unable to heal object %s/%s: %w", bucket, entry.name, err))
-				} else {
-					result = healEntrySuccess(0)
-				}
-
-				select {
-				case <-ctx.Done():
-					return
-				case results <- result:
-				}
+				result = healEntryFailure(0)
+				logger.LogIf(ctx, fmt.Errorf("unable to heal object %s/%s: %w", bucket, entry.name, err))
+			} else {
+				result = healEntrySuccess(0)
+			}
+			result.priority = p
 
+			select {
+			case <-ctx.Done():
 				return
+			case results <- result:
 			}
 
-			// erasureObjects layer needs object names to be encoded
-			encodedEntryName := encodeDirObject(entry.name)
-
-			for _, version := range fivs.Versions {
-				if _, err := er.HealObject(ctx, bucket, encodedEntryName,
-					version.VersionID, madmin.HealOpts{
-						ScanMode: scanMode,
-						Remove:   healDeleteDangling,
-					}); err != nil {
-					// If not deleted, assume they failed.
-					result = healEntryFailure(uint64(version.Size))
-					if version.VersionID != "" {
-						logger.LogIf(ctx, fmt.Errorf("unable to heal object %s/%s-v(%s): %w", bucket, version.Name, version.VersionID, err))
-					} else {
-						logger.LogIf(ctx, fmt.Errorf("unable to heal object %s/%s: %w", bucket, version.Name, err))
-					}
-				} else {
-					result = healEntrySuccess(uint64(version.Size))
-				}
-				bgSeq.logHeal(madmin.HealItemObject)
+			return
+		}
 
-				select {
-				case <-ctx.Done():
-					return
-				case results <- result:
+		// erasureObjects layer needs object names to be encoded
+		encodedEntryName := encodeDirObject(entry.name)
+
+		for _, version := range fivs.Versions {
+			if _, err := er.HealObject(ctx, bucket, encodedEntryName,
+				version.VersionID, madmin.HealOpts{
+					ScanMode: scanMode,
+					Remove:   healDeleteDangling,
+				}); err != nil {
+				// If not deleted, assume they failed.
+				result = healEntryFailure(uint64(version.Size))
+				if version.VersionID != "" {
+					logger.LogIf(ctx, fmt.Errorf("unable to heal object %s/%s-v(%s): %w", bucket, version.Name, version.VersionID, err))
+				} else {
+					logger.LogIf(ctx, fmt.Errorf("unable to heal object %s/%s: %w", bucket, version.Name, err))
 				}
+			} else {
+				result = healEntrySuccess(uint64(version.Size))
 			}
+			result.priority = p
+			bgSeq.logHeal(madmin.HealItemObject)
+
 			select {
 			case <-ctx.Done():
 				return
-			case results <- healEntryDone(entry.name):
+			case results <- result:
 			}
-
-			// Wait and proceed if there are active requests
-			waitForLowHTTPReq()
 		}
+		// Wait and proceed if there are active requests
+		waitForLowHTTPReq()
+	}
 
-		// How to resolve partial results.
-		resolver := metadataResolutionParams{
-			dirQuorum: 1,
-			objQuorum: 1,
-			bucket:    bucket,
+	var batch []metaCacheEntry
+	var priorities []healEntryPriority
+	healBatch := func() {
+		if len(batch) == 0 {
+			return
 		}
-
-		err = listPathRaw(ctx, listPathRawOptions{
-			disks:          disks,
-			bucket:         bucket,
-			recursive:      true,
-			forwardTo:      forwardTo,
-			minDisks:       1,
-			reportNotFound: false,
-			agreed: func(entry metaCacheEntry) {
-				jt.Take()
-				go healEntry(entry)
-			},
-			partial: func(entries metaCacheEntries, _ []error) {
-				entry, ok := entries.resolve(&resolver)
-				if !ok {
-					// check if we can get one entry atleast
-					// proceed to heal nonetheless.
-					entry, _ = entries.firstFound()
-				}
-				jt.Take()
-				go healEntry(*entry)
-			},
-			finished: nil,
-		})
-		jt.Wait() // synchronize all the concurrent heal jobs
-		close(results)
-		if err != nil {
-			// Set this such that when we return this function
-			// we let the caller retry this disk again for the
-			// buckets it failed to list.
-			retErr = err
-			logger.LogIf(ctx, err)
-			continue
+		// Entries are listed in order, resume after the last one.
+		last := batch[len(batch)-1].name
+		for _, i := range sortHealBatch(priorities) {
+			jt.Take()
+			go healEntry(batch[i], priorities[i])
 		}
-
+		jt.Wait()
 		select {
-		// If context is canceled don't mark as done...
 		case <-ctx.Done():
-			return ctx.Err()
-		default:
-			tracker.bucketDone(bucket)
-			logger.LogIf(ctx, tracker.update(ctx))
+		case results <- healEntryDone(last):
+		}
+		batch, priorities = batch[:0], priorities[:0]
+	}
+	addEntry := func(entry metaCacheEntry, missing int) {
+		batch = append(batch, entry)
+		priorities = append(priorities, healPriorityOf(priority, bucket, entry.name, missing))
+		if len(batch) >= healBatchSize {
+			healBatch()
 		}
 	}
-	tracker.Object = ""
-	tracker.Bucket = ""
 
-	return retErr
+	// How to resolve partial results.
+	resolver := metadataResolutionParams{
+		dirQuorum: 1,
+		objQuorum: 1,
+		bucket:    bucket,
+	}
+
+	err := listPathRaw(ctx, listPathRawOptions{
+		disks:          disks,
+		bucket:         bucket,
+		recursive:      true,
+		forwardTo:      forwardTo,
+		minDisks:       1,
+		reportNotFound: false,
+		agreed: func(entry metaCacheEntry) {
+			addEntry(entry, 0)
+		},
+		partial: func(entries metaCacheEntries, _ []error) {
+			entry, ok := entries.resolve(&resolver)
+			if !ok {
+				// check if we can get one entry atleast
+				// proceed to heal nonetheless.
+				entry, _ = entries.firstFound()
+			}
+			// Objects missing from more of the listed drives
+			// are healed first.
+			missing := len(entries)
+			for i := range entries {
+				if _, ok := entries[i].matches(entry, false); ok {
+					missing--
+				}
+			}
+			addEntry(*entry, missing)
+		},
+		finished: nil,
+	})
+	healBatch()
+	close(results)
+	<-resultsDone
+	return err
 }
 
 // healObject heals given object path in deep to fix bitrot.
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/internal/config/heal"
+)
+
+func TestHealPriority(t *testing.T) {
+	var cfg heal.Config
+	cfg.Update(heal.Config{Priority: "logs/audit/:100,logs/tmp/:1,logs:50,critical/db/:20"})
+	rules := cfg.PriorityRules()
+
+	testCases := []struct {
+		bucket, object string
+		expected       healEntryPriority
+	}{
+		{"logs", "audit/1.log", healEntryPriority{class: "logs/audit/", weight: 100}},
+		{"logs", "tmp/1.log", healEntryPriority{class: "logs/tmp/", weight: 1}},
+		{"logs", "app/1.log", healEntryPriority{class: "logs", weight: 50}},
+		{"critical", "db/1.db", healEntryPriority{class: "critical/db/", weight: 20}},
+		{"critical", "web/index.html", healEntryPriority{class: heal.DefaultClass}},
+		{"other", "object", healEntryPriority{class: heal.DefaultClass}},
+	}
+	var batch []healEntryPriority
+	for _, testCase := range testCases {
+		p := healPriorityOf(rules, testCase.bucket, testCase.object, 0)
+		if p != testCase.expected {
+			t.Errorf("%s/%s: expected %v, got %v", testCase.bucket, testCase.object, testCase.expected, p)
+		}
+		batch = append(batch, p)
+	}
+
+	// Objects are healed by decreasing weight, then by decreasing number
+	// of drives they are missing from.
+	batch = append(batch, healPriorityOf(rules, "logs", "tmp/2.log", 2), healPriorityOf(rules, "other", "missing", 1))
+	if order := sortHealBatch(batch); !reflect.DeepEqual(order, []int{0, 2, 3, 6, 1, 7, 4, 5}) {
+		t.Errorf("unexpected heal order %v", order)
+	}
+}
+
+func TestHealingTrackerClasses(t *testing.T) {
+	var h healingTracker
+	h.classProgress(heal.DefaultClass, 0).ItemsHealed++
+	h.classProgress("logs", 50).ItemsHealed++
+	h.bucketDone("logs")
+
+	// Progress of the next bucket is lost when its healing is resumed
+	// from the start of the bucket.
+	h.classProgress("logs", 50).ItemsFailed++
+	h.classProgress(heal.DefaultClass, 0).BytesDone += 10
+	h.resume()
+
+	expected := []healClassProgress{
+		{Class: "logs", Weight: 50, ItemsHealed: 1, ResumeItemsHealed: 1},
+		{Class: heal.DefaultClass, ItemsHealed: 1, ResumeItemsHealed: 1},
+	}
+	if !reflect.DeepEqual(h.Classes, expected) {
+		t.Fatalf("Expected classes %v, got %v", expected, h.Classes)
+	}
+}
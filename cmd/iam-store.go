@@ -1729,6 +1729,9 @@ func (store *IAMStoreSys) DeleteUsers(ctx context.Context, users []string) error
 type ParentUserInfo struct {
 	subClaimValue string
 	roleArns      set.StringSet
+	// ldapUser is set if a credential of the parent user was issued
+	// by LDAP STS.
+	ldapUser bool
 }
 
 // GetAllParentUsers - returns all distinct "parent-users" associated with STS
@@ -1781,16 +1784,19 @@ func (store *IAMStoreSys) GetAllParentUsers() map[string]ParentUserInfo {
 		if ok && ok2 {
 			roleArn = val
 		}
+		_, isLDAPUser := claims[ldapUser]
 		v, ok := res[cred.ParentUser]
 		if ok {
 			res[cred.ParentUser] = ParentUserInfo{
 				subClaimValue: subClaimValue,
 				roleArns:      v.roleArns.Union(set.CreateStringSet(roleArn)),
+				ldapUser:      v.ldapUser || isLDAPUser,
 			}
 		} else {
 			res[cred.ParentUser] = ParentUserInfo{
 				subClaimValue: subClaimValue,
 				roleArns:      set.CreateStringSet(roleArn),
+				ldapUser:      isLDAPUser,
 			}
 		}
 	}
@@ -1942,6 +1948,18 @@ func (store *IAMStoreSys) UpdateServiceAccount(ctx context.Context, accessKey st
 		m[iamPolicyClaimNameSA()] = embeddedPolicyType
 	}
 
+	if opts.expiration != nil {
+		if err := setSvcAccExpiryClaim(m, *opts.expiration); err != nil {
+			return updatedAt, err
+		}
+	}
+
+	if opts.allowedIPs != nil {
+		if err := setSvcAccAllowedIPsClaim(m, opts.allowedIPs); err != nil {
+			return updatedAt, err
+		}
+	}
+
 	cr.SessionToken, err = auth.JWTSignWithAccessKey(accessKey, m, cr.SecretKey)
 	if err != nil {
 		return updatedAt, err
@@ -2097,6 +2115,21 @@ func (store *IAMStoreSys) GetSTSAndServiceAccounts() []auth.Credentials {
 	return res
 }
 
+// ListAccessKeys - returns the identities of all users and service accounts
+// from the cache.
+func (store *IAMStoreSys) ListAccessKeys() []UserIdentity {
+	cache := store.rlock()
+	defer store.runlock()
+
+	var res []UserIdentity
+	for _, u := range cache.iamUsersMap {
+		if !u.Credentials.IsTemp() {
+			res = append(res, u)
+		}
+	}
+	return res
+}
+
 // UpdateUserIdentity - updates a user credential.
 func (store *IAMStoreSys) UpdateUserIdentity(ctx context.Context, cred auth.Credentials) error {
 	cache := store.lock()
@@ -0,0 +1,297 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Usage recorded in memory is persisted at most this often, to avoid a
+// write to the IAM store for every request.
+const iamUsagePersistInterval = 5 * time.Minute
+
+// Each server persists the usage it recorded in an object of its own under
+// this prefix, servers never overwrite each other's counts nor the IAM
+// identities.
+const iamConfigAccessKeyUsagePrefix = iamConfigPrefix + "/access-key-usage/"
+
+// getAccessKeyUsagePath - returns the path of the usage persisted by node,
+// node names are encoded as they contain a port separator.
+func getAccessKeyUsagePath(node string) string {
+	return iamConfigAccessKeyUsagePrefix + base64.RawURLEncoding.EncodeToString([]byte(node)) + ".json"
+}
+
+// accessKeyUsageNodes - returns the servers of the deployment, which may
+// have persisted usage.
+func accessKeyUsageNodes() []string {
+	nodes, _ := globalEndpoints.peers()
+	if len(nodes) == 0 {
+		return []string{globalLocalNodeName}
+	}
+	return nodes
+}
+
+// accessKeyUsage - records when and from where an access key was last
+// used to authenticate a request and how many requests it authenticated.
+type accessKeyUsage struct {
+	LastUsed     time.Time `json:"lastUsed"`
+	LastSourceIP string    `json:"lastSourceIP,omitempty"`
+	Calls        uint64    `json:"calls,omitempty"`
+}
+
+// merge - returns the usage combining u and o, both are expected to
+// count distinct requests.
+func (u accessKeyUsage) merge(o accessKeyUsage) accessKeyUsage {
+	if o.LastUsed.After(u.LastUsed) {
+		u.LastUsed = o.LastUsed
+		u.LastSourceIP = o.LastSourceIP
+	}
+	u.Calls += o.Calls
+	return u
+}
+
+// iamUsageTracker - accumulates access key usage on this server until
+// it is persisted with the IAM identities.
+type iamUsageTracker struct {
+	mu      sync.Mutex
+	pending map[string]accessKeyUsage
+}
+
+func newIAMUsageTracker() *iamUsageTracker {
+	return &iamUsageTracker{pending: make(map[string]accessKeyUsage)}
+}
+
+// record - records that accessKey authenticated a request from sourceIP
+// at now.
+func (t *iamUsageTracker) record(accessKey, sourceIP string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pending[accessKey] = t.pending[accessKey].merge(accessKeyUsage{
+		LastUsed:     now,
+		LastSourceIP: sourceIP,
+		Calls:        1,
+	})
+}
+
+// get - returns the usage of accessKey not yet persisted.
+func (t *iamUsageTracker) get(accessKey string) (accessKeyUsage, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	u, ok := t.pending[accessKey]
+	return u, ok
+}
+
+// snapshot - returns a copy of all usage not yet persisted.
+func (t *iamUsageTracker) snapshot() map[string]accessKeyUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pending := make(map[string]accessKeyUsage, len(t.pending))
+	for accessKey, u := range t.pending {
+		pending[accessKey] = u
+	}
+	return pending
+}
+
+// drain - returns and clears all usage not yet persisted.
+func (t *iamUsageTracker) drain() map[string]accessKeyUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pending := t.pending
+	t.pending = make(map[string]accessKeyUsage, len(pending))
+	return pending
+}
+
+// restore - adds back usage that could not be persisted.
+func (t *iamUsageTracker) restore(usage map[string]accessKeyUsage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for accessKey, u := range usage {
+		t.pending[accessKey] = t.pending[accessKey].merge(u)
+	}
+}
+
+// forget - drops any usage recorded for a removed access key.
+func (t *iamUsageTracker) forget(accessKey string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.pending, accessKey)
+}
+
+// recordAccessKeyUsage - records that cred was used to authenticate a
+// request from sourceIP, must only be called once the request signature
+// is verified. Only the usage of users and service accounts is tracked,
+// sourceIP may be empty when the request has no network source.
+func (sys *IAMSys) recordAccessKeyUsage(cred auth.Credentials, sourceIP string) {
+	if cred.IsTemp() || cred.AccessKey == globalActiveCred.AccessKey {
+		return
+	}
+	sys.usage.record(cred.AccessKey, sourceIP, UTCNow())
+}
+
+// loadAccessKeyUsage - returns the usage persisted by all servers.
+func (sys *IAMSys) loadAccessKeyUsage(ctx context.Context) (map[string]accessKeyUsage, error) {
+	usage := make(map[string]accessKeyUsage)
+	for _, node := range accessKeyUsageNodes() {
+		nodeUsage := make(map[string]accessKeyUsage)
+		err := sys.store.loadIAMConfig(ctx, &nodeUsage, getAccessKeyUsagePath(node))
+		if err == errConfigNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for accessKey, u := range nodeUsage {
+			usage[accessKey] = usage[accessKey].merge(u)
+		}
+	}
+	return usage, nil
+}
+
+// GetAccessKeyUsage - returns the usage of the access key, combining what
+// all servers persisted with what this server recorded since.
+func (sys *IAMSys) GetAccessKeyUsage(ctx context.Context, accessKey string) (accessKeyUsage, error) {
+	persisted, err := sys.loadAccessKeyUsage(ctx)
+	if err != nil {
+		return accessKeyUsage{}, err
+	}
+	usage := persisted[accessKey]
+	if pending, ok := sys.usage.get(accessKey); ok {
+		usage = usage.merge(pending)
+	}
+	return usage, nil
+}
+
+// persistAccessKeyUsage - adds the usage recorded on this server to the
+// usage it persisted, usage that fails to save is retried on the next call.
+// Only this server writes its usage, so no lock is needed, usage of removed
+// access keys is dropped.
+func (sys *IAMSys) persistAccessKeyUsage(ctx context.Context) {
+	if !sys.Initialized() {
+		return
+	}
+
+	pending := sys.usage.drain()
+	if len(pending) == 0 {
+		return
+	}
+
+	path := getAccessKeyUsagePath(globalLocalNodeName)
+	usage := make(map[string]accessKeyUsage)
+	if err := sys.store.loadIAMConfig(ctx, &usage, path); err != nil && err != errConfigNotFound {
+		logger.LogIf(ctx, err)
+		sys.usage.restore(pending)
+		return
+	}
+	for accessKey, u := range pending {
+		usage[accessKey] = usage[accessKey].merge(u)
+	}
+	for accessKey := range usage {
+		if u, ok := sys.store.GetUser(accessKey); !ok || u.Credentials.IsTemp() {
+			delete(usage, accessKey)
+		}
+	}
+
+	if err := sys.store.saveIAMConfig(ctx, usage, path); err != nil {
+		logger.LogIf(ctx, err)
+		sys.usage.restore(pending)
+	}
+}
+
+// accessKeyUsageInfo - usage of a user or service account as reported by
+// the ListAccessKeyUsage admin API.
+type accessKeyUsageInfo struct {
+	AccessKey    string     `json:"accessKey"`
+	ParentUser   string     `json:"parentUser,omitempty"`
+	AccountType  string     `json:"accountType"`
+	Status       string     `json:"status"`
+	LastUsed     *time.Time `json:"lastUsed,omitempty"`
+	LastSourceIP string     `json:"lastSourceIP,omitempty"`
+	Calls        uint64     `json:"calls"`
+}
+
+// Account types reported in accessKeyUsageInfo.
+const (
+	accessKeyUsageUser           = "user"
+	accessKeyUsageServiceAccount = "service-account"
+)
+
+// ListAccessKeyUsage - returns the usage of all users and service accounts,
+// combining what all servers persisted with what they recorded since. When
+// unusedSince is not zero only the credentials not used since then are
+// returned, including those never used.
+func (sys *IAMSys) ListAccessKeyUsage(ctx context.Context, unusedSince time.Time) ([]accessKeyUsageInfo, error) {
+	if !sys.Initialized() {
+		return nil, errServerNotInitialized
+	}
+
+	persisted, err := sys.loadAccessKeyUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending map[string]accessKeyUsage
+	if globalNotificationSys != nil {
+		pending = globalNotificationSys.GetAccessKeyUsage(ctx)
+	} else {
+		pending = sys.usage.snapshot()
+	}
+
+	var res []accessKeyUsageInfo
+	for _, u := range sys.store.ListAccessKeys() {
+		usage := persisted[u.Credentials.AccessKey].merge(pending[u.Credentials.AccessKey])
+
+		if !unusedSince.IsZero() && !usage.LastUsed.Before(unusedSince) {
+			continue
+		}
+
+		info := accessKeyUsageInfo{
+			AccessKey:    u.Credentials.AccessKey,
+			AccountType:  accessKeyUsageUser,
+			Status:       u.Credentials.Status,
+			LastSourceIP: usage.LastSourceIP,
+			Calls:        usage.Calls,
+		}
+		if u.Credentials.IsServiceAccount() {
+			info.AccountType = accessKeyUsageServiceAccount
+			info.ParentUser = u.Credentials.ParentUser
+		}
+		if !usage.LastUsed.IsZero() {
+			lastUsed := usage.LastUsed
+			info.LastUsed = &lastUsed
+		}
+		res = append(res, info)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].AccessKey < res[j].AccessKey
+	})
+	return res, nil
+}
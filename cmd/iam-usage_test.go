@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIAMUsageTracker(t *testing.T) {
+	t1 := time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Minute)
+
+	tracker := newIAMUsageTracker()
+	tracker.record("key", "10.0.0.2", t2)
+	tracker.record("key", "10.0.0.1", t1)
+
+	u, ok := tracker.get("key")
+	if !ok {
+		t.Fatal("expected usage to be recorded")
+	}
+	if !u.LastUsed.Equal(t2) || u.LastSourceIP != "10.0.0.2" || u.Calls != 2 {
+		t.Fatalf("unexpected usage %#v", u)
+	}
+
+	pending := tracker.drain()
+	if _, ok = tracker.get("key"); ok {
+		t.Fatal("expected usage to be drained")
+	}
+
+	// Usage that failed to persist is combined with usage recorded since.
+	tracker.record("key", "10.0.0.1", t1)
+	tracker.restore(pending)
+	u, _ = tracker.get("key")
+	if !u.LastUsed.Equal(t2) || u.LastSourceIP != "10.0.0.2" || u.Calls != 3 {
+		t.Fatalf("unexpected usage after restore %#v", u)
+	}
+
+	snapshot := tracker.snapshot()
+	tracker.forget("key")
+	if _, ok = tracker.get("key"); ok {
+		t.Fatal("expected usage to be forgotten")
+	}
+	if snapshot["key"] != u {
+		t.Fatalf("snapshot changed after forget: %#v", snapshot["key"])
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"net"
 	"path"
 	"sort"
 	"strings"
@@ -42,6 +43,7 @@ import (
 	xldap "github.com/minio/minio/internal/config/identity/ldap"
 	"github.com/minio/minio/internal/config/identity/openid"
 	idplugin "github.com/minio/minio/internal/config/identity/plugin"
+	"github.com/minio/minio/internal/config/identity/saml"
 	"github.com/minio/minio/internal/config/policy/opa"
 	polplugin "github.com/minio/minio/internal/config/policy/plugin"
 	xhttp "github.com/minio/minio/internal/http"
@@ -75,6 +77,10 @@ const (
 	inheritedPolicyType = "inherited-policy"
 )
 
+// iamPurgeLockTimeout bounds how long a server waits to become the one
+// purging expired service accounts.
+var iamPurgeLockTimeout = newDynamicTimeout(10*time.Second, 5*time.Second)
+
 // IAMSys - config system.
 type IAMSys struct {
 	// Need to keep them here to keep alignment - ref: https://golang.org/pkg/sync/atomic/#pkg-note-BUG
@@ -99,6 +105,10 @@ type IAMSys struct {
 
 	// configLoaded will be closed and remain so after first load.
 	configLoaded chan struct{}
+
+	// usage accumulates access key usage on this server until it is
+	// persisted with the IAM identities.
+	usage *iamUsageTracker
 }
 
 // IAMUserType represents a user type inside MinIO server
@@ -225,6 +235,12 @@ func (sys *IAMSys) Init(ctx context.Context, objAPI ObjectLayer, etcdClient *etc
 		logger.LogIf(ctx, fmt.Errorf("Unable to parse LDAP configuration: %w", err))
 	}
 
+	globalSAMLConfig, err = saml.LookupConfig(s[config.IdentitySAMLSubSys][config.Default],
+		NewHTTPTransport(), xhttp.DrainBody, globalSite.Region)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to initialize SAML: %w", err))
+	}
+
 	authNPluginCfg, err := idplugin.LookupConfig(s[config.IdentityPluginSubSys][config.Default],
 		NewHTTPTransport(), xhttp.DrainBody, globalSite.Region)
 	if err != nil {
@@ -354,6 +370,46 @@ func (sys *IAMSys) Init(ctx context.Context, objAPI ObjectLayer, etcdClient *etc
 		}()
 	}
 
+	// Set up health checks of the LDAP servers, so that failed servers
+	// are used again once they recover.
+	if sys.ldapConfig.Enabled() {
+		go sys.ldapConfig.MonitorServers(ctx)
+	}
+
+	// Set up polling for expired service accounts.
+	go func() {
+		timer := time.NewTimer(refreshInterval)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-timer.C:
+				sys.purgeExpiredServiceAccounts(ctx, objAPI)
+
+				timer.Reset(refreshInterval)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Periodically persist access key usage recorded on this server.
+	go func() {
+		timer := time.NewTimer(iamUsagePersistInterval)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-timer.C:
+				sys.persistAccessKeyUsage(ctx)
+
+				timer.Reset(iamUsagePersistInterval)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
 	// Start watching changes to storage.
 	go sys.watch(ctx)
 
@@ -365,6 +421,11 @@ func (sys *IAMSys) Init(ctx context.Context, objAPI ObjectLayer, etcdClient *etc
 		sys.validateAndAddRolePolicyMappings(ctx, riMap)
 	}
 
+	// From SAML
+	if riMap := globalSAMLConfig.GetRoleInfo(); riMap != nil {
+		sys.validateAndAddRolePolicyMappings(ctx, riMap)
+	}
+
 	// From AuthN plugin if enabled.
 	if authn := newGlobalAuthNPluginFn(); authn != nil {
 		riMap := authn.GetRoleInfo()
@@ -896,6 +957,138 @@ type newServiceAccountOpts struct {
 	claims map[string]interface{}
 }
 
+const (
+	// JWT claim holding the unix time after which a service account
+	// can no longer be used.
+	svcAccExpiryClaim = "svcAccExpiry"
+
+	// JWT claim holding the comma separated list of networks (in CIDR
+	// notation) a service account may be used from.
+	svcAccAllowedIPsClaim = "svcAccAllowedIPs"
+)
+
+// svcAccRestrictions - expiry and source network restrictions of a
+// service account, as recorded in its claims.
+type svcAccRestrictions struct {
+	expiration time.Time
+	allowedIPs []*net.IPNet
+}
+
+// parseSvcAccRestrictions - extracts the restrictions of a service account
+// from its claims.
+func parseSvcAccRestrictions(claims map[string]interface{}) (r svcAccRestrictions, err error) {
+	if v, ok := claims[svcAccExpiryClaim]; ok {
+		expAt, err := auth.ExpToInt64(v)
+		if err != nil {
+			return r, err
+		}
+		if expAt > 0 {
+			r.expiration = time.Unix(expAt, 0).UTC()
+		}
+	}
+	if v, ok := claims[svcAccAllowedIPsClaim]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return r, errIAMServiceAccountInvalidAllowedIPs
+		}
+		r.allowedIPs, err = parseSvcAccAllowedIPs(strings.Split(s, ","))
+		if err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+// parseSvcAccAllowedIPs - parses a list of IP addresses or CIDR ranges,
+// single addresses are treated as a network of one host.
+func parseSvcAccAllowedIPs(ips []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(ips))
+	for _, ip := range ips {
+		ip = strings.TrimSpace(ip)
+		if ip == "" {
+			continue
+		}
+		if !strings.Contains(ip, "/") {
+			addr := net.ParseIP(ip)
+			if addr == nil {
+				return nil, errIAMServiceAccountInvalidAllowedIPs
+			}
+			bits := 8 * net.IPv4len
+			if addr.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			ip = fmt.Sprintf("%s/%d", addr, bits)
+		}
+		_, ipNet, err := net.ParseCIDR(ip)
+		if err != nil {
+			return nil, errIAMServiceAccountInvalidAllowedIPs
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// IsExpired - returns whether the service account expiry has passed.
+func (r svcAccRestrictions) IsExpired(now time.Time) bool {
+	return !r.expiration.IsZero() && !now.Before(r.expiration)
+}
+
+// IsSourceAllowed - returns whether the service account may be used from
+// the given source IP address.
+func (r svcAccRestrictions) IsSourceAllowed(sourceIP string) bool {
+	if len(r.allowedIPs) == 0 {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(sourceIP, "[]"))
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range r.allowedIPs {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedIPs - returns the allowed source networks in CIDR notation.
+func (r svcAccRestrictions) AllowedIPs() []string {
+	ips := make([]string, 0, len(r.allowedIPs))
+	for _, ipNet := range r.allowedIPs {
+		ips = append(ips, ipNet.String())
+	}
+	return ips
+}
+
+// setSvcAccExpiryClaim - records the expiry of a service account in its
+// claims, a zero expiration removes any existing expiry.
+func setSvcAccExpiryClaim(claims map[string]interface{}, expiration time.Time) error {
+	if expiration.IsZero() {
+		delete(claims, svcAccExpiryClaim)
+		return nil
+	}
+	if !expiration.After(UTCNow()) {
+		return errIAMServiceAccountInvalidExpiry
+	}
+	claims[svcAccExpiryClaim] = expiration.Unix()
+	return nil
+}
+
+// setSvcAccAllowedIPsClaim - records the source networks a service account
+// is restricted to in its claims, an empty list removes the restriction.
+func setSvcAccAllowedIPsClaim(claims map[string]interface{}, allowedIPs []string) error {
+	nets, err := parseSvcAccAllowedIPs(allowedIPs)
+	if err != nil {
+		return err
+	}
+	if len(nets) == 0 {
+		delete(claims, svcAccAllowedIPsClaim)
+		return nil
+	}
+	claims[svcAccAllowedIPsClaim] = strings.Join(svcAccRestrictions{allowedIPs: nets}.AllowedIPs(), ",")
+	return nil
+}
+
 // NewServiceAccount - create a new service account
 func (sys *IAMSys) NewServiceAccount(ctx context.Context, parentUser string, groups []string, opts newServiceAccountOpts) (auth.Credentials, time.Time, error) {
 	if !sys.Initialized() {
@@ -976,6 +1169,10 @@ type updateServiceAccountOpts struct {
 	sessionPolicy *iampolicy.Policy
 	secretKey     string
 	status        string
+
+	// expiration and allowedIPs are left unchanged when nil.
+	expiration *time.Time
+	allowedIPs []string
 }
 
 // UpdateServiceAccount - edit a service account
@@ -1048,6 +1245,16 @@ func (sys *IAMSys) getServiceAccount(ctx context.Context, accessKey string) (Use
 	return sa, embeddedPolicy, nil
 }
 
+// getSvcAccRestrictions - returns the expiry and source network
+// restrictions recorded in the claims of a service account credential.
+func getSvcAccRestrictions(cred auth.Credentials) (svcAccRestrictions, error) {
+	claims, err := getClaimsFromTokenWithSecret(cred.SessionToken, cred.SecretKey)
+	if err != nil {
+		return svcAccRestrictions{}, err
+	}
+	return parseSvcAccRestrictions(claims)
+}
+
 func (sys *IAMSys) getTempAccount(ctx context.Context, accessKey string) (UserIdentity, *iampolicy.Policy, error) {
 	tmpAcc, embeddedPolicy, err := sys.getAccountWithEmbeddedPolicy(ctx, accessKey)
 	if err != nil {
@@ -1138,6 +1345,7 @@ func (sys *IAMSys) DeleteServiceAccount(ctx context.Context, accessKey string, n
 	if err := sys.store.DeleteUser(ctx, accessKey, svcUser); err != nil {
 		return err
 	}
+	sys.usage.forget(accessKey)
 
 	if notifyPeers && !sys.HasWatcher() {
 		for _, nerr := range globalNotificationSys.DeleteServiceAccount(accessKey) {
@@ -1239,13 +1447,64 @@ func (sys *IAMSys) purgeExpiredCredentialsForExternalSSO(ctx context.Context) {
 	_ = sys.store.DeleteUsers(ctx, expiredUsers)
 }
 
+// purgeExpiredServiceAccounts - removes service accounts whose expiry has
+// passed, notifying peers and replicated sites like an explicit removal.
+// Only one server in the cluster purges at a time, the others skip the
+// cycle while the lock is held.
+func (sys *IAMSys) purgeExpiredServiceAccounts(ctx context.Context, objAPI ObjectLayer) {
+	locker := objAPI.NewNSLock(minioMetaBucket, "iam/purge-expired-service-accounts.lock")
+	lkctx, err := locker.GetLock(ctx, iamPurgeLockTimeout)
+	if err != nil {
+		// Another server is purging expired service accounts.
+		return
+	}
+	ctx = lkctx.Context()
+	defer locker.Unlock(lkctx.Cancel)
+
+	now := UTCNow()
+	for _, cred := range sys.store.GetSTSAndServiceAccounts() {
+		if !cred.IsServiceAccount() {
+			continue
+		}
+		r, err := getSvcAccRestrictions(cred)
+		if err != nil {
+			logger.LogIf(GlobalContext, fmt.Errorf("service account %s has invalid restrictions: %w", cred.AccessKey, err))
+			continue
+		}
+		if !r.IsExpired(now) {
+			continue
+		}
+
+		if err = sys.DeleteServiceAccount(ctx, cred.AccessKey, true); err != nil {
+			logger.LogIf(GlobalContext, err)
+			continue
+		}
+
+		// Call site replication hook - non-root user accounts are replicated.
+		if cred.ParentUser != globalActiveCred.AccessKey {
+			logger.LogIf(GlobalContext, globalSiteReplicationSys.IAMChangeHook(ctx, madmin.SRIAMItem{
+				Type: madmin.SRIAMItemSvcAcc,
+				SvcAccChange: &madmin.SRSvcAccChange{
+					Delete: &madmin.SRSvcAccDelete{
+						AccessKey: cred.AccessKey,
+					},
+				},
+				UpdatedAt: UTCNow(),
+			}))
+		}
+	}
+}
+
 // purgeExpiredCredentialsForLDAP - validates if local credentials are still
 // valid by checking LDAP server if the relevant users are still present.
 func (sys *IAMSys) purgeExpiredCredentialsForLDAP(ctx context.Context) {
 	parentUsers := sys.store.GetAllParentUsers()
 	var allDistNames []string
-	for parentUser := range parentUsers {
-		if !sys.ldapConfig.IsLDAPUserDN(parentUser) {
+	for parentUser, info := range parentUsers {
+		// Users issued credentials by LDAP STS are checked even when
+		// they are no longer under the base DN of any LDAP
+		// configuration, they are not eligible anymore then.
+		if !info.ldapUser && !sys.ldapConfig.IsLDAPUserDN(parentUser) {
 			continue
 		}
 
@@ -1254,9 +1513,9 @@ func (sys *IAMSys) purgeExpiredCredentialsForLDAP(ctx context.Context) {
 
 	expiredUsers, err := sys.ldapConfig.GetNonEligibleUserDistNames(allDistNames)
 	if err != nil {
-		// Log and return on error - perhaps it'll work the next time.
+		// Log and purge the users found expired in the reachable
+		// directories - the others may be purged the next time.
 		logger.LogIf(GlobalContext, err)
-		return
 	}
 
 	// We ignore any errors
@@ -1324,14 +1583,17 @@ func (sys *IAMSys) updateGroupMembershipsForLDAP(ctx context.Context) {
 	// 2. Query LDAP server for groups of the LDAP users collected.
 	updatedGroups, err := sys.ldapConfig.LookupGroupMemberships(parentUsers, parentUserToLDAPUsernameMap)
 	if err != nil {
-		// Log and return on error - perhaps it'll work the next time.
+		// Log and update the users found in the reachable directories -
+		// the others may be updated the next time.
 		logger.LogIf(GlobalContext, err)
-		return
 	}
 
 	// 3. Update creds for those users whose groups are changed
 	for _, parentUser := range parentUsers {
-		currGroupsSet := updatedGroups[parentUser]
+		currGroupsSet, ok := updatedGroups[parentUser]
+		if !ok {
+			continue
+		}
 		currGroups := currGroupsSet.ToSlice()
 		for _, cred := range parentUserToCredsMap[parentUser] {
 			gSet := set.CreateStringSet(cred.Groups...)
@@ -1717,10 +1979,17 @@ func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args, parentUser string) bool {
 	args.ConditionValues["username"] = []string{parentUser}
 	args.ConditionValues["userid"] = []string{parentUser}
 
-	// Now check if we have a sessionPolicy.
+	// Session tags are only ever taken from the signed claims.
+	for k, v := range getSessionTagsFromClaims(args.Claims) {
+		args.ConditionValues[principalTagPrefix+k] = []string{v}
+	}
+
+	// Now check if we have a sessionPolicy, the inline and managed session
+	// policies together limit the permissions of the session.
 	hasSessionPolicy, isAllowedSP := isAllowedBySessionPolicy(args)
-	if hasSessionPolicy {
-		return isAllowedSP && (isOwnerDerived || combinedPolicy.IsAllowed(args))
+	hasManagedSessionPolicy, isAllowedMSP := sys.isAllowedByManagedSessionPolicy(args)
+	if hasSessionPolicy || hasManagedSessionPolicy {
+		return (isAllowedSP || isAllowedMSP) && (isOwnerDerived || combinedPolicy.IsAllowed(args))
 	}
 
 	// Sub policy not set, this is most common since subPolicy
@@ -1772,6 +2041,14 @@ func (sys *IAMSys) GetCombinedPolicy(policies ...string) iampolicy.Policy {
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
+	return sys.isAllowedAs(args, nil)
+}
+
+// isAllowedAs - checks the policy args as IsAllowed does, with the given
+// credentials of the account instead of its stored ones when not nil. The
+// policy simulator uses it for principals without stored credentials, i.e.
+// groups, roles and LDAP users.
+func (sys *IAMSys) isAllowedAs(args iampolicy.Args, cred *auth.Credentials) bool {
 	// If opa is configured, use OPA always.
 	if authz := newGlobalAuthZPluginFn(); authz != nil {
 		ok, err := authz.IsAllowed(args)
@@ -1786,26 +2063,29 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 		return true
 	}
 
-	// If the credential is temporary, perform STS related checks.
-	ok, parentUser, err := sys.IsTempUser(args.AccountName)
-	if err != nil {
-		return false
+	if cred == nil {
+		if !sys.Initialized() {
+			return false
+		}
+		u, ok := sys.store.GetUser(args.AccountName)
+		if !ok {
+			return false
+		}
+		cred = &u.Credentials
 	}
-	if ok {
-		return sys.IsAllowedSTS(args, parentUser)
+
+	// If the credential is temporary, perform STS related checks.
+	if cred.IsTemp() {
+		return sys.IsAllowedSTS(args, cred.ParentUser)
 	}
 
 	// If the credential is for a service account, perform related check
-	ok, parentUser, err = sys.IsServiceAccount(args.AccountName)
-	if err != nil {
-		return false
-	}
-	if ok {
-		return sys.IsAllowedServiceAccount(args, parentUser)
+	if cred.IsServiceAccount() {
+		return sys.IsAllowedServiceAccount(args, cred.ParentUser)
 	}
 
 	// Continue with the assumption of a regular user
-	policies, err := sys.PolicyDBGet(args.AccountName, false, args.Groups...)
+	policies, err := sys.userPolicies(args.AccountName, args.Groups)
 	if err != nil {
 		return false
 	}
@@ -1819,6 +2099,24 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 	return sys.GetCombinedPolicy(policies...).IsAllowed(args)
 }
 
+// userPolicies - returns the policies of a regular user and its groups. A
+// request simulated for the members of a group has no user, only the
+// policies of the groups apply.
+func (sys *IAMSys) userPolicies(name string, groups []string) ([]string, error) {
+	if name != "" {
+		return sys.PolicyDBGet(name, false, groups...)
+	}
+	var policies []string
+	for _, group := range groups {
+		ps, err := sys.PolicyDBGet(group, true)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, ps...)
+	}
+	return policies, nil
+}
+
 // SetUsersSysType - sets the users system type, regular or LDAP.
 func (sys *IAMSys) SetUsersSysType(t UsersSysType) {
 	sys.usersSysType = t
@@ -1834,5 +2132,6 @@ func NewIAMSys() *IAMSys {
 	return &IAMSys{
 		usersSysType: MinIOUsersSysType,
 		configLoaded: make(chan struct{}),
+		usage:        newIAMUsageTracker(),
 	}
 }
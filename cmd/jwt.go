@@ -51,16 +51,16 @@ var (
 	errNoAuthToken        = errors.New("JWT token missing")
 )
 
-func authenticateJWTUsers(accessKey, secretKey string, expiry time.Duration) (string, error) {
+func authenticateJWTUsers(accessKey, secretKey, sourceIP string, expiry time.Duration) (string, error) {
 	passedCredential, err := auth.CreateCredentials(accessKey, secretKey)
 	if err != nil {
 		return "", err
 	}
 	expiresAt := UTCNow().Add(expiry)
-	return authenticateJWTUsersWithCredentials(passedCredential, expiresAt)
+	return authenticateJWTUsersWithCredentials(passedCredential, sourceIP, expiresAt)
 }
 
-func authenticateJWTUsersWithCredentials(credentials auth.Credentials, expiresAt time.Time) (string, error) {
+func authenticateJWTUsersWithCredentials(credentials auth.Credentials, sourceIP string, expiresAt time.Time) (string, error) {
 	serverCred := globalActiveCred
 	if serverCred.AccessKey != credentials.AccessKey {
 		u, ok := globalIAMSys.GetUser(context.TODO(), credentials.AccessKey)
@@ -74,6 +74,14 @@ func authenticateJWTUsersWithCredentials(credentials auth.Credentials, expiresAt
 		return "", errAuthentication
 	}
 
+	if serverCred.IsServiceAccount() {
+		sr, err := getSvcAccRestrictions(serverCred)
+		if err != nil || sr.IsExpired(UTCNow()) || !sr.IsSourceAllowed(sourceIP) {
+			return "", errAuthentication
+		}
+	}
+	globalIAMSys.recordAccessKeyUsage(serverCred, sourceIP)
+
 	claims := xjwt.NewMapClaims()
 	claims.SetExpiry(expiresAt)
 	claims.SetAccessKey(credentials.AccessKey)
@@ -121,12 +129,12 @@ func authenticateNode(accessKey, secretKey, audience string) (string, error) {
 	return jwt.SignedString([]byte(secretKey))
 }
 
-func authenticateWeb(accessKey, secretKey string) (string, error) {
-	return authenticateJWTUsers(accessKey, secretKey, defaultJWTExpiry)
+func authenticateWeb(accessKey, secretKey, sourceIP string) (string, error) {
+	return authenticateJWTUsers(accessKey, secretKey, sourceIP, defaultJWTExpiry)
 }
 
-func authenticateURL(accessKey, secretKey string) (string, error) {
-	return authenticateJWTUsers(accessKey, secretKey, defaultURLJWTExpiry)
+func authenticateURL(accessKey, secretKey, sourceIP string) (string, error) {
+	return authenticateJWTUsers(accessKey, secretKey, sourceIP, defaultURLJWTExpiry)
 }
 
 // Check if the request is authenticated.
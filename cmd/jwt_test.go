@@ -71,9 +71,9 @@ func testAuthenticate(authType string, t *testing.T) {
 	for _, testCase := range testCases {
 		var err error
 		if authType == "web" {
-			_, err = authenticateWeb(testCase.accessKey, testCase.secretKey)
+			_, err = authenticateWeb(testCase.accessKey, testCase.secretKey, "127.0.0.1")
 		} else if authType == "url" {
-			_, err = authenticateURL(testCase.accessKey, testCase.secretKey)
+			_, err = authenticateURL(testCase.accessKey, testCase.secretKey, "127.0.0.1")
 		}
 
 		if testCase.expectedErr != nil {
@@ -276,6 +276,6 @@ func BenchmarkAuthenticateWeb(b *testing.B) {
 	b.ResetTimer()
 	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
-		authenticateWeb(creds.AccessKey, creds.SecretKey)
+		authenticateWeb(creds.AccessKey, creds.SecretKey, "127.0.0.1")
 	}
 }
@@ -172,6 +172,15 @@ const (
 	writeBytes      MetricName = "write_bytes"
 	wcharBytes      MetricName = "wchar_bytes"
 
+	rtcLatencyMilliSec    MetricName = "rtc_latency_ms"
+	rtcWithinThreshold    MetricName = "rtc_within_threshold_count"
+	rtcAfterThreshold     MetricName = "rtc_after_threshold_count"
+	rtcMissedThreshold    MetricName = "rtc_missed_threshold_count"
+	rtcMaxLatencyMilliSec MetricName = "rtc_max_latency_ms"
+	dlqEntries            MetricName = "dlq_entries"
+	bandwidthLimitBytes   MetricName = "bandwidth_limit_bytes"
+	bandwidthPaused       MetricName = "bandwidth_paused"
+
 	latencyMicroSec MetricName = "latency_us"
 	latencyNanoSec  MetricName = "latency_ns"
 
@@ -194,6 +203,11 @@ const (
 	transitionPendingTasks MetricName = "transition_pending_tasks"
 	transitionActiveTasks  MetricName = "transition_active_tasks"
 
+	tierMovedInVersions  MetricName = "tier_moved_in_versions"
+	tierMovedInBytes     MetricName = "tier_moved_in_bytes"
+	tierMovedOutVersions MetricName = "tier_moved_out_versions"
+	tierMovedOutBytes    MetricName = "tier_moved_out_bytes"
+
 	transitionedBytes    MetricName = "transitioned_bytes"
 	transitionedObjects  MetricName = "transitioned_objects"
 	transitionedVersions MetricName = "transitioned_versions"
@@ -483,6 +497,86 @@ func getBucketRepLatencyMD() MetricDescription {
 	}
 }
 
+func getBucketRepRTCLatencyMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      rtcLatencyMilliSec,
+		Help:      "Replication latency percentiles in milliseconds from object creation, for targets with replication time control",
+		Type:      histogramMetric,
+	}
+}
+
+func getBucketRepRTCMaxLatencyMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      rtcMaxLatencyMilliSec,
+		Help:      "Highest replication latency in milliseconds from object creation, for targets with replication time control",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketRepRTCWithinThresholdMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      rtcWithinThreshold,
+		Help:      "Total number of objects replicated within the replication time threshold",
+		Type:      counterMetric,
+	}
+}
+
+func getBucketRepRTCAfterThresholdMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      rtcAfterThreshold,
+		Help:      "Total number of objects replicated after the replication time threshold",
+		Type:      counterMetric,
+	}
+}
+
+func getBucketRepRTCMissedThresholdMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      rtcMissedThreshold,
+		Help:      "Total number of objects still pending replication past the event threshold",
+		Type:      counterMetric,
+	}
+}
+
+func getBucketRepDLQEntriesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      dlqEntries,
+		Help:      "Total number of objects which failed to replicate with permanent errors, in the dead-letter list",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketRepBandwidthLimitMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      bandwidthLimitBytes,
+		Help:      "Bandwidth limit in bytes per second in effect for the target, from its bandwidth schedule or static limit, 0 if unlimited",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketRepBandwidthPausedMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      bandwidthPaused,
+		Help:      "Set to 1 while replication to the target is paused by its bandwidth schedule",
+		Type:      gaugeMetric,
+	}
+}
+
 func getBucketRepFailedBytesMD() MetricDescription {
 	return MetricDescription{
 		Namespace: bucketMetricNamespace,
@@ -1218,6 +1312,16 @@ func getExpiryPendingTasksMD() MetricDescription {
 	}
 }
 
+func getTierMovedMD(name MetricName, help string) MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      name,
+		Help:      help,
+		Type:      counterMetric,
+	}
+}
+
 func getILMNodeMetrics() *MetricsGroup {
 	mg := &MetricsGroup{}
 	mg.RegisterRead(func(_ context.Context) []Metric {
@@ -1233,15 +1337,41 @@ func getILMNodeMetrics() *MetricsGroup {
 		if globalExpiryState != nil {
 			expPendingTasks.Value = float64(globalExpiryState.PendingTasks())
 		}
-		if globalTransitionState != nil {
-			trPendingTasks.Value = float64(globalTransitionState.PendingTasks())
-			trActiveTasks.Value = float64(globalTransitionState.ActiveTasks())
-		}
-		return []Metric{
+		metrics := []Metric{
 			expPendingTasks,
 			trPendingTasks,
 			trActiveTasks,
 		}
+		if globalTransitionState != nil {
+			metrics[1].Value = float64(globalTransitionState.PendingTasks())
+			metrics[2].Value = float64(globalTransitionState.ActiveTasks())
+			for tier, st := range globalTransitionState.getMoveStats() {
+				labels := map[string]string{"tier": tier}
+				metrics = append(metrics,
+					Metric{
+						Description:    getTierMovedMD(tierMovedInVersions, "Total number of versions moved to a tier by this node since server start"),
+						Value:          float64(st.InVersions),
+						VariableLabels: labels,
+					},
+					Metric{
+						Description:    getTierMovedMD(tierMovedInBytes, "Total bytes moved to a tier by this node since server start"),
+						Value:          float64(st.InBytes),
+						VariableLabels: labels,
+					},
+					Metric{
+						Description:    getTierMovedMD(tierMovedOutVersions, "Total number of versions moved from a tier to another tier by this node since server start"),
+						Value:          float64(st.OutVersions),
+						VariableLabels: labels,
+					},
+					Metric{
+						Description:    getTierMovedMD(tierMovedOutBytes, "Total bytes moved from a tier to another tier by this node since server start"),
+						Value:          float64(st.OutBytes),
+						VariableLabels: labels,
+					},
+				)
+			}
+		}
+		return metrics
 	})
 	return mg
 }
@@ -1827,12 +1957,62 @@ func getBucketUsageMetrics() *MetricsGroup {
 						Value:          float64(stat.FailedCount),
 						VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
 					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketRepDLQEntriesMD(),
+						Value:          float64(globalReplicationDLQ.Depth(ctx, objLayer, bucket, arn)),
+						VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+					})
+					if limit, paused, ok := globalBucketMonitor.Limit(bucket, arn); ok {
+						metrics = append(metrics, Metric{
+							Description:    getBucketRepBandwidthLimitMD(),
+							Value:          float64(limit),
+							VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+						})
+						pausedValue := 0.0
+						if paused {
+							pausedValue = 1
+						}
+						metrics = append(metrics, Metric{
+							Description:    getBucketRepBandwidthPausedMD(),
+							Value:          pausedValue,
+							VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+						})
+					}
 					metrics = append(metrics, Metric{
 						Description:          getBucketRepLatencyMD(),
 						HistogramBucketLabel: "range",
 						Histogram:            stat.Latency.getUploadLatency(),
 						VariableLabels:       map[string]string{"bucket": bucket, "operation": "upload", "targetArn": arn},
 					})
+					if !stat.RTC.isEmpty() {
+						labels := map[string]string{"bucket": bucket, "targetArn": arn}
+						metrics = append(metrics, Metric{
+							Description:          getBucketRepRTCLatencyMD(),
+							HistogramBucketLabel: "quantile",
+							Histogram:            stat.RTC.getLatencyPercentiles(),
+							VariableLabels:       labels,
+						})
+						metrics = append(metrics, Metric{
+							Description:    getBucketRepRTCMaxLatencyMD(),
+							Value:          float64(stat.RTC.MaxLatency / time.Millisecond),
+							VariableLabels: labels,
+						})
+						metrics = append(metrics, Metric{
+							Description:    getBucketRepRTCWithinThresholdMD(),
+							Value:          float64(stat.RTC.WithinThreshold),
+							VariableLabels: labels,
+						})
+						metrics = append(metrics, Metric{
+							Description:    getBucketRepRTCAfterThresholdMD(),
+							Value:          float64(stat.RTC.AfterThreshold),
+							VariableLabels: labels,
+						})
+						metrics = append(metrics, Metric{
+							Description:    getBucketRepRTCMissedThresholdMD(),
+							Value:          float64(stat.RTC.MissedThreshold),
+							VariableLabels: labels,
+						})
+					}
 
 				}
 			}
@@ -1888,7 +2068,7 @@ func getClusterTierMetrics() *MetricsGroup {
 		if objLayer == nil {
 			return
 		}
-		if globalTierConfigMgr.Empty() {
+		if globalTierConfigMgr.Empty() && !globalStorageClass.HasCustom() {
 			return
 		}
 
@@ -0,0 +1,254 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+//msgp:ignore mrfJournal
+
+// mrfEntry is a partial operation recorded in the MRF journal.
+type mrfEntry struct {
+	Bucket    string    `msg:"b"`
+	Object    string    `msg:"o"`
+	VersionID string    `msg:"v"`
+	Size      int64     `msg:"s"`
+	SetIndex  int       `msg:"si"`
+	PoolIndex int       `msg:"pi"`
+	QueuedAt  time.Time `msg:"t"`
+}
+
+const (
+	mrfJournalVersion = 1
+	mrfJournalHdrLen  = 2 // 2 bytes
+)
+
+var errUnsupportedMRFJournalVersion = errors.New("unsupported MRF journal version")
+
+// mrfJournal persists the partial operations queued for healing on a local
+// drive, so that they are healed after a restart. Entries are appended as
+// operations are queued, the journal is rewritten with the pending
+// operations to drop the healed ones.
+type mrfJournal struct {
+	sync.Mutex
+	diskPaths []string // local drives, in order of preference
+	file      *os.File // active journal
+}
+
+func newMRFJournal(diskPaths []string) *mrfJournal {
+	return &mrfJournal{diskPaths: diskPaths}
+}
+
+func mrfJournalPath(diskPath string) string {
+	return filepath.Join(diskPath, minioMetaBucket, "mrf", "journal.bin")
+}
+
+// open opens the journal on the first available local drive, if not
+// already open.
+func (j *mrfJournal) open() (err error) {
+	if j.file != nil {
+		return nil
+	}
+	for _, diskPath := range j.diskPaths {
+		path := mrfJournalPath(diskPath)
+		if err = mkdirAll(filepath.Dir(path), 0o777); err != nil {
+			continue
+		}
+		var f *os.File
+		f, err = OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY|writeMode, 0o666)
+		if err != nil {
+			continue
+		}
+		var fi os.FileInfo
+		if fi, err = f.Stat(); err == nil && fi.Size() == 0 {
+			var data [mrfJournalHdrLen]byte
+			binary.LittleEndian.PutUint16(data[:], mrfJournalVersion)
+			_, err = f.Write(data[:])
+		}
+		if err != nil {
+			f.Close()
+			continue
+		}
+		j.file = f
+		return nil
+	}
+	if err == nil {
+		err = errDiskNotFound
+	}
+	return err
+}
+
+// append adds the entry to the journal.
+func (j *mrfJournal) append(e mrfEntry) error {
+	b, err := e.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+
+	j.Lock()
+	defer j.Unlock()
+	if err = j.open(); err != nil {
+		return err
+	}
+	if _, err = j.file.Write(b); err != nil {
+		// reset to reopen, possibly on another drive, next time.
+		j.file.Close()
+		j.file = nil
+	}
+	return err
+}
+
+// load returns the entries of the journals on all local drives, with the
+// first error. Entries are read up to the first one which cannot be decoded,
+// e.g. the last one when a node crashed while writing it.
+func (j *mrfJournal) load() (entries []mrfEntry, err error) {
+	j.Lock()
+	defer j.Unlock()
+
+	for _, diskPath := range j.diskPaths {
+		des, derr := readMRFJournal(mrfJournalPath(diskPath))
+		entries = append(entries, des...)
+		if derr != nil && !errors.Is(derr, os.ErrNotExist) && err == nil {
+			err = fmt.Errorf("mrf-journal: %s: %w", diskPath, derr)
+		}
+	}
+	return entries, err
+}
+
+func readMRFJournal(path string) (entries []mrfEntry, err error) {
+	f, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var data [mrfJournalHdrLen]byte
+	if _, err = io.ReadFull(f, data[:]); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if binary.LittleEndian.Uint16(data[:]) != mrfJournalVersion {
+		return nil, errUnsupportedMRFJournalVersion
+	}
+
+	mr := msgp.NewReader(f)
+	for {
+		var e mrfEntry
+		if err = e.DecodeMsg(mr); err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+			return entries, err
+		}
+		entries = append(entries, e)
+	}
+}
+
+// rewrite replaces the journals of all local drives with a journal of the
+// entries. The entries are taken with the journal locked, so that no entry
+// appended meanwhile is lost.
+func (j *mrfJournal) rewrite(entriesFn func() []mrfEntry) (err error) {
+	j.Lock()
+	defer j.Unlock()
+
+	if j.file != nil {
+		j.file.Close()
+		j.file = nil
+	}
+
+	entries := entriesFn()
+
+	written := ""
+	for _, diskPath := range j.diskPaths {
+		if err = writeMRFJournal(mrfJournalPath(diskPath), entries); err == nil {
+			written = diskPath
+			break
+		}
+	}
+	if written == "" {
+		return err
+	}
+	for _, diskPath := range j.diskPaths {
+		if diskPath != written {
+			os.Remove(mrfJournalPath(diskPath))
+		}
+	}
+	return nil
+}
+
+// writeMRFJournal writes the journal to a temporary file and renames it to
+// the journal, which is never left partially written.
+func writeMRFJournal(path string, entries []mrfEntry) error {
+	if err := mkdirAll(filepath.Dir(path), 0o777); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	f, err := OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY|writeMode, 0o666)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(f)
+	var data [mrfJournalHdrLen]byte
+	binary.LittleEndian.PutUint16(data[:], mrfJournalVersion)
+	_, err = bw.Write(data[:])
+	mw := msgp.NewWriter(bw)
+	for i := 0; err == nil && i < len(entries); i++ {
+		err = entries[i].EncodeMsg(mw)
+	}
+	if err == nil {
+		err = mw.Flush()
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// Close closes the active journal.
+func (j *mrfJournal) Close() error {
+	j.Lock()
+	defer j.Unlock()
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+func sameMRFEntries(a, b []mrfEntry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		x, y := a[i], b[i]
+		if !x.QueuedAt.Equal(y.QueuedAt) {
+			return false
+		}
+		x.QueuedAt, y.QueuedAt = time.Time{}, time.Time{}
+		if x != y {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMRFJournal(t *testing.T) {
+	// The first drive is not usable, the journal is kept on the second.
+	dir := t.TempDir()
+	badDisk := filepath.Join(dir, "disk1")
+	if err := os.WriteFile(badDisk, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	disk := filepath.Join(dir, "disk2")
+	j := newMRFJournal([]string{badDisk, disk})
+
+	queuedAt := UTCNow().Truncate(time.Second)
+	entries := []mrfEntry{
+		{Bucket: "bucket", Object: "obj1", Size: 10, QueuedAt: queuedAt},
+		{Bucket: "bucket", Object: "obj2", VersionID: "v1", SetIndex: 1, PoolIndex: 1, QueuedAt: queuedAt},
+	}
+	for _, e := range entries {
+		if err := j.append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	j.Close()
+
+	// The unusable drive is reported.
+	got, err := j.load()
+	if err == nil || !sameMRFEntries(got, entries) {
+		t.Fatalf("Expected %v and an error, got %v: %v", entries, got, err)
+	}
+
+	j = newMRFJournal([]string{disk})
+	if got, err = j.load(); err != nil || !sameMRFEntries(got, entries) {
+		t.Fatalf("Expected %v, got %v: %v", entries, got, err)
+	}
+
+	// A partially written entry is ignored.
+	f, err := os.OpenFile(mrfJournalPath(disk), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := entries[0].MarshalMsg(nil)
+	f.Write(b[:len(b)/2])
+	f.Close()
+	if got, err = j.load(); err == nil || !sameMRFEntries(got, entries) {
+		t.Fatalf("Expected %v and an error, got %v: %v", entries, got, err)
+	}
+
+	// Rewriting keeps only the given entries.
+	if err = j.rewrite(func() []mrfEntry { return entries[1:] }); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = j.load(); err != nil || !sameMRFEntries(got, entries[1:]) {
+		t.Fatalf("Unexpected entries after rewrite: %v, %v", got, err)
+	}
+	if err = j.append(entries[0]); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = j.load(); err != nil || len(got) != 2 {
+		t.Fatalf("Unexpected entries after append: %v, %v", got, err)
+	}
+}
+
+func TestMRFReplayJournal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	j := newMRFJournal([]string{t.TempDir()})
+	queuedAt := UTCNow().Add(-time.Hour)
+	entries := []mrfEntry{
+		{Bucket: "bucket", Object: "obj1", Size: 10, QueuedAt: queuedAt},
+		{Bucket: "bucket", Object: "obj1", Size: 10, QueuedAt: queuedAt},
+		{Bucket: "bucket", Object: "obj2", Size: 5, SetIndex: 1, QueuedAt: queuedAt.Add(time.Minute)},
+	}
+	for _, e := range entries {
+		if err := j.append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := &mrfState{
+		ctx:        ctx,
+		pendingOps: make(map[partialOperation]pendingOpInfo),
+		journal:    j,
+	}
+	sets := m.replayJournal()
+	if len(sets) != 2 {
+		t.Fatalf("Expected 2 sets to heal, got %v", sets)
+	}
+	if len(m.pendingOps) != 2 || m.pendingItems != 2 || m.pendingBytes != 15 {
+		t.Fatalf("Unexpected pending operations %v", m.pendingOps)
+	}
+
+	// Duplicates are dropped from the journal.
+	got, err := j.load()
+	if err != nil || len(got) != 2 {
+		t.Fatalf("Unexpected journal entries after replay: %v, %v", got, err)
+	}
+
+	st := m.getQueueStatus()
+	if st.Items != 2 || st.Bytes != 15 || !st.Oldest.Equal(queuedAt) || st.Age < time.Hour {
+		t.Fatalf("Unexpected queue status %#v", st)
+	}
+}
+
+func TestMRFHealInProgress(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	setObjectLayer(obj)
+
+	if err = obj.MakeBucketWithLocation(ctx, "bucket", MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.PutObject(ctx, "bucket", "object", mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	op := partialOperation{bucket: "bucket", object: "object", size: 4}
+	m := &mrfState{
+		ctx:        ctx,
+		objectAPI:  obj,
+		pendingOps: map[partialOperation]pendingOpInfo{op: {queuedAt: UTCNow()}},
+	}
+	m.pendingItems, m.pendingBytes = 1, 4
+
+	// The operation stays queued while another node heals it.
+	lk := obj.NewNSLock(minioMetaBucket, pathJoin("mrf", op.bucket, op.object, op.versionID))
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.healPartialOps([]partialOperation{op}, madmin.HealOpts{}) {
+		t.Fatal("Expected the operation to be retried")
+	}
+	if _, ok := m.pendingOps[op]; !ok || m.pendingItems != 1 || m.journalStale {
+		t.Fatalf("Expected the operation to stay queued, got %v", m.pendingOps)
+	}
+	lk.Unlock(lkctx.Cancel)
+
+	if m.healPartialOps([]partialOperation{op}, madmin.HealOpts{}) {
+		t.Fatal("Expected the operation to be healed")
+	}
+	if len(m.pendingOps) != 0 || m.pendingItems != 0 || m.itemsHealed != 1 || !m.journalStale {
+		t.Fatalf("Expected the operation to be healed, got %v", m.pendingOps)
+	}
+}
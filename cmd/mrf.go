@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -28,10 +29,19 @@ import (
 )
 
 const (
-	mrfInfoResetInterval = 10 * time.Second
-	mrfOpsQueueSize      = 10000
+	mrfInfoResetInterval      = 10 * time.Second
+	mrfJournalCompactInterval = 5 * time.Minute
+	mrfHealRetryInterval      = time.Minute
+	mrfOpsQueueSize           = 10000
 )
 
+// mrfHealLockTimeout - the time to wait for a partial operation being healed
+// by another node, the operation stays queued once the wait times out.
+var mrfHealLockTimeout = newDynamicTimeout(time.Second, time.Second)
+
+// errMRFHealInProgress - the partial operation is being healed by another node.
+var errMRFHealInProgress = errors.New("partial operation is being healed by another node")
+
 // partialOperation is a successful upload/delete of an object
 // but not written in all disks (having quorum)
 type partialOperation struct {
@@ -47,6 +57,20 @@ type setInfo struct {
 	index, pool int
 }
 
+// pendingOpInfo - where and when a partial operation was queued.
+type pendingOpInfo struct {
+	set      setInfo
+	queuedAt time.Time
+}
+
+// mrfQueueStatus - the partial operations of a node waiting to be healed.
+type mrfQueueStatus struct {
+	Items  int           `json:"items"`
+	Bytes  uint64        `json:"bytes"`
+	Oldest time.Time     `json:"oldest,omitempty"`
+	Age    time.Duration `json:"age"` // age of the oldest operation
+}
+
 // mrfState sncapsulates all the information
 // related to the global background MRF.
 type mrfState struct {
@@ -58,9 +82,14 @@ type mrfState struct {
 
 	mu                sync.Mutex
 	opCh              chan partialOperation
-	pendingOps        map[partialOperation]setInfo
+	pendingOps        map[partialOperation]pendingOpInfo
 	setReconnectEvent chan setInfo
 
+	// journal of the pending operations, nil without local drives.
+	journal *mrfJournal
+	// set when healed operations are to be dropped from the journal.
+	journalStale bool
+
 	itemsHealed  uint64
 	bytesHealed  uint64
 	pendingItems uint64
@@ -77,15 +106,119 @@ func (m *mrfState) init(ctx context.Context, objAPI ObjectLayer) {
 	m.ctx = ctx
 	m.objectAPI = objAPI
 	m.opCh = make(chan partialOperation, mrfOpsQueueSize)
-	m.pendingOps = make(map[partialOperation]setInfo)
+	m.pendingOps = make(map[partialOperation]pendingOpInfo)
 	m.setReconnectEvent = make(chan setInfo)
 
+	var replayed []setInfo
+	if diskPaths := globalEndpoints.LocalDisksPaths(); len(diskPaths) > 0 {
+		m.journal = newMRFJournal(diskPaths)
+		replayed = m.replayJournal()
+	}
+
 	go globalMRFState.maintainMRFList()
 	go globalMRFState.healRoutine()
 
+	// The sets of the replayed operations may not reconnect, all of
+	// their drives may be online already.
+	go globalMRFState.healReplayed(replayed)
+
 	atomic.StoreInt32(&m.ready, 1)
 }
 
+// replayJournal - queues the partial operations of the journal, which
+// were not healed before the node restarted, and returns their sets. The
+// caller must hold the lock.
+func (m *mrfState) replayJournal() (sets []setInfo) {
+	entries, err := m.journal.load()
+	logger.LogIf(m.ctx, err)
+
+	seen := make(map[setInfo]struct{})
+	for _, e := range entries {
+		op := partialOperation{
+			bucket:    e.Bucket,
+			object:    e.Object,
+			versionID: e.VersionID,
+			size:      e.Size,
+			setIndex:  e.SetIndex,
+			poolIndex: e.PoolIndex,
+		}
+		if _, ok := m.pendingOps[op]; ok || len(m.pendingOps) >= mrfOpsQueueSize {
+			continue
+		}
+		set := setInfo{index: e.SetIndex, pool: e.PoolIndex}
+		m.pendingOps[op] = pendingOpInfo{set: set, queuedAt: e.QueuedAt}
+		m.pendingItems++
+		if op.size > 0 {
+			m.pendingBytes += uint64(op.size)
+		}
+		if _, ok := seen[set]; !ok {
+			seen[set] = struct{}{}
+			sets = append(sets, set)
+		}
+	}
+	if len(entries) > 0 {
+		// Drop duplicates and operations past the queue size.
+		entries := m.journalEntries()
+		logger.LogIf(m.ctx, m.journal.rewrite(func() []mrfEntry { return entries }))
+	}
+	return sets
+}
+
+// journalEntries - returns the pending operations as journal entries. The
+// caller must hold the lock.
+func (m *mrfState) journalEntries() []mrfEntry {
+	entries := make([]mrfEntry, 0, len(m.pendingOps))
+	for op, info := range m.pendingOps {
+		entries = append(entries, mrfEntry{
+			Bucket:    op.bucket,
+			Object:    op.object,
+			VersionID: op.versionID,
+			Size:      op.size,
+			SetIndex:  op.setIndex,
+			PoolIndex: op.poolIndex,
+			QueuedAt:  info.queuedAt,
+		})
+	}
+	return entries
+}
+
+// compactJournal - rewrites the journal without the healed operations.
+func (m *mrfState) compactJournal() {
+	if m.journal == nil {
+		return
+	}
+	m.mu.Lock()
+	stale := m.journalStale
+	m.mu.Unlock()
+	if !stale {
+		return
+	}
+
+	err := m.journal.rewrite(func() []mrfEntry {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.journalStale = false
+		return m.journalEntries()
+	})
+	if err != nil {
+		logger.LogIf(m.ctx, err)
+		m.mu.Lock()
+		m.journalStale = true
+		m.mu.Unlock()
+	}
+}
+
+// healReplayed - heals the partial operations replayed from the journal.
+func (m *mrfState) healReplayed(sets []setInfo) {
+	for _, set := range sets {
+		select {
+		case m.setReconnectEvent <- set:
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
 func (m *mrfState) initialized() bool {
 	return atomic.LoadInt32(&m.ready) != 0
 }
@@ -140,6 +273,27 @@ func (m *mrfState) getCurrentMRFRoundInfo() madmin.MRFStatus {
 	}
 }
 
+// getQueueStatus - returns the depth and age of the queue of partial
+// operations waiting to be healed.
+func (m *mrfState) getQueueStatus() (st mrfQueueStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	st.Items = len(m.pendingOps)
+	for op, info := range m.pendingOps {
+		if op.size > 0 {
+			st.Bytes += uint64(op.size)
+		}
+		if st.Oldest.IsZero() || info.queuedAt.Before(st.Oldest) {
+			st.Oldest = info.queuedAt
+		}
+	}
+	if !st.Oldest.IsZero() {
+		st.Age = time.Since(st.Oldest)
+	}
+	return st
+}
+
 // maintainMRFList gathers the list of successful partial uploads
 // from all underlying er.sets and puts them in a global map which
 // should not have more than 10000 entries.
@@ -150,14 +304,34 @@ func (m *mrfState) maintainMRFList() {
 			m.mu.Unlock()
 			continue
 		}
+		if _, ok := m.pendingOps[fOp]; ok {
+			m.mu.Unlock()
+			continue
+		}
 
-		m.pendingOps[fOp] = setInfo{index: fOp.setIndex, pool: fOp.poolIndex}
+		queuedAt := UTCNow()
+		m.pendingOps[fOp] = pendingOpInfo{
+			set:      setInfo{index: fOp.setIndex, pool: fOp.poolIndex},
+			queuedAt: queuedAt,
+		}
 		m.pendingItems++
 		if fOp.size > 0 {
 			m.pendingBytes += uint64(fOp.size)
 		}
 
 		m.mu.Unlock()
+
+		if m.journal != nil {
+			logger.LogIf(m.ctx, m.journal.append(mrfEntry{
+				Bucket:    fOp.bucket,
+				Object:    fOp.object,
+				VersionID: fOp.versionID,
+				Size:      fOp.size,
+				SetIndex:  fOp.setIndex,
+				PoolIndex: fOp.poolIndex,
+				QueuedAt:  queuedAt,
+			}))
+		}
 	}
 }
 
@@ -184,6 +358,9 @@ func (m *mrfState) healRoutine() {
 	idler := time.NewTimer(mrfInfoResetInterval)
 	defer idler.Stop()
 
+	compactTicker := time.NewTicker(mrfJournalCompactInterval)
+	defer compactTicker.Stop()
+
 	mrfHealingOpts := madmin.HealOpts{
 		ScanMode: madmin.HealNormalScan,
 		Remove:   healDeleteDangling,
@@ -192,17 +369,22 @@ func (m *mrfState) healRoutine() {
 	for {
 		select {
 		case <-m.ctx.Done():
+			if m.journal != nil {
+				m.journal.Close()
+			}
 			return
 		case <-idler.C:
 			m.resetMRFInfoIfNoPendingOps()
 			idler.Reset(mrfInfoResetInterval)
+		case <-compactTicker.C:
+			m.compactJournal()
 		case setInfo := <-m.setReconnectEvent:
 			// Get the list of objects related the er.set
 			// to which the connected disk belongs.
 			var mrfOperations []partialOperation
 			m.mu.Lock()
 			for k, v := range m.pendingOps {
-				if v == setInfo {
+				if v.set == setInfo {
 					mrfOperations = append(mrfOperations, k)
 				}
 			}
@@ -217,22 +399,8 @@ func (m *mrfState) healRoutine() {
 			m.mu.Unlock()
 
 			// Heal objects
-			for _, u := range mrfOperations {
-				_, err := m.objectAPI.HealObject(m.ctx, u.bucket, u.object, u.versionID, mrfHealingOpts)
-				m.mu.Lock()
-				if err == nil {
-					m.itemsHealed++
-					m.bytesHealed += uint64(u.size)
-				}
-				m.pendingItems--
-				m.pendingBytes -= uint64(u.size)
-				delete(m.pendingOps, u)
-				m.mu.Unlock()
-
-				if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
-					// Log healing error if any
-					logger.LogIf(m.ctx, err)
-				}
+			if m.healPartialOps(mrfOperations, mrfHealingOpts) {
+				go m.retrySet(setInfo)
 			}
 
 			waitForLowHTTPReq()
@@ -240,6 +408,65 @@ func (m *mrfState) healRoutine() {
 	}
 }
 
+// healPartialOps - heals the given partial operations and drops them from
+// the queue, except those being healed by another node which stay queued
+// and in the journal. Returns whether any operation is still queued.
+func (m *mrfState) healPartialOps(ops []partialOperation, opts madmin.HealOpts) (retry bool) {
+	for _, u := range ops {
+		err := m.healPartialOp(u, opts)
+		if err == errMRFHealInProgress {
+			retry = true
+			continue
+		}
+
+		m.mu.Lock()
+		if err == nil {
+			m.itemsHealed++
+			m.bytesHealed += uint64(u.size)
+		}
+		m.pendingItems--
+		m.pendingBytes -= uint64(u.size)
+		delete(m.pendingOps, u)
+		m.journalStale = true
+		m.mu.Unlock()
+
+		if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			// Log healing error if any
+			logger.LogIf(m.ctx, err)
+		}
+	}
+	return retry
+}
+
+// retrySet - heals the queued partial operations of a set again after
+// mrfHealRetryInterval.
+func (m *mrfState) retrySet(set setInfo) {
+	timer := time.NewTimer(mrfHealRetryInterval)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		m.healReplayed([]setInfo{set})
+	case <-m.ctx.Done():
+	}
+}
+
+// healPartialOp - heals the object of a partial operation. Nodes may have
+// queued the same operation, e.g. replayed from their journals, it is only
+// healed by one of them at a time. Returns errMRFHealInProgress when
+// another node holds the operation.
+func (m *mrfState) healPartialOp(u partialOperation, opts madmin.HealOpts) error {
+	lk := m.objectAPI.NewNSLock(minioMetaBucket, pathJoin("mrf", u.bucket, u.object, u.versionID))
+	lkctx, err := lk.GetLock(m.ctx, mrfHealLockTimeout)
+	if err != nil {
+		return errMRFHealInProgress
+	}
+	defer lk.Unlock(lkctx.Cancel)
+
+	_, err = m.objectAPI.HealObject(lkctx.Context(), u.bucket, u.object, u.versionID, opts)
+	return err
+}
+
 // Initialize healing MRF
 func initHealMRF(ctx context.Context, obj ObjectLayer) {
 	globalMRFState.init(ctx, obj)
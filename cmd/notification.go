@@ -227,9 +227,9 @@ func (sys *NotificationSys) LoadServiceAccount(accessKey string) []NotificationP
 }
 
 // BackgroundHealStatus - returns background heal status of all peers
-func (sys *NotificationSys) BackgroundHealStatus() ([]madmin.BgHealState, []NotificationPeerErr) {
+func (sys *NotificationSys) BackgroundHealStatus() ([]bgHealState, []NotificationPeerErr) {
 	ng := WithNPeers(len(sys.peerClients))
-	states := make([]madmin.BgHealState, len(sys.peerClients))
+	states := make([]bgHealState, len(sys.peerClients))
 	for idx, client := range sys.peerClients {
 		if client == nil {
 			continue
@@ -694,6 +694,27 @@ func (sys *NotificationSys) LoadTransitionTierConfig(ctx context.Context) {
 	}
 }
 
+// FlushReplicationRecords notifies remote peers to persist their replication
+// records, waiting for them to be persisted.
+func (sys *NotificationSys) FlushReplicationRecords(ctx context.Context) {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.FlushReplicationRecords(ctx)
+		}, idx, *client.host)
+	}
+	for _, nErr := range ng.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+}
+
 // GetCPUs - Get all CPU information.
 func (sys *NotificationSys) GetCPUs(ctx context.Context) []madmin.CPUs {
 	reply := make([]madmin.CPUs, len(sys.peerClients))
@@ -1394,3 +1415,34 @@ func (sys *NotificationSys) GetLastDayTierStats(ctx context.Context) DailyAllTie
 	}
 	return merged
 }
+
+// GetAccessKeyUsage fetches the access key usage not persisted yet from all
+// peers and merges it with the usage recorded locally.
+func (sys *NotificationSys) GetAccessKeyUsage(ctx context.Context) map[string]accessKeyUsage {
+	errs := make([]error, len(sys.allPeerClients))
+	usage := make([]map[string]accessKeyUsage, len(sys.allPeerClients))
+	var wg sync.WaitGroup
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			usage[index], errs[index] = sys.peerClients[index].GetAccessKeyUsage(ctx)
+		}(index)
+	}
+
+	wg.Wait()
+	merged := globalIAMSys.usage.snapshot()
+	for i, u := range usage {
+		if errs[i] != nil {
+			logger.LogIf(ctx, fmt.Errorf("failed to fetch access key usage: %w", errs[i]))
+			continue
+		}
+		for accessKey, au := range u {
+			merged[accessKey] = merged[accessKey].merge(au)
+		}
+	}
+	return merged
+}
@@ -394,6 +394,34 @@ func (e BucketQuotaConfigNotFound) Error() string {
 	return "No quota config found for bucket : " + e.Bucket
 }
 
+// BucketPoolAffinityNotFound - no bucket pool affinity found.
+type BucketPoolAffinityNotFound GenericError
+
+func (e BucketPoolAffinityNotFound) Error() string {
+	return "No pool affinity found for bucket : " + e.Bucket
+}
+
+// BucketReplicationConflictPolicyNotFound - no bucket replication conflict policy found.
+type BucketReplicationConflictPolicyNotFound GenericError
+
+func (e BucketReplicationConflictPolicyNotFound) Error() string {
+	return "No replication conflict policy found for bucket : " + e.Bucket
+}
+
+// BucketBandwidthSchedulesNotFound - no bucket bandwidth schedules found.
+type BucketBandwidthSchedulesNotFound GenericError
+
+func (e BucketBandwidthSchedulesNotFound) Error() string {
+	return "No bandwidth schedules found for bucket : " + e.Bucket
+}
+
+// BucketGenericTargetsNotFound - no bucket generic targets found.
+type BucketGenericTargetsNotFound GenericError
+
+func (e BucketGenericTargetsNotFound) Error() string {
+	return "No generic targets found for bucket : " + e.Bucket
+}
+
 // BucketQuotaExceeded - bucket quota exceeded.
 type BucketQuotaExceeded GenericError
 
@@ -485,6 +513,14 @@ func (e BucketReplicationSourceNotVersioned) Error() string {
 	return "Replication source does not have versioning enabled: " + e.Bucket
 }
 
+// ReplicationConflictRejected - replica rejected by the replication conflict
+// policy of the bucket.
+type ReplicationConflictRejected GenericError
+
+func (e ReplicationConflictRejected) Error() string {
+	return "Replica " + e.VersionID + " of " + e.Bucket + "/" + e.Object + " lost a replication conflict against the latest version"
+}
+
 // TransitionStorageClassNotFound remote tier not configured.
 type TransitionStorageClassNotFound GenericError
 
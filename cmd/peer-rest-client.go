@@ -475,14 +475,14 @@ func (client *peerRESTClient) SignalService(sig serviceSignal, subSys string) er
 	return nil
 }
 
-func (client *peerRESTClient) BackgroundHealStatus() (madmin.BgHealState, error) {
+func (client *peerRESTClient) BackgroundHealStatus() (bgHealState, error) {
 	respBody, err := client.call(peerRESTMethodBackgroundHealStatus, nil, nil, -1)
 	if err != nil {
-		return madmin.BgHealState{}, err
+		return bgHealState{}, err
 	}
 	defer http.DrainBody(respBody)
 
-	state := madmin.BgHealState{}
+	state := bgHealState{}
 	err = gob.NewDecoder(respBody).Decode(&state)
 	return state, err
 }
@@ -585,6 +585,16 @@ func (client *peerRESTClient) LoadTransitionTierConfig(ctx context.Context) erro
 	return nil
 }
 
+// FlushReplicationRecords - persists the replication records of the peer.
+func (client *peerRESTClient) FlushReplicationRecords(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodFlushReplicationRecords, nil, nil, 0)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 func (client *peerRESTClient) doTrace(traceCh chan<- madmin.TraceInfo, doneCh <-chan struct{}, traceOpts madmin.ServiceTraceOpts) {
 	values := make(url.Values)
 	traceOpts.AddParams(values)
@@ -923,6 +933,20 @@ func (client *peerRESTClient) GetLastDayTierStats(ctx context.Context) (DailyAll
 	return DailyAllTierStats(result), nil
 }
 
+// GetAccessKeyUsage - fetches the access key usage the peer has not
+// persisted yet.
+func (client *peerRESTClient) GetAccessKeyUsage(ctx context.Context) (map[string]accessKeyUsage, error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetAccessKeyUsage, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+
+	var result map[string]accessKeyUsage
+	err = gob.NewDecoder(respBody).Decode(&result)
+	return result, err
+}
+
 // DevNull - Used by netperf to pump data to peer
 func (client *peerRESTClient) DevNull(ctx context.Context, r io.Reader) error {
 	respBody, err := client.callWithContext(ctx, peerRESTMethodDevNull, nil, r, -1)
@@ -18,7 +18,7 @@
 package cmd
 
 const (
-	peerRESTVersion = "v28" // Added Rebalance peer APIs
+	peerRESTVersion = "v30" // Added MRF queue to background heal status
 
 	peerRESTVersionPrefix = SlashSeparator + peerRESTVersion
 	peerRESTPrefix        = minioReservedBucketPath + "/peer"
@@ -75,6 +75,8 @@ const (
 	peerRESTMethodDevNull                     = "/devnull"
 	peerRESTMethodNetperf                     = "/netperf"
 	peerRESTMethodMetrics                     = "/metrics"
+	peerRESTMethodGetAccessKeyUsage           = "/getaccesskeyusage"
+	peerRESTMethodFlushReplicationRecords     = "/flushreplicationrecords"
 )
 
 const (
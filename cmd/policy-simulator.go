@@ -0,0 +1,511 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/arn"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/pkg/bucket/policy"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// Principal types of the policy simulator.
+const (
+	simulatedAnonymous      = "anonymous"
+	simulatedUser           = "user"
+	simulatedGroup          = "group"
+	simulatedServiceAccount = "serviceaccount"
+	simulatedSTS            = "sts"
+)
+
+// Policy sources reported by the policy simulator.
+const (
+	policySourceOwner         = "owner"
+	policySourceAuthZPlugin   = "authz-plugin"
+	policySourceIdentity      = "identity"
+	policySourceRole          = "role"
+	policySourceClaim         = "claim"
+	policySourceSession       = "session-policy"
+	policySourceManagedSess   = "managed-session-policy"
+	policySourceServiceAccess = "service-account-policy"
+	policySourceBucket        = "bucket-policy"
+)
+
+// policySimulationRequest - a request to evaluate with the policies of a
+// principal.
+type policySimulationRequest struct {
+	// PrincipalType is one of anonymous, user, group, serviceaccount and
+	// sts.
+	PrincipalType string `json:"principalType"`
+
+	// Principal is the user (DN for LDAP), the group, the access key of the
+	// service account or of the temporary credentials, or a role ARN.
+	Principal string `json:"principal,omitempty"`
+
+	// Groups are the additional groups of a user, e.g. LDAP group DNs.
+	Groups []string `json:"groups,omitempty"`
+
+	Action string `json:"action"`
+
+	// Resource is an S3 ARN e.g. "arn:aws:s3:::bucket/object", it may be
+	// empty for admin and KMS actions.
+	Resource string `json:"resource,omitempty"`
+
+	// Conditions are added to the condition context of the request, e.g.
+	// {"aws:SourceIp": ["10.0.0.1"]}.
+	Conditions map[string][]string `json:"conditions,omitempty"`
+}
+
+// matchedStatement - a statement of a policy matching the request.
+type matchedStatement struct {
+	Policy    string      `json:"policy,omitempty"`
+	Statement interface{} `json:"statement"`
+}
+
+// policySourceResult - the evaluation of the request by a policy source.
+type policySourceResult struct {
+	Source   string   `json:"source"`
+	Policies []string `json:"policies,omitempty"`
+
+	// Applied is false when the source does not take part in the
+	// decision, e.g. bucket policies for authenticated requests.
+	Applied bool `json:"applied"`
+	Allowed bool `json:"allowed"`
+
+	// Statements matching the request, Deny statements take precedence.
+	Statements []matchedStatement `json:"statements,omitempty"`
+
+	// Error is set when the source could not be evaluated.
+	Error string `json:"error,omitempty"`
+}
+
+// policySimulationResult - the decision for a simulated request and its
+// evaluation by each policy source.
+type policySimulationResult struct {
+	Allowed bool                 `json:"allowed"`
+	Sources []policySourceResult `json:"sources"`
+}
+
+var errInvalidSimulationRequest = errors.New("invalid policy simulation request")
+
+// defaultSimulatedSessionDuration - validity of the temporary credentials of
+// a simulated role session, they are never stored nor returned.
+const defaultSimulatedSessionDuration = time.Hour
+
+// parseSimulatedResource - returns the bucket and object of an S3 ARN.
+func parseSimulatedResource(resource string) (bucket, object string, err error) {
+	if resource == "" {
+		return "", "", nil
+	}
+	if !strings.HasPrefix(resource, policy.ResourceARNPrefix) {
+		return "", "", fmt.Errorf("%w: resource must start with %s", errInvalidSimulationRequest, policy.ResourceARNPrefix)
+	}
+	resource = strings.TrimPrefix(resource, policy.ResourceARNPrefix)
+	bucket, object, _ = strings.Cut(resource, SlashSeparator)
+	if bucket == "" {
+		return "", "", fmt.Errorf("%w: resource has no bucket", errInvalidSimulationRequest)
+	}
+	return bucket, object, nil
+}
+
+// simulatedConditionValues - returns the condition context of a request
+// made by the principal, with the conditions of the simulation request.
+// Principal tags, as for actual requests, only come from the claims.
+func simulatedConditionValues(username string, claims map[string]interface{}, conditions map[string][]string) (map[string][]string, error) {
+	r := &http.Request{
+		Header: make(http.Header),
+		Form:   make(url.Values),
+		URL:    &url.URL{},
+	}
+	values := getConditionValues(r, "", username, claims)
+	// There is no client, its address is only known when given.
+	delete(values, "SourceIp")
+	for key, v := range conditions {
+		// Policies use qualified keys such as aws:SourceIp while the
+		// condition context is keyed by their names.
+		if _, name, ok := strings.Cut(key, ":"); ok {
+			key = name
+		}
+		if isPrincipalTagKey(key) {
+			continue
+		}
+		if key == "SourceIp" {
+			for _, ip := range v {
+				if net.ParseIP(ip) == nil {
+					return nil, fmt.Errorf("%w: invalid IP address %s", errInvalidSimulationRequest, ip)
+				}
+			}
+		}
+		values[key] = v
+	}
+	return values, nil
+}
+
+// matchingStatements - returns the statements of a policy matching the
+// request.
+func matchingStatements(name string, p iampolicy.Policy, args iampolicy.Args) (res []matchedStatement) {
+	for _, st := range p.Statements {
+		// Statement.IsAllowed is false for matching Deny statements.
+		if st.IsAllowed(args) == (st.Effect == policy.Allow) {
+			res = append(res, matchedStatement{Policy: name, Statement: st})
+		}
+	}
+	return res
+}
+
+// evaluateNamedPolicies - evaluates the request with the named policies.
+func (sys *IAMSys) evaluateNamedPolicies(source string, policies []string, args iampolicy.Args) policySourceResult {
+	res := policySourceResult{
+		Source:   source,
+		Policies: policies,
+		Applied:  true,
+	}
+	if len(policies) == 0 {
+		return res
+	}
+	res.Allowed = sys.GetCombinedPolicy(policies...).IsAllowed(args)
+	for _, name := range policies {
+		p, err := sys.store.GetPolicy(name)
+		if err != nil {
+			res.Error = fmt.Sprintf("policy %s: %v", name, err)
+			continue
+		}
+		res.Statements = append(res.Statements, matchingStatements(name, p, args)...)
+	}
+	return res
+}
+
+// evaluateInlinePolicy - evaluates the request with an inline policy.
+func evaluateInlinePolicy(source, policyStr string, args iampolicy.Args) policySourceResult {
+	res := policySourceResult{
+		Source:  source,
+		Applied: true,
+	}
+	p, err := iampolicy.ParseConfig(bytes.NewReader([]byte(policyStr)))
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Allowed = p.IsAllowed(args)
+	res.Statements = matchingStatements("", *p, args)
+	return res
+}
+
+// evaluateBucketPolicy - evaluates the request with the bucket policy,
+// which only applies to anonymous requests.
+func evaluateBucketPolicy(args iampolicy.Args, applied bool) (res policySourceResult, ok bool) {
+	if args.BucketName == "" {
+		return res, false
+	}
+	res = policySourceResult{
+		Source:  policySourceBucket,
+		Applied: applied,
+	}
+	bargs := policy.Args{
+		AccountName:     args.AccountName,
+		Groups:          args.Groups,
+		Action:          policy.Action(args.Action),
+		BucketName:      args.BucketName,
+		ConditionValues: args.ConditionValues,
+		ObjectName:      args.ObjectName,
+	}
+	res.Allowed = globalPolicySys.IsAllowed(bargs)
+
+	p, err := globalPolicySys.Get(args.BucketName)
+	if err != nil {
+		if _, ok := err.(BucketPolicyNotFound); !ok {
+			res.Error = err.Error()
+		}
+		return res, true
+	}
+	for _, st := range p.Statements {
+		if st.IsAllowed(bargs) == (st.Effect == policy.Allow) {
+			res.Statements = append(res.Statements, matchedStatement{Statement: st})
+		}
+	}
+	return res, true
+}
+
+// SimulatePolicy - evaluates a request as if it was made by the principal,
+// with the same logic as actual requests. The decision is returned along
+// with the evaluation by each policy source.
+func (sys *IAMSys) SimulatePolicy(ctx context.Context, req policySimulationRequest) (res policySimulationResult, err error) {
+	if !sys.Initialized() {
+		return res, errServerNotInitialized
+	}
+
+	action := iampolicy.Action(req.Action)
+	if !action.IsValid() {
+		return res, fmt.Errorf("%w: unknown action %s", errInvalidSimulationRequest, req.Action)
+	}
+	bucket, object, err := parseSimulatedResource(req.Resource)
+	if err != nil {
+		return res, err
+	}
+
+	args := iampolicy.Args{
+		Action:     action,
+		BucketName: bucket,
+		ObjectName: object,
+		Groups:     req.Groups,
+	}
+
+	switch req.PrincipalType {
+	case simulatedAnonymous:
+		if args.ConditionValues, err = simulatedConditionValues("", nil, req.Conditions); err != nil {
+			return res, err
+		}
+		bres, ok := evaluateBucketPolicy(args, true)
+		if ok {
+			res.Allowed = bres.Allowed
+			res.Sources = append(res.Sources, bres)
+		}
+		return res, nil
+	case simulatedUser, simulatedGroup, simulatedServiceAccount, simulatedSTS:
+	default:
+		return res, fmt.Errorf("%w: unknown principal type %s", errInvalidSimulationRequest, req.PrincipalType)
+	}
+	if req.Principal == "" {
+		return res, fmt.Errorf("%w: principal is required", errInvalidSimulationRequest)
+	}
+
+	cred, err := sys.simulatedCredentials(ctx, req)
+	if err != nil {
+		return res, err
+	}
+	if res, err = sys.simulateAccount(req, cred, args); err != nil {
+		return res, err
+	}
+
+	// Bucket policies are reported for reference, they do not apply to
+	// authenticated requests.
+	if bres, ok := evaluateBucketPolicy(args, false); ok {
+		res.Sources = append(res.Sources, bres)
+	}
+	return res, nil
+}
+
+// simulateAuthZPlugin - evaluates the request with the AuthZ plugin if one is
+// configured, in which case it makes the decision.
+func simulateAuthZPlugin(args iampolicy.Args, res *policySimulationResult) bool {
+	authz := newGlobalAuthZPluginFn()
+	if authz == nil {
+		return false
+	}
+	pres := policySourceResult{
+		Source:  policySourceAuthZPlugin,
+		Applied: true,
+	}
+	ok, err := authz.IsAllowed(args)
+	if err != nil {
+		pres.Error = err.Error()
+	}
+	pres.Allowed = ok
+	res.Allowed = ok
+	res.Sources = append(res.Sources, pres)
+	return true
+}
+
+// notApplied - marks the sources as not taking part in the decision.
+func notApplied(sources []policySourceResult) []policySourceResult {
+	for i := range sources {
+		sources[i].Applied = false
+	}
+	return sources
+}
+
+// simulatedCredentials - returns the credentials of the principal making
+// the simulated request. Principals without stored credentials get the ones
+// an actual request would be made with: members of a group have no user,
+// role sessions get temporary credentials and LDAP users are known by their
+// DN.
+func (sys *IAMSys) simulatedCredentials(ctx context.Context, req policySimulationRequest) (cred auth.Credentials, err error) {
+	switch {
+	case req.PrincipalType == simulatedGroup:
+		if sys.usersSysType == MinIOUsersSysType {
+			if _, err = sys.GetGroupDescription(req.Principal); err != nil {
+				return cred, err
+			}
+		}
+		cred.Groups = []string{req.Principal}
+		return cred, nil
+	case req.PrincipalType == simulatedSTS && strings.HasPrefix(req.Principal, "arn:"):
+		a, err := arn.Parse(req.Principal)
+		if err != nil {
+			return cred, fmt.Errorf("%w: %v", errInvalidSimulationRequest, err)
+		}
+		if _, ok := sys.rolesMap[a]; !ok {
+			return cred, fmt.Errorf("%w: unknown role ARN %s", errInvalidSimulationRequest, req.Principal)
+		}
+		claims := map[string]interface{}{
+			expClaim:     UTCNow().Add(defaultSimulatedSessionDuration).Unix(),
+			roleArnClaim: req.Principal,
+			parentClaim:  req.Principal,
+		}
+		cred, err = auth.GetNewCredentialsWithMetadata(claims, globalActiveCred.SecretKey)
+		if err != nil {
+			return cred, err
+		}
+		cred.ParentUser = req.Principal
+		return cred, nil
+	}
+
+	cred.AccessKey = req.Principal
+	if req.Principal != globalActiveCred.AccessKey {
+		u, ok := sys.GetUser(ctx, req.Principal)
+		switch {
+		case ok:
+			cred = u.Credentials
+		case req.PrincipalType == simulatedUser && sys.usersSysType == LDAPUsersSysType:
+			// LDAP users are not stored, their DN is used with the
+			// policies mapped to it.
+		default:
+			return cred, errNoSuchUser
+		}
+	}
+
+	switch req.PrincipalType {
+	case simulatedUser:
+		if cred.IsTemp() || cred.IsServiceAccount() {
+			return cred, fmt.Errorf("%w: %s is not a user", errInvalidSimulationRequest, req.Principal)
+		}
+	case simulatedServiceAccount:
+		if !cred.IsServiceAccount() {
+			return cred, errNoSuchServiceAccount
+		}
+	case simulatedSTS:
+		if !cred.IsTemp() {
+			return cred, errNoSuchTempAccount
+		}
+	}
+	return cred, nil
+}
+
+// simulateAccount - simulates a request made with the credentials of the
+// principal. The decision is made by IsAllowed, as for actual requests.
+func (sys *IAMSys) simulateAccount(req policySimulationRequest, cred auth.Credentials, args iampolicy.Args) (res policySimulationResult, err error) {
+	var claims map[string]interface{}
+	if cred.SessionToken != "" {
+		secret := globalActiveCred.SecretKey
+		if cred.IsServiceAccount() {
+			secret = cred.SecretKey
+		}
+		claims, err = getClaimsFromTokenWithSecret(cred.SessionToken, secret)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	args.AccountName = cred.AccessKey
+	args.Groups = append(append([]string(nil), cred.Groups...), req.Groups...)
+	args.Claims = claims
+	args.IsOwner = cred.AccessKey == globalActiveCred.AccessKey
+	if args.ConditionValues, err = simulatedConditionValues(cred.AccessKey, claims, req.Conditions); err != nil {
+		return res, err
+	}
+
+	// The decision, IsAllowed updates the condition values as it
+	// evaluates the request.
+	decisionArgs := args
+	decisionArgs.ConditionValues = make(map[string][]string, len(args.ConditionValues))
+	for k, v := range args.ConditionValues {
+		decisionArgs.ConditionValues[k] = v
+	}
+	isAllowed := sys.isAllowedAs(decisionArgs, &cred)
+
+	sources := sys.explainAccount(cred, args)
+	if authz := newGlobalAuthZPluginFn(); authz != nil {
+		simulateAuthZPlugin(args, &res)
+		sources = notApplied(sources)
+	}
+	res.Allowed = isAllowed
+	res.Sources = append(res.Sources, sources...)
+	return res, nil
+}
+
+// explainAccount - evaluates the request with each policy source of the
+// credentials, as IsAllowed combines them.
+func (sys *IAMSys) explainAccount(cred auth.Credentials, args iampolicy.Args) []policySourceResult {
+	if args.IsOwner {
+		return []policySourceResult{{Source: policySourceOwner, Applied: true, Allowed: true}}
+	}
+
+	parentUser := cred.AccessKey
+	if cred.IsTemp() || cred.IsServiceAccount() {
+		parentUser = cred.ParentUser
+		if parentUser == globalActiveCred.AccessKey {
+			return []policySourceResult{{Source: policySourceOwner, Applied: true, Allowed: true}}
+		}
+		args.ConditionValues["username"] = []string{parentUser}
+		args.ConditionValues["userid"] = []string{parentUser}
+		if cred.IsTemp() {
+			for k, v := range getSessionTagsFromClaims(args.Claims) {
+				args.ConditionValues[principalTagPrefix+k] = []string{v}
+			}
+		}
+	}
+
+	// The policies of the parent, from its role, its mappings or its
+	// policy claim.
+	var sources []policySourceResult
+	if roleArn := args.GetRoleArn(); roleArn != "" && parentUser != cred.AccessKey {
+		var policies []string
+		if a, err := arn.Parse(roleArn); err == nil {
+			policies = newMappedPolicy(sys.rolesMap[a]).toSlice()
+		}
+		sources = append(sources, sys.evaluateNamedPolicies(policySourceRole, policies, args))
+	} else {
+		policies, err := sys.userPolicies(parentUser, args.Groups)
+		if err != nil {
+			sources = append(sources, policySourceResult{Source: policySourceIdentity, Error: err.Error()})
+		} else if len(policies) > 0 || parentUser == cred.AccessKey {
+			sources = append(sources, sys.evaluateNamedPolicies(policySourceIdentity, policies, args))
+		} else {
+			policySet, _ := iampolicy.GetPoliciesFromClaims(args.Claims, iamPolicyClaimNameOpenID())
+			sources = append(sources, sys.evaluateNamedPolicies(policySourceClaim, policySet.ToSlice(), args))
+		}
+	}
+
+	// The policies limiting the permissions of the credentials.
+	if cred.IsServiceAccount() {
+		if v, _ := args.Claims[iamPolicyClaimNameSA()].(string); v != inheritedPolicyType {
+			if spolicy, ok := args.Claims[sessionPolicyNameExtracted].(string); ok {
+				sources = append(sources, evaluateInlinePolicy(policySourceServiceAccess, spolicy, args))
+			}
+		}
+	}
+	if cred.IsTemp() {
+		if spolicy, ok := args.Claims[sessionPolicyNameExtracted].(string); ok {
+			sources = append(sources, evaluateInlinePolicy(policySourceSession, spolicy, args))
+		}
+		if policies, ok := args.Claims[sessionPolicyArnsClaim].(string); ok && policies != "" {
+			sources = append(sources, sys.evaluateNamedPolicies(policySourceManagedSess, strings.Split(policies, ","), args))
+		}
+	}
+	return sources
+}
@@ -41,9 +41,11 @@ type rebalancePoolStatus struct {
 
 // rebalanceAdminStatus holds rebalance status related information exported to mc, console, etc.
 type rebalanceAdminStatus struct {
-	ID        string                // identifies the ongoing rebalance operation by a uuid
-	Pools     []rebalancePoolStatus `json:"pools"` // contains all pools, including inactive
-	StoppedAt time.Time             `json:"stoppedAt,omitempty"`
+	ID         string                // identifies the ongoing rebalance operation by a uuid
+	Pools      []rebalancePoolStatus `json:"pools"` // contains all pools, including inactive
+	StoppedAt  time.Time             `json:"stoppedAt,omitempty"`
+	Options    rebalanceOpts         `json:"options"`              // options rebalance was started with
+	NextWindow time.Time             `json:"nextWindow,omitempty"` // set when waiting for the next maintenance window
 }
 
 func rebalanceStatus(ctx context.Context, z *erasureServerPools) (r rebalanceAdminStatus, err error) {
@@ -70,6 +72,10 @@ func rebalanceStatus(ctx context.Context, z *erasureServerPools) (r rebalanceAdm
 		ID:        meta.ID,
 		StoppedAt: meta.StoppedAt,
 		Pools:     make([]rebalancePoolStatus, len(meta.PoolStats)),
+		Options:   meta.Opts,
+	}
+	if now := time.Now(); stopTime.IsZero() && !meta.windows.Contains(now) {
+		r.NextWindow = meta.windows.Next(now)
 	}
 	for i, ps := range meta.PoolStats {
 		r.Pools[i] = rebalancePoolStatus{
@@ -13,11 +13,12 @@ func _() {
 	_ = x[rebalanceMetricRebalanceObject-2]
 	_ = x[rebalanceMetricRebalanceRemoveObject-3]
 	_ = x[rebalanceMetricSaveMetadata-4]
+	_ = x[rebalanceMetricWaitWindow-5]
 }
 
-const _rebalanceMetric_name = "RebalanceBucketsRebalanceBucketRebalanceObjectRebalanceRemoveObjectSaveMetadata"
+const _rebalanceMetric_name = "RebalanceBucketsRebalanceBucketRebalanceObjectRebalanceRemoveObjectSaveMetadataWaitWindow"
 
-var _rebalanceMetric_index = [...]uint8{0, 16, 31, 46, 67, 79}
+var _rebalanceMetric_index = [...]uint8{0, 16, 31, 46, 67, 79, 89}
 
 func (i rebalanceMetric) String() string {
 	if i >= rebalanceMetric(len(_rebalanceMetric_index)-1) {
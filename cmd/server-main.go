@@ -237,6 +237,10 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 	globalEndpoints, setupType, err = createServerEndpoints(globalMinioAddr, serverCmdArgs(ctx)...)
 	logger.FatalIf(err, "Invalid command line arguments")
 
+	if spares := env.Get(EnvSpareDrives, ""); spares != "" {
+		logger.FatalIf(globalEndpoints.addSpares(globalMinioAddr, setupType, strings.Fields(spares)), "Invalid spare drives")
+	}
+
 	globalLocalNodeName = GetLocalPeer(globalEndpoints, globalMinioHost, globalMinioPort)
 
 	globalRemoteEndpoints = make(map[string]Endpoint)
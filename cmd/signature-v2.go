@@ -181,6 +181,7 @@ func doesPresignV2SignatureMatch(r *http.Request) APIErrorCode {
 	if !compareSignatureV2(gotSignature, expectedSignature) {
 		return ErrSignatureDoesNotMatch
 	}
+	globalIAMSys.recordAccessKeyUsage(cred, getPeerIP(r))
 
 	r.Form.Del(xhttp.Expires)
 
@@ -282,6 +283,7 @@ func doesSignV2Match(r *http.Request) APIErrorCode {
 	if !compareSignatureV2(v2Auth, expectedAuth) {
 		return ErrSignatureDoesNotMatch
 	}
+	globalIAMSys.recordAccessKeyUsage(cred, getPeerIP(r))
 	return ErrNone
 }
 
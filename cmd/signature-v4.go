@@ -327,6 +327,7 @@ func doesPresignedSignatureMatch(hashedPayload string, r *http.Request, region s
 	if !compareSignatureV4(req.Form.Get(xhttp.AmzSignature), newSignature) {
 		return ErrSignatureDoesNotMatch
 	}
+	globalIAMSys.recordAccessKeyUsage(cred, getPeerIP(r))
 	return ErrNone
 }
 
@@ -392,6 +393,7 @@ func doesSignatureMatch(hashedPayload string, r *http.Request, region string, st
 	if !compareSignatureV4(newSignature, signV4Values.Signature) {
 		return ErrSignatureDoesNotMatch
 	}
+	globalIAMSys.recordAccessKeyUsage(cred, getPeerIP(r))
 
 	// Return error none.
 	return ErrNone
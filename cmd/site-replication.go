@@ -2034,6 +2034,14 @@ func (c *SiteReplicationSys) isEnabled() bool {
 	return c.enabled
 }
 
+// isPeer returns true if deploymentID is a site replication peer.
+func (c *SiteReplicationSys) isPeer(deploymentID string) bool {
+	c.RLock()
+	defer c.RUnlock()
+	_, ok := c.state.Peers[deploymentID]
+	return ok
+}
+
 var errMissingSRConfig = fmt.Errorf("unable to find site replication configuration")
 
 // RemovePeerCluster - removes one or more clusters from site replication configuration.
@@ -50,6 +50,7 @@ type DiskInfo struct {
 	RootDisk   bool
 	Healing    bool
 	Scanning   bool
+	Evacuating bool
 	Endpoint   string
 	MountPath  string
 	ID         string
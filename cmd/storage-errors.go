@@ -54,6 +54,9 @@ var errFaultyRemoteDisk = StorageErr("remote drive is faulty")
 // errFaultyDisk - disk is faulty.
 var errFaultyDisk = StorageErr("drive is faulty")
 
+// errDiskEvacuating - disk takes no new data while being evacuated.
+var errDiskEvacuating = StorageErr("drive is being evacuated")
+
 // errDiskAccessDenied - we don't have write permissions on disk.
 var errDiskAccessDenied = StorageErr("drive access denied")
 
@@ -76,6 +76,8 @@ func toStorageErr(err error) error {
 		return errUnexpected
 	case errDiskFull.Error():
 		return errDiskFull
+	case errDiskEvacuating.Error():
+		return errDiskEvacuating
 	case errVolumeNotFound.Error():
 		return errVolumeNotFound
 	case errVolumeExists.Error():
@@ -1311,11 +1311,11 @@ func (s *storageRESTServer) ReadMultiple(w http.ResponseWriter, r *http.Request)
 func registerStorageRESTHandlers(router *mux.Router, endpointServerPools EndpointServerPools) {
 	storageDisks := make([][]*xlStorage, len(endpointServerPools))
 	for poolIdx, ep := range endpointServerPools {
-		storageDisks[poolIdx] = make([]*xlStorage, len(ep.Endpoints))
+		storageDisks[poolIdx] = make([]*xlStorage, len(ep.EndpointsWithSpares()))
 	}
 	var wg sync.WaitGroup
 	for poolIdx, ep := range endpointServerPools {
-		for setIdx, endpoint := range ep.Endpoints {
+		for setIdx, endpoint := range ep.EndpointsWithSpares() {
 			if !endpoint.IsLocal {
 				continue
 			}
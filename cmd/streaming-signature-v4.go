@@ -136,6 +136,7 @@ func calculateSeedSignature(r *http.Request) (cred auth.Credentials, signature s
 	if !compareSignatureV4(newSignature, signV4Values.Signature) {
 		return cred, "", "", time.Time{}, ErrSignatureDoesNotMatch
 	}
+	globalIAMSys.recordAccessKeyUsage(cred, getPeerIP(r))
 
 	// Return caculated signature.
 	return cred, newSignature, region, date, ErrNone
@@ -192,6 +192,38 @@ type LDAPIdentityResult struct {
 	Credentials auth.Credentials `xml:",omitempty"`
 }
 
+// AssumeRoleWithSAMLResponse contains the result of successful
+// AssumeRoleWithSAML request
+type AssumeRoleWithSAMLResponse struct {
+	XMLName          xml.Name   `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleWithSAMLResponse" json:"-"`
+	Result           SAMLResult `xml:"AssumeRoleWithSAMLResult"`
+	ResponseMetadata struct {
+		RequestID string `xml:"RequestId,omitempty"`
+	} `xml:"ResponseMetadata,omitempty"`
+}
+
+// SAMLResult - contains the credentials and the subject of a successful
+// AssumeRoleWithSAML request.
+type SAMLResult struct {
+	// The temporary security credentials, which include an access key ID,
+	// a secret access key, and a security token.
+	Credentials auth.Credentials `xml:",omitempty"`
+
+	// The value of the NameID element in the Subject element of the SAML
+	// assertion.
+	Subject string `xml:",omitempty"`
+
+	// The format of the name ID, `persistent` or `transient` for the
+	// corresponding SAML formats, otherwise the full format URI.
+	SubjectType string `xml:",omitempty"`
+
+	// The value of the Issuer element of the SAML assertion.
+	Issuer string `xml:",omitempty"`
+
+	// The audience the SAML assertion is restricted to.
+	Audience string `xml:",omitempty"`
+}
+
 // AssumeRoleWithCertificateResponse contains the result of
 // a successful AssumeRoleWithCertificate request.
 type AssumeRoleWithCertificateResponse struct {
@@ -88,6 +88,8 @@ const (
 	ErrSTSWebIdentityExpiredToken
 	ErrSTSClientGrantsExpiredToken
 	ErrSTSInvalidClientGrantsToken
+	ErrSTSSAMLExpiredToken
+	ErrSTSInvalidSAMLAssertion
 	ErrSTSMalformedPolicyDocument
 	ErrSTSInsecureConnection
 	ErrSTSInvalidClientCertificate
@@ -139,6 +141,16 @@ var stsErrCodes = stsErrorCodeMap{
 		Description:    "The client grants token that was passed could not be validated by MinIO.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrSTSSAMLExpiredToken: {
+		Code:           "ExpiredToken",
+		Description:    "The SAML assertion that was passed is expired. Get a new assertion from the identity provider and then retry the request.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSTSInvalidSAMLAssertion: {
+		Code:           "InvalidIdentityToken",
+		Description:    "The SAML assertion that was passed could not be validated by MinIO.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrSTSMalformedPolicyDocument: {
 		Code:           "MalformedPolicyDocument",
 		Description:    "The request was rejected because the policy document was malformed.",
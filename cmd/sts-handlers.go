@@ -31,8 +31,10 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/internal/auth"
 	"github.com/minio/minio/internal/config/identity/openid"
+	"github.com/minio/minio/internal/config/identity/saml"
 	"github.com/minio/minio/internal/hash/sha256"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
@@ -53,6 +55,10 @@ const (
 	stsDurationSeconds        = "DurationSeconds"
 	stsLDAPUsername           = "LDAPUsername"
 	stsLDAPPassword           = "LDAPPassword"
+	stsSAMLAssertion          = "SAMLAssertion"
+	stsTags                   = "Tags"
+	stsTransitiveTagKeys      = "TransitiveTagKeys"
+	stsPolicyArns             = "PolicyArns"
 
 	// STS API action constants
 	clientGrants        = "AssumeRoleWithClientGrants"
@@ -60,6 +66,7 @@ const (
 	ldapIdentity        = "AssumeRoleWithLDAPIdentity"
 	clientCertificate   = "AssumeRoleWithCertificate"
 	customTokenIdentity = "AssumeRoleWithCustomToken"
+	samlIdentity        = "AssumeRoleWithSAML"
 	assumeRole          = "AssumeRole"
 
 	stsRequestBodyLimit = 10 * (1 << 20) // 10 MiB
@@ -79,6 +86,10 @@ const (
 
 	// Role Claim key
 	roleArnClaim = "roleArn"
+
+	// Session tags and managed session policies claim keys
+	sessionTagsClaim       = "sessionTags"
+	sessionPolicyArnsClaim = "sessionPolicyArns"
 )
 
 // stsAPIHandlers implements and provides http handlers for AWS STS API.
@@ -126,6 +137,12 @@ func registerSTSRouter(router *mux.Router) {
 		Queries(stsLDAPUsername, "{LDAPUsername:.*}").
 		Queries(stsLDAPPassword, "{LDAPPassword:.*}")
 
+	// AssumeRoleWithSAML
+	stsRouter.Methods(http.MethodPost).HandlerFunc(httpTraceAll(sts.AssumeRoleWithSAML)).
+		Queries(stsAction, samlIdentity).
+		Queries(stsVersion, stsAPIVersion).
+		Queries(stsSAMLAssertion, "{SAMLAssertion:.*}")
+
 	// AssumeRoleWithCertificate
 	stsRouter.Methods(http.MethodPost).HandlerFunc(httpTraceAll(sts.AssumeRoleWithCertificate)).
 		Queries(stsAction, clientCertificate).
@@ -242,6 +259,18 @@ func (sts *stsAPIHandlers) AssumeRole(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	sessionPolicyArns, err := parseManagedSessionPolicies(r.Form)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
+	sessionTags, err := parseSessionTagsForm(r.Form)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
 	duration, err := openid.GetDefaultExpiration(r.Form.Get(stsDurationSeconds))
 	if err != nil {
 		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
@@ -250,6 +279,10 @@ func (sts *stsAPIHandlers) AssumeRole(w http.ResponseWriter, r *http.Request) {
 
 	claims[expClaim] = UTCNow().Add(duration).Unix()
 	claims[parentClaim] = user.AccessKey
+	setSessionTagsClaims(claims, sessionTags)
+	if sessionPolicyArns != "" {
+		claims[sessionPolicyArnsClaim] = sessionPolicyArns
+	}
 
 	// Validate that user.AccessKey's policies can be retrieved - it may not
 	// be in case the user is disabled.
@@ -328,6 +361,9 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 	case ldapIdentity:
 		sts.AssumeRoleWithLDAPIdentity(w, r)
 		return
+	case samlIdentity:
+		sts.AssumeRoleWithSAML(w, r)
+		return
 	case clientGrants, webIdentity:
 	default:
 		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, fmt.Errorf("Unsupported action %s", action))
@@ -375,6 +411,23 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Session tags and managed session policies may only be set by
+	// MinIO, never directly by claims of the identity provider.
+	delete(claims, sessionTagsClaim)
+	delete(claims, sessionPolicyArnsClaim)
+
+	if tagsClaim := globalOpenIDConfig.GetSessionTagsClaim(roleArn); tagsClaim != "" {
+		if v, ok := claims[tagsClaim]; ok {
+			sessionTags, err := parseSessionTagsClaim(v)
+			if err != nil {
+				writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+				return
+			}
+			delete(claims, tagsClaim)
+			setSessionTagsClaims(claims, sessionTags)
+		}
+	}
+
 	var policyName string
 	if globalIAMSys.HasRolePolicy() {
 		// If roleArn is used, we set it as a claim, and use the
@@ -430,6 +483,15 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 		claims[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString([]byte(sessionPolicyStr))
 	}
 
+	sessionPolicyArns, err := parseManagedSessionPolicies(r.Form)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+	if sessionPolicyArns != "" {
+		claims[sessionPolicyArnsClaim] = sessionPolicyArns
+	}
+
 	secret := globalActiveCred.SecretKey
 	cred, err := auth.GetNewCredentialsWithMetadata(claims, secret)
 	if err != nil {
@@ -596,12 +658,25 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 		}
 	}
 
-	ldapUserDN, groupDistNames, err := globalLDAPConfig.Bind(ldapUsername, ldapPassword)
+	sessionPolicyArns, err := parseManagedSessionPolicies(r.Form)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
+	ldapUserInfo, err := globalLDAPConfig.Bind(ldapUsername, ldapPassword)
 	if err != nil {
 		err = fmt.Errorf("LDAP server error: %w", err)
 		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
 		return
 	}
+	ldapUserDN, groupDistNames := ldapUserInfo.DN, ldapUserInfo.Groups
+
+	if err = validateSessionTags(ldapUserInfo.SessionTags); err != nil {
+		err = fmt.Errorf("LDAP user attributes cannot be used as session tags: %w", err)
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
 
 	// Check if this user or their groups have a policy applied.
 	ldapPolicies, _ := globalIAMSys.PolicyDBGet(ldapUserDN, false, groupDistNames...)
@@ -621,6 +696,10 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 	claims[expClaim] = UTCNow().Add(expiryDur).Unix()
 	claims[ldapUser] = ldapUserDN
 	claims[ldapUserN] = ldapUsername
+	setSessionTagsClaims(claims, ldapUserInfo.SessionTags)
+	if sessionPolicyArns != "" {
+		claims[sessionPolicyArnsClaim] = sessionPolicyArns
+	}
 
 	if len(sessionPolicyStr) > 0 {
 		claims[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString([]byte(sessionPolicyStr))
@@ -675,6 +754,232 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 }
 
+// AssumeRoleWithSAML - implementation of AWS STS API supporting SAML 2.0
+// assertions of identity providers such as ADFS, the assertion is the base64
+// encoded SAML response posted by the identity provider.
+//
+// Eg:-
+//
+//	$ curl https://minio:9000/?Action=AssumeRoleWithSAML&SAMLAssertion=<base64>&RoleArn=<arn>
+//
+// https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRoleWithSAML.html
+func (sts *stsAPIHandlers) AssumeRoleWithSAML(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AssumeRoleWithSAML")
+
+	claims := make(map[string]interface{})
+	defer logger.AuditLog(ctx, w, r, claims, stsSAMLAssertion)
+
+	// Parse the incoming form data.
+	if err := parseForm(r); err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
+	if r.Form.Get(stsVersion) != stsAPIVersion {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSMissingParameter,
+			fmt.Errorf("Invalid STS API version %s, expecting %s", r.Form.Get("Version"), stsAPIVersion))
+		return
+	}
+
+	action := r.Form.Get(stsAction)
+	if action != samlIdentity {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, fmt.Errorf("Unsupported action %s", action))
+		return
+	}
+
+	if !globalSAMLConfig.Enabled {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSNotInitialized, errors.New("STS API 'AssumeRoleWithSAML' is disabled"))
+		return
+	}
+
+	samlAssertion := r.Form.Get(stsSAMLAssertion)
+	if samlAssertion == "" {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSMissingParameter, fmt.Errorf("%s cannot be empty", stsSAMLAssertion))
+		return
+	}
+
+	assertion, err := globalSAMLConfig.ValidateResponse(samlAssertion)
+	if err != nil {
+		if errors.Is(err, saml.ErrAssertionExpired) {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSSAMLExpiredToken, err)
+			return
+		}
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidSAMLAssertion, err)
+		return
+	}
+
+	expiryDur, err := globalSAMLConfig.GetExpiryDuration(r.Form.Get(stsDurationSeconds))
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
+	// Credentials never outlive the session at the identity provider.
+	if !assertion.SessionNotOnOrAfter.IsZero() {
+		if d := assertion.SessionNotOnOrAfter.Sub(UTCNow()); d < expiryDur {
+			expiryDur = d
+		}
+		if expiryDur <= 0 {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSSAMLExpiredToken, saml.ErrAssertionExpired)
+			return
+		}
+	}
+
+	var policyName string
+	if globalSAMLConfig.RolePolicy != "" {
+		roleArn, _, err := globalIAMSys.GetRolePolicy(r.Form.Get(stsRoleArn))
+		if err != nil {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
+				fmt.Errorf("Error processing %s parameter: %v", stsRoleArn, err))
+			return
+		}
+		if roleArn != globalSAMLConfig.RoleARN {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
+				fmt.Errorf("Role ARN %s is not a SAML role", roleArn))
+			return
+		}
+
+		// The identity provider may restrict the roles a user can assume.
+		if roleArns, ok := globalSAMLConfig.GetRoleArns(assertion); ok &&
+			!set.CreateStringSet(roleArns...).Contains(roleArn.String()) {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSAccessDenied,
+				fmt.Errorf("The SAML assertion does not allow to assume the role %s", roleArn))
+			return
+		}
+
+		// If roleArn is used, we set it as a claim, and use the
+		// associated policy when credentials are used.
+		claims[roleArnClaim] = roleArn.String()
+	} else {
+		// If no role policy is configured, then we use the policies
+		// listed by an attribute of the assertion.
+		policies, ok := globalSAMLConfig.GetPolicies(assertion)
+		if ok {
+			policyName = globalIAMSys.CurrentPolicies(strings.Join(policies, ","))
+		}
+
+		if newGlobalAuthZPluginFn() == nil {
+			if !ok {
+				writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
+					errors.New("Policy attribute missing from the SAML assertion, credentials will not be generated"))
+				return
+			} else if policyName == "" {
+				writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
+					fmt.Errorf("None of the given policies (`%s`) are defined, credentials will not be generated", strings.Join(policies, ",")))
+				return
+			}
+		}
+		claims[iamPolicyClaimNameOpenID()] = policyName
+	}
+
+	sessionTags, err := parseSessionTagsSAMLAttributes(assertion.Attributes)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
+	sessionPolicyStr := r.Form.Get(stsPolicy)
+	// https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRoleWithSAML.html
+	// The plain text that you use for both inline and managed session
+	// policies shouldn't exceed 2048 characters.
+	if len(sessionPolicyStr) > 2048 {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, fmt.Errorf("Session policy should not exceed 2048 characters"))
+		return
+	}
+
+	if len(sessionPolicyStr) > 0 {
+		sessionPolicy, err := iampolicy.ParseConfig(bytes.NewReader([]byte(sessionPolicyStr)))
+		if err != nil {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+			return
+		}
+
+		// Version in policy must not be empty
+		if sessionPolicy.Version == "" {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, fmt.Errorf("Invalid session policy version"))
+			return
+		}
+
+		claims[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString([]byte(sessionPolicyStr))
+	}
+
+	sessionPolicyArns, err := parseManagedSessionPolicies(r.Form)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+	if sessionPolicyArns != "" {
+		claims[sessionPolicyArnsClaim] = sessionPolicyArns
+	}
+
+	claims[expClaim] = UTCNow().Add(expiryDur).Unix()
+	claims[subClaim] = assertion.NameID
+	claims[issClaim] = assertion.Issuer
+	setSessionTagsClaims(claims, sessionTags)
+
+	cred, err := auth.GetNewCredentialsWithMetadata(claims, globalActiveCred.SecretKey)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInternalError, err)
+		return
+	}
+
+	// The name ID is only unique for a given identity provider, which is
+	// typically an URL. Hash both to get a parent user that is a valid
+	// filename of bounded length, as for OpenID.
+	{
+		h := sha256.New()
+		h.Write([]byte("saml:" + assertion.NameID + ":" + assertion.Issuer))
+		bs := h.Sum(nil)
+		cred.ParentUser = base64.RawURLEncoding.EncodeToString(bs)
+	}
+
+	// Set the newly generated credentials.
+	updatedAt, err := globalIAMSys.SetTempUser(ctx, cred.AccessKey, cred, policyName)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInternalError, err)
+		return
+	}
+
+	// Call hook for site replication.
+	if err := globalSiteReplicationSys.IAMChangeHook(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemSTSAcc,
+		STSCredential: &madmin.SRSTSCredential{
+			AccessKey:           cred.AccessKey,
+			SecretKey:           cred.SecretKey,
+			SessionToken:        cred.SessionToken,
+			ParentUser:          cred.ParentUser,
+			ParentPolicyMapping: policyName,
+		},
+		UpdatedAt: updatedAt,
+	}); err != nil {
+		logger.LogIf(ctx, err)
+	}
+
+	samlResponse := &AssumeRoleWithSAMLResponse{
+		Result: SAMLResult{
+			Credentials: cred,
+			Subject:     assertion.NameID,
+			SubjectType: samlSubjectType(assertion.NameIDFormat),
+			Issuer:      assertion.Issuer,
+			Audience:    globalSAMLConfig.GetAudience(),
+		},
+	}
+	samlResponse.ResponseMetadata.RequestID = w.Header().Get(xhttp.AmzRequestID)
+	writeSuccessResponseXML(w, encodeResponse(samlResponse))
+}
+
+// samlSubjectType - returns the subject type of an AssumeRoleWithSAML
+// response for a SAML name ID format, as AWS STS does.
+func samlSubjectType(format string) string {
+	switch format {
+	case "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent":
+		return "persistent"
+	case "urn:oasis:names:tc:SAML:2.0:nameid-format:transient":
+		return "transient"
+	}
+	return format
+}
+
 // AssumeRoleWithCertificate implements user authentication with client certificates.
 // It verifies the client-provided X.509 certificate, maps the certificate to an S3 policy
 // and returns temp. S3 credentials to the client.
@@ -18,8 +18,22 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
@@ -29,6 +43,9 @@ import (
 	minio "github.com/minio/minio-go/v7"
 	cr "github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio-go/v7/pkg/signer"
+	"github.com/minio/minio/internal/config/identity/saml"
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 func runAllIAMSTSTests(suite *TestSuiteIAM, c *check) {
@@ -37,6 +54,8 @@ func runAllIAMSTSTests(suite *TestSuiteIAM, c *check) {
 	suite.TestSTSForRoot(c)
 	suite.TestSTS(c)
 	suite.TestSTSWithTags(c)
+	suite.TestSTSWithSessionTags(c)
+	suite.TestSTSWithSAML(c)
 	suite.TestSTSWithGroupPolicy(c)
 	suite.TearDownSuite(c)
 }
@@ -185,6 +204,368 @@ func (s *TestSuiteIAM) TestSTSWithTags(c *check) {
 	}
 }
 
+// assumeRoleWithForm - calls AssumeRole with additional form parameters
+// not supported by the minio-go STS client.
+func (s *TestSuiteIAM) assumeRoleWithForm(accessKey, secretKey string, form url.Values) (cr.Value, error) {
+	form.Set("Action", assumeRole)
+	form.Set("Version", stsAPIVersion)
+	body := form.Encode()
+
+	req, err := http.NewRequest(http.MethodPost, s.endPoint+SlashSeparator, strings.NewReader(body))
+	if err != nil {
+		return cr.Value{}, err
+	}
+	req.Header.Set(xhttp.ContentType, "application/x-www-form-urlencoded")
+	req.Header.Set(xhttp.AmzContentSha256, getSHA256Hash([]byte(body)))
+	req = signer.SignV4STS(*req, accessKey, secretKey, "")
+
+	resp, err := s.TestSuiteCommon.client.Do(req)
+	if err != nil {
+		return cr.Value{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return cr.Value{}, fmt.Errorf("AssumeRole failed with %s: %s", resp.Status, b)
+	}
+
+	var ar AssumeRoleResponse
+	if err = xml.NewDecoder(resp.Body).Decode(&ar); err != nil {
+		return cr.Value{}, err
+	}
+	cred := ar.Result.Credentials
+	return cr.Value{
+		AccessKeyID:     cred.AccessKey,
+		SecretAccessKey: cred.SecretKey,
+		SessionToken:    cred.SessionToken,
+	}, nil
+}
+
+const samlTestIssuer = "http://adfs.example.com/adfs/services/trust"
+
+// samlTestResponse - returns a SAML response with an assertion signed by
+// key. The assertion and its signed info are written in canonical form, so
+// that they are signed as they are. Each assertion has its own ID, as the
+// server accepts an assertion only once.
+func samlTestResponse(c *check, key *rsa.PrivateKey, policy string, notOnOrAfter time.Time) string {
+	now := UTCNow()
+	id := "_" + mustGetUUID()
+	assertion := func(signature string) string {
+		return fmt.Sprintf(`<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="%[6]s" IssueInstant="%[1]s" Version="2.0">`+
+			`<saml:Issuer>%[3]s</saml:Issuer>%[5]s<saml:Subject>`+
+			`<saml:NameID Format="urn:oasis:names:tc:SAML:2.0:nameid-format:persistent">EXAMPLE\alice</saml:NameID>`+
+			`<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">`+
+			`<saml:SubjectConfirmationData NotOnOrAfter="%[2]s"></saml:SubjectConfirmationData></saml:SubjectConfirmation></saml:Subject>`+
+			`<saml:Conditions NotBefore="%[1]s" NotOnOrAfter="%[2]s"><saml:AudienceRestriction>`+
+			`<saml:Audience>urn:amazon:webservices</saml:Audience></saml:AudienceRestriction></saml:Conditions>`+
+			`<saml:AttributeStatement><saml:Attribute Name="policy"><saml:AttributeValue>%[4]s</saml:AttributeValue></saml:Attribute>`+
+			`<saml:Attribute Name="https://aws.amazon.com/SAML/Attributes/PrincipalTag:project"><saml:AttributeValue>alpha</saml:AttributeValue></saml:Attribute>`+
+			`</saml:AttributeStatement></saml:Assertion>`,
+			now.Format(time.RFC3339), notOnOrAfter.Format(time.RFC3339), samlTestIssuer, policy, signature, id)
+	}
+
+	digest := sha256.Sum256([]byte(assertion("")))
+	signedInfo := `<ds:SignedInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">` +
+		`<ds:CanonicalizationMethod Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"></ds:CanonicalizationMethod>` +
+		`<ds:SignatureMethod Algorithm="http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"></ds:SignatureMethod>` +
+		`<ds:Reference URI="#` + id + `"><ds:Transforms>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2000/09/xmldsig#enveloped-signature"></ds:Transform>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"></ds:Transform></ds:Transforms>` +
+		`<ds:DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"></ds:DigestMethod>` +
+		`<ds:DigestValue>` + base64.StdEncoding.EncodeToString(digest[:]) + `</ds:DigestValue></ds:Reference></ds:SignedInfo>`
+
+	hashed := sha256.Sum256([]byte(signedInfo))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	if err != nil {
+		c.Fatalf("Unable to sign SAML assertion: %v", err)
+	}
+
+	return `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="_response" Version="2.0">` +
+		`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>` +
+		assertion(`<ds:Signature xmlns:ds="http://www.w3.org/2000/09/xmldsig#">`+signedInfo+
+			`<ds:SignatureValue>`+base64.StdEncoding.EncodeToString(signature)+`</ds:SignatureValue></ds:Signature>`) +
+		`</samlp:Response>`
+}
+
+func (s *TestSuiteIAM) assumeRoleWithSAML(samlResponse string) (AssumeRoleWithSAMLResponse, error) {
+	form := url.Values{}
+	form.Set("Action", samlIdentity)
+	form.Set("Version", stsAPIVersion)
+	form.Set(stsSAMLAssertion, base64.StdEncoding.EncodeToString([]byte(samlResponse)))
+
+	var ar AssumeRoleWithSAMLResponse
+	req, err := http.NewRequest(http.MethodPost, s.endPoint+SlashSeparator, strings.NewReader(form.Encode()))
+	if err != nil {
+		return ar, err
+	}
+	req.Header.Set(xhttp.ContentType, "application/x-www-form-urlencoded")
+
+	resp, err := s.TestSuiteCommon.client.Do(req)
+	if err != nil {
+		return ar, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return ar, fmt.Errorf("AssumeRoleWithSAML failed with %s: %s", resp.Status, b)
+	}
+
+	err = xml.NewDecoder(resp.Body).Decode(&ar)
+	return ar, err
+}
+
+func (s *TestSuiteIAM) TestSTSWithSAML(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	// Configure a SAML identity provider with a self-signed certificate.
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		c.Fatalf("Unable to generate key: %v", err)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ADFS Signing"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		c.Fatalf("Unable to create certificate: %v", err)
+	}
+	// The test directory name holds commas, which separate certificates.
+	f, err := os.CreateTemp("", "saml-*.crt")
+	if err != nil {
+		c.Fatalf("Unable to create certificate file: %v", err)
+	}
+	certFile := f.Name()
+	defer os.Remove(certFile)
+	_, err = f.Write(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	f.Close()
+	if err != nil {
+		c.Fatalf("Unable to write certificate: %v", err)
+	}
+
+	kvs := saml.DefaultKVS.Clone()
+	kvs.Set(saml.EntityID, samlTestIssuer)
+	kvs.Set(saml.Certificates, certFile)
+	kvs.Set(saml.Audience, "urn:amazon:webservices")
+	globalSAMLConfig, err = saml.LookupConfig(kvs, nil, xhttp.DrainBody, globalSite.Region)
+	if err != nil {
+		c.Fatalf("Unable to configure SAML: %v", err)
+	}
+	defer func() {
+		globalSAMLConfig = saml.Config{}
+	}()
+
+	bucket := getRandomBucketName()
+	if err = s.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+		c.Fatalf("bucket create error: %v", err)
+	}
+
+	policy := "samlpolicy"
+	policyBytes := []byte(fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": ["s3:PutObject"],
+   "Resource": ["arn:aws:s3:::%s/*"],
+   "Condition": {"StringEquals": {"aws:PrincipalTag/project": "alpha"}}
+  }
+ ]
+}`, bucket))
+	if err = s.adm.AddCannedPolicy(ctx, policy, policyBytes); err != nil {
+		c.Fatalf("policy add error: %v", err)
+	}
+
+	// 1. A valid assertion grants the policies of its policy attribute
+	// and its principal tags.
+	samlResponse := samlTestResponse(c, key, policy, UTCNow().Add(5*time.Minute))
+	ar, err := s.assumeRoleWithSAML(samlResponse)
+	if err != nil {
+		c.Fatalf("Unable to assume role with SAML: %v", err)
+	}
+	if ar.Result.Subject != `EXAMPLE\alice` || ar.Result.SubjectType != "persistent" || ar.Result.Issuer != samlTestIssuer {
+		c.Fatalf("Unexpected AssumeRoleWithSAML result: %#v", ar.Result)
+	}
+
+	minioClient, err := minio.New(s.endpoint, &minio.Options{
+		Creds:     cr.NewStaticV4(ar.Result.Credentials.AccessKey, ar.Result.Credentials.SecretKey, ar.Result.Credentials.SessionToken),
+		Secure:    s.secure,
+		Transport: s.TestSuiteCommon.client.Transport,
+	})
+	if err != nil {
+		c.Fatalf("Error initializing client: %v", err)
+	}
+	if _, err = minioClient.PutObject(ctx, bucket, "object", bytes.NewReader([]byte("data")), 4, minio.PutObjectOptions{}); err != nil {
+		c.Fatalf("SAML credentials were unable to upload: %v", err)
+	}
+	if _, err = minioClient.ListBuckets(ctx); err == nil {
+		c.Fatalf("SAML credentials were able to list buckets")
+	}
+
+	// 2. Tampered assertions are rejected.
+	tampered := strings.Replace(samlTestResponse(c, key, policy, UTCNow().Add(5*time.Minute)),
+		">"+policy+"<", ">consoleAdmin<", 1)
+	if _, err = s.assumeRoleWithSAML(tampered); err == nil || !strings.Contains(err.Error(), "InvalidIdentityToken") {
+		c.Fatalf("Expected tampered SAML assertion to be rejected, got %v", err)
+	}
+
+	// 3. Expired assertions are rejected.
+	if _, err = s.assumeRoleWithSAML(samlTestResponse(c, key, policy, UTCNow().Add(-10*time.Minute))); err == nil ||
+		!strings.Contains(err.Error(), "ExpiredToken") {
+		c.Fatalf("Expected expired SAML assertion to be rejected, got %v", err)
+	}
+
+	// 4. Assertions without a known policy are rejected.
+	if _, err = s.assumeRoleWithSAML(samlTestResponse(c, key, "nosuchpolicy", UTCNow().Add(5*time.Minute))); err == nil {
+		c.Fatalf("Expected SAML assertion without known policy to be rejected")
+	}
+
+	// 5. Assertions are accepted only once.
+	if _, err = s.assumeRoleWithSAML(samlResponse); err == nil || !strings.Contains(err.Error(), "InvalidIdentityToken") {
+		c.Fatalf("Expected replayed SAML assertion to be rejected, got %v", err)
+	}
+}
+
+func (s *TestSuiteIAM) TestSTSWithSessionTags(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	bucket := getRandomBucketName()
+	err := s.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{})
+	if err != nil {
+		c.Fatalf("bucket create error: %v", err)
+	}
+
+	// Create policy, user and associate policy
+	policy := "abacpolicy"
+	policyBytes := []byte(fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": ["s3:PutObject", "s3:GetObject"],
+   "Resource": ["arn:aws:s3:::%s/*"],
+   "Condition": {"StringEquals": {"aws:PrincipalTag/project": "alpha"}}
+  }
+ ]
+}`, bucket))
+	err = s.adm.AddCannedPolicy(ctx, policy, policyBytes)
+	if err != nil {
+		c.Fatalf("policy add error: %v", err)
+	}
+
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err = s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+
+	err = s.adm.SetPolicy(ctx, policy, accessKey, false)
+	if err != nil {
+		c.Fatalf("Unable to set policy: %v", err)
+	}
+
+	newClient := func(value cr.Value) *minio.Client {
+		minioClient, err := minio.New(s.endpoint, &minio.Options{
+			Creds:     cr.NewStaticV4(value.AccessKeyID, value.SecretAccessKey, value.SessionToken),
+			Secure:    s.secure,
+			Transport: s.TestSuiteCommon.client.Transport,
+		})
+		if err != nil {
+			c.Fatalf("Error initializing client: %v", err)
+		}
+		return minioClient
+	}
+
+	putObject := func(minioClient *minio.Client) error {
+		_, err := minioClient.PutObject(ctx, bucket, "object", bytes.NewReader([]byte("data")), 4, minio.PutObjectOptions{})
+		return err
+	}
+
+	// 1. The user has no principal tags, so it is denied.
+	if err = putObject(s.getUserClient(c, accessKey, secretKey, "")); err == nil {
+		c.Fatalf("user without principal tags was able to upload")
+	}
+
+	// 2. Session tags are available as principal tags.
+	value, err := s.assumeRoleWithForm(accessKey, secretKey, url.Values{
+		"Tags.member.1.Key":   {"project"},
+		"Tags.member.1.Value": {"alpha"},
+		"Tags.member.2.Key":   {"team"},
+		"Tags.member.2.Value": {"storage"},
+	})
+	if err != nil {
+		c.Fatalf("err calling assumeRole: %v", err)
+	}
+	alphaClient := newClient(value)
+	if err = putObject(alphaClient); err != nil {
+		c.Fatalf("session tagged with project=alpha is unable to upload: %v", err)
+	}
+
+	// 3. Session tags not matching the policy are denied, principal tags
+	// cannot be passed with the request.
+	value, err = s.assumeRoleWithForm(accessKey, secretKey, url.Values{
+		"Tags.member.1.Key":   {"project"},
+		"Tags.member.1.Value": {"beta"},
+	})
+	if err != nil {
+		c.Fatalf("err calling assumeRole: %v", err)
+	}
+	betaClient := newClient(value)
+	if err = putObject(betaClient); err == nil {
+		c.Fatalf("session tagged with project=beta was able to upload")
+	}
+	presignedURL, err := betaClient.Presign(ctx, http.MethodPut, bucket, "object", time.Minute,
+		url.Values{"PrincipalTag/project": {"alpha"}})
+	if err != nil {
+		c.Fatalf("Unable to presign: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPut, presignedURL.String(), bytes.NewReader([]byte("data")))
+	if err != nil {
+		c.Fatalf("Unable to create request: %v", err)
+	}
+	resp, err := s.TestSuiteCommon.client.Do(req)
+	if err != nil {
+		c.Fatalf("Unable to upload: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		c.Fatalf("session tagged with project=beta was able to upload with a spoofed principal tag: %s", resp.Status)
+	}
+
+	// 4. Managed session policies limit the session permissions.
+	value, err = s.assumeRoleWithForm(accessKey, secretKey, url.Values{
+		"Tags.member.1.Key":       {"project"},
+		"Tags.member.1.Value":     {"alpha"},
+		"PolicyArns.member.1.arn": {"arn:aws:iam::aws:policy/readonly"},
+	})
+	if err != nil {
+		c.Fatalf("err calling assumeRole: %v", err)
+	}
+	readOnlyClient := newClient(value)
+	if err = putObject(readOnlyClient); err == nil {
+		c.Fatalf("session limited to readonly was able to upload")
+	}
+	c.mustGetObject(ctx, readOnlyClient, bucket, "object")
+
+	// 5. Invalid session tags, transitive tag keys and unknown managed
+	// session policies are rejected.
+	for i, form := range []url.Values{
+		{"Tags.member.1.Key": {"project"}, "Tags.member.2.Key": {"Project"}},
+		{"Tags.member.1.Key": {"project"}, "TransitiveTagKeys.member.1": {"project"}},
+		{"Tags.member.1.Key": {"proj|ect"}},
+		{"PolicyArns.member.1.arn": {"arn:aws:iam::aws:policy/nosuchpolicy"}},
+		{"PolicyArns.member.1.arn": {"readonly"}},
+	} {
+		if _, err = s.assumeRoleWithForm(accessKey, secretKey, form); err == nil {
+			c.Fatalf("case %d: expected AssumeRole to fail", i+1)
+		}
+	}
+}
+
 func (s *TestSuiteIAM) TestSTS(c *check) {
 	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
 	defer cancel()
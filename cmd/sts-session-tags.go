@@ -0,0 +1,294 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/pkg/bucket/policy/condition"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/id_session-tags.html#id_session-tags_know
+const (
+	maxSessionTags           = 50
+	maxSessionTagKeyLength   = 128
+	maxSessionTagValueLength = 256
+	maxSessionPolicyArns     = 10
+)
+
+// principalTagKeyName - condition key under which session tags are
+// available to policies, e.g. `aws:PrincipalTag/project`.
+const principalTagKeyName condition.KeyName = "aws:PrincipalTag"
+
+// Prefix of the condition values holding session tags.
+var principalTagPrefix = principalTagKeyName.Name() + "/"
+
+// isPrincipalTagKey - returns whether a condition value key holds a
+// session tag, regardless of its case.
+func isPrincipalTagKey(key string) bool {
+	return len(key) >= len(principalTagPrefix) &&
+		strings.EqualFold(key[:len(principalTagPrefix)], principalTagPrefix)
+}
+
+var sessionTagRegexp = regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]*$`)
+
+// errTransitiveTagKeysNotSupported - transitive tag keys only apply to role
+// chaining, temporary credentials can never assume a role in MinIO.
+var errTransitiveTagKeysNotSupported = errors.New("Transitive tag keys are not supported, temporary credentials cannot assume roles")
+
+// validateSessionTags - validates session tags as AWS STS does, tag keys
+// are unique regardless of their case.
+func validateSessionTags(tags map[string]string) error {
+	if len(tags) > maxSessionTags {
+		return fmt.Errorf("Session tags cannot exceed %d tags", maxSessionTags)
+	}
+
+	keys := set.NewStringSet()
+	for k, v := range tags {
+		if k == "" || len(k) > maxSessionTagKeyLength || !sessionTagRegexp.MatchString(k) {
+			return fmt.Errorf("Invalid session tag key '%s'", k)
+		}
+		if len(v) > maxSessionTagValueLength || !sessionTagRegexp.MatchString(v) {
+			return fmt.Errorf("Invalid value for session tag '%s'", k)
+		}
+		lk := strings.ToLower(k)
+		if keys.Contains(lk) {
+			return fmt.Errorf("Duplicate session tag key '%s'", k)
+		}
+		keys.Add(lk)
+	}
+	return nil
+}
+
+// parseSessionTagsForm - parses the `Tags.member.N.Key` and
+// `Tags.member.N.Value` parameters of an STS request, `TransitiveTagKeys`
+// are rejected.
+func parseSessionTagsForm(form url.Values) (tags map[string]string, err error) {
+	if _, ok := form[stsTransitiveTagKeys+".member.1"]; ok {
+		return nil, errTransitiveTagKeysNotSupported
+	}
+
+	for i := 1; ; i++ {
+		prefix := stsTags + ".member." + strconv.Itoa(i) + "."
+		if _, ok := form[prefix+"Key"]; !ok {
+			break
+		}
+		if i > maxSessionTags {
+			return nil, fmt.Errorf("Session tags cannot exceed %d tags", maxSessionTags)
+		}
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+		k := form.Get(prefix + "Key")
+		if _, ok := tags[k]; ok {
+			return nil, fmt.Errorf("Duplicate session tag key '%s'", k)
+		}
+		tags[k] = form.Get(prefix + "Value")
+	}
+
+	return tags, validateSessionTags(tags)
+}
+
+// parseSessionTagsClaim - parses session tags from an OpenID claim in the
+// format used by AWS STS, i.e.
+//
+//	{"principal_tags": {"key": ["value"]}}
+//
+// non-empty `transitive_tag_keys` are rejected.
+func parseSessionTagsClaim(v interface{}) (tags map[string]string, err error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Session tags claim must be a JSON object")
+	}
+
+	if tk, ok := m["transitive_tag_keys"]; ok {
+		tkl, ok := tk.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Session tags claim has invalid `transitive_tag_keys`")
+		}
+		if len(tkl) > 0 {
+			return nil, errTransitiveTagKeysNotSupported
+		}
+	}
+
+	if pt, ok := m["principal_tags"]; ok {
+		ptm, ok := pt.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Session tags claim has invalid `principal_tags`")
+		}
+		tags = make(map[string]string, len(ptm))
+		for k, tv := range ptm {
+			// AWS expects a single value in a list, plain
+			// strings are accepted as well.
+			switch tv := tv.(type) {
+			case string:
+				tags[k] = tv
+			case []interface{}:
+				if len(tv) != 1 {
+					return nil, fmt.Errorf("Session tag '%s' must have exactly one value", k)
+				}
+				s, ok := tv[0].(string)
+				if !ok {
+					return nil, fmt.Errorf("Session tag '%s' has a non-string value", k)
+				}
+				tags[k] = s
+			default:
+				return nil, fmt.Errorf("Session tag '%s' has a non-string value", k)
+			}
+		}
+	}
+
+	return tags, validateSessionTags(tags)
+}
+
+// SAML attributes holding session tags, as used by AWS.
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/id_session-tags.html#id_session-tags_adding-assume-role-saml
+const (
+	samlPrincipalTagAttrPrefix = "https://aws.amazon.com/SAML/Attributes/PrincipalTag:"
+	samlTransitiveTagKeysAttr  = "https://aws.amazon.com/SAML/Attributes/TransitiveTagKeys"
+)
+
+// parseSessionTagsSAMLAttributes - parses session tags from the attributes
+// of a SAML assertion, each tag is an attribute with a single value.
+// Transitive tag keys are rejected.
+func parseSessionTagsSAMLAttributes(attrs map[string][]string) (tags map[string]string, err error) {
+	if len(attrs[samlTransitiveTagKeysAttr]) > 0 {
+		return nil, errTransitiveTagKeysNotSupported
+	}
+
+	for name, values := range attrs {
+		if !strings.HasPrefix(name, samlPrincipalTagAttrPrefix) {
+			continue
+		}
+		k := strings.TrimPrefix(name, samlPrincipalTagAttrPrefix)
+		if len(values) != 1 {
+			return nil, fmt.Errorf("Session tag '%s' must have exactly one value", k)
+		}
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+		tags[k] = values[0]
+	}
+	return tags, validateSessionTags(tags)
+}
+
+// setSessionTagsClaims - stores session tags in the claims of temporary
+// credentials.
+func setSessionTagsClaims(claims map[string]interface{}, tags map[string]string) {
+	if len(tags) > 0 {
+		claims[sessionTagsClaim] = tags
+	}
+}
+
+// getSessionTagsFromClaims - returns the session tags stored in the claims
+// of temporary credentials.
+func getSessionTagsFromClaims(claims map[string]interface{}) map[string]string {
+	var tags map[string]string
+	switch v := claims[sessionTagsClaim].(type) {
+	case map[string]string:
+		tags = v
+	case map[string]interface{}:
+		tags = make(map[string]string, len(v))
+		for k, tv := range v {
+			if s, ok := tv.(string); ok {
+				tags[k] = s
+			}
+		}
+	}
+	return tags
+}
+
+// parsePolicyArnsForm - parses the `PolicyArns.member.N.arn` parameters of
+// an STS request into the names of the managed session policies.
+func parsePolicyArnsForm(form url.Values) ([]string, error) {
+	var policies []string
+	for i := 1; ; i++ {
+		v, ok := form[stsPolicyArns+".member."+strconv.Itoa(i)+".arn"]
+		if !ok {
+			break
+		}
+		if i > maxSessionPolicyArns {
+			return nil, fmt.Errorf("Managed session policies cannot exceed %d policies", maxSessionPolicyArns)
+		}
+		name, err := parseSessionPolicyArn(v[0])
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, name)
+	}
+	return policies, nil
+}
+
+// parseManagedSessionPolicies - parses the managed session policies of an
+// STS request, they must all exist. Returns the comma separated policy names.
+func parseManagedSessionPolicies(form url.Values) (string, error) {
+	policies, err := parsePolicyArnsForm(form)
+	if err != nil {
+		return "", err
+	}
+	for _, policy := range policies {
+		if _, err = globalIAMSys.InfoPolicy(policy); err != nil {
+			return "", fmt.Errorf("Managed session policy '%s': %w", policy, err)
+		}
+	}
+	return strings.Join(policies, ","), nil
+}
+
+// parseSessionPolicyArn - returns the policy name of an IAM policy ARN such
+// as `arn:aws:iam::aws:policy/readonly` or `arn:minio:iam:::policy/readonly`.
+func parseSessionPolicyArn(s string) (string, error) {
+	tokens := strings.SplitN(s, ":", 6)
+	if len(tokens) != 6 || tokens[0] != "arn" || tokens[2] != "iam" ||
+		!strings.HasPrefix(tokens[5], "policy/") {
+		return "", fmt.Errorf("Invalid policy ARN '%s'", s)
+	}
+	name := strings.TrimPrefix(tokens[5], "policy/")
+	if name == "" || strings.Contains(name, ",") {
+		return "", fmt.Errorf("Invalid policy ARN '%s'", s)
+	}
+	return name, nil
+}
+
+// isAllowedByManagedSessionPolicy - checks the managed session policies
+// given by their ARNs when the temporary credentials were requested, if any.
+func (sys *IAMSys) isAllowedByManagedSessionPolicy(args iampolicy.Args) (hasSessionPolicy bool, isAllowed bool) {
+	v, ok := args.Claims[sessionPolicyArnsClaim]
+	if !ok {
+		return false, false
+	}
+
+	policies, ok := v.(string)
+	if !ok || policies == "" {
+		// Reject malformed claims.
+		return true, false
+	}
+
+	policy, err := sys.store.GetPolicy(policies)
+	if err != nil {
+		// Policies removed since are no longer allowed.
+		return true, false
+	}
+	return true, policy.IsAllowed(args)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseSessionTagsForm(t *testing.T) {
+	testCases := []struct {
+		form      url.Values
+		tags      map[string]string
+		expectErr bool
+	}{
+		// Test 1: no session tags.
+		{form: url.Values{}},
+		// Test 2: tags.
+		{
+			form: url.Values{
+				"Tags.member.1.Key":   {"project"},
+				"Tags.member.1.Value": {"alpha"},
+				"Tags.member.2.Key":   {"cost-center"},
+				"Tags.member.2.Value": {""},
+			},
+			tags: map[string]string{"project": "alpha", "cost-center": ""},
+		},
+		// Test 3: keys only differing in case.
+		{
+			form: url.Values{
+				"Tags.member.1.Key": {"project"},
+				"Tags.member.2.Key": {"PROJECT"},
+			},
+			expectErr: true,
+		},
+		// Test 4: transitive tag keys are not supported.
+		{
+			form: url.Values{
+				"Tags.member.1.Key":          {"project"},
+				"TransitiveTagKeys.member.1": {"project"},
+			},
+			expectErr: true,
+		},
+		// Test 5: invalid characters.
+		{
+			form:      url.Values{"Tags.member.1.Key": {"project"}, "Tags.member.1.Value": {"a|b"}},
+			expectErr: true,
+		},
+		// Test 6: empty key.
+		{
+			form:      url.Values{"Tags.member.1.Key": {""}},
+			expectErr: true,
+		},
+	}
+
+	for i, testCase := range testCases {
+		tags, err := parseSessionTagsForm(testCase.form)
+		if testCase.expectErr {
+			if err == nil {
+				t.Errorf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %d: unexpected error: %v", i+1, err)
+			continue
+		}
+		if !reflect.DeepEqual(tags, testCase.tags) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.tags, tags)
+		}
+	}
+}
+
+func TestParseSessionTagsClaim(t *testing.T) {
+	testCases := []struct {
+		claim     string
+		tags      map[string]string
+		expectErr bool
+	}{
+		{
+			claim: `{"principal_tags": {"project": ["alpha"], "team": "storage"}, "transitive_tag_keys": []}`,
+			tags:  map[string]string{"project": "alpha", "team": "storage"},
+		},
+		{claim: `{"principal_tags": {"project": ["alpha", "beta"]}}`, expectErr: true},
+		{claim: `{"principal_tags": {"project": 1}}`, expectErr: true},
+		{claim: `{"principal_tags": {"project": ["alpha"]}, "transitive_tag_keys": ["project"]}`, expectErr: true},
+		{claim: `["project"]`, expectErr: true},
+	}
+
+	for i, testCase := range testCases {
+		var v interface{}
+		if err := json.Unmarshal([]byte(testCase.claim), &v); err != nil {
+			t.Fatal(err)
+		}
+		tags, err := parseSessionTagsClaim(v)
+		if testCase.expectErr {
+			if err == nil {
+				t.Errorf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %d: unexpected error: %v", i+1, err)
+			continue
+		}
+		if !reflect.DeepEqual(tags, testCase.tags) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.tags, tags)
+		}
+	}
+}
+
+func TestParseSessionPolicyArn(t *testing.T) {
+	testCases := []struct {
+		arn       string
+		policy    string
+		expectErr bool
+	}{
+		{arn: "arn:aws:iam::aws:policy/readonly", policy: "readonly"},
+		{arn: "arn:minio:iam:::policy/app-read", policy: "app-read"},
+		{arn: "arn:aws:s3:::policy/readonly", expectErr: true},
+		{arn: "arn:aws:iam::aws:role/readonly", expectErr: true},
+		{arn: "arn:aws:iam::aws:policy/", expectErr: true},
+		{arn: "arn:aws:iam::aws:policy/a,b", expectErr: true},
+		{arn: "readonly", expectErr: true},
+	}
+
+	for i, testCase := range testCases {
+		policy, err := parseSessionPolicyArn(testCase.arn)
+		if testCase.expectErr != (err != nil) {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+			continue
+		}
+		if policy != testCase.policy {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.policy, policy)
+		}
+	}
+}
+
+func TestGetConditionValuesPrincipalTags(t *testing.T) {
+	r, err := http.NewRequest(http.MethodGet, "http://localhost/bucket/object?PrincipalTag/project=alpha&principaltag/team=storage", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set("PrincipalTag/project", "alpha")
+	if err = r.ParseForm(); err != nil {
+		t.Fatal(err)
+	}
+
+	claims := map[string]interface{}{
+		"PrincipalTag/project": "alpha",
+		sessionTagsClaim:       map[string]interface{}{"project": "beta"},
+	}
+	for k, v := range getConditionValues(r, "", "user", claims) {
+		if isPrincipalTagKey(k) {
+			t.Errorf("unexpected principal tag condition value %s=%v", k, v)
+		}
+	}
+
+	if tags := getSessionTagsFromClaims(claims); !reflect.DeepEqual(tags, map[string]string{"project": "beta"}) {
+		t.Errorf("unexpected session tags %v", tags)
+	}
+}
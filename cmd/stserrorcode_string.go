@@ -15,17 +15,19 @@ func _() {
 	_ = x[ErrSTSWebIdentityExpiredToken-4]
 	_ = x[ErrSTSClientGrantsExpiredToken-5]
 	_ = x[ErrSTSInvalidClientGrantsToken-6]
-	_ = x[ErrSTSMalformedPolicyDocument-7]
-	_ = x[ErrSTSInsecureConnection-8]
-	_ = x[ErrSTSInvalidClientCertificate-9]
-	_ = x[ErrSTSNotInitialized-10]
-	_ = x[ErrSTSUpstreamError-11]
-	_ = x[ErrSTSInternalError-12]
+	_ = x[ErrSTSSAMLExpiredToken-7]
+	_ = x[ErrSTSInvalidSAMLAssertion-8]
+	_ = x[ErrSTSMalformedPolicyDocument-9]
+	_ = x[ErrSTSInsecureConnection-10]
+	_ = x[ErrSTSInvalidClientCertificate-11]
+	_ = x[ErrSTSNotInitialized-12]
+	_ = x[ErrSTSUpstreamError-13]
+	_ = x[ErrSTSInternalError-14]
 }
 
-const _STSErrorCode_name = "STSNoneSTSAccessDeniedSTSMissingParameterSTSInvalidParameterValueSTSWebIdentityExpiredTokenSTSClientGrantsExpiredTokenSTSInvalidClientGrantsTokenSTSMalformedPolicyDocumentSTSInsecureConnectionSTSInvalidClientCertificateSTSNotInitializedSTSUpstreamErrorSTSInternalError"
+const _STSErrorCode_name = "STSNoneSTSAccessDeniedSTSMissingParameterSTSInvalidParameterValueSTSWebIdentityExpiredTokenSTSClientGrantsExpiredTokenSTSInvalidClientGrantsTokenSTSSAMLExpiredTokenSTSInvalidSAMLAssertionSTSMalformedPolicyDocumentSTSInsecureConnectionSTSInvalidClientCertificateSTSNotInitializedSTSUpstreamErrorSTSInternalError"
 
-var _STSErrorCode_index = [...]uint16{0, 7, 22, 41, 65, 91, 118, 145, 171, 192, 219, 236, 252, 268}
+var _STSErrorCode_index = [...]uint16{0, 7, 22, 41, 65, 91, 118, 145, 164, 187, 213, 234, 261, 278, 294, 310}
 
 func (i STSErrorCode) String() string {
 	if i < 0 || i >= STSErrorCode(len(_STSErrorCode_index)-1) {
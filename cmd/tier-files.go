@@ -0,0 +1,184 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/minio/madmin-go"
+)
+
+//go:generate msgp -file $GOFILE
+
+// Types of the file based remote tiers, they are not known to madmin and
+// are configured with FileTierConfig.
+const (
+	tierTypeFS   = "fs"
+	tierTypeSFTP = "sftp"
+)
+
+// TierFS is the configuration of a remote tier on a file system mounted at
+// the same path on all the nodes, e.g an NFS share. The objects are stored
+// as files of the directory Bucket below Path, under Prefix.
+type TierFS struct {
+	Path   string `json:",omitempty"`
+	Bucket string `json:",omitempty"`
+	Prefix string `json:",omitempty"`
+}
+
+// TierSFTP is the configuration of a remote tier on an SFTP server. The
+// objects are stored as files of the directory Bucket below Path, under
+// Prefix, Path is relative to the home directory of User if not absolute.
+type TierSFTP struct {
+	// Host is of the form host[:port].
+	Host string `json:",omitempty"`
+	// Fingerprint is the SHA256 fingerprint of the host key, as printed
+	// by ssh-keygen -l.
+	Fingerprint string `json:",omitempty"`
+	User        string `json:",omitempty"`
+	Password    string `json:",omitempty"`
+	// PrivateKey is a private key in PEM format, used instead of Password.
+	PrivateKey string `json:",omitempty"`
+	Path       string `json:",omitempty"`
+	Bucket     string `json:",omitempty"`
+	Prefix     string `json:",omitempty"`
+}
+
+// FileTierConfig is the configuration of a file based remote tier, in the
+// layout of madmin.TierConfig.
+type FileTierConfig struct {
+	Version string    `json:",omitempty"`
+	Type    string    `json:",omitempty"`
+	Name    string    `json:",omitempty"`
+	FS      *TierFS   `json:",omitempty"`
+	SFTP    *TierSFTP `json:",omitempty"`
+}
+
+// errTierInvalidConfig returns the error of an invalid remote tier config.
+func errTierInvalidConfig(format string, a ...interface{}) AdminError {
+	return AdminError{
+		Code:       "XMinioAdminTierInvalidConfig",
+		Message:    fmt.Sprintf(format, a...),
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
+// isFileTierType returns true if tierType is the type of a file based
+// remote tier.
+func isFileTierType(tierType string) bool {
+	return tierType == tierTypeFS || tierType == tierTypeSFTP
+}
+
+// validTierDir returns true if dir, the bucket or the prefix of a file
+// based remote tier, stays below the root of the tier.
+func validTierDir(dir string) bool {
+	for _, elem := range strings.Split(dir, slashSeparator) {
+		if elem == ".." {
+			return false
+		}
+	}
+	return !strings.Contains(dir, `\`)
+}
+
+// Validate checks that the configuration has the settings of its type.
+func (cfg FileTierConfig) Validate() error {
+	switch cfg.Type {
+	case tierTypeFS:
+		if cfg.FS == nil || cfg.SFTP != nil {
+			return errTierInvalidConfig("fs tier must only have FS settings")
+		}
+	case tierTypeSFTP:
+		if cfg.SFTP == nil || cfg.FS != nil {
+			return errTierInvalidConfig("sftp tier must only have SFTP settings")
+		}
+	default:
+		return errTierTypeUnsupported
+	}
+	return nil
+}
+
+// Clone returns a copy of the configuration with its secrets redacted.
+func (cfg FileTierConfig) Clone() FileTierConfig {
+	clone := cfg
+	if cfg.FS != nil {
+		fs := *cfg.FS
+		clone.FS = &fs
+	}
+	if cfg.SFTP != nil {
+		sftp := *cfg.SFTP
+		if sftp.Password != "" {
+			sftp.Password = "REDACTED"
+		}
+		if sftp.PrivateKey != "" {
+			sftp.PrivateKey = "REDACTED"
+		}
+		clone.SFTP = &sftp
+	}
+	return clone
+}
+
+// withCreds returns the configuration with the credentials in creds, the
+// access key is the user name and the secret key its password or private
+// key.
+func (cfg FileTierConfig) withCreds(creds madmin.TierCreds) (FileTierConfig, error) {
+	if cfg.Type != tierTypeSFTP {
+		return cfg, errTierCredsUnsupported
+	}
+	if creds.AccessKey == "" || creds.SecretKey == "" {
+		return cfg, errTierInsufficientCreds
+	}
+	sftp := *cfg.SFTP
+	sftp.User = creds.AccessKey
+	sftp.Password, sftp.PrivateKey = "", ""
+	if isPEMPrivateKey(creds.SecretKey) {
+		sftp.PrivateKey = creds.SecretKey
+	} else {
+		sftp.Password = creds.SecretKey
+	}
+	cfg.SFTP = &sftp
+	return cfg, nil
+}
+
+// newFileWarmBackend instantiates the WarmBackend of a file based remote
+// tier and runs checkWarmBackend on it.
+func newFileWarmBackend(ctx context.Context, cfg FileTierConfig) (d WarmBackend, err error) {
+	if err = cfg.Validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Type {
+	case tierTypeFS:
+		d, err = newWarmBackendFS(*cfg.FS)
+	case tierTypeSFTP:
+		d, err = newWarmBackendSFTP(*cfg.SFTP)
+	}
+	if err != nil {
+		var adminErr AdminError
+		if errors.As(err, &adminErr) {
+			return nil, err
+		}
+		return nil, errTierTypeUnsupported
+	}
+	if err = checkWarmBackend(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -25,7 +25,6 @@ import (
 	"github.com/gorilla/mux"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/minio/madmin-go"
-	"github.com/minio/minio/internal/config/storageclass"
 	"github.com/minio/minio/internal/logger"
 	iampolicy "github.com/minio/pkg/iam/policy"
 )
@@ -87,16 +86,33 @@ func (api adminAPIHandlers) AddTierHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	var cfg madmin.TierConfig
+	// File based tiers are not known to madmin, they have their own config.
+	var tierType struct {
+		Type string
+	}
 	json := jsoniter.ConfigCompatibleWithStandardLibrary
-	if err := json.Unmarshal(reqBytes, &cfg); err != nil {
+	if err := json.Unmarshal(reqBytes, &tierType); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
 
-	// Disallow remote tiers with internal storage class names
-	switch cfg.Name {
-	case storageclass.STANDARD, storageclass.RRS:
+	var cfg madmin.TierConfig
+	var fileCfg FileTierConfig
+	fileTier := isFileTierType(tierType.Type)
+	if fileTier {
+		err = json.Unmarshal(reqBytes, &fileCfg)
+		cfg.Name = fileCfg.Name
+	} else {
+		err = json.Unmarshal(reqBytes, &cfg)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Disallow remote tiers named after standard or custom storage
+	// classes, lifecycle transitions refer to both by name.
+	if globalStorageClass.IsValid(cfg.Name) {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errTierReservedName), r.URL)
 		return
 	}
@@ -106,7 +122,11 @@ func (api adminAPIHandlers) AddTierHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	err = globalTierConfigMgr.Add(ctx, cfg)
+	if fileTier {
+		err = globalTierConfigMgr.AddFileTier(ctx, fileCfg)
+	} else {
+		err = globalTierConfigMgr.Add(ctx, cfg)
+	}
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -133,8 +153,15 @@ func (api adminAPIHandlers) ListTierHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	tiers := globalTierConfigMgr.ListTiers()
-	data, err := json.Marshal(tiers)
+	var data []byte
+	var err error
+	// The file based tiers are listed apart, madmin.TierConfig can't
+	// decode them.
+	if r.Form.Get("type") == "files" {
+		data, err = json.Marshal(globalTierConfigMgr.ListFileTiers())
+	} else {
+		data, err = json.Marshal(globalTierConfigMgr.ListTiers())
+	}
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
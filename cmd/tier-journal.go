@@ -174,6 +174,15 @@ func (jd *tierDiskJournal) deletePending(ctx context.Context) {
 	}
 }
 
+// addEntryDurable adds je to the in-memory journal, or to the on-disk journal
+// when the former is full, so that the remote object is deleted eventually.
+func (j *tierJournal) addEntryDurable(je jentry) error {
+	if err := j.AddEntry(je); err == nil {
+		return nil
+	}
+	return j.addEntry(je)
+}
+
 func (jd *tierDiskJournal) addEntry(je jentry) error {
 	// Open journal if it hasn't been
 	err := jd.Open()
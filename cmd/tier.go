@@ -41,6 +41,7 @@ var (
 	errTierBackendInUse      = errors.New("remote tier backend already in use")
 	errTierTypeUnsupported   = errors.New("unsupported tier type")
 	errTierBackendNotEmpty   = errors.New("remote tier not empty")
+	errTierCredsUnsupported  = errors.New("remote tier has no credentials")
 )
 
 const (
@@ -61,6 +62,8 @@ type TierConfigMgr struct {
 	drivercache  map[string]WarmBackend `msg:"-"`
 
 	Tiers map[string]madmin.TierConfig `json:"tiers"`
+	// FileTiers are the file based remote tiers, see FileTierConfig.
+	FileTiers map[string]FileTierConfig `json:"fileTiers,omitempty" msg:"FileTiers,omitempty"`
 }
 
 // IsTierValid returns true if there exists a remote tier by name tierName,
@@ -80,6 +83,9 @@ func (config *TierConfigMgr) isTierNameInUse(tierName string) (madmin.TierType,
 	if t, ok := config.Tiers[tierName]; ok {
 		return t.Type, true
 	}
+	if _, ok := config.FileTiers[tierName]; ok {
+		return madmin.Unsupported, true
+	}
 	return madmin.Unsupported, false
 }
 
@@ -118,6 +124,40 @@ func (config *TierConfigMgr) Add(ctx context.Context, tier madmin.TierConfig) er
 	return nil
 }
 
+// AddFileTier adds the file based remote tier cfg if it passes all
+// validations.
+func (config *TierConfigMgr) AddFileTier(ctx context.Context, cfg FileTierConfig) error {
+	config.Lock()
+	defer config.Unlock()
+
+	tierName := cfg.Name
+	if tierName != strings.ToUpper(tierName) {
+		return errTierNameNotUppercase
+	}
+	if _, exists := config.isTierNameInUse(tierName); exists {
+		return errTierAlreadyExists
+	}
+
+	d, err := newFileWarmBackend(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	inUse, err := d.InUse(ctx)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errTierBackendInUse
+	}
+
+	if config.FileTiers == nil {
+		config.FileTiers = make(map[string]FileTierConfig)
+	}
+	config.FileTiers[tierName] = cfg
+	config.drivercache[tierName] = d
+	return nil
+}
+
 // Remove removes tier if it is empty.
 func (config *TierConfigMgr) Remove(ctx context.Context, tier string) error {
 	d, err := config.getDriver(tier)
@@ -131,6 +171,7 @@ func (config *TierConfigMgr) Remove(ctx context.Context, tier string) error {
 	} else {
 		config.Lock()
 		delete(config.Tiers, tier)
+		delete(config.FileTiers, tier)
 		delete(config.drivercache, tier)
 		config.Unlock()
 	}
@@ -152,7 +193,7 @@ func (config *TierConfigMgr) Empty() bool {
 	if config == nil {
 		return true
 	}
-	return len(config.ListTiers()) == 0
+	return len(config.ListTiers()) == 0 && len(config.ListFileTiers()) == 0
 }
 
 // ListTiers lists remote tiers configured in this deployment.
@@ -170,6 +211,19 @@ func (config *TierConfigMgr) ListTiers() []madmin.TierConfig {
 	return tierCfgs
 }
 
+// ListFileTiers lists the file based remote tiers configured in this
+// deployment.
+func (config *TierConfigMgr) ListFileTiers() []FileTierConfig {
+	config.RLock()
+	defer config.RUnlock()
+
+	var tierCfgs []FileTierConfig
+	for _, tier := range config.FileTiers {
+		tierCfgs = append(tierCfgs, tier.Clone())
+	}
+	return tierCfgs
+}
+
 // Edit replaces the credentials of the remote tier specified by tierName with creds.
 func (config *TierConfigMgr) Edit(ctx context.Context, tierName string, creds madmin.TierCreds) error {
 	config.Lock()
@@ -181,6 +235,20 @@ func (config *TierConfigMgr) Edit(ctx context.Context, tierName string, creds ma
 		return errTierNotFound
 	}
 
+	if fileCfg, ok := config.FileTiers[tierName]; ok {
+		fileCfg, err := fileCfg.withCreds(creds)
+		if err != nil {
+			return err
+		}
+		d, err := newFileWarmBackend(ctx, fileCfg)
+		if err != nil {
+			return err
+		}
+		config.FileTiers[tierName] = fileCfg
+		config.drivercache[tierName] = d
+		return nil
+	}
+
 	cfg := config.Tiers[tierName]
 	switch tierType {
 	case madmin.S3:
@@ -249,11 +317,13 @@ func (config *TierConfigMgr) getDriver(tierName string) (d WarmBackend, err erro
 	}
 
 	// Initialize driver from tier config matching tierName
-	t, ok := config.Tiers[tierName]
-	if !ok {
+	if t, ok := config.Tiers[tierName]; ok {
+		d, err = newWarmBackend(context.TODO(), t)
+	} else if ft, ok := config.FileTiers[tierName]; ok {
+		d, err = newFileWarmBackend(context.TODO(), ft)
+	} else {
 		return nil, errTierNotFound
 	}
-	d, err = newWarmBackend(context.TODO(), t)
 	if err != nil {
 		return nil, err
 	}
@@ -339,6 +409,7 @@ func (config *TierConfigMgr) Reload(ctx context.Context, objAPI ObjectLayer) err
 	for tier, cfg := range newConfig.Tiers {
 		config.Tiers[tier] = cfg
 	}
+	config.FileTiers = newConfig.FileTiers
 
 	return nil
 }
@@ -410,6 +481,7 @@ func (config *TierConfigMgr) Reset() {
 	for k := range config.Tiers {
 		delete(config.Tiers, k)
 	}
+	config.FileTiers = nil
 	config.Unlock()
 }
 
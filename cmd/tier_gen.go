@@ -55,6 +55,36 @@ func (z *TierConfigMgr) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 				z.Tiers[za0001] = za0002
 			}
+		case "FileTiers":
+			var zb0003 uint32
+			zb0003, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "FileTiers")
+				return
+			}
+			if z.FileTiers == nil {
+				z.FileTiers = make(map[string]FileTierConfig, zb0003)
+			} else if len(z.FileTiers) > 0 {
+				for key := range z.FileTiers {
+					delete(z.FileTiers, key)
+				}
+			}
+			for zb0003 > 0 {
+				zb0003--
+				var za0003 string
+				var za0004 FileTierConfig
+				za0003, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "FileTiers")
+					return
+				}
+				err = za0004.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "FileTiers", za0003)
+					return
+				}
+				z.FileTiers[za0003] = za0004
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -68,9 +98,24 @@ func (z *TierConfigMgr) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *TierConfigMgr) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 1
+	// omitempty: check for empty values
+	zb0001Len := uint32(2)
+	var zb0001Mask uint8 /* 2 bits */
+	_ = zb0001Mask
+	if z.FileTiers == nil {
+		zb0001Len--
+		zb0001Mask |= 0x2
+	}
+	// variable map header, size zb0001Len
+	err = en.Append(0x80 | uint8(zb0001Len))
+	if err != nil {
+		return
+	}
+	if zb0001Len == 0 {
+		return
+	}
 	// write "Tiers"
-	err = en.Append(0x81, 0xa5, 0x54, 0x69, 0x65, 0x72, 0x73)
+	err = en.Append(0xa5, 0x54, 0x69, 0x65, 0x72, 0x73)
 	if err != nil {
 		return
 	}
@@ -91,15 +136,51 @@ func (z *TierConfigMgr) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
+	if (zb0001Mask & 0x2) == 0 { // if not empty
+		// write "FileTiers"
+		err = en.Append(0xa9, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x69, 0x65, 0x72, 0x73)
+		if err != nil {
+			return
+		}
+		err = en.WriteMapHeader(uint32(len(z.FileTiers)))
+		if err != nil {
+			err = msgp.WrapError(err, "FileTiers")
+			return
+		}
+		for za0003, za0004 := range z.FileTiers {
+			err = en.WriteString(za0003)
+			if err != nil {
+				err = msgp.WrapError(err, "FileTiers")
+				return
+			}
+			err = za0004.EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "FileTiers", za0003)
+				return
+			}
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *TierConfigMgr) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
+	// omitempty: check for empty values
+	zb0001Len := uint32(2)
+	var zb0001Mask uint8 /* 2 bits */
+	_ = zb0001Mask
+	if z.FileTiers == nil {
+		zb0001Len--
+		zb0001Mask |= 0x2
+	}
+	// variable map header, size zb0001Len
+	o = append(o, 0x80|uint8(zb0001Len))
+	if zb0001Len == 0 {
+		return
+	}
 	// string "Tiers"
-	o = append(o, 0x81, 0xa5, 0x54, 0x69, 0x65, 0x72, 0x73)
+	o = append(o, 0xa5, 0x54, 0x69, 0x65, 0x72, 0x73)
 	o = msgp.AppendMapHeader(o, uint32(len(z.Tiers)))
 	for za0001, za0002 := range z.Tiers {
 		o = msgp.AppendString(o, za0001)
@@ -109,6 +190,19 @@ func (z *TierConfigMgr) MarshalMsg(b []byte) (o []byte, err error) {
 			return
 		}
 	}
+	if (zb0001Mask & 0x2) == 0 { // if not empty
+		// string "FileTiers"
+		o = append(o, 0xa9, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x69, 0x65, 0x72, 0x73)
+		o = msgp.AppendMapHeader(o, uint32(len(z.FileTiers)))
+		for za0003, za0004 := range z.FileTiers {
+			o = msgp.AppendString(o, za0003)
+			o, err = za0004.MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "FileTiers", za0003)
+				return
+			}
+		}
+	}
 	return
 }
 
@@ -160,6 +254,36 @@ func (z *TierConfigMgr) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 				z.Tiers[za0001] = za0002
 			}
+		case "FileTiers":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FileTiers")
+				return
+			}
+			if z.FileTiers == nil {
+				z.FileTiers = make(map[string]FileTierConfig, zb0003)
+			} else if len(z.FileTiers) > 0 {
+				for key := range z.FileTiers {
+					delete(z.FileTiers, key)
+				}
+			}
+			for zb0003 > 0 {
+				var za0003 string
+				var za0004 FileTierConfig
+				zb0003--
+				za0003, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "FileTiers")
+					return
+				}
+				bts, err = za0004.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "FileTiers", za0003)
+					return
+				}
+				z.FileTiers[za0003] = za0004
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -181,5 +305,12 @@ func (z *TierConfigMgr) Msgsize() (s int) {
 			s += msgp.StringPrefixSize + len(za0001) + za0002.Msgsize()
 		}
 	}
+	s += 10 + msgp.MapHeaderSize
+	if z.FileTiers != nil {
+		for za0003, za0004 := range z.FileTiers {
+			_ = za0004
+			s += msgp.StringPrefixSize + len(za0003) + za0004.Msgsize()
+		}
+	}
 	return
 }
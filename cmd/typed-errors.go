@@ -103,6 +103,12 @@ var errIAMServiceAccount = errors.New("Specified service account cannot be updat
 // error returned in IAM service account is already used.
 var errIAMServiceAccountUsed = errors.New("Specified service account is used by another user")
 
+// error returned when a service account expiry is not in the future.
+var errIAMServiceAccountInvalidExpiry = errors.New("Specified service account expiration must be in the future")
+
+// error returned when a service account network restriction cannot be parsed.
+var errIAMServiceAccountInvalidAllowedIPs = errors.New("Specified service account allowed IPs must be valid IP addresses or CIDR ranges")
+
 // error returned in IAM subsystem when IAM sub-system is still being initialized.
 var errIAMNotInitialized = errors.New("IAM sub-system is being initialized, please try again")
 
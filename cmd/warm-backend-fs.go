@@ -0,0 +1,235 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// tierFS is the file system of a file based remote tier, its paths are
+// slash separated and relative to the root of the tier. The files returned
+// by Create and Open are bound to ctx until closed.
+type tierFS interface {
+	Stat(ctx context.Context, name string) (os.FileInfo, error)
+	MkdirAll(ctx context.Context, dir string) error
+	// Create creates a new file, failing if it exists.
+	Create(ctx context.Context, name string) (io.WriteCloser, error)
+	Rename(ctx context.Context, oldname, newname string) error
+	Open(ctx context.Context, name string) (io.ReadSeekCloser, error)
+	// Remove removes a file or an empty directory.
+	Remove(ctx context.Context, name string) error
+	// ReadDirNames returns at most n names of the entries of dir.
+	ReadDirNames(ctx context.Context, dir string, n int) ([]string, error)
+}
+
+// warmBackendFiles stores the objects of a remote tier as files of the
+// directory Bucket, under Prefix.
+type warmBackendFiles struct {
+	fs     tierFS
+	Bucket string
+	Prefix string
+}
+
+var _ WarmBackend = (*warmBackendFiles)(nil)
+
+func (f *warmBackendFiles) getDest(object string) string {
+	return path.Join(f.Bucket, f.Prefix, object)
+}
+
+func (f *warmBackendFiles) toObjectError(err error, object string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, os.ErrNotExist):
+		return ObjectNotFound{Bucket: f.Bucket, Object: f.getDest(object)}
+	case errors.Is(err, os.ErrPermission):
+		return PrefixAccessDenied{Bucket: f.Bucket, Object: f.getDest(object)}
+	}
+	return err
+}
+
+func (f *warmBackendFiles) Put(ctx context.Context, object string, r io.Reader, length int64) (remoteVersionID, error) {
+	// The directory of the tier must exist, like the bucket of an object
+	// storage tier.
+	if _, err := f.fs.Stat(ctx, f.Bucket); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", BucketNotFound{Bucket: f.Bucket}
+		}
+		return "", err
+	}
+
+	// Write to a temporary file renamed once complete, readers never see
+	// partial objects.
+	dest := f.getDest(object)
+	tmp := dest + ".tmp-" + mustGetUUID()
+	if err := f.fs.MkdirAll(ctx, path.Dir(dest)); err != nil {
+		return "", f.toObjectError(err, object)
+	}
+	w, err := f.fs.Create(ctx, tmp)
+	if err != nil {
+		return "", f.toObjectError(err, object)
+	}
+	n, err := io.Copy(w, r)
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && length >= 0 && n != length {
+		err = IncompleteBody{Bucket: f.Bucket, Object: dest}
+	}
+	if err == nil {
+		err = f.fs.Rename(ctx, tmp, dest)
+	}
+	if err != nil {
+		f.fs.Remove(ctx, tmp)
+		return "", f.toObjectError(err, object)
+	}
+	return "", nil
+}
+
+func (f *warmBackendFiles) Get(ctx context.Context, object string, rv remoteVersionID, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	r, err := f.fs.Open(ctx, f.getDest(object))
+	if err != nil {
+		return nil, f.toObjectError(err, object)
+	}
+	if opts.startOffset > 0 {
+		if _, err = r.Seek(opts.startOffset, io.SeekStart); err != nil {
+			r.Close()
+			return nil, f.toObjectError(err, object)
+		}
+	}
+	if opts.startOffset >= 0 && opts.length > 0 {
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(r, opts.length), r}, nil
+	}
+	return r, nil
+}
+
+func (f *warmBackendFiles) Remove(ctx context.Context, object string, rv remoteVersionID) error {
+	dest := f.getDest(object)
+	if err := f.fs.Remove(ctx, dest); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return f.toObjectError(err, object)
+	}
+	// Remove the directories left empty, up to the prefix.
+	top := path.Join(f.Bucket, f.Prefix)
+	for dir := path.Dir(dest); dir != top && strings.HasPrefix(dir, top+slashSeparator); dir = path.Dir(dir) {
+		if f.fs.Remove(ctx, dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func (f *warmBackendFiles) InUse(ctx context.Context) (bool, error) {
+	names, err := f.fs.ReadDirNames(ctx, path.Join(f.Bucket, f.Prefix), 1)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(names) > 0, nil
+}
+
+// localTierFS is the file system of a tier on a local or network mounted
+// file system, such as NFS, shared by all the nodes.
+type localTierFS struct {
+	root string
+}
+
+func (l localTierFS) path(name string) string {
+	return filepath.Join(l.root, filepath.FromSlash(name))
+}
+
+func (l localTierFS) Stat(_ context.Context, name string) (os.FileInfo, error) {
+	return os.Stat(l.path(name))
+}
+
+func (l localTierFS) MkdirAll(_ context.Context, dir string) error {
+	return os.MkdirAll(l.path(dir), 0o755)
+}
+
+func (l localTierFS) Create(_ context.Context, name string) (io.WriteCloser, error) {
+	w, err := os.OpenFile(l.path(name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return syncCloser{w}, nil
+}
+
+func (l localTierFS) Rename(_ context.Context, oldname, newname string) error {
+	return os.Rename(l.path(oldname), l.path(newname))
+}
+
+func (l localTierFS) Open(_ context.Context, name string) (io.ReadSeekCloser, error) {
+	return os.Open(l.path(name))
+}
+
+func (l localTierFS) Remove(_ context.Context, name string) error {
+	return os.Remove(l.path(name))
+}
+
+func (l localTierFS) ReadDirNames(_ context.Context, dir string, n int) ([]string, error) {
+	d, err := os.Open(l.path(dir))
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+	names, err := d.Readdirnames(n)
+	if err == io.EOF {
+		err = nil
+	}
+	return names, err
+}
+
+// syncCloser flushes a file to stable storage before closing it.
+type syncCloser struct {
+	*os.File
+}
+
+func (s syncCloser) Close() error {
+	if err := s.File.Sync(); err != nil {
+		s.File.Close()
+		return err
+	}
+	return s.File.Close()
+}
+
+func newWarmBackendFS(conf TierFS) (*warmBackendFiles, error) {
+	if !filepath.IsAbs(conf.Path) {
+		return nil, errTierInvalidConfig("fs tier path must be absolute, got '%s'", conf.Path)
+	}
+	if conf.Bucket == "" || !validTierDir(conf.Bucket) {
+		return nil, errTierInvalidConfig("invalid fs tier directory '%s'", conf.Bucket)
+	}
+	if !validTierDir(conf.Prefix) {
+		return nil, errTierInvalidConfig("invalid fs tier prefix '%s'", conf.Prefix)
+	}
+	return &warmBackendFiles{
+		fs:     localTierFS{root: filepath.Clean(conf.Path)},
+		Bucket: conf.Bucket,
+		Prefix: strings.Trim(conf.Prefix, slashSeparator),
+	}, nil
+}
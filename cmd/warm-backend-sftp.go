@@ -0,0 +1,342 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	sftpTierDialTimeout = 10 * time.Second
+	// sftpTierOpTimeout bounds every request to the server, the
+	// connection is dropped when a request is not answered within it.
+	sftpTierOpTimeout = time.Minute
+)
+
+// sftpConn is a connection to the SFTP server of a tier.
+type sftpConn struct {
+	client *sftp.Client
+	closer io.Closer // underlying transport
+}
+
+// sftpTierFS is the file system of a tier on an SFTP server, rooted at
+// base. The connection is established on first use and re-established
+// after it is lost or dropped.
+type sftpTierFS struct {
+	base string
+	dial func() (*sftpConn, error)
+
+	mu   sync.Mutex
+	conn *sftpConn
+}
+
+func (s *sftpTierFS) connect() (*sftpConn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		return s.conn, nil
+	}
+	c, err := s.dial()
+	if err != nil {
+		return nil, BackendDown{Err: err.Error()}
+	}
+	s.conn = c
+	go func() {
+		c.client.Wait()
+		s.drop(c)
+	}()
+	return c, nil
+}
+
+// drop closes the connection c, failing the requests in flight on it.
+func (s *sftpTierFS) drop(c *sftpConn) {
+	s.mu.Lock()
+	if s.conn == c {
+		s.conn = nil
+	}
+	s.mu.Unlock()
+	// Closing the transport first unblocks the client of a stalled
+	// server.
+	go func() {
+		c.closer.Close()
+		c.client.Close()
+	}()
+}
+
+// guard drops the connection c if ctx is done or if sftpTierOpTimeout
+// elapses before the returned function is called, which returns the error
+// of the request cut short, nil otherwise.
+func (s *sftpTierFS) guard(ctx context.Context, c *sftpConn) func() error {
+	done := make(chan struct{})
+	dropped := make(chan bool, 1)
+	go func() {
+		timer := time.NewTimer(sftpTierOpTimeout)
+		defer timer.Stop()
+		select {
+		case <-done:
+			dropped <- false
+			return
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		s.drop(c)
+		dropped <- true
+	}()
+	return func() error {
+		close(done)
+		if !<-dropped {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return BackendDown{Err: "sftp tier request timed out"}
+	}
+}
+
+// do runs fn with the connection to the server, bound to ctx.
+func (s *sftpTierFS) do(ctx context.Context, fn func(c *sftp.Client) error) error {
+	c, err := s.connect()
+	if err != nil {
+		return err
+	}
+	stop := s.guard(ctx, c)
+	err = fn(c.client)
+	if gerr := stop(); gerr != nil {
+		return gerr
+	}
+	if errors.Is(err, sftp.ErrSSHFxConnectionLost) {
+		return BackendDown{Err: err.Error()}
+	}
+	return err
+}
+
+func (s *sftpTierFS) path(name string) string {
+	return path.Join(s.base, name)
+}
+
+func (s *sftpTierFS) Stat(ctx context.Context, name string) (fi os.FileInfo, err error) {
+	err = s.do(ctx, func(c *sftp.Client) error {
+		fi, err = c.Stat(s.path(name))
+		return err
+	})
+	return fi, err
+}
+
+func (s *sftpTierFS) MkdirAll(ctx context.Context, dir string) error {
+	return s.do(ctx, func(c *sftp.Client) error {
+		return c.MkdirAll(s.path(dir))
+	})
+}
+
+func (s *sftpTierFS) Create(ctx context.Context, name string) (w io.WriteCloser, err error) {
+	err = s.do(ctx, func(c *sftp.Client) error {
+		f, err := c.OpenFile(s.path(name), os.O_WRONLY|os.O_CREATE|os.O_EXCL)
+		if err == nil {
+			w = &sftpTierFile{File: f, fs: s, ctx: ctx}
+		}
+		return err
+	})
+	return w, err
+}
+
+func (s *sftpTierFS) Rename(ctx context.Context, oldname, newname string) error {
+	return s.do(ctx, func(c *sftp.Client) error {
+		return c.Rename(s.path(oldname), s.path(newname))
+	})
+}
+
+func (s *sftpTierFS) Open(ctx context.Context, name string) (r io.ReadSeekCloser, err error) {
+	err = s.do(ctx, func(c *sftp.Client) error {
+		f, err := c.Open(s.path(name))
+		if err == nil {
+			r = &sftpTierFile{File: f, fs: s, ctx: ctx}
+		}
+		return err
+	})
+	return r, err
+}
+
+func (s *sftpTierFS) Remove(ctx context.Context, name string) error {
+	return s.do(ctx, func(c *sftp.Client) error {
+		return c.Remove(s.path(name))
+	})
+}
+
+func (s *sftpTierFS) ReadDirNames(ctx context.Context, dir string, n int) (names []string, err error) {
+	err = s.do(ctx, func(c *sftp.Client) error {
+		fis, err := c.ReadDir(s.path(dir))
+		if err != nil {
+			return err
+		}
+		for _, fi := range fis {
+			if len(names) == n {
+				break
+			}
+			names = append(names, fi.Name())
+		}
+		return nil
+	})
+	return names, err
+}
+
+// sftpTierFile is a file of an SFTP tier, every request on it is bound to
+// the context it was opened with.
+type sftpTierFile struct {
+	*sftp.File
+	fs  *sftpTierFS
+	ctx context.Context
+}
+
+// call runs fn on the file like sftpTierFS.do.
+func (f *sftpTierFile) call(fn func() error) error {
+	return f.fs.do(f.ctx, func(*sftp.Client) error {
+		return fn()
+	})
+}
+
+func (f *sftpTierFile) Read(p []byte) (n int, err error) {
+	err = f.call(func() error {
+		n, err = f.File.Read(p)
+		return err
+	})
+	return n, err
+}
+
+func (f *sftpTierFile) Write(p []byte) (n int, err error) {
+	err = f.call(func() error {
+		n, err = f.File.Write(p)
+		return err
+	})
+	return n, err
+}
+
+func (f *sftpTierFile) Seek(offset int64, whence int) (n int64, err error) {
+	err = f.call(func() error {
+		n, err = f.File.Seek(offset, whence)
+		return err
+	})
+	return n, err
+}
+
+func (f *sftpTierFile) Close() error {
+	return f.call(f.File.Close)
+}
+
+// sftpTierHostKeyCallback accepts the host key matching fingerprint, its
+// SHA256 fingerprint as printed by ssh-keygen -l.
+func sftpTierHostKeyCallback(fingerprint string) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if ssh.FingerprintSHA256(key) != fingerprint {
+			return errors.New("ssh: host key fingerprint mismatch for " + hostname)
+		}
+		return nil
+	}
+}
+
+// isPEMPrivateKey returns true if secret is a private key in PEM format.
+func isPEMPrivateKey(secret string) bool {
+	return strings.HasPrefix(strings.TrimSpace(secret), "-----BEGIN")
+}
+
+// sftpTierAuth returns the authentication method of an SFTP tier, by
+// private key or by password.
+func sftpTierAuth(conf TierSFTP) (ssh.AuthMethod, error) {
+	switch {
+	case conf.PrivateKey != "" && conf.Password != "":
+		return nil, errTierInvalidConfig("sftp tier must have either a password or a private key")
+	case conf.PrivateKey != "":
+		signer, err := ssh.ParsePrivateKey([]byte(conf.PrivateKey))
+		if err != nil {
+			return nil, errTierInvalidConfig("invalid sftp tier private key: %v", err)
+		}
+		return ssh.PublicKeys(signer), nil
+	case conf.Password != "":
+		return ssh.Password(conf.Password), nil
+	}
+	return nil, errTierInsufficientCreds
+}
+
+// newWarmBackendSFTP returns the driver of a tier on an SFTP server, the
+// server must present the host key of the configured fingerprint.
+func newWarmBackendSFTP(conf TierSFTP) (*warmBackendFiles, error) {
+	host, port, err := net.SplitHostPort(conf.Host)
+	if err != nil {
+		host, port = conf.Host, "22"
+	}
+	if host == "" {
+		return nil, errTierInvalidConfig("sftp tier has no host")
+	}
+	if !strings.HasPrefix(conf.Fingerprint, "SHA256:") {
+		return nil, errTierInvalidConfig("sftp tier must set the SHA256 fingerprint of the host key")
+	}
+	if conf.User == "" {
+		return nil, errTierInsufficientCreds
+	}
+	if conf.Bucket == "" || !validTierDir(conf.Bucket) {
+		return nil, errTierInvalidConfig("invalid sftp tier directory '%s'", conf.Bucket)
+	}
+	if !validTierDir(conf.Prefix) {
+		return nil, errTierInvalidConfig("invalid sftp tier prefix '%s'", conf.Prefix)
+	}
+	auth, err := sftpTierAuth(conf)
+	if err != nil {
+		return nil, err
+	}
+	base := conf.Path
+	if base == "" {
+		base = "."
+	}
+	addr := net.JoinHostPort(host, port)
+	config := &ssh.ClientConfig{
+		User:            conf.User,
+		Auth:            []ssh.AuthMethod{auth},
+		HostKeyCallback: sftpTierHostKeyCallback(conf.Fingerprint),
+		Timeout:         sftpTierDialTimeout,
+	}
+	return &warmBackendFiles{
+		fs: &sftpTierFS{
+			base: base,
+			dial: func() (*sftpConn, error) {
+				sshClient, err := ssh.Dial("tcp", addr, config)
+				if err != nil {
+					return nil, err
+				}
+				client, err := sftp.NewClient(sshClient)
+				if err != nil {
+					sshClient.Close()
+					return nil, err
+				}
+				return &sftpConn{client: client, closer: sshClient}, nil
+			},
+		},
+		Bucket: conf.Bucket,
+		Prefix: strings.Trim(conf.Prefix, slashSeparator),
+	}, nil
+}
@@ -144,6 +144,9 @@ func (p *xlStorageDiskIDCheck) String() string {
 }
 
 func (p *xlStorageDiskIDCheck) IsOnline() bool {
+	if globalDriveEvacuations.isEvacuated(p.storage.diskPath) {
+		return false
+	}
 	storedDiskID, err := p.storage.GetDiskID()
 	if err != nil {
 		return false
@@ -244,6 +247,11 @@ func (p *xlStorageDiskIDCheck) DiskInfo(ctx context.Context) (info DiskInfo, err
 		return info, errFaultyDisk
 	}
 
+	if globalDriveEvacuations.isEvacuated(p.storage.diskPath) {
+		return info, errDiskNotFound
+	}
+	info.Evacuating = globalDriveEvacuations.isEvacuating(p.storage.diskPath)
+
 	return info, nil
 }
 
@@ -657,6 +665,11 @@ func (p *xlStorageDiskIDCheck) TrackDiskHealth(ctx context.Context, s storageMet
 		return ctx, done, errFaultyDisk
 	}
 
+	// Evacuated drives are offline, evacuating drives take no new data.
+	if err = globalDriveEvacuations.check(p.storage.diskPath, s); err != nil {
+		return ctx, done, err
+	}
+
 	// Verify if the disk is not stale
 	// - missing format.json (unformatted drive)
 	// - format.json is valid but invalid 'uuid'
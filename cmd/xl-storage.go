@@ -520,7 +520,7 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 		}
 		sizeS := sizeSummary{}
 		var noTiers bool
-		if noTiers = globalTierConfigMgr.Empty(); !noTiers {
+		if noTiers = globalTierConfigMgr.Empty() && !globalStorageClass.HasCustom(); !noTiers {
 			sizeS.tiers = make(map[string]tierStats)
 		}
 
@@ -556,8 +556,12 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 				continue
 			}
 			tier := minioHotTier
-			if oi.TransitionedObject.Status == lifecycle.TransitionComplete {
+			switch {
+			case oi.TransitionedObject.Status == lifecycle.TransitionComplete:
 				tier = oi.TransitionedObject.Tier
+			case globalStorageClass.IsCustom(oi.StorageClass):
+				// custom storage classes are accounted as internal tiers
+				tier = oi.StorageClass
 			}
 			sizeS.tiers[tier] = sizeS.tiers[tier].add(oi.tierStats())
 		}
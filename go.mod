@@ -8,6 +8,7 @@ require (
 	github.com/Shopify/sarama v1.36.0
 	github.com/alecthomas/participle v0.7.1
 	github.com/bcicen/jstream v1.0.1
+	github.com/beevik/etree v1.2.0
 	github.com/beevik/ntp v0.3.0
 	github.com/bits-and-blooms/bloom/v3 v3.3.1
 	github.com/buger/jsonparser v1.1.1
@@ -66,12 +67,14 @@ require (
 	github.com/philhofer/fwd v1.1.2-0.20210722190033-5c56ac6d0bb9
 	github.com/pierrec/lz4 v2.6.1+incompatible
 	github.com/pkg/errors v0.9.1
+	github.com/pkg/sftp v1.13.5
 	github.com/prometheus/client_golang v1.13.0
 	github.com/prometheus/client_model v0.3.0
 	github.com/prometheus/common v0.37.0
 	github.com/prometheus/procfs v0.8.0
 	github.com/rs/cors v1.8.2
 	github.com/rs/dnscache v0.0.0-20211102005908-e0241e321417
+	github.com/russellhaering/goxmldsig v1.4.0
 	github.com/secure-io/sio-go v0.3.1
 	github.com/shirou/gopsutil/v3 v3.22.9
 	github.com/streadway/amqp v1.0.0
@@ -99,6 +102,8 @@ require (
 	github.com/docker/go-units v0.5.0 // indirect
 	github.com/frankban/quicktest v1.14.0 // indirect
 	github.com/google/pprof v0.0.0-20220829040838-70bd9ae97f40 // indirect
+	github.com/jonboulle/clockwork v0.2.2 // indirect
+	github.com/kr/fs v0.1.0 // indirect
 	github.com/minio/mc v0.0.0-20221103000258-583d449e38cd // indirect
 	github.com/mitchellh/mapstructure v1.5.0 // indirect
 	github.com/nats-io/nats-streaming-server v0.24.1 // indirect
@@ -23,18 +23,104 @@ import (
 	"time"
 
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/schedule"
 	"golang.org/x/time/rate"
 )
 
+// ScheduleEntry is a bandwidth limit in bytes per second for the whole
+// cluster in effect during Windows, a zero limit pauses the transfers.
+type ScheduleEntry struct {
+	Windows schedule.Windows
+	Limit   int64
+}
+
+// Schedule is a list of bandwidth limits by time of the day and day of
+// the week, the first entry open at a given time is in effect. The static
+// limit of the target applies outside of all the entries.
+type Schedule []ScheduleEntry
+
+// limit returns the limit of the first entry open at t.
+func (s Schedule) limit(t time.Time) (int64, bool) {
+	for _, e := range s {
+		if e.Windows.Contains(t) {
+			return e.Limit, true
+		}
+	}
+	return 0, false
+}
+
+// targetKey identifies the throttle of a remote target of a bucket.
+type targetKey struct {
+	bucket string
+	arn    string
+}
+
 type throttle struct {
-	*rate.Limiter
-	NodeBandwidthPerSec int64
+	sync.Mutex
+	*rate.Limiter                 // limiter in effect, nil if unlimited
+	NodeBandwidthPerSec int64     // static limit per node
+	schedule            Schedule  // limits by time of the day
+	bandwidth           int64     // limit in effect per node
+	paused              bool      // transfers paused by the schedule
+	evaluated           time.Time // minute of the last evaluation
+}
+
+// current returns the limiter in effect at now and its bandwidth per node,
+// nil if the transfers are unlimited, and whether the transfers are paused.
+// The schedule is evaluated at most once per minute, the granularity of its
+// windows.
+func (t *throttle) current(now time.Time, nodeCount uint64) (*rate.Limiter, int64, bool) {
+	t.Lock()
+	defer t.Unlock()
+	minute := now.Truncate(time.Minute)
+	if !t.evaluated.IsZero() && minute.Equal(t.evaluated) {
+		return t.Limiter, t.bandwidth, t.paused
+	}
+	t.evaluated = minute
+
+	bw, paused := t.NodeBandwidthPerSec, false
+	if limit, ok := t.schedule.limit(now); ok {
+		bw, paused = nodeShare(limit, nodeCount), limit == 0
+	}
+	t.paused = paused
+	if bw <= 0 {
+		t.Limiter, t.bandwidth = nil, 0
+		return nil, 0, paused
+	}
+	if t.Limiter == nil || t.bandwidth != bw {
+		t.Limiter = rate.NewLimiter(rate.Every(time.Second/time.Duration(bw)), int(bw))
+	}
+	t.bandwidth = bw
+	return t.Limiter, bw, false
+}
+
+// nodeShare returns the share of a cluster wide limit for one node, a
+// positive limit gives a share of at least one byte per second so that it
+// is never mistaken for a pause or for no limit.
+func nodeShare(limit int64, nodeCount uint64) int64 {
+	if limit <= 0 {
+		return 0
+	}
+	if nodeCount == 0 {
+		nodeCount = 1
+	}
+	if share := limit / int64(nodeCount); share > 0 {
+		return share
+	}
+	return 1
+}
+
+// reset forces the evaluation of the limit in effect on next use.
+func (t *throttle) reset() {
+	t.Lock()
+	t.evaluated = time.Time{}
+	t.Unlock()
 }
 
 // Monitor holds the state of the global bucket monitor
 type Monitor struct {
 	tlock                 sync.RWMutex // mutex for bucketThrottle
-	bucketThrottle        map[string]*throttle
+	bucketThrottle        map[targetKey]*throttle
 	mlock                 sync.RWMutex                  // mutex for activeBuckets map
 	activeBuckets         map[string]*bucketMeasurement // Buckets with objects in flight
 	bucketMovingAvgTicker *time.Ticker                  // Ticker for calculating moving averages
@@ -46,7 +132,7 @@ type Monitor struct {
 func NewMonitor(ctx context.Context, numNodes uint64) *Monitor {
 	m := &Monitor{
 		activeBuckets:         make(map[string]*bucketMeasurement),
-		bucketThrottle:        make(map[string]*throttle),
+		bucketThrottle:        make(map[targetKey]*throttle),
 		bucketMovingAvgTicker: time.NewTicker(2 * time.Second),
 		ctx:                   ctx,
 		NodeCount:             numNodes,
@@ -94,15 +180,25 @@ func (m *Monitor) getReport(selectBucket SelectionFunction) *madmin.BucketBandwi
 	report := &madmin.BucketBandwidthReport{
 		BucketStats: make(map[string]madmin.BandwidthDetails),
 	}
+	now := time.Now()
 	for bucket, bucketMeasurement := range m.activeBuckets {
 		if !selectBucket(bucket) {
 			continue
 		}
 		m.tlock.RLock()
-		bucketThrottle, ok := m.bucketThrottle[bucket]
-		if ok {
+		var limit int64
+		throttled := false
+		for key, t := range m.bucketThrottle {
+			if key.bucket != bucket {
+				continue
+			}
+			throttled = true
+			_, bw, _ := t.current(now, m.NodeCount)
+			limit += bw * int64(m.NodeCount)
+		}
+		if throttled {
 			report.BucketStats[bucket] = madmin.BandwidthDetails{
-				LimitInBytesPerSecond:            bucketThrottle.NodeBandwidthPerSec * int64(m.NodeCount),
+				LimitInBytesPerSecond:            limit,
 				CurrentBandwidthInBytesPerSecond: bucketMeasurement.getExpMovingAvgBytesPerSecond(),
 			}
 		}
@@ -150,41 +246,98 @@ func (m *Monitor) track(bucket string) {
 // DeleteBucket deletes monitoring the 'bucket'
 func (m *Monitor) DeleteBucket(bucket string) {
 	m.tlock.Lock()
-	delete(m.bucketThrottle, bucket)
+	for key := range m.bucketThrottle {
+		if key.bucket == bucket {
+			delete(m.bucketThrottle, key)
+		}
+	}
 	m.tlock.Unlock()
 	m.mlock.Lock()
 	delete(m.activeBuckets, bucket)
 	m.mlock.Unlock()
 }
 
-// throttle returns currently configured throttle for this bucket
-func (m *Monitor) throttle(bucket string) *throttle {
+// DeleteBucketThrottle deletes the bandwidth limit and schedule of the
+// remote target 'arn' of 'bucket'.
+func (m *Monitor) DeleteBucketThrottle(bucket, arn string) {
+	m.tlock.Lock()
+	delete(m.bucketThrottle, targetKey{bucket: bucket, arn: arn})
+	m.tlock.Unlock()
+}
+
+// throttle returns currently configured throttle for this bucket target
+func (m *Monitor) throttle(bucket, arn string) *throttle {
 	m.tlock.RLock()
 	defer m.tlock.RUnlock()
-	return m.bucketThrottle[bucket]
+	return m.bucketThrottle[targetKey{bucket: bucket, arn: arn}]
 }
 
-// SetBandwidthLimit sets the bandwidth limit for a bucket
-func (m *Monitor) SetBandwidthLimit(bucket string, limit int64) {
+// updateThrottle applies fn to the throttle of a bucket target, the
+// throttle is deleted if it has neither a limit nor a schedule.
+func (m *Monitor) updateThrottle(bucket, arn string, fn func(t *throttle)) {
 	m.tlock.Lock()
 	defer m.tlock.Unlock()
-	bw := limit / int64(m.NodeCount)
-	t, ok := m.bucketThrottle[bucket]
+	key := targetKey{bucket: bucket, arn: arn}
+	t, ok := m.bucketThrottle[key]
 	if !ok {
-		t = &throttle{
-			NodeBandwidthPerSec: bw,
-		}
+		t = &throttle{}
+	}
+	fn(t)
+	t.reset()
+	if t.NodeBandwidthPerSec <= 0 && len(t.schedule) == 0 {
+		delete(m.bucketThrottle, key)
+		return
 	}
-	t.NodeBandwidthPerSec = bw
-	newlimit := rate.Every(time.Second / time.Duration(t.NodeBandwidthPerSec))
-	t.Limiter = rate.NewLimiter(newlimit, int(t.NodeBandwidthPerSec))
-	m.bucketThrottle[bucket] = t
+	m.bucketThrottle[key] = t
 }
 
-// IsThrottled returns true if a bucket has bandwidth throttling enabled.
-func (m *Monitor) IsThrottled(bucket string) bool {
+// SetBandwidthLimit sets the static bandwidth limit for a bucket target,
+// a zero limit removes it.
+func (m *Monitor) SetBandwidthLimit(bucket, arn string, limit int64) {
+	m.updateThrottle(bucket, arn, func(t *throttle) {
+		t.Lock()
+		t.NodeBandwidthPerSec = nodeShare(limit, m.NodeCount)
+		t.Unlock()
+	})
+}
+
+// SetBandwidthSchedule sets the bandwidth schedule for a bucket target,
+// an empty schedule removes it.
+func (m *Monitor) SetBandwidthSchedule(bucket, arn string, s Schedule) {
+	m.updateThrottle(bucket, arn, func(t *throttle) {
+		t.Lock()
+		t.schedule = s
+		t.Unlock()
+	})
+}
+
+// IsThrottled returns true if a bucket target has bandwidth throttling enabled.
+func (m *Monitor) IsThrottled(bucket, arn string) bool {
 	m.tlock.RLock()
 	defer m.tlock.RUnlock()
-	_, ok := m.bucketThrottle[bucket]
+	_, ok := m.bucketThrottle[targetKey{bucket: bucket, arn: arn}]
 	return ok
 }
+
+// Limit returns the bandwidth limit for the whole cluster in effect for a
+// bucket target, zero if unlimited, and whether its transfers are paused
+// by its schedule. ok is false if the target is not throttled.
+func (m *Monitor) Limit(bucket, arn string) (limit int64, paused, ok bool) {
+	t := m.throttle(bucket, arn)
+	if t == nil {
+		return 0, false, false
+	}
+	_, bw, paused := t.current(time.Now(), m.NodeCount)
+	return bw * int64(m.NodeCount), paused, true
+}
+
+// Paused returns true if the transfers to a bucket target are paused by
+// its schedule at this time.
+func (m *Monitor) Paused(bucket, arn string) bool {
+	t := m.throttle(bucket, arn)
+	if t == nil {
+		return false
+	}
+	_, _, paused := t.current(time.Now(), m.NodeCount)
+	return paused
+}
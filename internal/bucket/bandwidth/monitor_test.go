@@ -18,11 +18,13 @@
 package bandwidth
 
 import (
+	"context"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/schedule"
 )
 
 const (
@@ -94,7 +96,7 @@ func TestMonitor_GetReport(t *testing.T) {
 			}
 			m := &Monitor{
 				activeBuckets:  tt.fields.activeBuckets,
-				bucketThrottle: map[string]*throttle{"bucket": &thr},
+				bucketThrottle: map[targetKey]*throttle{{bucket: "bucket", arn: "arn"}: &thr},
 				NodeCount:      1,
 			}
 			m.activeBuckets["bucket"].updateExponentialMovingAverage(tt.fields.endTime)
@@ -111,3 +113,62 @@ func TestMonitor_GetReport(t *testing.T) {
 		})
 	}
 }
+
+func TestMonitor_Schedule(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	always, err := schedule.Parse("sun-sat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	never := schedule.Windows{{End: 24 * time.Hour}}
+
+	m := NewMonitor(ctx, 2)
+	m.SetBandwidthLimit("bucket", "arn", 200*1000*1000)
+	if limit, paused, ok := m.Limit("bucket", "arn"); !ok || paused || limit != 200*1000*1000 {
+		t.Fatalf("expected the static limit, got %d %v %v", limit, paused, ok)
+	}
+
+	m.SetBandwidthSchedule("bucket", "arn", Schedule{
+		{Windows: never, Limit: 0},
+		{Windows: always, Limit: 100 * 1000 * 1000},
+	})
+	if limit, paused, ok := m.Limit("bucket", "arn"); !ok || paused || limit != 100*1000*1000 {
+		t.Fatalf("expected the scheduled limit, got %d %v %v", limit, paused, ok)
+	}
+
+	m.SetBandwidthSchedule("bucket", "arn", Schedule{{Windows: always, Limit: 0}})
+	if limit, paused, ok := m.Limit("bucket", "arn"); !ok || !paused || limit != 0 {
+		t.Fatalf("expected a pause, got %d %v %v", limit, paused, ok)
+	}
+	if !m.Paused("bucket", "arn") {
+		t.Fatal("expected the transfers to be paused")
+	}
+	r := NewMonitoredReader(ctx, m, reader{}, &MonitorReaderOptions{Bucket: "bucket", ARN: "arn"})
+	if _, err = r.Read(make([]byte, 10)); err != ErrTransferPaused {
+		t.Fatalf("expected the read to stop on the pause, got %v", err)
+	}
+
+	// A limit lower than the number of nodes is not mistaken for a pause
+	// or for no limit.
+	m.SetBandwidthSchedule("bucket", "arn", Schedule{{Windows: always, Limit: 1}})
+	if limit, paused, ok := m.Limit("bucket", "arn"); !ok || paused || limit != 2 {
+		t.Fatalf("expected the minimum limit, got %d %v %v", limit, paused, ok)
+	}
+
+	m.SetBandwidthSchedule("bucket", "arn", nil)
+	m.SetBandwidthLimit("bucket", "arn", 0)
+	if m.IsThrottled("bucket", "arn") {
+		t.Fatal("expected the throttle to be removed")
+	}
+	if m.Paused("bucket", "arn") {
+		t.Fatal("expected the transfers to resume")
+	}
+}
+
+type reader struct{}
+
+func (reader) Read(b []byte) (int, error) {
+	return len(b), nil
+}
@@ -19,10 +19,16 @@ package bandwidth
 
 import (
 	"context"
+	"errors"
 	"io"
 	"math"
+	"time"
 )
 
+// ErrTransferPaused is returned by a monitored reader when the transfers to
+// its bucket target are paused by the bandwidth schedule.
+var ErrTransferPaused = errors.New("transfers paused by the bandwidth schedule")
+
 // MonitoredReader represents a throttled reader subject to bandwidth monitoring
 type MonitoredReader struct {
 	r        io.Reader
@@ -36,6 +42,7 @@ type MonitoredReader struct {
 // MonitorReaderOptions provides configurable options for monitor reader implementation.
 type MonitorReaderOptions struct {
 	Bucket     string
+	ARN        string
 	HeaderSize int
 }
 
@@ -48,7 +55,21 @@ func (r *MonitoredReader) Read(buf []byte) (n int, err error) {
 		err = r.lastErr
 		return
 	}
-	b := r.throttle.Burst()  // maximum available tokens
+	limiter, _, paused := r.throttle.current(time.Now(), r.m.NodeCount)
+	if paused {
+		r.lastErr = ErrTransferPaused
+		return 0, r.lastErr
+	}
+	if limiter == nil { // unlimited at this time
+		n, err = r.r.Read(buf)
+		if err != nil {
+			r.lastErr = err
+		}
+		r.m.updateMeasurement(r.opts.Bucket, uint64(n))
+		return
+	}
+
+	b := limiter.Burst()     // maximum available tokens
 	need := len(buf)         // number of bytes requested by caller
 	hdr := r.opts.HeaderSize // remaining header bytes
 	var tokens int           // number of tokens to request
@@ -69,7 +90,7 @@ func (r *MonitoredReader) Read(buf []byte) (n int, err error) {
 		tokens = need
 	}
 
-	err = r.throttle.WaitN(r.ctx, tokens)
+	err = limiter.WaitN(r.ctx, tokens)
 	if err != nil {
 		return
 	}
@@ -84,11 +105,11 @@ func (r *MonitoredReader) Read(buf []byte) (n int, err error) {
 }
 
 // NewMonitoredReader returns reference to a monitored reader that throttles reads to configured bandwidth for the
-// bucket.
+// bucket target.
 func NewMonitoredReader(ctx context.Context, m *Monitor, r io.Reader, opts *MonitorReaderOptions) *MonitoredReader {
 	reader := MonitoredReader{
 		r:        r,
-		throttle: m.throttle(opts.Bucket),
+		throttle: m.throttle(opts.Bucket, opts.ARN),
 		m:        m,
 		opts:     opts,
 		ctx:      ctx,
@@ -259,6 +259,7 @@ type ObjectOpts struct {
 	TransitionStatus string
 	RestoreOngoing   bool
 	RestoreExpires   time.Time
+	StorageClass     string
 }
 
 // ExpiredObjectDeleteMarker returns true if an object version referred to by o
@@ -385,7 +386,8 @@ func (lc Lifecycle) eval(obj ObjectOpts, now time.Time) Event {
 		}
 
 		if !obj.IsLatest && !rule.NoncurrentVersionTransition.IsNull() {
-			if !obj.DeleteMarker && obj.TransitionStatus != TransitionComplete {
+			if !obj.DeleteMarker && obj.TransitionStatus != TransitionComplete &&
+				obj.StorageClass != rule.NoncurrentVersionTransition.StorageClass {
 				// Non current versions should be transitioned if their age exceeds non current days configuration
 				// https://docs.aws.amazon.com/AmazonS3/latest/dev/intro-lifecycle-rules.html#intro-lifecycle-rules-actions
 				if due, ok := rule.NoncurrentVersionTransition.NextDue(obj); ok && (now.IsZero() || now.After(due)) {
@@ -420,15 +422,16 @@ func (lc Lifecycle) eval(obj ObjectOpts, now time.Time) Event {
 				}
 			}
 
-			if obj.TransitionStatus != TransitionComplete {
-				if due, ok := rule.Transition.NextDue(obj); ok && (now.IsZero() || now.After(due)) {
-					events = append(events, Event{
-						Action:       TransitionAction,
-						RuleID:       rule.ID,
-						Due:          due,
-						StorageClass: rule.Transition.StorageClass,
-					})
-				}
+			// Objects already in the storage class of a transition, e.g a
+			// custom storage class of this cluster or a remote tier, are
+			// transitioned only by the transitions following it.
+			if t, due, ok := rule.nextTransition(obj, now); ok {
+				events = append(events, Event{
+					Action:       TransitionAction,
+					RuleID:       rule.ID,
+					Due:          due,
+					StorageClass: t.StorageClass,
+				})
 			}
 		}
 	}
@@ -751,6 +751,16 @@ func TestTransitionTier(t *testing.T) {
 	if evt.StorageClass != "TIER-2" {
 		t.Fatalf("Expected TIER-2 but got %s", evt.StorageClass)
 	}
+
+	// Objects already in the storage class of the rule are not transitioned
+	obj1.StorageClass = "TIER-1"
+	if evt = lc.eval(obj1, now); evt.Action != NoneAction {
+		t.Fatalf("Expected action: %s but got %s", NoneAction, evt.Action)
+	}
+	obj2.StorageClass = "TIER-2"
+	if evt = lc.eval(obj2, now); evt.Action != NoneAction {
+		t.Fatalf("Expected action: %s but got %s", NoneAction, evt.Action)
+	}
 }
 
 func TestTransitionTierWithPrefixAndTags(t *testing.T) {
@@ -837,6 +847,80 @@ func TestTransitionTierWithPrefixAndTags(t *testing.T) {
 	}
 }
 
+func TestMultiHopTransition(t *testing.T) {
+	lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration>
+	<Rule>
+		<ID>rule-1</ID>
+		<Status>Enabled</Status>
+		<Filter></Filter>
+		<Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition>
+		<Transition><Days>365</Days><StorageClass>ARCHIVE</StorageClass></Transition>
+	</Rule>
+</LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = lc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := xml.Marshal(lc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(b), "<Transition>"); n != 2 {
+		t.Fatalf("Expected 2 transitions in %s, got %d", b, n)
+	}
+
+	modTime := time.Now().UTC()
+	testCases := []struct {
+		storageClass string
+		transitioned bool
+		days         int
+		expected     string
+	}{
+		{storageClass: "STANDARD", days: 10},
+		{storageClass: "STANDARD", days: 40, expected: "WARM"},
+		// Intermediate tiers are skipped for objects due for a later hop
+		{storageClass: "STANDARD", days: 400, expected: "ARCHIVE"},
+		{storageClass: "WARM", transitioned: true, days: 40},
+		{storageClass: "WARM", transitioned: true, days: 400, expected: "ARCHIVE"},
+		{storageClass: "ARCHIVE", transitioned: true, days: 400},
+		// Objects in a tier unknown to the rule are left alone
+		{storageClass: "OTHER", transitioned: true, days: 400},
+	}
+	for i, tc := range testCases {
+		obj := ObjectOpts{
+			Name:         "obj",
+			IsLatest:     true,
+			ModTime:      modTime,
+			StorageClass: tc.storageClass,
+		}
+		if tc.transitioned {
+			obj.TransitionStatus = TransitionComplete
+		}
+		evt := lc.eval(obj, modTime.Add(time.Duration(tc.days)*24*time.Hour))
+		switch {
+		case tc.expected == "" && evt.Action != NoneAction:
+			t.Fatalf("Test %d: Expected no action but got %s to %s", i+1, evt.Action, evt.StorageClass)
+		case tc.expected != "" && (evt.Action != TransitionAction || evt.StorageClass != tc.expected):
+			t.Fatalf("Test %d: Expected transition to %s but got %s to %s", i+1, tc.expected, evt.Action, evt.StorageClass)
+		}
+	}
+
+	// The upcoming transition of an object in the warm tier is to the
+	// archive tier.
+	evt := lc.eval(ObjectOpts{
+		Name:             "obj",
+		IsLatest:         true,
+		ModTime:          modTime,
+		StorageClass:     "WARM",
+		TransitionStatus: TransitionComplete,
+	}, time.Time{})
+	if evt.StorageClass != "ARCHIVE" || !evt.Due.Equal(ExpectedExpiryTime(modTime, 365)) {
+		t.Fatalf("Expected transition to ARCHIVE at %s but got %s at %s", ExpectedExpiryTime(modTime, 365), evt.StorageClass, evt.Due)
+	}
+}
+
 func TestNoncurrentVersionsLimit(t *testing.T) {
 	// test that the lowest max noncurrent versions limit is returned among
 	// matching rules
@@ -20,6 +20,7 @@ package lifecycle
 import (
 	"bytes"
 	"encoding/xml"
+	"time"
 )
 
 // Status represents lifecycle configuration status
@@ -40,11 +41,79 @@ type Rule struct {
 	Prefix     Prefix     `xml:"Prefix,omitempty"`
 	Expiration Expiration `xml:"Expiration,omitempty"`
 	Transition Transition `xml:"Transition,omitempty"`
+	// Transitions holds the transitions following Transition in a
+	// multi-hop rule, in increasing Days.
+	Transitions []Transition `xml:"-"`
 	// FIXME: add a type to catch unsupported AbortIncompleteMultipartUpload AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
 	NoncurrentVersionExpiration NoncurrentVersionExpiration `xml:"NoncurrentVersionExpiration,omitempty"`
 	NoncurrentVersionTransition NoncurrentVersionTransition `xml:"NoncurrentVersionTransition,omitempty"`
 }
 
+// ruleXML is the XML form of Rule, all the Transition elements of a rule are
+// collected in Transitions.
+type ruleXML struct {
+	XMLName                     xml.Name                    `xml:"Rule"`
+	ID                          string                      `xml:"ID,omitempty"`
+	Status                      Status                      `xml:"Status"`
+	Filter                      Filter                      `xml:"Filter,omitempty"`
+	Prefix                      Prefix                      `xml:"Prefix,omitempty"`
+	Expiration                  Expiration                  `xml:"Expiration,omitempty"`
+	Transitions                 []Transition                `xml:"Transition,omitempty"`
+	NoncurrentVersionExpiration NoncurrentVersionExpiration `xml:"NoncurrentVersionExpiration,omitempty"`
+	NoncurrentVersionTransition NoncurrentVersionTransition `xml:"NoncurrentVersionTransition,omitempty"`
+}
+
+// UnmarshalXML decodes a rule, the first Transition element goes to
+// Transition and the following ones to Transitions.
+func (r *Rule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var rx ruleXML
+	if err := d.DecodeElement(&rx, &start); err != nil {
+		return err
+	}
+	*r = Rule{
+		XMLName:                     rx.XMLName,
+		ID:                          rx.ID,
+		Status:                      rx.Status,
+		Filter:                      rx.Filter,
+		Prefix:                      rx.Prefix,
+		Expiration:                  rx.Expiration,
+		NoncurrentVersionExpiration: rx.NoncurrentVersionExpiration,
+		NoncurrentVersionTransition: rx.NoncurrentVersionTransition,
+	}
+	if len(rx.Transitions) > 0 {
+		r.Transition = rx.Transitions[0]
+	}
+	if len(rx.Transitions) > 1 {
+		r.Transitions = rx.Transitions[1:]
+	}
+	return nil
+}
+
+// MarshalXML encodes a rule with all its Transition elements.
+func (r Rule) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	rx := ruleXML{
+		XMLName:                     r.XMLName,
+		ID:                          r.ID,
+		Status:                      r.Status,
+		Filter:                      r.Filter,
+		Prefix:                      r.Prefix,
+		Expiration:                  r.Expiration,
+		Transitions:                 r.AllTransitions(),
+		NoncurrentVersionExpiration: r.NoncurrentVersionExpiration,
+		NoncurrentVersionTransition: r.NoncurrentVersionTransition,
+	}
+	return e.EncodeElement(rx, start)
+}
+
+// AllTransitions returns the transitions of the rule in the order they
+// apply.
+func (r Rule) AllTransitions() []Transition {
+	if r.Transition.IsNull() {
+		return nil
+	}
+	return append([]Transition{r.Transition}, r.Transitions...)
+}
+
 var (
 	errInvalidRuleID     = Errorf("ID length is limited to 255 characters")
 	errEmptyRuleStatus   = Errorf("Status should not be empty")
@@ -92,7 +161,76 @@ func (r Rule) validatePrefixAndFilter() error {
 }
 
 func (r Rule) validateTransition() error {
-	return r.Transition.Validate()
+	if err := r.Transition.Validate(); err != nil {
+		return err
+	}
+	if len(r.Transitions) == 0 {
+		return nil
+	}
+	if r.Transition.IsNull() {
+		return errXMLNotWellFormed
+	}
+	prev := r.Transition
+	storageClasses := map[string]struct{}{prev.StorageClass: {}}
+	for _, t := range r.Transitions {
+		if err := t.Validate(); err != nil {
+			return err
+		}
+		if t.IsNull() {
+			return errXMLNotWellFormed
+		}
+		if !prev.IsDateNull() || !t.IsDateNull() {
+			return errTransitionMultipleDate
+		}
+		if t.Days <= prev.Days {
+			return errTransitionDaysNotIncreasing
+		}
+		if _, ok := storageClasses[t.StorageClass]; ok {
+			return errTransitionDuplicateStorageClass
+		}
+		storageClasses[t.StorageClass] = struct{}{}
+		prev = t
+	}
+	return nil
+}
+
+// nextTransition returns the transition of the rule obj is due for at now,
+// along with its due date. An object is moved only forward, i.e to a
+// transition following the one of its current storage class; when several
+// are due the last one is returned so that intermediate tiers are skipped.
+// If now is the zero value of time.Time, the upcoming transition is returned.
+func (r Rule) nextTransition(obj ObjectOpts, now time.Time) (Transition, time.Time, bool) {
+	transitions := r.AllTransitions()
+	cur := -1
+	for i, t := range transitions {
+		if t.StorageClass == obj.StorageClass {
+			cur = i
+			break
+		}
+	}
+	// Objects transitioned to a tier unknown to this rule are left alone.
+	if obj.TransitionStatus == TransitionComplete && cur < 0 {
+		return Transition{}, time.Time{}, false
+	}
+
+	var (
+		next    Transition
+		nextDue time.Time
+		found   bool
+	)
+	for _, t := range transitions[cur+1:] {
+		due, ok := t.NextDue(obj)
+		if !ok {
+			continue
+		}
+		if now.IsZero() {
+			return t, due, true
+		}
+		if now.After(due) {
+			next, nextDue, found = t, due, true
+		}
+	}
+	return next, nextDue, found
 }
 
 func (r Rule) validateNoncurrentTransition() error {
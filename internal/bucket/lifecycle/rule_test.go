@@ -61,6 +61,47 @@ func TestInvalidRules(t *testing.T) {
 	                    </Rule>`,
 			expectedErr: errInvalidRuleStatus,
 		},
+		{ // Rule with multiple transitions
+			inputXML: ` <Rule>
+			                  <ID>rule with multiple transitions</ID>
+			                  <Filter></Filter>
+			                  <Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition>
+			                  <Transition><Days>365</Days><StorageClass>ARCHIVE</StorageClass></Transition>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: nil,
+		},
+		{ // Rule with transitions not in increasing days
+			inputXML: ` <Rule>
+			                  <ID>rule with decreasing transitions</ID>
+			                  <Filter></Filter>
+			                  <Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition>
+			                  <Transition><Days>30</Days><StorageClass>ARCHIVE</StorageClass></Transition>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errTransitionDaysNotIncreasing,
+		},
+		{ // Rule with transitions to the same storage class
+			inputXML: ` <Rule>
+			                  <ID>rule with duplicate transitions</ID>
+			                  <Filter></Filter>
+			                  <Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition>
+			                  <Transition><Days>60</Days><StorageClass>ARCHIVE</StorageClass></Transition>
+			                  <Transition><Days>90</Days><StorageClass>WARM</StorageClass></Transition>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errTransitionDuplicateStorageClass,
+		},
+		{ // Rule with multiple transitions by date
+			inputXML: ` <Rule>
+			                  <ID>rule with transitions by date</ID>
+			                  <Filter></Filter>
+			                  <Transition><Date>2030-01-01T00:00:00Z</Date><StorageClass>WARM</StorageClass></Transition>
+			                  <Transition><Days>365</Days><StorageClass>ARCHIVE</StorageClass></Transition>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errTransitionMultipleDate,
+		},
 	}
 
 	for i, tc := range invalidTestCases {
@@ -27,6 +27,10 @@ var (
 	errTransitionInvalidDate     = Errorf("Date must be provided in ISO 8601 format")
 	errTransitionInvalid         = Errorf("Exactly one of Days (0 or greater) or Date (positive ISO 8601 format) should be present in Transition.")
 	errTransitionDateNotMidnight = Errorf("'Date' must be at midnight GMT")
+
+	errTransitionMultipleDate          = Errorf("Days must be used instead of Date in a rule with more than one Transition")
+	errTransitionDaysNotIncreasing     = Errorf("Days must be increasing across the Transitions of a rule")
+	errTransitionDuplicateStorageClass = Errorf("StorageClass must be unique across the Transitions of a rule")
 )
 
 // TransitionDate is a embedded type containing time.Time to unmarshal
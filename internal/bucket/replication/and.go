@@ -26,13 +26,18 @@ type And struct {
 	XMLName xml.Name `xml:"And" json:"And"`
 	Prefix  string   `xml:"Prefix,omitempty" json:"Prefix,omitempty"`
 	Tags    []Tag    `xml:"Tag,omitempty" json:"Tag,omitempty"`
+
+	ObjectSizeGreaterThan int64  `xml:"ObjectSizeGreaterThan,omitempty" json:"ObjectSizeGreaterThan,omitempty"`
+	ObjectSizeLessThan    int64  `xml:"ObjectSizeLessThan,omitempty" json:"ObjectSizeLessThan,omitempty"`
+	StorageClass          string `xml:"StorageClass,omitempty" json:"StorageClass,omitempty"`
 }
 
 var errDuplicateTagKey = Errorf("Duplicate Tag Keys are not allowed")
 
-// isEmpty returns true if Tags field is null
+// isEmpty returns true if no field is set
 func (a And) isEmpty() bool {
-	return len(a.Tags) == 0 && a.Prefix == ""
+	return len(a.Tags) == 0 && a.Prefix == "" &&
+		a.ObjectSizeGreaterThan == 0 && a.ObjectSizeLessThan == 0 && a.StorageClass == ""
 }
 
 // Validate - validates the And field
@@ -45,6 +50,12 @@ func (a And) Validate() error {
 			return err
 		}
 	}
+	if a.ObjectSizeGreaterThan < 0 || a.ObjectSizeLessThan < 0 {
+		return errInvalidObjectSize
+	}
+	if a.ObjectSizeLessThan > 0 && a.ObjectSizeGreaterThan >= a.ObjectSizeLessThan {
+		return errInvalidObjectSizeRange
+	}
 	return nil
 }
 
@@ -38,13 +38,66 @@ type Destination struct {
 	StorageClass string   `xml:"StorageClass" json:"StorageClass"`
 	ARN          string
 	// EncryptionConfiguration TODO: not needed for MinIO
+
+	ReplicationTime ReplicationTime `xml:"ReplicationTime,omitempty" json:"ReplicationTime,omitempty"`
+	Metrics         Metrics         `xml:"Metrics,omitempty" json:"Metrics,omitempty"`
+
+	// StorageClassMappings rewrite the storage class of the replicated
+	// objects, this is a MinIO only extension.
+	StorageClassMappings []StorageClassMapping `xml:"StorageClassMapping,omitempty" json:"StorageClassMapping,omitempty"`
+}
+
+// StorageClassMapping - maps the storage class of source objects to the
+// storage class of the replicated objects.
+type StorageClassMapping struct {
+	Source      string `xml:"Source" json:"Source"`
+	Destination string `xml:"Destination" json:"Destination"`
+}
+
+var (
+	errInvalidStorageClassMapping   = Errorf("StorageClassMapping must have Source and Destination specified")
+	errDuplicateStorageClassMapping = Errorf("Duplicate StorageClassMapping Sources are not allowed")
+)
+
+func isValidDestinationStorageClass(sc string) bool {
+	return sc == "STANDARD" || sc == "REDUCED_REDUNDANCY"
 }
 
 func (d Destination) isValidStorageClass() bool {
 	if d.StorageClass == "" {
 		return true
 	}
-	return d.StorageClass == "STANDARD" || d.StorageClass == "REDUCED_REDUNDANCY"
+	return isValidDestinationStorageClass(d.StorageClass)
+}
+
+// validateStorageClassMappings validates the storage class mappings of the
+// destination.
+func (d Destination) validateStorageClassMappings() error {
+	sources := make(map[string]struct{}, len(d.StorageClassMappings))
+	for _, m := range d.StorageClassMappings {
+		if m.Source == "" || m.Destination == "" {
+			return errInvalidStorageClassMapping
+		}
+		if !isValidDestinationStorageClass(m.Destination) {
+			return Errorf("unknown storage class %s", m.Destination)
+		}
+		if _, ok := sources[m.Source]; ok {
+			return errDuplicateStorageClassMapping
+		}
+		sources[m.Source] = struct{}{}
+	}
+	return nil
+}
+
+// MapStorageClass returns the storage class of the objects of storage class
+// sc replicated to the destination, empty if sc is not mapped.
+func (d Destination) MapStorageClass(sc string) string {
+	for _, m := range d.StorageClassMappings {
+		if m.Source == sc {
+			return m.Destination
+		}
+	}
+	return ""
 }
 
 // IsValid - checks whether Destination is valid or not.
@@ -81,6 +134,21 @@ func (d Destination) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 			return err
 		}
 	}
+	if !d.ReplicationTime.IsEmpty() {
+		if err := e.EncodeElement(d.ReplicationTime, xml.StartElement{Name: xml.Name{Local: "ReplicationTime"}}); err != nil {
+			return err
+		}
+	}
+	if !d.Metrics.IsEmpty() {
+		if err := e.EncodeElement(d.Metrics, xml.StartElement{Name: xml.Name{Local: "Metrics"}}); err != nil {
+			return err
+		}
+	}
+	for _, m := range d.StorageClassMappings {
+		if err := e.EncodeElement(m, xml.StartElement{Name: xml.Name{Local: "StorageClassMapping"}}); err != nil {
+			return err
+		}
+	}
 	return e.EncodeToken(xml.EndElement{Name: start.Name})
 }
 
@@ -97,14 +165,13 @@ func (d *Destination) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) (er
 	if err != nil {
 		return err
 	}
-	if dest.StorageClass != "" {
-		switch dest.StorageClass {
-		case "STANDARD", "REDUCED_REDUNDANCY":
-		default:
-			return fmt.Errorf("unknown storage class %s", dest.StorageClass)
-		}
+	if dest.StorageClass != "" && !isValidDestinationStorageClass(dest.StorageClass) {
+		return fmt.Errorf("unknown storage class %s", dest.StorageClass)
 	}
 	parsedDest.StorageClass = dest.StorageClass
+	parsedDest.ReplicationTime = dest.ReplicationTime
+	parsedDest.Metrics = dest.Metrics
+	parsedDest.StorageClassMappings = dest.StorageClassMappings
 	*d = parsedDest
 	return nil
 }
@@ -23,7 +23,11 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 )
 
-var errInvalidFilter = Errorf("Filter must have exactly one of Prefix, Tag, or And specified")
+var (
+	errInvalidFilter          = Errorf("Filter must have exactly one of Prefix, Tag, ObjectSizeGreaterThan, ObjectSizeLessThan, StorageClass or And specified")
+	errInvalidObjectSize      = Errorf("Object size must be a positive number")
+	errInvalidObjectSizeRange = Errorf("ObjectSizeGreaterThan must be less than ObjectSizeLessThan")
+)
 
 // Filter - a filter for a replication configuration Rule.
 type Filter struct {
@@ -32,17 +36,26 @@ type Filter struct {
 	And     And
 	Tag     Tag
 
+	// Objects size range in bytes, 0 if not set.
+	ObjectSizeGreaterThan int64 `xml:"ObjectSizeGreaterThan,omitempty" json:"ObjectSizeGreaterThan,omitempty"`
+	ObjectSizeLessThan    int64 `xml:"ObjectSizeLessThan,omitempty" json:"ObjectSizeLessThan,omitempty"`
+
+	// StorageClass of the objects, this is a MinIO only extension.
+	StorageClass string `xml:"StorageClass,omitempty" json:"StorageClass,omitempty"`
+
 	// Caching tags, only once
 	cachedTags map[string]string
 }
 
 // IsEmpty returns true if filter is not set
 func (f Filter) IsEmpty() bool {
-	return f.And.isEmpty() && f.Tag.IsEmpty() && f.Prefix == ""
+	return f.And.isEmpty() && f.Tag.IsEmpty() && f.Prefix == "" &&
+		f.ObjectSizeGreaterThan == 0 && f.ObjectSizeLessThan == 0 && f.StorageClass == ""
 }
 
 // MarshalXML - produces the xml representation of the Filter struct
-// only one of Prefix, And and Tag should be present in the output.
+// only one of Prefix, And, Tag, ObjectSizeGreaterThan, ObjectSizeLessThan and
+// StorageClass should be present in the output.
 func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if err := e.EncodeToken(start); err != nil {
 		return err
@@ -57,8 +70,20 @@ func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		if err := e.EncodeElement(f.Tag, xml.StartElement{Name: xml.Name{Local: "Tag"}}); err != nil {
 			return err
 		}
+	case f.ObjectSizeGreaterThan > 0:
+		if err := e.EncodeElement(f.ObjectSizeGreaterThan, xml.StartElement{Name: xml.Name{Local: "ObjectSizeGreaterThan"}}); err != nil {
+			return err
+		}
+	case f.ObjectSizeLessThan > 0:
+		if err := e.EncodeElement(f.ObjectSizeLessThan, xml.StartElement{Name: xml.Name{Local: "ObjectSizeLessThan"}}); err != nil {
+			return err
+		}
+	case f.StorageClass != "":
+		if err := e.EncodeElement(f.StorageClass, xml.StartElement{Name: xml.Name{Local: "StorageClass"}}); err != nil {
+			return err
+		}
 	default:
-		// Always print Prefix field when both And & Tag are empty
+		// Always print Prefix field when all other fields are empty
 		if err := e.EncodeElement(f.Prefix, xml.StartElement{Name: xml.Name{Local: "Prefix"}}); err != nil {
 			return err
 		}
@@ -69,28 +94,37 @@ func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 
 // Validate - validates the filter element
 func (f Filter) Validate() error {
-	// A Filter must have exactly one of Prefix, Tag, or And specified.
-	if !f.And.isEmpty() {
-		if f.Prefix != "" {
-			return errInvalidFilter
-		}
-		if !f.Tag.IsEmpty() {
-			return errInvalidFilter
+	// A Filter must have exactly one of Prefix, Tag, ObjectSizeGreaterThan,
+	// ObjectSizeLessThan, StorageClass or And specified.
+	var n int
+	for _, set := range []bool{
+		f.Prefix != "",
+		!f.Tag.IsEmpty(),
+		f.ObjectSizeGreaterThan != 0,
+		f.ObjectSizeLessThan != 0,
+		f.StorageClass != "",
+		!f.And.isEmpty(),
+	} {
+		if set {
+			n++
 		}
+	}
+	if n > 1 {
+		return errInvalidFilter
+	}
+	if !f.And.isEmpty() {
 		if err := f.And.Validate(); err != nil {
 			return err
 		}
 	}
-	if f.Prefix != "" {
-		if !f.Tag.IsEmpty() {
-			return errInvalidFilter
-		}
-	}
 	if !f.Tag.IsEmpty() {
 		if err := f.Tag.Validate(); err != nil {
 			return err
 		}
 	}
+	if f.ObjectSizeGreaterThan < 0 || f.ObjectSizeLessThan < 0 {
+		return errInvalidObjectSize
+	}
 	return nil
 }
 
@@ -136,3 +170,43 @@ func (f *Filter) TestTags(userTags string) bool {
 
 	return false
 }
+
+// objectSizeRange returns the objects size range of the Filter, 0 if not
+// set.
+func (f Filter) objectSizeRange() (greaterThan, lessThan int64) {
+	if !f.And.isEmpty() {
+		return f.And.ObjectSizeGreaterThan, f.And.ObjectSizeLessThan
+	}
+	return f.ObjectSizeGreaterThan, f.ObjectSizeLessThan
+}
+
+// TestSize tests if the object size satisfies the Filter size range, it
+// returns true if there is no size range in the underlying Filter or if the
+// size is unknown (negative).
+func (f Filter) TestSize(size int64) bool {
+	if size < 0 {
+		return true
+	}
+	greaterThan, lessThan := f.objectSizeRange()
+	if greaterThan > 0 && size <= greaterThan {
+		return false
+	}
+	if lessThan > 0 && size >= lessThan {
+		return false
+	}
+	return true
+}
+
+// TestStorageClass tests if the object storage class satisfies the Filter
+// storage class, it returns true if there is no storage class in the
+// underlying Filter or if the storage class is unknown (empty).
+func (f Filter) TestStorageClass(storageClass string) bool {
+	sc := f.StorageClass
+	if !f.And.isEmpty() {
+		sc = f.And.StorageClass
+	}
+	if sc == "" || storageClass == "" {
+		return true
+	}
+	return sc == storageClass
+}
@@ -159,6 +159,8 @@ type ObjectOpts struct {
 	Replica        bool
 	ExistingObject bool
 	TargetArn      string
+	Size           int64  // negative if unknown
+	StorageClass   string // empty if unknown
 }
 
 // HasExistingObjectReplication returns true if any of the rule returns 'ExistingObjects' replication.
@@ -177,7 +179,8 @@ func (c Config) HasExistingObjectReplication(arn string) (hasARN, isEnabled bool
 }
 
 // FilterActionableRules returns the rules actions that need to be executed
-// after evaluating prefix/tag filtering
+// after evaluating prefix/tag/size/storage class filtering, deletes are not
+// filtered on size and storage class.
 func (c Config) FilterActionableRules(obj ObjectOpts) []Rule {
 	if obj.Name == "" && !(obj.OpType == ResyncReplicationType || obj.OpType == AllReplicationType) {
 		return nil
@@ -202,6 +205,11 @@ func (c Config) FilterActionableRules(obj ObjectOpts) []Rule {
 		if !strings.HasPrefix(obj.Name, rule.Prefix()) {
 			continue
 		}
+		if obj.OpType != DeleteReplicationType {
+			if !rule.Filter.TestSize(obj.Size) || !rule.Filter.TestStorageClass(obj.StorageClass) {
+				continue
+			}
+		}
 		if rule.Filter.TestTags(obj.UserTags) {
 			rules = append(rules, rule)
 		}
@@ -213,6 +221,17 @@ func (c Config) FilterActionableRules(obj ObjectOpts) []Rule {
 	return rules
 }
 
+// DestinationStorageClass returns the storage class of the object replicated
+// to obj.TargetArn as mapped by the rules applying to it, empty if not mapped.
+func (c Config) DestinationStorageClass(obj ObjectOpts) string {
+	for _, rule := range c.FilterActionableRules(obj) {
+		if sc := rule.Destination.MapStorageClass(obj.StorageClass); sc != "" {
+			return sc
+		}
+	}
+	return ""
+}
+
 // GetDestination returns destination bucket and storage class.
 func (c Config) GetDestination() Destination {
 	if len(c.Rules) > 0 {
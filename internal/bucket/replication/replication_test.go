@@ -19,6 +19,7 @@ package replication
 
 import (
 	"bytes"
+	"encoding/xml"
 	"fmt"
 	"testing"
 )
@@ -145,6 +146,54 @@ func TestParseAndValidateReplicationConfig(t *testing.T) {
 			expectedParsingErr:    fmt.Errorf("invalid destination '%v'", "arn:xx:replication::8320b6d18f9032b4700f1f03b50d8d1853de8f22cab86931ee794e12f190852c:destinationbucket"),
 			expectedValidationErr: nil,
 		},
+		// 15 valid object size range filter
+		{
+			inputConfig:           `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><And><Prefix>prefix</Prefix><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan><ObjectSizeLessThan>2048</ObjectSizeLessThan></And></Filter><Destination><Bucket>arn:minio:replication:xxx::destinationbucket</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			destBucket:            "destinationbucket",
+			sameTarget:            false,
+			expectedParsingErr:    nil,
+			expectedValidationErr: nil,
+		},
+		// 16 object size filter with prefix outside And
+		{
+			inputConfig:           `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><Prefix>prefix</Prefix><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter><Destination><Bucket>arn:minio:replication:xxx::destinationbucket</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			destBucket:            "destinationbucket",
+			sameTarget:            false,
+			expectedParsingErr:    nil,
+			expectedValidationErr: errInvalidFilter,
+		},
+		// 17 invalid object size range filter
+		{
+			inputConfig:           `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><And><ObjectSizeGreaterThan>2048</ObjectSizeGreaterThan><ObjectSizeLessThan>1024</ObjectSizeLessThan></And></Filter><Destination><Bucket>arn:minio:replication:xxx::destinationbucket</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			destBucket:            "destinationbucket",
+			sameTarget:            false,
+			expectedParsingErr:    nil,
+			expectedValidationErr: errInvalidObjectSizeRange,
+		},
+		// 18 valid storage class mapping
+		{
+			inputConfig:           `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><StorageClass>REDUCED_REDUNDANCY</StorageClass></Filter><Destination><Bucket>arn:minio:replication:xxx::destinationbucket</Bucket><StorageClassMapping><Source>REDUCED_REDUNDANCY</Source><Destination>STANDARD</Destination></StorageClassMapping></Destination></Rule></ReplicationConfiguration>`,
+			destBucket:            "destinationbucket",
+			sameTarget:            false,
+			expectedParsingErr:    nil,
+			expectedValidationErr: nil,
+		},
+		// 19 duplicate storage class mapping
+		{
+			inputConfig:           `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><Prefix>prefix</Prefix></Filter><Destination><Bucket>arn:minio:replication:xxx::destinationbucket</Bucket><StorageClassMapping><Source>STANDARD</Source><Destination>STANDARD</Destination></StorageClassMapping><StorageClassMapping><Source>STANDARD</Source><Destination>REDUCED_REDUNDANCY</Destination></StorageClassMapping></Destination></Rule></ReplicationConfiguration>`,
+			destBucket:            "destinationbucket",
+			sameTarget:            false,
+			expectedParsingErr:    nil,
+			expectedValidationErr: errDuplicateStorageClassMapping,
+		},
+		// 20 storage class mapping without destination
+		{
+			inputConfig:           `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><Prefix>prefix</Prefix></Filter><Destination><Bucket>arn:minio:replication:xxx::destinationbucket</Bucket><StorageClassMapping><Source>STANDARD</Source></StorageClassMapping></Destination></Rule></ReplicationConfiguration>`,
+			destBucket:            "destinationbucket",
+			sameTarget:            false,
+			expectedParsingErr:    nil,
+			expectedValidationErr: errInvalidStorageClassMapping,
+		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
@@ -419,3 +468,67 @@ func TestFilterActionableRules(t *testing.T) {
 		}
 	}
 }
+
+func TestFilterActionableRulesSizeStorageClass(t *testing.T) {
+	inputConfig := `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Enabled</Status></DeleteReplication><Filter><And><Prefix>prefix</Prefix><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan><ObjectSizeLessThan>4096</ObjectSizeLessThan></And></Filter><Priority>2</Priority><Destination><Bucket>arn:minio:replication:xxx::destinationbucket</Bucket><StorageClassMapping><Source>REDUCED_REDUNDANCY</Source><Destination>STANDARD</Destination></StorageClassMapping></Destination></Rule><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><StorageClass>STANDARD</StorageClass></Filter><Priority>1</Priority><Destination><Bucket>arn:minio:replication:xxx::destinationbucket2</Bucket></Destination></Rule></ReplicationConfiguration>`
+	cfg, err := ParseConfig(bytes.NewReader([]byte(inputConfig)))
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if err = cfg.Validate("bucket", false); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		opts         ObjectOpts
+		expectedArns []string
+		expectedSC   string
+	}{
+		// 1. size below range, storage class matches second rule
+		{ObjectOpts{Name: "prefix/a", Size: 1024, StorageClass: "STANDARD"}, []string{"arn:minio:replication:xxx::destinationbucket2"}, ""},
+		// 2. size within range, RRS storage class is mapped to STANDARD
+		{ObjectOpts{Name: "prefix/a", Size: 2048, StorageClass: "REDUCED_REDUNDANCY"}, []string{"arn:minio:replication:xxx::destinationbucket"}, "STANDARD"},
+		// 3. size within range, STANDARD storage class is not mapped
+		{ObjectOpts{Name: "prefix/a", Size: 2048, StorageClass: "STANDARD"}, []string{"arn:minio:replication:xxx::destinationbucket", "arn:minio:replication:xxx::destinationbucket2"}, ""},
+		// 4. size above range
+		{ObjectOpts{Name: "prefix/a", Size: 4096, StorageClass: "REDUCED_REDUNDANCY"}, nil, ""},
+		// 5. unknown size and storage class match all rules
+		{ObjectOpts{Name: "prefix/a", Size: -1}, []string{"arn:minio:replication:xxx::destinationbucket", "arn:minio:replication:xxx::destinationbucket2"}, ""},
+		// 6. deletes are not filtered on size and storage class
+		{ObjectOpts{Name: "prefix/a", VersionID: "v1", OpType: DeleteReplicationType}, []string{"arn:minio:replication:xxx::destinationbucket", "arn:minio:replication:xxx::destinationbucket2"}, ""},
+	}
+	for i, tc := range testCases {
+		var got []string
+		for _, rule := range cfg.FilterActionableRules(tc.opts) {
+			got = append(got, rule.Destination.ARN)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tc.expectedArns) {
+			t.Fatalf("Test %d: expected rules for %v, got %v", i+1, tc.expectedArns, got)
+		}
+		opts := tc.opts
+		opts.TargetArn = "arn:minio:replication:xxx::destinationbucket"
+		if sc := cfg.DestinationStorageClass(opts); sc != tc.expectedSC {
+			t.Fatalf("Test %d: expected storage class %q, got %q", i+1, tc.expectedSC, sc)
+		}
+	}
+}
+
+func TestFilterMarshalXML(t *testing.T) {
+	testCases := []struct {
+		filter   Filter
+		expected string
+	}{
+		{Filter{ObjectSizeGreaterThan: 1024}, `<Filter><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter>`},
+		{Filter{StorageClass: "STANDARD"}, `<Filter><StorageClass>STANDARD</StorageClass></Filter>`},
+		{Filter{And: And{Prefix: "prefix", ObjectSizeLessThan: 2048}}, `<Filter><And><Prefix>prefix</Prefix><ObjectSizeLessThan>2048</ObjectSizeLessThan></And></Filter>`},
+	}
+	for i, tc := range testCases {
+		b, err := xml.Marshal(tc.filter)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if string(b) != tc.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, tc.expected, b)
+		}
+	}
+}
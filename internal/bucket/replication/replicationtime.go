@@ -0,0 +1,150 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replication
+
+import (
+	"time"
+)
+
+var (
+	errInvalidReplicationTimeStatus  = Errorf("ReplicationTime status must be set to either Enabled or Disabled")
+	errInvalidReplicationTimeMinutes = Errorf("ReplicationTime minutes must be a positive number")
+	errInvalidMetricsStatus          = Errorf("Metrics status must be set to either Enabled or Disabled")
+	errInvalidEventThresholdMinutes  = Errorf("Metrics EventThreshold minutes must be a positive number")
+	errReplicationTimeMetricsMissing = Errorf("Metrics must be enabled when ReplicationTime is enabled")
+	errEventThresholdWithoutRTC      = Errorf("Metrics EventThreshold is only supported when ReplicationTime is enabled")
+)
+
+// ReplicationTimeValue - time threshold in minutes.
+type ReplicationTimeValue struct {
+	Minutes int `xml:"Minutes" json:"Minutes"`
+}
+
+// Duration returns the threshold as a duration.
+func (v ReplicationTimeValue) Duration() time.Duration {
+	return time.Duration(v.Minutes) * time.Minute
+}
+
+// ReplicationTime - replication time control (RTC), the time within which
+// objects are expected to be replicated - https://docs.aws.amazon.com/AmazonS3/latest/userguide/replication-time-control.html
+type ReplicationTime struct {
+	Status Status               `xml:"Status" json:"Status"`
+	Time   ReplicationTimeValue `xml:"Time" json:"Time"`
+}
+
+// IsEmpty returns true if ReplicationTime is not set
+func (r ReplicationTime) IsEmpty() bool {
+	return len(r.Status) == 0
+}
+
+// Validate validates the replication time control.
+func (r ReplicationTime) Validate() error {
+	if r.IsEmpty() {
+		return nil
+	}
+	if r.Status != Enabled && r.Status != Disabled {
+		return errInvalidReplicationTimeStatus
+	}
+	if r.Status == Enabled && r.Time.Minutes <= 0 {
+		return errInvalidReplicationTimeMinutes
+	}
+	return nil
+}
+
+// Metrics - whether replication metrics and events are enabled, the
+// events threshold defaults to the ReplicationTime threshold.
+type Metrics struct {
+	Status         Status                `xml:"Status" json:"Status"`
+	EventThreshold *ReplicationTimeValue `xml:"EventThreshold,omitempty" json:"EventThreshold,omitempty"`
+}
+
+// IsEmpty returns true if Metrics is not set
+func (m Metrics) IsEmpty() bool {
+	return len(m.Status) == 0
+}
+
+// Validate validates the replication metrics.
+func (m Metrics) Validate() error {
+	if m.IsEmpty() {
+		return nil
+	}
+	if m.Status != Enabled && m.Status != Disabled {
+		return errInvalidMetricsStatus
+	}
+	if m.EventThreshold != nil && m.EventThreshold.Minutes <= 0 {
+		return errInvalidEventThresholdMinutes
+	}
+	return nil
+}
+
+// validateReplicationTime validates the replication time control and
+// metrics of the destination together.
+func (d Destination) validateReplicationTime() error {
+	if err := d.ReplicationTime.Validate(); err != nil {
+		return err
+	}
+	if err := d.Metrics.Validate(); err != nil {
+		return err
+	}
+	rtc := d.ReplicationTime.Status == Enabled
+	if rtc && d.Metrics.Status != Enabled {
+		return errReplicationTimeMetricsMissing
+	}
+	if !rtc && d.Metrics.EventThreshold != nil {
+		return errEventThresholdWithoutRTC
+	}
+	return nil
+}
+
+// ReplicationTimeThreshold returns the time within which objects are
+// expected to be replicated to the destination, and the time after which
+// missed threshold events are sent. Both are zero if replication time
+// control is disabled.
+func (d Destination) ReplicationTimeThreshold() (threshold, eventThreshold time.Duration) {
+	if d.ReplicationTime.Status != Enabled {
+		return 0, 0
+	}
+	threshold = d.ReplicationTime.Time.Duration()
+	eventThreshold = threshold
+	if d.Metrics.EventThreshold != nil {
+		eventThreshold = d.Metrics.EventThreshold.Duration()
+	}
+	return threshold, eventThreshold
+}
+
+// ReplicationTimeThreshold returns the replication time control thresholds
+// of the target arn, see Destination.ReplicationTimeThreshold. The lowest
+// thresholds apply if several rules replicate to the target.
+func (c Config) ReplicationTimeThreshold(arn string) (threshold, eventThreshold time.Duration) {
+	for _, rule := range c.Rules {
+		if rule.Status == Disabled {
+			continue
+		}
+		if rule.Destination.ARN != arn && c.RoleArn != arn {
+			continue
+		}
+		t, et := rule.Destination.ReplicationTimeThreshold()
+		if t > 0 && (threshold == 0 || t < threshold) {
+			threshold = t
+		}
+		if et > 0 && (eventThreshold == 0 || et < eventThreshold) {
+			eventThreshold = et
+		}
+	}
+	return threshold, eventThreshold
+}
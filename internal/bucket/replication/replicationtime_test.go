@@ -0,0 +1,86 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replication
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReplicationTime(t *testing.T) {
+	const arn = "arn:minio:replication:us-east-1:target:destinationbucket"
+	rtcConfig := func(destination string) string {
+		return `<ReplicationConfiguration><Rule><Status>Enabled</Status><Priority>1</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Destination><Bucket>` + arn + `</Bucket>` + destination + `</Destination></Rule></ReplicationConfiguration>`
+	}
+	testCases := []struct {
+		destination    string
+		validationErr  error
+		threshold      time.Duration
+		eventThreshold time.Duration
+	}{
+		// 1. replication time control not configured
+		{"", nil, 0, 0},
+		// 2. replication time control with the default event threshold
+		{`<ReplicationTime><Status>Enabled</Status><Time><Minutes>15</Minutes></Time></ReplicationTime><Metrics><Status>Enabled</Status></Metrics>`, nil, 15 * time.Minute, 15 * time.Minute},
+		// 3. replication time control with an event threshold
+		{`<ReplicationTime><Status>Enabled</Status><Time><Minutes>60</Minutes></Time></ReplicationTime><Metrics><Status>Enabled</Status><EventThreshold><Minutes>30</Minutes></EventThreshold></Metrics>`, nil, time.Hour, 30 * time.Minute},
+		// 4. replication time control disabled
+		{`<ReplicationTime><Status>Disabled</Status><Time><Minutes>15</Minutes></Time></ReplicationTime><Metrics><Status>Enabled</Status></Metrics>`, nil, 0, 0},
+		// 5. metrics missing
+		{`<ReplicationTime><Status>Enabled</Status><Time><Minutes>15</Minutes></Time></ReplicationTime>`, errReplicationTimeMetricsMissing, 0, 0},
+		// 6. invalid time
+		{`<ReplicationTime><Status>Enabled</Status><Time><Minutes>0</Minutes></Time></ReplicationTime><Metrics><Status>Enabled</Status></Metrics>`, errInvalidReplicationTimeMinutes, 0, 0},
+		// 7. event threshold without replication time control
+		{`<Metrics><Status>Enabled</Status><EventThreshold><Minutes>15</Minutes></EventThreshold></Metrics>`, errEventThresholdWithoutRTC, 0, 0},
+		// 8. invalid status
+		{`<ReplicationTime><Status>On</Status><Time><Minutes>15</Minutes></Time></ReplicationTime>`, errInvalidReplicationTimeStatus, 0, 0},
+	}
+	for i, tc := range testCases {
+		cfg, err := ParseConfig(bytes.NewReader([]byte(rtcConfig(tc.destination))))
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if err = cfg.Validate("bucket", false); err != tc.validationErr {
+			t.Errorf("Test %d: expected validation error %v, got %v", i+1, tc.validationErr, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		threshold, eventThreshold := cfg.ReplicationTimeThreshold(arn)
+		if threshold != tc.threshold || eventThreshold != tc.eventThreshold {
+			t.Errorf("Test %d: expected thresholds %v, %v, got %v, %v", i+1, tc.threshold, tc.eventThreshold, threshold, eventThreshold)
+		}
+
+		// The configuration survives a round trip.
+		b, err := xml.Marshal(cfg)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		cfg2, err := ParseConfig(bytes.NewReader(b))
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(cfg2.Rules[0].Destination, cfg.Rules[0].Destination) {
+			t.Errorf("Test %d: expected %v after a round trip, got %v", i+1, cfg.Rules[0].Destination, cfg2.Rules[0].Destination)
+		}
+	}
+}
@@ -233,6 +233,12 @@ func (r Rule) Validate(bucket string, sameTarget bool) error {
 	if err := r.SourceSelectionCriteria.Validate(); err != nil {
 		return err
 	}
+	if err := r.Destination.validateReplicationTime(); err != nil {
+		return err
+	}
+	if err := r.Destination.validateStorageClassMappings(); err != nil {
+		return err
+	}
 
 	if r.Priority < 0 {
 		return errPriorityMissing
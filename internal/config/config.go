@@ -103,6 +103,7 @@ const (
 	IdentityLDAPSubSys   = madmin.IdentityLDAPSubSys
 	IdentityTLSSubSys    = madmin.IdentityTLSSubSys
 	IdentityPluginSubSys = madmin.IdentityPluginSubSys
+	IdentitySAMLSubSys   = "identity_saml"
 	CacheSubSys          = madmin.CacheSubSys
 	SiteSubSys           = madmin.SiteSubSys
 	RegionSubSys         = madmin.RegionSubSys
@@ -160,7 +161,9 @@ var LoggerSubSystems = set.CreateStringSet(
 )
 
 // SubSystems - all supported sub-systems
-var SubSystems = madmin.SubSystems
+var SubSystems = madmin.SubSystems.Union(set.CreateStringSet(
+	IdentitySAMLSubSys,
+))
 
 // SubSystemsDynamic - all sub-systems that have dynamic config.
 var SubSystemsDynamic = set.CreateStringSet(
@@ -188,9 +191,9 @@ var SubSystemsSingleTargets = set.CreateStringSet(
 	CompressionSubSys,
 	PolicyOPASubSys,
 	PolicyPluginSubSys,
-	IdentityLDAPSubSys,
 	IdentityTLSSubSys,
 	IdentityPluginSubSys,
+	IdentitySAMLSubSys,
 	HealSubSys,
 	ScannerSubSys,
 	SubnetSubSys,
@@ -20,24 +20,35 @@ package heal
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/schedule"
 	"github.com/minio/pkg/env"
 )
 
 // Compression environment variables
 const (
-	Bitrot  = "bitrotscan"
-	Sleep   = "max_sleep"
-	IOCount = "max_io"
+	Bitrot         = "bitrotscan"
+	Sleep          = "max_sleep"
+	IOCount        = "max_io"
+	Priority       = "priority"
+	ScrubInterval  = "scrub_interval"
+	ScrubWindows   = "scrub_windows"
+	ScrubBandwidth = "scrub_bandwidth"
 
-	EnvBitrot  = "MINIO_HEAL_BITROTSCAN"
-	EnvSleep   = "MINIO_HEAL_MAX_SLEEP"
-	EnvIOCount = "MINIO_HEAL_MAX_IO"
+	EnvBitrot         = "MINIO_HEAL_BITROTSCAN"
+	EnvSleep          = "MINIO_HEAL_MAX_SLEEP"
+	EnvIOCount        = "MINIO_HEAL_MAX_IO"
+	EnvPriority       = "MINIO_HEAL_PRIORITY"
+	EnvScrubInterval  = "MINIO_HEAL_SCRUB_INTERVAL"
+	EnvScrubWindows   = "MINIO_HEAL_SCRUB_WINDOWS"
+	EnvScrubBandwidth = "MINIO_HEAL_SCRUB_BANDWIDTH"
 )
 
 var configMutex sync.RWMutex
@@ -51,11 +62,91 @@ type Config struct {
 	Sleep   time.Duration `json:"sleep"`
 	IOCount int           `json:"iocount"`
 
+	// Priority of buckets and prefixes when healing drives.
+	Priority string `json:"priority"`
+
+	// Scrubbing verifies the checksums of all the shards of the local
+	// drives every ScrubInterval, within ScrubWindows and at most at
+	// ScrubBandwidth per drive.
+	ScrubInterval  string `json:"scrub_interval"`
+	ScrubWindows   string `json:"scrub_windows"`
+	ScrubBandwidth string `json:"scrub_bandwidth"`
+
 	// Cached value from Bitrot field
 	cache struct {
 		// -1: bitrot enabled, 0: bitrot disabled, > 0: bitrot cycle
 		bitrotCycle time.Duration
+
+		// parsed Priority field
+		priority PriorityRules
+
+		// parsed Scrub fields, 0 interval if disabled, 0 bandwidth if unlimited
+		scrubInterval  time.Duration
+		scrubWindows   schedule.Windows
+		scrubBandwidth uint64
+	}
+}
+
+// DefaultClass is the priority class of objects matching no rule.
+const DefaultClass = "*"
+
+// PriorityRule assigns a healing weight to a bucket, or to a prefix
+// of a bucket. Higher weights are healed first.
+type PriorityRule struct {
+	Bucket string
+	Prefix string
+	Weight int
+}
+
+// Class returns the name of the priority class of the rule.
+func (r PriorityRule) Class() string {
+	if r.Prefix == "" {
+		return r.Bucket
+	}
+	return r.Bucket + "/" + r.Prefix
+}
+
+// PriorityRules is the list of healing priority rules, by decreasing weight.
+type PriorityRules []PriorityRule
+
+// BucketWeight returns the highest weight of the rules of the bucket,
+// buckets are healed in that order.
+func (p PriorityRules) BucketWeight(bucket string) int {
+	for _, r := range p {
+		if r.Bucket == bucket {
+			return r.Weight
+		}
 	}
+	return 0
+}
+
+// Match returns the most specific rule matching the object.
+func (p PriorityRules) Match(bucket, object string) (match PriorityRule, ok bool) {
+	for _, r := range p {
+		if r.Bucket != bucket || !strings.HasPrefix(object, r.Prefix) {
+			continue
+		}
+		if !ok || len(r.Prefix) > len(match.Prefix) {
+			match, ok = r, true
+		}
+	}
+	return match, ok
+}
+
+// PriorityRules returns the configured healing priority rules.
+func (opts Config) PriorityRules() PriorityRules {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return opts.cache.priority
+}
+
+// Scrub returns the interval between scrubs of the drives, 0 if disabled,
+// the windows scrubbing runs in and the bandwidth per drive in bytes per
+// second, 0 if unlimited.
+func (opts Config) Scrub() (interval time.Duration, windows schedule.Windows, bandwidth uint64) {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return opts.cache.scrubInterval, opts.cache.scrubWindows, opts.cache.scrubBandwidth
 }
 
 // BitrotScanCycle returns the configured cycle for the scanner healing
@@ -113,8 +204,16 @@ func (opts *Config) Update(nopts Config) {
 	opts.Bitrot = nopts.Bitrot
 	opts.IOCount = nopts.IOCount
 	opts.Sleep = nopts.Sleep
+	opts.Priority = nopts.Priority
+	opts.ScrubInterval = nopts.ScrubInterval
+	opts.ScrubWindows = nopts.ScrubWindows
+	opts.ScrubBandwidth = nopts.ScrubBandwidth
 
 	opts.cache.bitrotCycle, _ = parseBitrotConfig(nopts.Bitrot)
+	opts.cache.priority, _ = parsePriority(nopts.Priority)
+	opts.cache.scrubInterval, _ = parseScrubInterval(nopts.ScrubInterval)
+	opts.cache.scrubWindows, _ = schedule.Parse(nopts.ScrubWindows)
+	opts.cache.scrubBandwidth, _ = parseScrubBandwidth(nopts.ScrubBandwidth)
 }
 
 // DefaultKVS - default KV config for heal settings
@@ -131,6 +230,22 @@ var DefaultKVS = config.KVS{
 		Key:   IOCount,
 		Value: "100",
 	},
+	config.KV{
+		Key:   Priority,
+		Value: "",
+	},
+	config.KV{
+		Key:   ScrubInterval,
+		Value: config.EnableOff,
+	},
+	config.KV{
+		Key:   ScrubWindows,
+		Value: "",
+	},
+	config.KV{
+		Key:   ScrubBandwidth,
+		Value: "",
+	},
 }
 
 const minimumBitrotCycleInMonths = 1
@@ -164,6 +279,68 @@ func parseBitrotConfig(s string) (time.Duration, error) {
 	return time.Duration(months) * 30 * 24 * time.Hour, nil
 }
 
+// parseScrubInterval parses the interval between scrubs, "off" disables
+// scrubbing.
+func parseScrubInterval(s string) (time.Duration, error) {
+	if s == "" || s == config.EnableOff {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < time.Hour {
+		return 0, errors.New("minimum scrub interval is 1h")
+	}
+	return d, nil
+}
+
+// parseScrubBandwidth parses the bandwidth per drive of scrubbing, e.g.
+// "50MiB" per second, unlimited if empty.
+func parseScrubBandwidth(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return humanize.ParseBytes(s)
+}
+
+// parsePriority parses a comma separated list of `bucket[/prefix]:weight`
+// rules, e.g. `critical:100,logs/audit/:50`.
+func parsePriority(s string) (rules PriorityRules, err error) {
+	classes := make(map[string]struct{})
+	for _, v := range strings.Split(s, config.ValueSeparator) {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		i := strings.LastIndex(v, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("missing weight in '%s'", v)
+		}
+		weight, err := strconv.Atoi(v[i+1:])
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("weight must be a positive integer in '%s'", v)
+		}
+		bucket, prefix := v[:i], ""
+		if j := strings.Index(bucket, "/"); j >= 0 {
+			bucket, prefix = bucket[:j], bucket[j+1:]
+		}
+		if bucket == "" {
+			return nil, fmt.Errorf("missing bucket in '%s'", v)
+		}
+		r := PriorityRule{Bucket: bucket, Prefix: prefix, Weight: weight}
+		if _, ok := classes[r.Class()]; ok {
+			return nil, fmt.Errorf("duplicate rule for '%s'", r.Class())
+		}
+		classes[r.Class()] = struct{}{}
+		rules = append(rules, r)
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Weight > rules[j].Weight
+	})
+	return rules, nil
+}
+
 // LookupConfig - lookup config and override with valid environment settings if any.
 func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	if err = config.CheckValidKeys(config.HealSubSys, kvs, DefaultKVS); err != nil {
@@ -182,5 +359,21 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	if err != nil {
 		return cfg, fmt.Errorf("'heal:max_io' value invalid: %w", err)
 	}
+	cfg.Priority = env.Get(EnvPriority, kvs.GetWithDefault(Priority, DefaultKVS))
+	if _, err = parsePriority(cfg.Priority); err != nil {
+		return cfg, fmt.Errorf("'heal:priority' value invalid: %w", err)
+	}
+	cfg.ScrubInterval = env.Get(EnvScrubInterval, kvs.GetWithDefault(ScrubInterval, DefaultKVS))
+	if _, err = parseScrubInterval(cfg.ScrubInterval); err != nil {
+		return cfg, fmt.Errorf("'heal:scrub_interval' value invalid: %w", err)
+	}
+	cfg.ScrubWindows = env.Get(EnvScrubWindows, kvs.GetWithDefault(ScrubWindows, DefaultKVS))
+	if _, err = schedule.Parse(cfg.ScrubWindows); err != nil {
+		return cfg, fmt.Errorf("'heal:scrub_windows' value invalid: %w", err)
+	}
+	cfg.ScrubBandwidth = env.Get(EnvScrubBandwidth, kvs.GetWithDefault(ScrubBandwidth, DefaultKVS))
+	if _, err = parseScrubBandwidth(cfg.ScrubBandwidth); err != nil {
+		return cfg, fmt.Errorf("'heal:scrub_bandwidth' value invalid: %w", err)
+	}
 	return cfg, nil
 }
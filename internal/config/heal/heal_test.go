@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package heal
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestParsePriority(t *testing.T) {
+	testCases := []struct {
+		str           string
+		expectedRules PriorityRules
+		success       bool
+	}{
+		// invalid input
+		{"critical", nil, false},
+		{"critical:0", nil, false},
+		{"critical:high", nil, false},
+		{"/logs/:10", nil, false},
+		{"critical:10,critical:20", nil, false},
+
+		// valid input
+		{"", nil, true},
+		{" , ", nil, true},
+		{"critical:10", PriorityRules{{Bucket: "critical", Weight: 10}}, true},
+		{"logs/audit/:5, critical:10, logs:1", PriorityRules{
+			{Bucket: "critical", Weight: 10},
+			{Bucket: "logs", Prefix: "audit/", Weight: 5},
+			{Bucket: "logs", Weight: 1},
+		}, true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.str, func(t *testing.T) {
+			gotRules, err := parsePriority(testCase.str)
+			if !testCase.success && err == nil {
+				t.Error("expected failure but success instead")
+			}
+			if testCase.success && err != nil {
+				t.Errorf("expected success but failed instead %s", err)
+			}
+			if testCase.success && !reflect.DeepEqual(testCase.expectedRules, gotRules) {
+				t.Errorf("expected rules %v but got %v", testCase.expectedRules, gotRules)
+			}
+		})
+	}
+}
+
+func TestPriorityRulesMatch(t *testing.T) {
+	rules, err := parsePriority("logs:50,logs/audit/:10,logs/audit/2022/:100,critical/db/:20")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		bucket, object string
+		class          string
+		weight         int
+	}{
+		{"logs", "app/1.log", "logs", 50},
+		{"logs", "audit/1.log", "logs/audit/", 10},
+		{"logs", "audit/2022/1.log", "logs/audit/2022/", 100},
+		{"critical", "db/1", "critical/db/", 20},
+		{"critical", "web/1", "", 0},
+		{"other", "db/1", "", 0},
+	}
+	for _, testCase := range testCases {
+		r, ok := rules.Match(testCase.bucket, testCase.object)
+		if ok != (testCase.class != "") || r.Class() != testCase.class || r.Weight != testCase.weight {
+			t.Errorf("%s/%s: expected class %q (%d), got %v", testCase.bucket, testCase.object, testCase.class, testCase.weight, r)
+		}
+	}
+
+	for bucket, weight := range map[string]int{"logs": 100, "critical": 20, "other": 0} {
+		if w := rules.BucketWeight(bucket); w != weight {
+			t.Errorf("%s: expected bucket weight %d, got %d", bucket, weight, w)
+		}
+	}
+}
+
+func TestLookupScrubConfig(t *testing.T) {
+	testCases := []struct {
+		interval, windows, bandwidth string
+		expectedInterval             time.Duration
+		expectedBandwidth            uint64
+		success                      bool
+	}{
+		{"off", "", "", 0, 0, true},
+		{"720h", "sat-sun", "50MiB", 720 * time.Hour, 50 << 20, true},
+		{"168h", "", "1GB", 168 * time.Hour, 1000 * 1000 * 1000, true},
+		{"30m", "", "", 0, 0, false},
+		{"weekly", "", "", 0, 0, false},
+		{"720h", "weekend", "", 0, 0, false},
+		{"720h", "", "fast", 0, 0, false},
+	}
+
+	for i, testCase := range testCases {
+		kvs := config.KVS{
+			config.KV{Key: ScrubInterval, Value: testCase.interval},
+			config.KV{Key: ScrubWindows, Value: testCase.windows},
+			config.KV{Key: ScrubBandwidth, Value: testCase.bandwidth},
+		}
+		cfg, err := LookupConfig(kvs)
+		if (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+			continue
+		}
+		if !testCase.success {
+			continue
+		}
+		var opts Config
+		opts.Update(cfg)
+		interval, windows, bandwidth := opts.Scrub()
+		if interval != testCase.expectedInterval || bandwidth != testCase.expectedBandwidth {
+			t.Errorf("Test %d: expected %s at %d, got %s at %d", i+1, testCase.expectedInterval, testCase.expectedBandwidth, interval, bandwidth)
+		}
+		if (testCase.windows == "") != (len(windows) == 0) {
+			t.Errorf("Test %d: unexpected windows %v", i+1, windows)
+		}
+	}
+}
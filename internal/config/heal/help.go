@@ -45,5 +45,29 @@ var (
 			Optional:    true,
 			Type:        "int",
 		},
+		config.HelpKV{
+			Key:         Priority,
+			Description: `comma separated list of 'bucket[/prefix]:weight' to heal first when healing drives, e.g. "critical:100,logs/audit/:50"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         ScrubInterval,
+			Description: `interval between scrubs verifying the checksums of all the shards of the drives, e.g. "720h"` + defaultHelpPostfix(ScrubInterval),
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         ScrubWindows,
+			Description: `comma separated list of windows scrubbing runs in, e.g. "mon-fri 22:00-06:00,sat-sun"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         ScrubBandwidth,
+			Description: `maximum bandwidth per drive per second used by scrubbing, e.g. "50MiB"`,
+			Optional:    true,
+			Type:        "string",
+		},
 	}
 )
@@ -20,7 +20,9 @@ package ldap
 import (
 	"crypto/x509"
 	"errors"
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/minio/madmin-go"
@@ -37,9 +39,23 @@ const (
 
 // Config contains AD/LDAP server connectivity information.
 type Config struct {
+	// LDAP is the default configuration.
 	LDAP ldap.Config
 
 	stsExpiryDuration time.Duration // contains converted value
+
+	// The default configuration followed by the named configurations,
+	// in the order users are looked up.
+	providers []*providerCfg
+}
+
+// providerCfg - an LDAP configuration, with its own directory servers
+// and search bases.
+type providerCfg struct {
+	name                  string
+	ldap                  ldap.Config
+	servers               *serverPool
+	sessionTagsAttributes []string // user attributes used as session tags
 }
 
 // Enabled returns if LDAP is enabled.
@@ -55,6 +71,7 @@ func (l *Config) Clone() Config {
 	cfg := Config{
 		LDAP:              l.LDAP.Clone(),
 		stsExpiryDuration: l.stsExpiryDuration,
+		providers:         append([]*providerCfg(nil), l.providers...),
 	}
 	return cfg
 }
@@ -72,6 +89,8 @@ const (
 	ServerInsecure     = "server_insecure"
 	ServerStartTLS     = "server_starttls"
 
+	SessionTagsAttributes = "session_tags_attributes"
+
 	EnvServerAddr         = "MINIO_IDENTITY_LDAP_SERVER_ADDR"
 	EnvTLSSkipVerify      = "MINIO_IDENTITY_LDAP_TLS_SKIP_VERIFY"
 	EnvServerInsecure     = "MINIO_IDENTITY_LDAP_SERVER_INSECURE"
@@ -83,6 +102,8 @@ const (
 	EnvGroupSearchBaseDN  = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_BASE_DN"
 	EnvLookupBindDN       = "MINIO_IDENTITY_LDAP_LOOKUP_BIND_DN"
 	EnvLookupBindPassword = "MINIO_IDENTITY_LDAP_LOOKUP_BIND_PASSWORD"
+
+	EnvSessionTagsAttributes = "MINIO_IDENTITY_LDAP_SESSION_TAGS_ATTRIBUTES"
 )
 
 var removedKeys = []string{
@@ -136,6 +157,10 @@ var (
 			Key:   LookupBindPassword,
 			Value: "",
 		},
+		config.KV{
+			Key:   SessionTagsAttributes,
+			Value: "",
+		},
 	}
 )
 
@@ -149,75 +174,174 @@ func Lookup(s config.Config, rootCAs *x509.CertPool) (l Config, err error) {
 	l = Config{}
 
 	// Purge all removed keys first
-	kvs := s[config.IdentityLDAPSubSys][config.Default]
-	if len(kvs) > 0 {
-		for _, k := range removedKeys {
-			kvs.Delete(k)
+	for cfgName, kvs := range s[config.IdentityLDAPSubSys] {
+		if len(kvs) > 0 {
+			for _, k := range removedKeys {
+				kvs.Delete(k)
+			}
+			s[config.IdentityLDAPSubSys][cfgName] = kvs
 		}
-		s[config.IdentityLDAPSubSys][config.Default] = kvs
 	}
 
 	if err := s.CheckValidKeys(config.IdentityLDAPSubSys, removedKeys); err != nil {
 		return l, err
 	}
 
+	ldapTargets, err := s.GetAvailableTargets(config.IdentityLDAPSubSys)
+	if err != nil {
+		return l, err
+	}
+
+	for _, cfgName := range ldapTargets {
+		p, err := lookupProvider(s, cfgName, rootCAs)
+		if err != nil {
+			if cfgName != config.Default {
+				return Config{}, fmt.Errorf("LDAP configuration %s: %w", cfgName, err)
+			}
+			return Config{}, err
+		}
+		if p == nil {
+			continue
+		}
+		if cfgName != config.Default && len(l.providers) == 0 {
+			return Config{}, config.Errorf("LDAP configuration %s requires the default LDAP configuration", cfgName)
+		}
+		l.providers = append(l.providers, p)
+	}
+	if len(l.providers) == 0 {
+		return l, nil
+	}
+
+	// Policies are mapped to user and group DNs, which must identify
+	// the configuration they belong to.
+	if err = checkDistinctSearchBases(l.providers); err != nil {
+		return Config{}, err
+	}
+
+	l.LDAP = l.providers[0].ldap
+	l.stsExpiryDuration = defaultLDAPExpiry
+	return l, nil
+}
+
+// lookupProvider - initializes an LDAP configuration, returns nil if it
+// is not enabled.
+func lookupProvider(s config.Config, cfgName string, rootCAs *x509.CertPool) (p *providerCfg, err error) {
 	getCfgVal := func(cfgParam string) string {
 		// As parameters are already validated, we skip checking
 		// if the config param was found.
-		val, _ := s.ResolveConfigParam(config.IdentityLDAPSubSys, config.Default, cfgParam)
+		val, _ := s.ResolveConfigParam(config.IdentityLDAPSubSys, cfgName, cfgParam)
 		return val
 	}
 
 	ldapServer := getCfgVal(ServerAddr)
 	if ldapServer == "" {
-		return l, nil
+		return nil, nil
+	}
+	addrs, err := parseServerAddrs(ldapServer)
+	if err != nil {
+		return nil, err
+	}
+
+	p = &providerCfg{
+		name:    cfgName,
+		servers: newServerPool(addrs),
 	}
-	l.LDAP = ldap.Config{
+	p.ldap = ldap.Config{
 		Enabled:    true,
 		RootCAs:    rootCAs,
 		ServerAddr: ldapServer,
 	}
-	l.stsExpiryDuration = defaultLDAPExpiry
 
 	// LDAP connection configuration
 	if v := getCfgVal(ServerInsecure); v != "" {
-		l.LDAP.ServerInsecure, err = config.ParseBool(v)
+		p.ldap.ServerInsecure, err = config.ParseBool(v)
 		if err != nil {
-			return l, err
+			return nil, err
 		}
 	}
 	if v := getCfgVal(ServerStartTLS); v != "" {
-		l.LDAP.ServerStartTLS, err = config.ParseBool(v)
+		p.ldap.ServerStartTLS, err = config.ParseBool(v)
 		if err != nil {
-			return l, err
+			return nil, err
 		}
 	}
 	if v := getCfgVal(TLSSkipVerify); v != "" {
-		l.LDAP.TLSSkipVerify, err = config.ParseBool(v)
+		p.ldap.TLSSkipVerify, err = config.ParseBool(v)
 		if err != nil {
-			return l, err
+			return nil, err
 		}
 	}
 
 	// Lookup bind user configuration
-	l.LDAP.LookupBindDN = getCfgVal(LookupBindDN)
-	l.LDAP.LookupBindPassword = getCfgVal(LookupBindPassword)
+	p.ldap.LookupBindDN = getCfgVal(LookupBindDN)
+	p.ldap.LookupBindPassword = getCfgVal(LookupBindPassword)
 
 	// User DN search configuration
-	l.LDAP.UserDNSearchFilter = getCfgVal(UserDNSearchFilter)
-	l.LDAP.UserDNSearchBaseDistName = getCfgVal(UserDNSearchBaseDN)
+	p.ldap.UserDNSearchFilter = getCfgVal(UserDNSearchFilter)
+	p.ldap.UserDNSearchBaseDistName = getCfgVal(UserDNSearchBaseDN)
 
 	// Group search params configuration
-	l.LDAP.GroupSearchFilter = getCfgVal(GroupSearchFilter)
-	l.LDAP.GroupSearchBaseDistName = getCfgVal(GroupSearchBaseDN)
+	p.ldap.GroupSearchFilter = getCfgVal(GroupSearchFilter)
+	p.ldap.GroupSearchBaseDistName = getCfgVal(GroupSearchBaseDN)
+
+	// Session tags configuration
+	if v := getCfgVal(SessionTagsAttributes); v != "" {
+		for _, attr := range strings.Split(v, ",") {
+			attr = strings.TrimSpace(attr)
+			if attr == "" {
+				return nil, config.Errorf("empty attribute name is not allowed in '%s'", v)
+			}
+			p.sessionTagsAttributes = append(p.sessionTagsAttributes, attr)
+		}
+	}
 
-	// Validate and test configuration.
-	valResult := l.LDAP.Validate()
+	// Validate and test configuration with the first reachable server,
+	// which also records the health of the servers.
+	var valResult ldap.Validation
+	for _, srv := range p.servers.servers {
+		cfg := p.ldap
+		cfg.ServerAddr = srv.addr
+		valResult = cfg.Validate()
+		srv.setOnline(valResult.Result != ldap.ConnectivityError)
+		if valResult.Result != ldap.ConnectivityError {
+			p.ldap.UserDNSearchBaseDistNames = cfg.UserDNSearchBaseDistNames
+			p.ldap.GroupSearchBaseDistNames = cfg.GroupSearchBaseDistNames
+			break
+		}
+	}
 	if !valResult.IsOk() {
-		return l, valResult
+		return nil, valResult
 	}
 
-	return l, nil
+	return p, nil
+}
+
+// checkDistinctSearchBases - verifies that the search bases of different
+// configurations do not overlap.
+func checkDistinctSearchBases(providers []*providerCfg) error {
+	overlaps := func(a, b []string) bool {
+		for _, x := range a {
+			x = strings.ToLower(x)
+			for _, y := range b {
+				y = strings.ToLower(y)
+				if x == y || strings.HasSuffix(x, ","+y) || strings.HasSuffix(y, ","+x) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for i, p := range providers {
+		for _, q := range providers[i+1:] {
+			if overlaps(p.ldap.UserDNSearchBaseDistNames, q.ldap.UserDNSearchBaseDistNames) {
+				return config.Errorf("LDAP configurations %s and %s have overlapping user DN search bases", p.name, q.name)
+			}
+			if overlaps(p.ldap.GroupSearchBaseDistNames, q.ldap.GroupSearchBaseDistNames) {
+				return config.Errorf("LDAP configurations %s and %s have overlapping group search bases", p.name, q.name)
+			}
+		}
+	}
+	return nil
 }
 
 // GetConfigList - returns a list of LDAP configurations.
@@ -227,30 +351,51 @@ func (l *Config) GetConfigList(s config.Config) ([]madmin.IDPListItem, error) {
 		return nil, err
 	}
 
-	// For now, ldapConfigs will only have a single entry for the default
-	// configuration.
-
 	var res []madmin.IDPListItem
 	for _, cfg := range ldapConfigs {
 		res = append(res, madmin.IDPListItem{
 			Type:    "ldap",
 			Name:    cfg,
-			Enabled: l.Enabled(),
+			Enabled: l.provider(cfg) != nil,
 		})
 	}
 
 	return res, nil
 }
 
+// provider - returns the LDAP configuration with the given name, nil if it
+// is not enabled.
+func (l *Config) provider(cfgName string) *providerCfg {
+	for _, p := range l.providers {
+		if p.name == cfgName {
+			return p
+		}
+	}
+	return nil
+}
+
 // ErrProviderConfigNotFound - represents a non-existing provider error.
 var ErrProviderConfigNotFound = errors.New("provider configuration not found")
 
 // GetConfigInfo - returns config details for an LDAP configuration.
 func (l *Config) GetConfigInfo(s config.Config, cfgName string) ([]madmin.IDPCfgInfo, error) {
-	// For now only a single LDAP config is supported.
-	if cfgName != madmin.Default {
+	ldapConfigs, err := s.GetAvailableTargets(config.IdentityLDAPSubSys)
+	if err != nil {
+		return nil, err
+	}
+
+	present := false
+	for _, cfg := range ldapConfigs {
+		if cfg == cfgName {
+			present = true
+			break
+		}
+	}
+
+	if !present {
 		return nil, ErrProviderConfigNotFound
 	}
+
 	kvsrcs, err := s.GetResolvedConfigParams(config.IdentityLDAPSubSys, cfgName)
 	if err != nil {
 		return nil, err
@@ -28,7 +28,7 @@ var (
 	Help = config.HelpKVS{
 		config.HelpKV{
 			Key:         ServerAddr,
-			Description: `AD/LDAP server address e.g. "myldapserver.com:636"` + defaultHelpPostfix(ServerAddr),
+			Description: `comma separated AD/LDAP server addresses, used in order when unreachable e.g. "dc1.example.com:636,dc2.example.com:636"` + defaultHelpPostfix(ServerAddr),
 			Type:        "address",
 			Sensitive:   true,
 		},
@@ -88,6 +88,12 @@ var (
 			Optional:    true,
 			Type:        "on|off",
 		},
+		config.HelpKV{
+			Key:         SessionTagsAttributes,
+			Description: `"," separated list of user attributes passed as session tags e.g. "department,costCenter"` + defaultHelpPostfix(SessionTagsAttributes),
+			Optional:    true,
+			Type:        "list",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -18,6 +18,7 @@
 package ldap
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -28,75 +29,193 @@ import (
 	"github.com/minio/minio/internal/auth"
 )
 
-// LookupUserDN searches for the full DN and groups of a given username
-func (l *Config) LookupUserDN(username string) (string, []string, error) {
-	conn, err := l.LDAP.Connect()
+// UserInfo - the LDAP identity of an authenticated user.
+type UserInfo struct {
+	DN          string
+	Groups      []string
+	SessionTags map[string]string
+}
+
+// searchUserDN - searches for the DN of the user given their username, conn
+// is assumed to be using the lookup bind service account. An empty DN is
+// returned if the user is not found in the directory.
+func (p *providerCfg) searchUserDN(conn *ldap.Conn, username string) (string, error) {
+	filter := strings.ReplaceAll(p.ldap.UserDNSearchFilter, "%s", ldap.EscapeFilter(username))
+	var foundDistNames []string
+	for _, userSearchBase := range p.ldap.UserDNSearchBaseDistNames {
+		searchRequest := ldap.NewSearchRequest(
+			userSearchBase,
+			ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+			filter,
+			[]string{}, // only need DN, so no pass no attributes here
+			nil,
+		)
+
+		searchResult, err := conn.Search(searchRequest)
+		if err != nil {
+			return "", err
+		}
+
+		for _, entry := range searchResult.Entries {
+			foundDistNames = append(foundDistNames, entry.DN)
+		}
+	}
+	if len(foundDistNames) > 1 {
+		return "", fmt.Errorf("Multiple DNs for %s found - please fix the search filter", username)
+	}
+	if len(foundDistNames) == 0 {
+		return "", nil
+	}
+	return foundDistNames[0], nil
+}
+
+// lookupUserDN - connects to the directory and searches for the DN of a
+// user, the returned connection is bound to the lookup user account. The
+// returned DN is empty if the user is not found.
+func (p *providerCfg) lookupUserDN(username string) (*ldap.Conn, string, error) {
+	conn, err := p.connect()
 	if err != nil {
-		return "", nil, err
+		return nil, "", err
 	}
-	defer conn.Close()
 
 	// Bind to the lookup user account
-	if err = l.LDAP.LookupBind(conn); err != nil {
-		return "", nil, err
+	if err = p.ldap.LookupBind(conn); err != nil {
+		conn.Close()
+		return nil, "", err
 	}
 
 	// Lookup user DN
-	bindDN, err := l.LDAP.LookupUserDN(conn, username)
+	bindDN, err := p.searchUserDN(conn, username)
 	if err != nil {
-		errRet := fmt.Errorf("Unable to find user DN: %w", err)
-		return "", nil, errRet
+		conn.Close()
+		return nil, "", fmt.Errorf("Unable to find user DN: %w", err)
 	}
+	return conn, bindDN, nil
+}
 
-	groups, err := l.LDAP.SearchForUserGroups(conn, username, bindDN)
-	if err != nil {
-		return "", nil, err
-	}
+// LookupUserDN searches for the full DN and groups of a given username, in
+// the first LDAP configuration the user is found in.
+func (l *Config) LookupUserDN(username string) (string, []string, error) {
+	var firstErr error
+	for _, p := range l.providers {
+		conn, bindDN, err := p.lookupUserDN(username)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if bindDN == "" {
+			conn.Close()
+			continue
+		}
 
-	return bindDN, groups, nil
+		groups, err := p.ldap.SearchForUserGroups(conn, username, bindDN)
+		conn.Close()
+		if err != nil {
+			return "", nil, err
+		}
+		return bindDN, groups, nil
+	}
+	if firstErr != nil {
+		return "", nil, firstErr
+	}
+	return "", nil, fmt.Errorf("Unable to find user DN: User DN for %s not found", username)
 }
 
 // Bind - binds to ldap, searches LDAP and returns the distinguished name of the
-// user and the list of groups.
-func (l *Config) Bind(username, password string) (string, []string, error) {
-	conn, err := l.LDAP.Connect()
-	if err != nil {
-		return "", nil, err
+// user, the list of groups and the session tags taken from the user attributes.
+// The LDAP configurations are tried in order, the user is authenticated by the
+// first one where the user is found and the password is valid.
+func (l *Config) Bind(username, password string) (UserInfo, error) {
+	var firstErr error
+	for _, p := range l.providers {
+		u, err := p.bind(username, password)
+		if err == nil {
+			return u, nil
+		}
+		if firstErr == nil || errors.Is(firstErr, errUserNotFound) {
+			firstErr = err
+		}
 	}
-	defer conn.Close()
-
-	var bindDN string
-	// Bind to the lookup user account
-	if err = l.LDAP.LookupBind(conn); err != nil {
-		return "", nil, err
+	if errors.Is(firstErr, errUserNotFound) {
+		return UserInfo{}, fmt.Errorf("Unable to find user DN: User DN for %s not found", username)
 	}
+	return UserInfo{}, firstErr
+}
 
-	// Lookup user DN
-	bindDN, err = l.LDAP.LookupUserDN(conn, username)
+var errUserNotFound = errors.New("LDAP user not found")
+
+func (p *providerCfg) bind(username, password string) (UserInfo, error) {
+	conn, bindDN, err := p.lookupUserDN(username)
 	if err != nil {
-		errRet := fmt.Errorf("Unable to find user DN: %w", err)
-		return "", nil, errRet
+		return UserInfo{}, err
+	}
+	defer conn.Close()
+	if bindDN == "" {
+		return UserInfo{}, errUserNotFound
 	}
 
 	// Authenticate the user credentials.
 	err = conn.Bind(bindDN, password)
 	if err != nil {
 		errRet := fmt.Errorf("LDAP auth failed for DN %s: %w", bindDN, err)
-		return "", nil, errRet
+		return UserInfo{}, errRet
 	}
 
 	// Bind to the lookup user account again to perform group search.
-	if err = l.LDAP.LookupBind(conn); err != nil {
-		return "", nil, err
+	if err = p.ldap.LookupBind(conn); err != nil {
+		return UserInfo{}, err
 	}
 
 	// User groups lookup.
-	groups, err := l.LDAP.SearchForUserGroups(conn, username, bindDN)
+	groups, err := p.ldap.SearchForUserGroups(conn, username, bindDN)
+	if err != nil {
+		return UserInfo{}, err
+	}
+
+	// Lookup the user attributes passed as session tags.
+	tags, err := p.lookupSessionTags(conn, bindDN)
+	if err != nil {
+		return UserInfo{}, err
+	}
+
+	return UserInfo{
+		DN:          bindDN,
+		Groups:      groups,
+		SessionTags: tags,
+	}, nil
+}
+
+// lookupSessionTags - returns the values of the configured session tag
+// attributes of the user, only the first value of multi-valued attributes
+// is used.
+func (p *providerCfg) lookupSessionTags(conn *ldap.Conn, userDN string) (map[string]string, error) {
+	if len(p.sessionTagsAttributes) == 0 {
+		return nil, nil
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		userDN,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)",
+		p.sessionTagsAttributes,
+		nil,
+	)
+	searchResult, err := conn.Search(searchRequest)
 	if err != nil {
-		return "", nil, err
+		return nil, fmt.Errorf("Unable to lookup attributes of %s: %w", userDN, err)
 	}
 
-	return bindDN, groups, nil
+	tags := make(map[string]string, len(p.sessionTagsAttributes))
+	for _, entry := range searchResult.Entries {
+		for _, attr := range p.sessionTagsAttributes {
+			if values := entry.GetEqualFoldAttributeValues(attr); len(values) > 0 {
+				tags[attr] = values[0]
+			}
+		}
+	}
+	return tags, nil
 }
 
 // GetExpiryDuration - return parsed expiry duration.
@@ -120,40 +239,82 @@ func (l Config) GetExpiryDuration(dsecs string) (time.Duration, error) {
 
 // IsLDAPUserDN determines if the given string could be a user DN from LDAP.
 func (l Config) IsLDAPUserDN(user string) bool {
-	for _, baseDN := range l.LDAP.UserDNSearchBaseDistNames {
-		if strings.HasSuffix(user, ","+baseDN) {
-			return true
+	return l.userProvider(user) != nil
+}
+
+// userProvider - returns the LDAP configuration a user DN belongs to.
+func (l Config) userProvider(user string) *providerCfg {
+	for _, p := range l.providers {
+		for _, baseDN := range p.ldap.UserDNSearchBaseDistNames {
+			if strings.HasSuffix(user, ","+baseDN) {
+				return p
+			}
 		}
 	}
-	return false
+	return nil
 }
 
 // IsLDAPGroupDN determines if the given string could be a group DN from LDAP.
 func (l Config) IsLDAPGroupDN(user string) bool {
-	for _, baseDN := range l.LDAP.GroupSearchBaseDistNames {
-		if strings.HasSuffix(user, ","+baseDN) {
-			return true
+	for _, p := range l.providers {
+		for _, baseDN := range p.ldap.GroupSearchBaseDistNames {
+			if strings.HasSuffix(user, ","+baseDN) {
+				return true
+			}
 		}
 	}
 	return false
 }
 
+// usersByProvider - groups user DNs by the LDAP configuration they belong to,
+// the DNs under the base DNs of no configuration are returned separately.
+func (l Config) usersByProvider(userDistNames []string) (res map[*providerCfg][]string, orphans []string) {
+	res = make(map[*providerCfg][]string)
+	for _, dn := range userDistNames {
+		if p := l.userProvider(dn); p != nil {
+			res[p] = append(res[p], dn)
+		} else {
+			orphans = append(orphans, dn)
+		}
+	}
+	return res, orphans
+}
+
 // GetNonEligibleUserDistNames - find user accounts (DNs) that are no longer
-// present in the LDAP server or do not meet filter criteria anymore
+// present in the LDAP server, do not meet filter criteria anymore or are no
+// longer under the base DNs of any LDAP configuration. The accounts found in
+// the reachable directories are returned along with the first error.
 func (l *Config) GetNonEligibleUserDistNames(userDistNames []string) ([]string, error) {
-	conn, err := l.LDAP.Connect()
+	byProvider, orphans := l.usersByProvider(userDistNames)
+	nonExistentUsers := append([]string{}, orphans...)
+	var firstErr error
+	for p, dns := range byProvider {
+		users, err := p.getNonEligibleUserDistNames(dns)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		nonExistentUsers = append(nonExistentUsers, users...)
+	}
+	return nonExistentUsers, firstErr
+}
+
+func (p *providerCfg) getNonEligibleUserDistNames(userDistNames []string) ([]string, error) {
+	conn, err := p.connect()
 	if err != nil {
 		return nil, err
 	}
 	defer conn.Close()
 
 	// Bind to the lookup user account
-	if err = l.LDAP.LookupBind(conn); err != nil {
+	if err = p.ldap.LookupBind(conn); err != nil {
 		return nil, err
 	}
 
 	// Evaluate the filter again with generic wildcard instead of  specific values
-	filter := strings.ReplaceAll(l.LDAP.UserDNSearchFilter, "%s", "*")
+	filter := strings.ReplaceAll(p.ldap.UserDNSearchFilter, "%s", "*")
 
 	nonExistentUsers := []string{}
 	for _, dn := range userDistNames {
@@ -184,28 +345,40 @@ func (l *Config) GetNonEligibleUserDistNames(userDistNames []string) ([]string,
 }
 
 // LookupGroupMemberships - for each DN finds the set of LDAP groups they are a
-// member of.
+// member of. The memberships found in the reachable directories are returned
+// along with the first error.
 func (l *Config) LookupGroupMemberships(userDistNames []string, userDNToUsernameMap map[string]string) (map[string]set.StringSet, error) {
-	conn, err := l.LDAP.Connect()
+	res := make(map[string]set.StringSet, len(userDistNames))
+	var firstErr error
+	byProvider, _ := l.usersByProvider(userDistNames)
+	for p, dns := range byProvider {
+		if err := p.lookupGroupMemberships(dns, userDNToUsernameMap, res); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return res, firstErr
+}
+
+func (p *providerCfg) lookupGroupMemberships(userDistNames []string, userDNToUsernameMap map[string]string, res map[string]set.StringSet) error {
+	conn, err := p.connect()
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer conn.Close()
 
 	// Bind to the lookup user account
-	if err = l.LDAP.LookupBind(conn); err != nil {
-		return nil, err
+	if err = p.ldap.LookupBind(conn); err != nil {
+		return err
 	}
 
-	res := make(map[string]set.StringSet, len(userDistNames))
 	for _, userDistName := range userDistNames {
 		username := userDNToUsernameMap[userDistName]
-		groups, err := l.LDAP.SearchForUserGroups(conn, username, userDistName)
+		groups, err := p.ldap.SearchForUserGroups(conn, username, userDistName)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		res[userDistName] = set.CreateStringSet(groups...)
 	}
 
-	return res, nil
+	return nil
 }
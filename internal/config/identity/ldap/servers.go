@@ -0,0 +1,172 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	ldap "github.com/go-ldap/ldap/v3"
+	"github.com/minio/minio/internal/config"
+	xldap "github.com/minio/pkg/ldap"
+)
+
+// Interval between health checks of the offline LDAP servers.
+const serverHealthCheckInterval = 30 * time.Second
+
+// ldapServer - an LDAP server of a configuration and its health.
+type ldapServer struct {
+	addr    string
+	offline int32 // set atomically when the last connection failed
+}
+
+func (s *ldapServer) isOnline() bool {
+	return atomic.LoadInt32(&s.offline) == 0
+}
+
+func (s *ldapServer) setOnline(online bool) {
+	if online {
+		atomic.StoreInt32(&s.offline, 0)
+	} else {
+		atomic.StoreInt32(&s.offline, 1)
+	}
+}
+
+// connect - connects to the server with the given configuration and
+// records the health of the server.
+func (s *ldapServer) connect(cfg xldap.Config) (*ldap.Conn, error) {
+	cfg.ServerAddr = s.addr
+	conn, err := cfg.Connect()
+	if err != nil {
+		if conn != nil {
+			conn.Close()
+		}
+		s.setOnline(false)
+		return nil, fmt.Errorf("%s: %w", s.addr, err)
+	}
+	s.setOnline(true)
+	return conn, nil
+}
+
+// serverPool - the LDAP servers of a configuration, in order of
+// preference. The pool is shared by the clones of a configuration.
+type serverPool struct {
+	servers []*ldapServer
+}
+
+// parseServerAddrs - parses the comma separated list of LDAP servers.
+func parseServerAddrs(v string) ([]string, error) {
+	var addrs []string
+	for _, addr := range strings.Split(v, config.ValueSeparator) {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			return nil, config.Errorf("empty server address is not allowed in '%s'", v)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+func newServerPool(addrs []string) *serverPool {
+	p := &serverPool{}
+	for _, addr := range addrs {
+		p.servers = append(p.servers, &ldapServer{addr: addr})
+	}
+	return p
+}
+
+// connect - connects to the first online server, in order of preference.
+// The offline servers are tried last, in case they have recovered since
+// they failed.
+func (p *serverPool) connect(cfg xldap.Config) (conn *ldap.Conn, err error) {
+	if p == nil || len(p.servers) == 0 {
+		return nil, errors.New("LDAP is not configured")
+	}
+
+	var errs []string
+	for _, online := range []bool{true, false} {
+		for _, s := range p.servers {
+			if s.isOnline() != online {
+				continue
+			}
+			conn, err = s.connect(cfg)
+			if err == nil {
+				return conn, nil
+			}
+			errs = append(errs, err.Error())
+		}
+	}
+	return nil, fmt.Errorf("Unable to connect to any LDAP server: %s", strings.Join(errs, "; "))
+}
+
+// checkOffline - tries to connect to the offline servers, so that they are
+// preferred again once they recover.
+func (p *serverPool) checkOffline(cfg xldap.Config) {
+	for _, s := range p.servers {
+		if s.isOnline() {
+			continue
+		}
+		if conn, err := s.connect(cfg); err == nil {
+			conn.Close()
+		}
+	}
+}
+
+// connect - connects to a server of the configuration.
+func (p *providerCfg) connect() (*ldap.Conn, error) {
+	conn, err := p.servers.connect(p.ldap)
+	if err != nil && p.name != config.Default {
+		return nil, fmt.Errorf("LDAP configuration %s: %w", p.name, err)
+	}
+	return conn, err
+}
+
+// MonitorServers - periodically checks the health of the offline LDAP
+// servers of all configurations until the context is canceled.
+func (l *Config) MonitorServers(ctx context.Context) {
+	ticker := time.NewTicker(serverHealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, p := range l.providers {
+				p.servers.checkOffline(p.ldap)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// CheckConnectivity - verifies that a server of each LDAP configuration
+// is reachable.
+func (l *Config) CheckConnectivity() error {
+	for _, p := range l.providers {
+		conn, err := p.connect()
+		if err != nil {
+			return err
+		}
+		conn.Close()
+	}
+	return nil
+}
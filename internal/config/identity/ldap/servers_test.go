@@ -0,0 +1,169 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"net"
+	"testing"
+
+	xldap "github.com/minio/pkg/ldap"
+)
+
+func TestParseServerAddrs(t *testing.T) {
+	testCases := []struct {
+		value   string
+		addrs   []string
+		success bool
+	}{
+		{"ldap.example.com:636", []string{"ldap.example.com:636"}, true},
+		{"dc1.example.com:636, dc2.example.com", []string{"dc1.example.com:636", "dc2.example.com"}, true},
+		{"dc1.example.com:636,", nil, false},
+	}
+	for i, testCase := range testCases {
+		addrs, err := parseServerAddrs(testCase.value)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, err)
+		}
+		if len(addrs) != len(testCase.addrs) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.addrs, addrs)
+		}
+		for j := range addrs {
+			if addrs[j] != testCase.addrs[j] {
+				t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.addrs, addrs)
+			}
+		}
+	}
+}
+
+// listen - returns the address of a TCP listener accepting connections and
+// of a closed one.
+func listen(t *testing.T) (online, offline string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed.Close()
+	return l.Addr().String(), closed.Addr().String()
+}
+
+func TestServerPoolFailover(t *testing.T) {
+	online, offline := listen(t)
+	cfg := xldap.Config{Enabled: true, ServerInsecure: true}
+
+	p := newServerPool([]string{offline, online})
+	conn, err := p.connect(cfg)
+	if err != nil {
+		t.Fatalf("Expected failover to %s, got %v", online, err)
+	}
+	conn.Close()
+	if p.servers[0].isOnline() || !p.servers[1].isOnline() {
+		t.Fatalf("Unexpected server health: %v, %v", p.servers[0].isOnline(), p.servers[1].isOnline())
+	}
+
+	// The failed server recovers.
+	p.servers[0].addr = online
+	p.checkOffline(cfg)
+	if !p.servers[0].isOnline() {
+		t.Fatal("Expected the recovered server to be online")
+	}
+
+	p = newServerPool([]string{offline})
+	if _, err = p.connect(cfg); err == nil {
+		t.Fatal("Expected an error when no server is reachable")
+	}
+}
+
+func TestCheckDistinctSearchBases(t *testing.T) {
+	provider := func(name string, userBases, groupBases []string) *providerCfg {
+		p := &providerCfg{name: name}
+		p.ldap.UserDNSearchBaseDistNames = userBases
+		p.ldap.GroupSearchBaseDistNames = groupBases
+		return p
+	}
+
+	testCases := []struct {
+		providers []*providerCfg
+		success   bool
+	}{
+		{
+			[]*providerCfg{
+				provider("_", []string{"ou=people,dc=corp,dc=example,dc=com"}, []string{"ou=groups,dc=corp,dc=example,dc=com"}),
+				provider("emea", []string{"ou=people,dc=emea,dc=example,dc=com"}, []string{"ou=groups,dc=emea,dc=example,dc=com"}),
+			},
+			true,
+		},
+		{
+			[]*providerCfg{
+				provider("_", []string{"dc=example,dc=com"}, nil),
+				provider("emea", []string{"ou=people,dc=emea,dc=example,dc=com"}, nil),
+			},
+			false,
+		},
+		{
+			[]*providerCfg{
+				provider("_", []string{"ou=people,dc=corp,dc=example,dc=com"}, []string{"OU=Groups,DC=example,DC=com"}),
+				provider("emea", []string{"ou=people,dc=emea,dc=example,dc=com"}, []string{"ou=groups,dc=example,dc=com"}),
+			},
+			false,
+		},
+	}
+	for i, testCase := range testCases {
+		err := checkDistinctSearchBases(testCase.providers)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, err)
+		}
+	}
+}
+
+func TestNonEligibleOrphanUsers(t *testing.T) {
+	p := &providerCfg{name: "emea"}
+	p.ldap.UserDNSearchBaseDistNames = []string{"ou=people,dc=emea,dc=example,dc=com"}
+	l := Config{providers: []*providerCfg{p}}
+
+	inside := "uid=alice,ou=people,dc=emea,dc=example,dc=com"
+	outside := "uid=bob,ou=people,dc=corp,dc=example,dc=com"
+	byProvider, orphans := l.usersByProvider([]string{inside, outside})
+	if len(byProvider) != 1 || len(byProvider[p]) != 1 || byProvider[p][0] != inside {
+		t.Fatalf("Unexpected users by configuration %v", byProvider)
+	}
+	if len(orphans) != 1 || orphans[0] != outside {
+		t.Fatalf("Unexpected users outside of the configurations %v", orphans)
+	}
+
+	// Users outside of the base DNs of every configuration are not
+	// eligible anymore, no directory needs to be reached.
+	users, err := l.GetNonEligibleUserDistNames([]string{outside})
+	if err != nil || len(users) != 1 || users[0] != outside {
+		t.Fatalf("Expected %s to be non eligible, got %v, %v", outside, users, err)
+	}
+}
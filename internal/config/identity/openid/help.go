@@ -67,6 +67,12 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         SessionTagsClaim,
+			Description: `JWT claim carrying session tags in the form {"principal_tags": {"key": ["value"]}} e.g. "https://aws.amazon.com/tags", non-empty "transitive_tag_keys" are rejected` + defaultHelpPostfix(SessionTagsClaim),
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         Scopes,
 			Description: `Comma separated list of OpenID scopes for server, defaults to advertised scopes from discovery document e.g. "email,admin"` + defaultHelpPostfix(Scopes),
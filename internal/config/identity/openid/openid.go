@@ -50,6 +50,8 @@ const (
 	RolePolicy    = "role_policy"
 	DisplayName   = "display_name"
 
+	SessionTagsClaim = "session_tags_claim"
+
 	Scopes             = "scopes"
 	RedirectURI        = "redirect_uri"
 	RedirectURIDynamic = "redirect_uri_dynamic"
@@ -103,6 +105,10 @@ var (
 			Key:   ClaimPrefix,
 			Value: "",
 		},
+		config.KV{
+			Key:   SessionTagsClaim,
+			Value: "",
+		},
 		config.KV{
 			Key:   RedirectURI,
 			Value: "",
@@ -559,6 +565,16 @@ func (r *Config) GetIAMPolicyClaimName() string {
 	return pCfg.ClaimPrefix + pCfg.ClaimName
 }
 
+// GetSessionTagsClaim - returns the name of the claim carrying session tags
+// for the provider of the given role ARN, empty if none is configured.
+func (r *Config) GetSessionTagsClaim(roleArn arn.ARN) string {
+	pCfg, ok := r.arnProviderCfgsMap[roleArn]
+	if !ok {
+		return ""
+	}
+	return pCfg.SessionTagsClaim
+}
+
 // LookupUser lookup userid for the provider
 func (r Config) LookupUser(roleArn, userid string) (provider.User, error) {
 	// Can safely ignore error here as empty or invalid ARNs will not be
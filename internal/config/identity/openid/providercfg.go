@@ -47,6 +47,7 @@ type providerCfg struct {
 	ClientID           string
 	ClientSecret       string
 	RolePolicy         string
+	SessionTagsClaim   string
 
 	roleArn  arn.ARN
 	provider provider.Provider
@@ -63,6 +64,7 @@ func newProviderCfgFromConfig(getCfgVal func(cfgName string) string) providerCfg
 		ClientID:           getCfgVal(ClientID),
 		ClientSecret:       getCfgVal(ClientSecret),
 		RolePolicy:         getCfgVal(RolePolicy),
+		SessionTagsClaim:   getCfgVal(SessionTagsClaim),
 	}
 }
 
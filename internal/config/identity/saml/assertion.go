@@ -0,0 +1,345 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package saml
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+)
+
+// SAML 2.0 namespaces and identifiers.
+// https://docs.oasis-open.org/security/saml/v2.0/saml-core-2.0-os.pdf
+const (
+	protocolNamespace  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	assertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+
+	statusSuccess = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	bearerMethod  = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+)
+
+// Tolerated clock difference with the IdP, as AWS STS does.
+const maxClockSkew = 5 * time.Minute
+
+var (
+	// ErrAssertionExpired is returned when the SAML assertion or the
+	// session it grants has expired.
+	ErrAssertionExpired = errors.New("SAML assertion has expired")
+
+	// ErrNotConfigured is returned when no SAML IdP is configured.
+	ErrNotConfigured = errors.New("SAML identity provider is not configured")
+
+	// ErrAssertionReplayed is returned when the SAML assertion was
+	// already used while it is still valid.
+	ErrAssertionReplayed = errors.New("SAML assertion has already been used")
+)
+
+// Assertion - the validated contents of a SAML assertion.
+type Assertion struct {
+	Issuer       string
+	NameID       string
+	NameIDFormat string
+
+	// Attributes of the AttributeStatements, by name.
+	Attributes map[string][]string
+
+	// SessionNotOnOrAfter is the end of the session at the IdP, the
+	// zero time if it is not set.
+	SessionNotOnOrAfter time.Time
+}
+
+// ValidateResponse - validates a base64 encoded SAML response as posted by
+// the IdP and returns its assertion. The response or the assertion must be
+// signed by the IdP, the assertion must be valid now, be restricted to the
+// configured audience and confirm its subject as a bearer. An assertion
+// is accepted only once on this server.
+func (c Config) ValidateResponse(samlResponse string) (Assertion, error) {
+	if !c.Enabled {
+		return Assertion{}, ErrNotConfigured
+	}
+
+	data, err := decodeBase64(samlResponse)
+	if err != nil {
+		return Assertion{}, fmt.Errorf("SAML response is not base64 encoded: %w", err)
+	}
+	root, err := parseDocument(data)
+	if err != nil {
+		return Assertion{}, fmt.Errorf("SAML response is not valid XML: %w", err)
+	}
+	return c.validateResponse(root, time.Now().UTC())
+}
+
+func (c Config) validateResponse(resp *etree.Element, now time.Time) (a Assertion, err error) {
+	if !is(resp, protocolNamespace, "Response") {
+		return a, errors.New("SAML response must be a <samlp:Response>")
+	}
+	if v := attr(resp, "Version"); v != "2.0" {
+		return a, fmt.Errorf("SAML response version '%s' is not supported", v)
+	}
+
+	// Signatures reference elements by ID, which must be unique so that
+	// the verified elements are the ones used below.
+	ids := make(map[string]struct{})
+	if err = walk(resp, func(e *etree.Element) error {
+		id := attr(e, "ID")
+		if id == "" {
+			return nil
+		}
+		if _, ok := ids[id]; ok {
+			return fmt.Errorf("SAML response has duplicate ID '%s'", id)
+		}
+		ids[id] = struct{}{}
+		return nil
+	}); err != nil {
+		return a, err
+	}
+
+	status, err := child(resp, protocolNamespace, "Status")
+	if err != nil {
+		return a, err
+	}
+	statusCode, err := child(status, protocolNamespace, "StatusCode")
+	if err != nil {
+		return a, err
+	}
+	if v := attr(statusCode, "Value"); v != statusSuccess {
+		return a, fmt.Errorf("SAML response status is '%s'", v)
+	}
+
+	if err = c.checkIssuer(resp, false); err != nil {
+		return a, err
+	}
+
+	// Only the verified copies of the signed elements are used below.
+	respSigned := true
+	verified, err := verifySignature(resp, c.certs, now)
+	switch err {
+	case nil:
+		resp = verified
+	case errNotSigned:
+		respSigned = false
+	default:
+		return a, err
+	}
+
+	if len(childrenNamed(resp, assertionNamespace, "EncryptedAssertion")) > 0 {
+		return a, errors.New("Encrypted SAML assertions are not supported")
+	}
+	assertion, err := child(resp, assertionNamespace, "Assertion")
+	if err != nil {
+		return a, err
+	}
+	verified, err = verifySignature(assertion, c.certs, now)
+	switch err {
+	case nil:
+		assertion = verified
+	case errNotSigned:
+		if !respSigned {
+			return a, errors.New("Neither the SAML response nor its assertion are signed")
+		}
+	default:
+		return a, err
+	}
+
+	id := attr(assertion, "ID")
+	if id == "" {
+		return a, errors.New("SAML assertion has no ID")
+	}
+	if v := attr(assertion, "Version"); v != "2.0" {
+		return a, fmt.Errorf("SAML assertion version '%s' is not supported", v)
+	}
+	if err = c.checkIssuer(assertion, true); err != nil {
+		return a, err
+	}
+	a.Issuer = c.entityID
+
+	expiry, err := c.checkConditions(assertion, now)
+	if err != nil {
+		return a, err
+	}
+
+	confirmationExpiry, err := checkSubject(assertion, now, &a)
+	if err != nil {
+		return a, err
+	}
+	if expiry.IsZero() || confirmationExpiry.Before(expiry) {
+		expiry = confirmationExpiry
+	}
+
+	for _, stmt := range childrenNamed(assertion, assertionNamespace, "AuthnStatement") {
+		v := attr(stmt, "SessionNotOnOrAfter")
+		if v == "" {
+			continue
+		}
+		t, err := parseTime(v)
+		if err != nil {
+			return a, err
+		}
+		if !now.Add(-maxClockSkew).Before(t) {
+			return a, ErrAssertionExpired
+		}
+		if a.SessionNotOnOrAfter.IsZero() || t.Before(a.SessionNotOnOrAfter) {
+			a.SessionNotOnOrAfter = t
+		}
+	}
+
+	a.Attributes = make(map[string][]string)
+	for _, stmt := range childrenNamed(assertion, assertionNamespace, "AttributeStatement") {
+		for _, at := range childrenNamed(stmt, assertionNamespace, "Attribute") {
+			name := attr(at, "Name")
+			for _, v := range childrenNamed(at, assertionNamespace, "AttributeValue") {
+				a.Attributes[name] = append(a.Attributes[name], strings.TrimSpace(text(v)))
+			}
+		}
+	}
+
+	// The assertion is remembered while it is valid, with the tolerated
+	// clock skew.
+	if !usedAssertions.use(a.Issuer, id, expiry.Add(maxClockSkew), now) {
+		return a, ErrAssertionReplayed
+	}
+	return a, nil
+}
+
+// checkIssuer - verifies that the issuer of a response or assertion is the
+// configured IdP, the issuer of responses is optional.
+func (c Config) checkIssuer(e *etree.Element, required bool) error {
+	issuer, err := optionalChild(e, assertionNamespace, "Issuer")
+	if err != nil {
+		return err
+	}
+	if issuer == nil {
+		if required {
+			return fmt.Errorf("<Issuer> is missing in <%s>", e.Tag)
+		}
+		return nil
+	}
+	if v := strings.TrimSpace(text(issuer)); v != c.entityID {
+		return fmt.Errorf("SAML issuer '%s' is not the configured identity provider", v)
+	}
+	return nil
+}
+
+// checkConditions - verifies the validity period and the audience
+// restrictions of an assertion and returns the end of its validity, the
+// zero time if it is not set.
+func (c Config) checkConditions(assertion *etree.Element, now time.Time) (time.Time, error) {
+	conds, err := child(assertion, assertionNamespace, "Conditions")
+	if err != nil {
+		return time.Time{}, err
+	}
+	notOnOrAfter, err := checkValidity(conds, now)
+	if err != nil {
+		return notOnOrAfter, err
+	}
+
+	// All audience restrictions must be satisfied and the assertion must
+	// be restricted to us.
+	restrictions := childrenNamed(conds, assertionNamespace, "AudienceRestriction")
+	if len(restrictions) == 0 {
+		return notOnOrAfter, errors.New("SAML assertion has no audience restriction")
+	}
+	for _, r := range restrictions {
+		var found bool
+		for _, aud := range childrenNamed(r, assertionNamespace, "Audience") {
+			if strings.TrimSpace(text(aud)) == c.audience {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return notOnOrAfter, fmt.Errorf("SAML assertion is not restricted to the audience '%s'", c.audience)
+		}
+	}
+	return notOnOrAfter, nil
+}
+
+// checkSubject - verifies that an assertion confirms its subject as a bearer
+// and sets the name ID of the subject. It returns the end of the validity
+// of the bearer confirmation.
+func checkSubject(assertion *etree.Element, now time.Time, a *Assertion) (time.Time, error) {
+	subject, err := child(assertion, assertionNamespace, "Subject")
+	if err != nil {
+		return time.Time{}, err
+	}
+	nameID, err := child(subject, assertionNamespace, "NameID")
+	if err != nil {
+		return time.Time{}, err
+	}
+	a.NameID = strings.TrimSpace(text(nameID))
+	a.NameIDFormat = attr(nameID, "Format")
+	if a.NameID == "" {
+		return time.Time{}, errors.New("SAML assertion has an empty <NameID>")
+	}
+
+	err = errors.New("SAML assertion has no bearer subject confirmation")
+	for _, sc := range childrenNamed(subject, assertionNamespace, "SubjectConfirmation") {
+		if attr(sc, "Method") != bearerMethod {
+			continue
+		}
+		data, cerr := child(sc, assertionNamespace, "SubjectConfirmationData")
+		if cerr != nil {
+			err = cerr
+			continue
+		}
+		// Bearer confirmations must be limited in time.
+		if attr(data, "NotOnOrAfter") == "" {
+			err = errors.New("SAML bearer subject confirmation has no NotOnOrAfter")
+			continue
+		}
+		var notOnOrAfter time.Time
+		if notOnOrAfter, err = checkValidity(data, now); err == nil {
+			return notOnOrAfter, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// checkValidity - verifies the NotBefore and NotOnOrAfter attributes of an
+// element, if present, and returns the NotOnOrAfter time.
+func checkValidity(e *etree.Element, now time.Time) (notOnOrAfter time.Time, err error) {
+	if v := attr(e, "NotBefore"); v != "" {
+		t, err := parseTime(v)
+		if err != nil {
+			return notOnOrAfter, err
+		}
+		if now.Add(maxClockSkew).Before(t) {
+			return notOnOrAfter, fmt.Errorf("SAML <%s> is not yet valid", e.Tag)
+		}
+	}
+	if v := attr(e, "NotOnOrAfter"); v != "" {
+		if notOnOrAfter, err = parseTime(v); err != nil {
+			return notOnOrAfter, err
+		}
+		if !now.Add(-maxClockSkew).Before(notOnOrAfter) {
+			return notOnOrAfter, ErrAssertionExpired
+		}
+	}
+	return notOnOrAfter, nil
+}
+
+func parseTime(v string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return t, fmt.Errorf("Invalid SAML timestamp '%s'", v)
+	}
+	return t, nil
+}
@@ -0,0 +1,347 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package saml
+
+import (
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/arn"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+	xnet "github.com/minio/pkg/net"
+)
+
+// SAML Identity Provider config and env variables
+const (
+	MetadataURL     = "metadata_url"
+	EntityID        = "entity_id"
+	Certificates    = "certificates"
+	Audience        = "audience"
+	RolePolicy      = "role_policy"
+	RoleID          = "role_id"
+	RoleAttribute   = "role_attribute"
+	PolicyAttribute = "policy_attribute"
+
+	EnvIdentitySAMLMetadataURL     = "MINIO_IDENTITY_SAML_METADATA_URL"
+	EnvIdentitySAMLEntityID        = "MINIO_IDENTITY_SAML_ENTITY_ID"
+	EnvIdentitySAMLCertificates    = "MINIO_IDENTITY_SAML_CERTIFICATES"
+	EnvIdentitySAMLAudience        = "MINIO_IDENTITY_SAML_AUDIENCE"
+	EnvIdentitySAMLRolePolicy      = "MINIO_IDENTITY_SAML_ROLE_POLICY"
+	EnvIdentitySAMLRoleID          = "MINIO_IDENTITY_SAML_ROLE_ID"
+	EnvIdentitySAMLRoleAttribute   = "MINIO_IDENTITY_SAML_ROLE_ATTRIBUTE"
+	EnvIdentitySAMLPolicyAttribute = "MINIO_IDENTITY_SAML_POLICY_ATTRIBUTE"
+)
+
+const (
+	// Attribute listing the roles a user may assume, as used by AWS.
+	defaultRoleAttribute = "https://aws.amazon.com/SAML/Attributes/Role"
+
+	// Attribute listing the policies of a user, when no role policy
+	// is configured.
+	defaultPolicyAttribute = "policy"
+)
+
+var (
+	// DefaultKVS - default config for SAML identity provider config
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   MetadataURL,
+			Value: "",
+		},
+		config.KV{
+			Key:   EntityID,
+			Value: "",
+		},
+		config.KV{
+			Key:   Certificates,
+			Value: "",
+		},
+		config.KV{
+			Key:   Audience,
+			Value: "",
+		},
+		config.KV{
+			Key:   RolePolicy,
+			Value: "",
+		},
+		config.KV{
+			Key:   RoleID,
+			Value: "",
+		},
+		config.KV{
+			Key:   RoleAttribute,
+			Value: defaultRoleAttribute,
+		},
+		config.KV{
+			Key:   PolicyAttribute,
+			Value: defaultPolicyAttribute,
+		},
+	}
+
+	defaultHelpPostfix = func(key string) string {
+		return config.DefaultHelpPostfix(DefaultKVS, key)
+	}
+
+	// Help for SAML identity provider
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         MetadataURL,
+			Description: `IdP metadata URL e.g. "https://adfs.example.com/FederationMetadata/2007-06/FederationMetadata.xml"` + defaultHelpPostfix(MetadataURL),
+			Optional:    true,
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         EntityID,
+			Description: "IdP entity ID, the issuer of assertions, overrides the one from metadata" + defaultHelpPostfix(EntityID),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Certificates,
+			Description: "comma separated paths of PEM encoded IdP signing certificates, in addition to the ones from metadata" + defaultHelpPostfix(Certificates),
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         Audience,
+			Description: `audience the assertions must be restricted to e.g. "urn:amazon:webservices"` + defaultHelpPostfix(Audience),
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         RolePolicy,
+			Description: "policies to apply for SAML authorized users, uses the policy attribute if not set" + defaultHelpPostfix(RolePolicy),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         RoleID,
+			Description: "unique ID to generate the ARN" + defaultHelpPostfix(RoleID),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         RoleAttribute,
+			Description: "attribute listing the role ARNs the user may assume, if present" + defaultHelpPostfix(RoleAttribute),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         PolicyAttribute,
+			Description: "attribute listing the policies of the user, when no role policy is set" + defaultHelpPostfix(PolicyAttribute),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+)
+
+// Allows only Base64 URL encoding characters.
+var validRoleIDRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Config - SAML identity provider configuration.
+type Config struct {
+	Enabled bool
+
+	entityID        string
+	certs           []*x509.Certificate
+	audience        string
+	roleAttribute   string
+	policyAttribute string
+
+	RolePolicy string
+	RoleARN    arn.ARN
+}
+
+// Enabled returns if a SAML identity provider is configured.
+func Enabled(kvs config.KVS) bool {
+	return kvs.Get(MetadataURL) != "" || kvs.Get(EntityID) != ""
+}
+
+// LookupConfig lookup SAML identity provider from config, override with any ENVs.
+func LookupConfig(kvs config.KVS, transport http.RoundTripper, closeRespFn func(io.ReadCloser), serverRegion string) (c Config, err error) {
+	if err = config.CheckValidKeys(config.IdentitySAMLSubSys, kvs, DefaultKVS); err != nil {
+		return c, err
+	}
+
+	metadataURL := env.Get(EnvIdentitySAMLMetadataURL, kvs.Get(MetadataURL))
+	entityID := env.Get(EnvIdentitySAMLEntityID, kvs.Get(EntityID))
+	if metadataURL == "" && entityID == "" {
+		return c, nil
+	}
+
+	if metadataURL != "" {
+		u, err := xnet.ParseHTTPURL(metadataURL)
+		if err != nil {
+			return c, err
+		}
+		md, err := fetchMetadata(u, transport, closeRespFn)
+		if err != nil {
+			return c, config.Errorf("unable to load SAML metadata from %s: %v", metadataURL, err)
+		}
+		c.entityID = md.entityID
+		c.certs = md.certs
+	}
+	if entityID != "" {
+		c.entityID = entityID
+	}
+
+	if certFiles := env.Get(EnvIdentitySAMLCertificates, kvs.Get(Certificates)); certFiles != "" {
+		for _, certFile := range strings.Split(certFiles, config.ValueSeparator) {
+			certs, err := config.ParsePublicCertFile(strings.TrimSpace(certFile))
+			if err != nil {
+				return c, config.Errorf("unable to load SAML certificates from %s: %v", certFile, err)
+			}
+			c.certs = append(c.certs, certs...)
+		}
+	}
+
+	if c.entityID == "" {
+		return c, config.Errorf("SAML IdP entity ID is not set and not found in metadata")
+	}
+	if len(c.certs) == 0 {
+		return c, config.Errorf("SAML IdP signing certificates are not set and not found in metadata")
+	}
+
+	c.audience = env.Get(EnvIdentitySAMLAudience, kvs.Get(Audience))
+	if c.audience == "" {
+		return c, config.Errorf("SAML audience must be specified")
+	}
+
+	c.roleAttribute = env.Get(EnvIdentitySAMLRoleAttribute, kvs.Get(RoleAttribute))
+	c.policyAttribute = env.Get(EnvIdentitySAMLPolicyAttribute, kvs.Get(PolicyAttribute))
+
+	c.RolePolicy = env.Get(EnvIdentitySAMLRolePolicy, kvs.Get(RolePolicy))
+	if c.RolePolicy != "" {
+		resourceID := "saml-"
+		roleID := env.Get(EnvIdentitySAMLRoleID, kvs.Get(RoleID))
+		if roleID == "" {
+			// We use a hash of the entity ID so that the ARN remains
+			// constant across restarts.
+			h := sha1.New()
+			h.Write([]byte(c.entityID))
+			bs := h.Sum(nil)
+			resourceID += base64.RawURLEncoding.EncodeToString(bs)
+		} else {
+			// Check that the roleID is restricted to URL safe characters
+			// (base64 URL encoding chars).
+			if !validRoleIDRegex.MatchString(roleID) {
+				return c, config.Errorf("Role ID must match the regexp `^[a-zA-Z0-9_-]+$`")
+			}
+
+			// Use the user provided ID here.
+			resourceID += roleID
+		}
+
+		c.RoleARN, err = arn.NewIAMRoleARN(resourceID, serverRegion)
+		if err != nil {
+			return c, config.Errorf("unable to generate ARN from the SAML config: %v", err)
+		}
+	}
+
+	c.Enabled = true
+	return c, nil
+}
+
+// GetRoleInfo - returns ARN to policies map if a role policy is configured.
+func (c Config) GetRoleInfo() map[arn.ARN]string {
+	if !c.Enabled || c.RolePolicy == "" {
+		return nil
+	}
+	return map[arn.ARN]string{
+		c.RoleARN: c.RolePolicy,
+	}
+}
+
+// GetRoleArns - returns the role ARNs listed by the role attribute of an
+// assertion, ok is false if the attribute is absent. AWS style values pair
+// the role ARN with the provider ARN, e.g. `<role-arn>,<provider-arn>`.
+func (c Config) GetRoleArns(a Assertion) (arns []string, ok bool) {
+	values, ok := a.Attributes[c.roleAttribute]
+	if !ok {
+		return nil, false
+	}
+	for _, v := range values {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				arns = append(arns, s)
+			}
+		}
+	}
+	return arns, true
+}
+
+// GetPolicies - returns the policies listed by the policy attribute of
+// an assertion, values may hold several comma separated policies.
+func (c Config) GetPolicies(a Assertion) (policies []string, ok bool) {
+	values, ok := a.Attributes[c.policyAttribute]
+	if !ok {
+		return nil, false
+	}
+	for _, v := range values {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				policies = append(policies, s)
+			}
+		}
+	}
+	return policies, true
+}
+
+// GetAudience - returns the audience assertions must be restricted to.
+func (c Config) GetAudience() string {
+	return c.audience
+}
+
+const (
+	defaultExpiry time.Duration = 1 * time.Hour
+	minExpiry     time.Duration = 15 * time.Minute
+	maxExpiry     time.Duration = 365 * 24 * time.Hour
+)
+
+// GetExpiryDuration - return parsed expiry duration.
+func (c Config) GetExpiryDuration(dsecs string) (time.Duration, error) {
+	if dsecs == "" {
+		return defaultExpiry, nil
+	}
+
+	d, err := strconv.Atoi(dsecs)
+	if err != nil {
+		return 0, auth.ErrInvalidDuration
+	}
+
+	dur := time.Duration(d) * time.Second
+
+	if dur < minExpiry || dur > maxExpiry {
+		return 0, auth.ErrInvalidDuration
+	}
+	return dur, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package saml
+
+import (
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	xnet "github.com/minio/pkg/net"
+)
+
+// Limit of the metadata document size, ADFS metadata is usually well
+// below 100KiB.
+const maxMetadataSize = 5 << 20
+
+type entityDescriptor struct {
+	XMLName           xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID          string   `xml:"entityID,attr"`
+	IDPSSODescriptors []struct {
+		KeyDescriptors []struct {
+			Use          string   `xml:"use,attr"`
+			Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+		} `xml:"KeyDescriptor"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:metadata IDPSSODescriptor"`
+}
+
+// metadata - the IdP settings found in its SAML metadata.
+type metadata struct {
+	entityID string
+	certs    []*x509.Certificate
+}
+
+// parseMetadata - parses the entity ID and the signing certificates of an
+// IdP from its SAML metadata.
+// https://docs.oasis-open.org/security/saml/v2.0/saml-metadata-2.0-os.pdf
+func parseMetadata(data []byte) (md metadata, err error) {
+	var ed entityDescriptor
+	if err = xml.Unmarshal(data, &ed); err != nil {
+		return md, err
+	}
+	if len(ed.IDPSSODescriptors) == 0 {
+		return md, errors.New("metadata has no IDPSSODescriptor")
+	}
+
+	md.entityID = ed.EntityID
+	for _, idp := range ed.IDPSSODescriptors {
+		for _, kd := range idp.KeyDescriptors {
+			// Keys without usage are used for both signing and
+			// encryption.
+			if kd.Use != "" && kd.Use != "signing" {
+				continue
+			}
+			for _, c := range kd.Certificates {
+				der, err := decodeBase64(c)
+				if err != nil {
+					return md, fmt.Errorf("invalid certificate in metadata: %w", err)
+				}
+				cert, err := x509.ParseCertificate(der)
+				if err != nil {
+					return md, fmt.Errorf("invalid certificate in metadata: %w", err)
+				}
+				md.certs = append(md.certs, cert)
+			}
+		}
+	}
+	return md, nil
+}
+
+// fetchMetadata - downloads and parses the SAML metadata of an IdP.
+func fetchMetadata(u *xnet.URL, transport http.RoundTripper, closeRespFn func(io.ReadCloser)) (md metadata, err error) {
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return md, err
+	}
+
+	clnt := http.Client{
+		Transport: transport,
+	}
+	resp, err := clnt.Do(req)
+	if err != nil {
+		return md, err
+	}
+	defer closeRespFn(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return md, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize))
+	if err != nil {
+		return md, err
+	}
+	return parseMetadata(data)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package saml
+
+import (
+	"sync"
+	"time"
+)
+
+// replayCache - remembers the assertions that were used until they
+// expire, so that a bearer assertion grants credentials only once.
+type replayCache struct {
+	mu  sync.Mutex
+	ids map[string]time.Time
+}
+
+func newReplayCache() *replayCache {
+	return &replayCache{ids: make(map[string]time.Time)}
+}
+
+// use - records the assertion with the given issuer and ID, valid until
+// expiry. It returns false if the assertion was already used.
+func (r *replayCache) use(issuer, id string, expiry, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for k, exp := range r.ids {
+		if !now.Before(exp) {
+			delete(r.ids, k)
+		}
+	}
+
+	key := issuer + "\x00" + id
+	if _, ok := r.ids[key]; ok {
+		return false
+	}
+	r.ids[key] = expiry
+	return true
+}
+
+// Assertions used on this server, kept across configuration reloads.
+var usedAssertions = newReplayCache()
@@ -0,0 +1,361 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package saml
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+func TestParseDocument(t *testing.T) {
+	testCases := []struct {
+		doc       string
+		shouldErr bool
+	}{
+		{`<a xmlns:p="urn:p"><p:b/></a>`, false},
+		{`<a><p:b/></a>`, true},
+		{`<a><b></c></a>`, true},
+		{`<a/><b/>`, true},
+		{`<!DOCTYPE a [<!ENTITY x "y">]><a>&x;</a>`, true},
+		{`<a>`, true},
+		{`<a p:attr="1"/>`, true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := parseDocument([]byte(testCase.doc))
+		if (err != nil) != testCase.shouldErr {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.shouldErr, err)
+		}
+	}
+}
+
+const (
+	testIdP      = "http://adfs.example.com/adfs/services/trust"
+	testAudience = "urn:amazon:webservices"
+	testRoleArn  = "arn:minio:iam:::role/saml-test"
+)
+
+type testAssertion struct {
+	issuer       string
+	audience     string
+	notOnOrAfter time.Time
+	policy       string
+}
+
+func (a testAssertion) response() string {
+	now := time.Now().UTC()
+	if a.issuer == "" {
+		a.issuer = testIdP
+	}
+	if a.audience == "" {
+		a.audience = testAudience
+	}
+	if a.notOnOrAfter.IsZero() {
+		a.notOnOrAfter = now.Add(5 * time.Minute)
+	}
+	if a.policy == "" {
+		a.policy = "readwrite"
+	}
+	return fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?>
+<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="_resp" Version="2.0" IssueInstant="%[1]s" Destination="https://signin.aws.amazon.com/saml">
+  <Issuer xmlns="urn:oasis:names:tc:SAML:2.0:assertion">%[3]s</Issuer>
+  <samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success" /></samlp:Status>
+  <Assertion xmlns="urn:oasis:names:tc:SAML:2.0:assertion" ID="_assertion" IssueInstant="%[1]s" Version="2.0">
+    <Issuer>%[3]s</Issuer>
+    <Subject>
+      <NameID Format="urn:oasis:names:tc:SAML:2.0:nameid-format:persistent">EXAMPLE\alice</NameID>
+      <SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">
+        <SubjectConfirmationData NotOnOrAfter="%[2]s" Recipient="https://signin.aws.amazon.com/saml" />
+      </SubjectConfirmation>
+    </Subject>
+    <Conditions NotBefore="%[1]s" NotOnOrAfter="%[2]s">
+      <AudienceRestriction><Audience>%[4]s</Audience></AudienceRestriction>
+    </Conditions>
+    <AttributeStatement xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+      <Attribute Name="policy"><AttributeValue xsi:type="xs:string">%[5]s</AttributeValue></Attribute>
+      <Attribute Name="https://aws.amazon.com/SAML/Attributes/Role"><AttributeValue>%[6]s,arn:minio:iam:::saml-provider/adfs</AttributeValue></Attribute>
+    </AttributeStatement>
+    <AuthnStatement AuthnInstant="%[1]s" SessionNotOnOrAfter="%[7]s"><AuthnContext><AuthnContextClassRef>urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport</AuthnContextClassRef></AuthnContext></AuthnStatement>
+  </Assertion>
+</samlp:Response>`,
+		now.Format(time.RFC3339Nano), a.notOnOrAfter.Format(time.RFC3339Nano), a.issuer, a.audience,
+		a.policy, testRoleArn, now.Add(time.Hour).Format(time.RFC3339))
+}
+
+// sign - signs the element with the given ID with exclusive
+// canonicalization, as ADFS does.
+func sign(t *testing.T, doc, id string, key *rsa.PrivateKey, cert *x509.Certificate) string {
+	t.Helper()
+
+	ctx, err := dsig.NewSigningContext(key, [][]byte{cert.Raw})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	return signWith(t, doc, id, ctx)
+}
+
+func signWith(t *testing.T, doc, id string, ctx *dsig.SigningContext) string {
+	t.Helper()
+
+	d := etree.NewDocument()
+	if err := d.ReadFromString(doc); err != nil {
+		t.Fatal(err)
+	}
+	var found *etree.Element
+	walk(d.Root(), func(e *etree.Element) error {
+		if attr(e, "ID") == id {
+			found = e
+		}
+		return nil
+	})
+
+	nsCtx, err := etreeutils.NSBuildParentContext(found)
+	if err != nil {
+		t.Fatal(err)
+	}
+	detached, err := etreeutils.NSDetatch(nsCtx, found)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := ctx.SignEnveloped(detached)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent := found.Parent(); parent != nil {
+		parent.InsertChildAt(found.Index(), signed)
+		parent.RemoveChild(found)
+	} else {
+		d.SetRoot(signed)
+	}
+
+	s, err := d.WriteToString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func generateCert(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ADFS Signing"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, cert
+}
+
+func TestValidateResponse(t *testing.T) {
+	key, cert := generateCert(t)
+	otherKey, otherCert := generateCert(t)
+
+	cfg := Config{
+		Enabled:         true,
+		entityID:        testIdP,
+		certs:           []*x509.Certificate{cert},
+		audience:        testAudience,
+		roleAttribute:   defaultRoleAttribute,
+		policyAttribute: defaultPolicyAttribute,
+	}
+
+	valid := testAssertion{}.response()
+	sha1Ctx, err := dsig.NewSigningContext(key, [][]byte{cert.Raw})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sha1Ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	if err = sha1Ctx.SetSignatureMethod(dsig.RSASHA1SignatureMethod); err != nil {
+		t.Fatal(err)
+	}
+	signedAssertion := sign(t, valid, "_assertion", key, cert)
+
+	testCases := []struct {
+		name     string
+		response string
+		disabled bool
+		err      error
+	}{
+		{
+			name:     "signed assertion",
+			response: signedAssertion,
+		},
+		{
+			name:     "signed response",
+			response: sign(t, valid, "_resp", key, cert),
+		},
+		{
+			name:     "signed response and assertion",
+			response: sign(t, signedAssertion, "_resp", key, cert),
+		},
+		{
+			name:     "unsigned",
+			response: valid,
+			err:      errors.New("are signed"),
+		},
+		{
+			name:     "signed with another key",
+			response: sign(t, valid, "_assertion", otherKey, otherCert),
+			err:      errors.New("cannot be verified"),
+		},
+		{
+			name:     "tampered attribute",
+			response: strings.Replace(signedAssertion, ">readwrite<", ">consoleAdmin<", 1),
+			err:      errors.New("cannot be verified"),
+		},
+		{
+			name:     "signed with SHA-1",
+			response: signWith(t, valid, "_assertion", sha1Ctx),
+			err:      errors.New("Unsupported signature method"),
+		},
+		{
+			name:     "other audience",
+			response: sign(t, testAssertion{audience: "urn:other"}.response(), "_assertion", key, cert),
+			err:      errors.New("audience"),
+		},
+		{
+			name:     "other issuer",
+			response: sign(t, testAssertion{issuer: "http://evil.example.com"}.response(), "_assertion", key, cert),
+			err:      errors.New("issuer"),
+		},
+		{
+			name:     "expired",
+			response: sign(t, testAssertion{notOnOrAfter: time.Now().Add(-10 * time.Minute)}.response(), "_assertion", key, cert),
+			err:      ErrAssertionExpired,
+		},
+		{
+			name: "wrapped assertion",
+			response: strings.Replace(signedAssertion, `<samlp:Status>`,
+				`<samlp:Extensions><Assertion xmlns="urn:oasis:names:tc:SAML:2.0:assertion" ID="_assertion" /></samlp:Extensions><samlp:Status>`, 1),
+			err: errors.New("duplicate ID"),
+		},
+		{
+			name: "injected assertion",
+			response: strings.Replace(signedAssertion, `</samlp:Response>`,
+				`<Assertion xmlns="urn:oasis:names:tc:SAML:2.0:assertion" ID="_evil" Version="2.0" /></samlp:Response>`, 1),
+			err: errors.New("only once"),
+		},
+		{
+			name:     "disabled",
+			response: signedAssertion,
+			disabled: true,
+			err:      ErrNotConfigured,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			usedAssertions = newReplayCache()
+			c := cfg
+			c.Enabled = !testCase.disabled
+			a, err := c.ValidateResponse(base64.StdEncoding.EncodeToString([]byte(testCase.response)))
+			if testCase.err != nil {
+				if err == nil {
+					t.Fatalf("expected error %v", testCase.err)
+				}
+				if !errors.Is(err, testCase.err) && !strings.Contains(err.Error(), testCase.err.Error()) {
+					t.Fatalf("expected error %v, got %v", testCase.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if a.NameID != `EXAMPLE\alice` || a.Issuer != testIdP || a.SessionNotOnOrAfter.IsZero() {
+				t.Fatalf("unexpected assertion %#v", a)
+			}
+			if policies, ok := c.GetPolicies(a); !ok || len(policies) != 1 || policies[0] != "readwrite" {
+				t.Fatalf("unexpected policies %v", policies)
+			}
+			if arns, ok := c.GetRoleArns(a); !ok || len(arns) != 2 || arns[0] != testRoleArn {
+				t.Fatalf("unexpected role ARNs %v", arns)
+			}
+		})
+	}
+
+	// An assertion grants credentials only once while it is valid.
+	usedAssertions = newReplayCache()
+	response := base64.StdEncoding.EncodeToString([]byte(signedAssertion))
+	if _, err = cfg.ValidateResponse(response); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cfg.ValidateResponse(response); !errors.Is(err, ErrAssertionReplayed) {
+		t.Fatalf("expected error %v, got %v", ErrAssertionReplayed, err)
+	}
+	if _, err = cfg.ValidateResponse(base64.StdEncoding.EncodeToString([]byte(sign(t, valid, "_resp", key, cert)))); !errors.Is(err, ErrAssertionReplayed) {
+		t.Fatalf("expected error %v for the assertion in another response, got %v", ErrAssertionReplayed, err)
+	}
+}
+
+func TestParseMetadata(t *testing.T) {
+	_, cert := generateCert(t)
+	_, encCert := generateCert(t)
+
+	md, err := parseMetadata([]byte(fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?>
+<EntityDescriptor ID="_md" entityID="%s" xmlns="urn:oasis:names:tc:SAML:2.0:metadata">
+  <RoleDescriptor xmlns:fed="http://docs.oasis-open.org/wsfed/federation/200706" />
+  <IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <KeyDescriptor use="encryption"><KeyInfo xmlns="http://www.w3.org/2000/09/xmldsig#"><X509Data><X509Certificate>%s</X509Certificate></X509Data></KeyInfo></KeyDescriptor>
+    <KeyDescriptor use="signing"><KeyInfo xmlns="http://www.w3.org/2000/09/xmldsig#"><X509Data><X509Certificate>
+      %s
+    </X509Certificate></X509Data></KeyInfo></KeyDescriptor>
+  </IDPSSODescriptor>
+</EntityDescriptor>`, testIdP, base64.StdEncoding.EncodeToString(encCert.Raw), base64.StdEncoding.EncodeToString(cert.Raw))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.entityID != testIdP {
+		t.Fatalf("expected entity ID %s, got %s", testIdP, md.entityID)
+	}
+	if len(md.certs) != 1 || !md.certs[0].Equal(cert) {
+		t.Fatalf("expected only the signing certificate, got %d certificates", len(md.certs))
+	}
+
+	if _, err = parseMetadata([]byte(`<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="x" />`)); err == nil {
+		t.Fatal("expected an error for metadata without IDPSSODescriptor")
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package saml
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+// Reserved prefixes which need no declaration.
+const (
+	xmlPrefix   = "xml"
+	xmlnsPrefix = "xmlns"
+)
+
+// parseDocument - parses an XML document into its root element. Documents
+// with a DTD, several root elements or undeclared namespace prefixes are
+// rejected.
+func parseDocument(data []byte) (*etree.Element, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, err
+	}
+
+	var root *etree.Element
+	for _, t := range doc.Child {
+		switch t := t.(type) {
+		case *etree.Directive:
+			return nil, errors.New("XML documents with a DTD are not supported")
+		case *etree.Element:
+			if root != nil {
+				return nil, errors.New("XML document has more than one root element")
+			}
+			root = t
+		}
+	}
+	if root == nil {
+		return nil, errors.New("XML document has no root element")
+	}
+
+	return root, walk(root, checkNamespaces)
+}
+
+// checkNamespaces - verifies that all prefixes used by the element and its
+// attributes are declared.
+func checkNamespaces(e *etree.Element) error {
+	if e.Space != "" && e.NamespaceURI() == "" {
+		return fmt.Errorf("undeclared namespace prefix '%s'", e.Space)
+	}
+	for _, a := range e.Attr {
+		if a.Space == "" || a.Space == xmlPrefix || a.Space == xmlnsPrefix {
+			continue
+		}
+		if a.NamespaceURI() == "" {
+			return fmt.Errorf("undeclared namespace prefix '%s'", a.Space)
+		}
+	}
+	return nil
+}
+
+// is - returns true if the element has the given name.
+func is(e *etree.Element, namespace, local string) bool {
+	return e.Tag == local && e.NamespaceURI() == namespace
+}
+
+// childrenNamed - returns the child elements with the given name.
+func childrenNamed(e *etree.Element, namespace, local string) []*etree.Element {
+	var children []*etree.Element
+	for _, c := range e.ChildElements() {
+		if is(c, namespace, local) {
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+// child - returns the only child element with the given name, it is an
+// error if there is none or more than one.
+func child(e *etree.Element, namespace, local string) (*etree.Element, error) {
+	c, err := optionalChild(e, namespace, local)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, fmt.Errorf("<%s> is missing in <%s>", local, e.Tag)
+	}
+	return c, nil
+}
+
+// optionalChild - same as child, except that a missing element is not an
+// error.
+func optionalChild(e *etree.Element, namespace, local string) (*etree.Element, error) {
+	children := childrenNamed(e, namespace, local)
+	switch len(children) {
+	case 0:
+		return nil, nil
+	case 1:
+		return children[0], nil
+	}
+	return nil, fmt.Errorf("<%s> must appear only once in <%s>", local, e.Tag)
+}
+
+// attr - returns the value of an attribute without namespace.
+func attr(e *etree.Element, local string) string {
+	for _, a := range e.Attr {
+		if a.Space == "" && a.Key == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// text - returns the text content of the element, without that of its
+// child elements.
+func text(e *etree.Element) string {
+	var sb strings.Builder
+	for _, t := range e.Child {
+		if c, ok := t.(*etree.CharData); ok {
+			sb.WriteString(c.Data)
+		}
+	}
+	return sb.String()
+}
+
+// walk - calls fn for the element and all its descendants.
+func walk(e *etree.Element, fn func(*etree.Element) error) error {
+	if err := fn(e); err != nil {
+		return err
+	}
+	for _, c := range e.ChildElements() {
+		if err := walk(c, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package saml
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+// Signature and digest methods accepted in XML signatures, SHA-1 based
+// ones are rejected.
+// https://www.w3.org/TR/xmldsig-core1/
+var (
+	signatureMethods = map[string]struct{}{
+		dsig.RSASHA256SignatureMethod:   {},
+		dsig.RSASHA384SignatureMethod:   {},
+		dsig.RSASHA512SignatureMethod:   {},
+		dsig.ECDSASHA256SignatureMethod: {},
+		dsig.ECDSASHA384SignatureMethod: {},
+		dsig.ECDSASHA512SignatureMethod: {},
+	}
+
+	digestMethods = map[string]struct{}{
+		"http://www.w3.org/2001/04/xmlenc#sha256":       {},
+		"http://www.w3.org/2001/04/xmldsig-more#sha384": {},
+		"http://www.w3.org/2001/04/xmlenc#sha512":       {},
+	}
+)
+
+// errNotSigned is returned when an element has no signature.
+var errNotSigned = errors.New("element is not signed")
+
+// verifySignature - verifies the enveloped signature of an element with
+// the trusted certificates and returns the verified copy of the element,
+// which must be used instead of the element itself. Certificates embedded
+// in the signature are only used if they are trusted. The signature must
+// reference the element itself, so that signed content cannot be moved
+// elsewhere in the document.
+func verifySignature(e *etree.Element, certs []*x509.Certificate, now time.Time) (*etree.Element, error) {
+	sigs := childrenNamed(e, dsig.Namespace, dsig.SignatureTag)
+	switch len(sigs) {
+	case 0:
+		return nil, errNotSigned
+	case 1:
+	default:
+		return nil, fmt.Errorf("<%s> has more than one signature", e.Tag)
+	}
+
+	signedInfo, err := child(sigs[0], dsig.Namespace, dsig.SignedInfoTag)
+	if err != nil {
+		return nil, err
+	}
+	sigMethod, err := child(signedInfo, dsig.Namespace, dsig.SignatureMethodTag)
+	if err != nil {
+		return nil, err
+	}
+	if alg := attr(sigMethod, dsig.AlgorithmAttr); !isSupported(signatureMethods, alg) {
+		return nil, fmt.Errorf("Unsupported signature method '%s'", alg)
+	}
+	ref, err := child(signedInfo, dsig.Namespace, dsig.ReferenceTag)
+	if err != nil {
+		return nil, err
+	}
+	id := attr(e, dsig.DefaultIdAttr)
+	if id == "" || attr(ref, dsig.URIAttr) != "#"+id {
+		return nil, fmt.Errorf("Signature of <%s> does not reference it", e.Tag)
+	}
+	digestMethod, err := child(ref, dsig.Namespace, dsig.DigestMethodTag)
+	if err != nil {
+		return nil, err
+	}
+	if alg := attr(digestMethod, dsig.AlgorithmAttr); !isSupported(digestMethods, alg) {
+		return nil, fmt.Errorf("Unsupported digest method '%s'", alg)
+	}
+
+	// The element is verified out of its document, with the namespaces
+	// declared by its ancestors.
+	nsCtx, err := etreeutils.NSBuildParentContext(e)
+	if err != nil {
+		return nil, err
+	}
+	detached, err := etreeutils.NSDetatch(nsCtx, e)
+	if err != nil {
+		return nil, err
+	}
+
+	// Signatures without key info are verified with each certificate.
+	err = errors.New("no certificate")
+	for _, cert := range certs {
+		ctx := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{
+			Roots: []*x509.Certificate{cert},
+		})
+		ctx.Clock = dsig.NewFakeClockAt(now)
+		var verified *etree.Element
+		if verified, err = ctx.Validate(detached); err == nil {
+			return verified, nil
+		}
+	}
+	return nil, fmt.Errorf("Signature of <%s> cannot be verified with the identity provider certificates: %w", e.Tag, err)
+}
+
+func isSupported(algorithms map[string]struct{}, alg string) bool {
+	_, ok := algorithms[alg]
+	return ok
+}
+
+// decodeBase64 - decodes base64 data, which may contain whitespace as it
+// is common in XML documents.
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
@@ -38,6 +38,12 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         ClassCustom,
+			Description: `set named storage classes as comma separated "NAME=EC:parity[@pool]" e.g. "ARCHIVE_EC8=EC:8,HOT_EC2=EC:2@1"` + defaultHelpPostfix(ClassCustom),
+			Optional:    true,
+			Type:        "csv",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
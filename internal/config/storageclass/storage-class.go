@@ -20,6 +20,7 @@ package storageclass
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -40,11 +41,14 @@ const (
 const (
 	ClassStandard = "standard"
 	ClassRRS      = "rrs"
+	ClassCustom   = "custom"
 
 	// Reduced redundancy storage class environment variable
 	RRSEnv = "MINIO_STORAGE_CLASS_RRS"
 	// Standard storage class environment variable
 	StandardEnv = "MINIO_STORAGE_CLASS_STANDARD"
+	// Custom storage classes environment variable
+	CustomEnv = "MINIO_STORAGE_CLASS_CUSTOM"
 
 	// Supported storage class scheme is EC
 	schemePrefix = "EC"
@@ -67,6 +71,10 @@ var (
 			Key:   ClassRRS,
 			Value: "EC:1",
 		},
+		config.KV{
+			Key:   ClassCustom,
+			Value: "",
+		},
 	}
 )
 
@@ -78,10 +86,20 @@ type StorageClass struct {
 // ConfigLock is a global lock for storage-class config
 var ConfigLock sync.RWMutex
 
+// CustomClass - holds a named storage class information
+type CustomClass struct {
+	Name   string
+	Parity int
+	// Pool is the index of the pool preferred for new objects
+	// of this storage class, -1 if none.
+	Pool int
+}
+
 // Config storage class configuration
 type Config struct {
-	Standard StorageClass `json:"standard"`
-	RRS      StorageClass `json:"rrs"`
+	Standard StorageClass  `json:"standard"`
+	RRS      StorageClass  `json:"rrs"`
+	Custom   []CustomClass `json:"-"`
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -101,6 +119,81 @@ func IsValid(sc string) bool {
 	return sc == RRS || sc == STANDARD
 }
 
+// IsValid - returns true if input string is STANDARD, RRS or
+// one of the configured custom storage classes.
+func (sCfg Config) IsValid(sc string) bool {
+	return IsValid(sc) || sCfg.IsCustom(sc)
+}
+
+// IsCustom - returns true if input string is one of the configured
+// custom storage classes.
+func (sCfg Config) IsCustom(sc string) bool {
+	_, ok := sCfg.getCustom(sc)
+	return ok
+}
+
+// HasCustom - returns true if custom storage classes are configured.
+func (sCfg Config) HasCustom() bool {
+	ConfigLock.RLock()
+	defer ConfigLock.RUnlock()
+	return len(sCfg.Custom) > 0
+}
+
+// CustomClasses - returns the names of the configured custom storage classes.
+func (sCfg Config) CustomClasses() []string {
+	ConfigLock.RLock()
+	defer ConfigLock.RUnlock()
+	names := make([]string, 0, len(sCfg.Custom))
+	for _, c := range sCfg.Custom {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// GetPoolForSC - returns the index of the pool preferred for new
+// objects of the storage class, -1 if there is none.
+func (sCfg Config) GetPoolForSC(sc string) int {
+	c, ok := sCfg.getCustom(sc)
+	if !ok {
+		return -1
+	}
+	return c.Pool
+}
+
+func (sCfg Config) getCustom(sc string) (CustomClass, bool) {
+	ConfigLock.RLock()
+	defer ConfigLock.RUnlock()
+	sc = strings.TrimSpace(sc)
+	for _, c := range sCfg.Custom {
+		if c.Name == sc {
+			return c, true
+		}
+	}
+	return CustomClass{}, false
+}
+
+// ValidatePools validates the preferred pools of the custom storage
+// classes against the number of pools.
+func (sCfg Config) ValidatePools(pools int) error {
+	for _, c := range sCfg.Custom {
+		if c.Pool >= pools {
+			return config.ErrStorageClassValue(nil).Msg(fmt.Sprintf("Storage class %s prefers pool %d, only %d pools are available", c.Name, c.Pool, pools))
+		}
+	}
+	return nil
+}
+
+// ValidateTiers validates that no custom storage class is named after a
+// remote tier, lifecycle transitions refer to both by name.
+func (sCfg Config) ValidateTiers(isTier func(name string) bool) error {
+	for _, c := range sCfg.Custom {
+		if isTier(c.Name) {
+			return config.ErrStorageClassValue(nil).Msg("Storage class " + c.Name + " is the name of a remote tier")
+		}
+	}
+	return nil
+}
+
 // UnmarshalText unmarshals storage class from its textual form into
 // storageClass structure.
 func (sc *StorageClass) UnmarshalText(b []byte) error {
@@ -162,6 +255,73 @@ func parseStorageClass(storageClassEnv string) (sc StorageClass, err error) {
 	}, nil
 }
 
+// Parses given custom storage classes, the supported format is a comma
+// separated list of "NAME=EC:parity[@pool]", e.g "ARCHIVE_EC8=EC:8,HOT_EC2=EC:2@1"
+func parseCustomClasses(customEnv string, setDriveCount int) (classes []CustomClass, err error) {
+	for _, cs := range strings.Split(customEnv, ",") {
+		cs = strings.TrimSpace(cs)
+		if cs == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(cs, "=")
+		if !ok {
+			return nil, config.ErrStorageClassValue(nil).Msg("Missing storage class value in " + cs)
+		}
+		name = strings.TrimSpace(name)
+		if err = validateCustomName(name); err != nil {
+			return nil, err
+		}
+		for _, c := range classes {
+			if c.Name == name {
+				return nil, config.ErrStorageClassValue(nil).Msg("Duplicate storage class " + name)
+			}
+		}
+		c := CustomClass{Name: name, Pool: -1}
+		if v, pool, ok := strings.Cut(value, "@"); ok {
+			value = v
+			c.Pool, err = strconv.Atoi(pool)
+			if err != nil {
+				return nil, config.ErrStorageClassValue(err)
+			}
+			if c.Pool < 0 {
+				return nil, config.ErrStorageClassValue(nil).Msg("Unsupported pool value " + pool + " provided")
+			}
+		}
+		sc, err := parseStorageClass(value)
+		if err != nil {
+			return nil, err
+		}
+		if err = ValidateParity(sc.Parity, setDriveCount); err != nil {
+			return nil, config.ErrStorageClassValue(nil).Msg("Invalid parity for storage class " + name + ": " + err.Error())
+		}
+		c.Parity = sc.Parity
+		classes = append(classes, c)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].Name < classes[j].Name
+	})
+	return classes, nil
+}
+
+// Custom storage class names are made of upper case letters, digits,
+// '_' and '-', STANDARD and REDUCED_REDUNDANCY are reserved.
+func validateCustomName(name string) error {
+	if name == "" {
+		return config.ErrStorageClassValue(nil).Msg("Empty storage class name")
+	}
+	if IsValid(name) {
+		return config.ErrStorageClassValue(nil).Msg("Reserved storage class name " + name)
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+		default:
+			return config.ErrStorageClassValue(nil).Msg("Unsupported storage class name " + name + ", only upper case letters, digits, '_' and '-' are allowed")
+		}
+	}
+	return nil
+}
+
 // ValidateParity validate standard storage class parity.
 func ValidateParity(ssParity, setDriveCount int) error {
 	// SS parity disks should be greater than or equal to minParityDisks.
@@ -225,6 +385,8 @@ func validateParity(ssParity, rrsParity, setDriveCount int) (err error) {
 //
 //	is returned, the caller is expected to choose the right parity
 //	at that point.
+//
+// -- if input is a custom storage class its parity is returned
 //根据storage class 返回校验磁盘的数量
 //现在只有RRS和标准
 //根据环境变量来的, MINIO_STORAGE_CLASS_RRS MINIO_STORAGE_CLASS_STANDARD
@@ -232,12 +394,18 @@ func validateParity(ssParity, rrsParity, setDriveCount int) (err error) {
 func (sCfg Config) GetParityForSC(sc string) (parity int) {
 	ConfigLock.RLock()
 	defer ConfigLock.RUnlock()
-	switch strings.TrimSpace(sc) {
+	switch sc = strings.TrimSpace(sc); sc {
 	case RRS:
 		return sCfg.RRS.Parity
-	default:
+	case STANDARD, "":
 		return sCfg.Standard.Parity
 	}
+	for _, c := range sCfg.Custom {
+		if c.Name == sc {
+			return c.Parity
+		}
+	}
+	return sCfg.Standard.Parity
 }
 
 // Update update storage-class with new config
@@ -246,13 +414,15 @@ func (sCfg *Config) Update(newCfg Config) {
 	defer ConfigLock.Unlock()
 	sCfg.RRS = newCfg.RRS
 	sCfg.Standard = newCfg.Standard
+	sCfg.Custom = newCfg.Custom
 }
 
 // Enabled returns if etcd is enabled.
 func Enabled(kvs config.KVS) bool {
 	ssc := kvs.Get(ClassStandard)
 	rrsc := kvs.Get(ClassRRS)
-	return ssc != "" || rrsc != ""
+	customc := kvs.Get(ClassCustom)
+	return ssc != "" || rrsc != "" || customc != ""
 }
 
 // DefaultParityBlocks returns default parity blocks for 'drive' count
@@ -320,5 +490,13 @@ func LookupConfig(kvs config.KVS, setDriveCount int) (cfg Config, err error) {
 		return cfg, err
 	}
 
+	if customc := env.Get(CustomEnv, kvs.Get(ClassCustom)); customc != "" {
+		cfg.Custom, err = parseCustomClasses(customc, setDriveCount)
+		if err != nil {
+			cfg.Custom = nil
+			return cfg, err
+		}
+	}
+
 	return cfg, nil
 }
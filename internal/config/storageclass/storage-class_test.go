@@ -187,3 +187,126 @@ func TestIsValidStorageClassKind(t *testing.T) {
 		}
 	}
 }
+
+func TestParseCustomClasses(t *testing.T) {
+	tests := []struct {
+		customEnv     string
+		wantClasses   []CustomClass
+		expectedError error
+	}{
+		{
+			"HOT_EC2=EC:2@1, ARCHIVE_EC8=EC:8",
+			[]CustomClass{
+				{Name: "ARCHIVE_EC8", Parity: 8, Pool: -1},
+				{Name: "HOT_EC2", Parity: 2, Pool: 1},
+			},
+			nil,
+		},
+		{
+			"",
+			nil,
+			nil,
+		},
+		{
+			"HOT_EC2",
+			nil,
+			errors.New("Missing storage class value in HOT_EC2"),
+		},
+		{
+			"hot=EC:2",
+			nil,
+			errors.New("Unsupported storage class name hot, only upper case letters, digits, '_' and '-' are allowed"),
+		},
+		{
+			"STANDARD=EC:2",
+			nil,
+			errors.New("Reserved storage class name STANDARD"),
+		},
+		{
+			"HOT=EC:2,HOT=EC:3",
+			nil,
+			errors.New("Duplicate storage class HOT"),
+		},
+		{
+			"HOT=EC:2@-1",
+			nil,
+			errors.New("Unsupported pool value -1 provided"),
+		},
+		{
+			"ARCHIVE=EC:9",
+			nil,
+			errors.New("Invalid parity for storage class ARCHIVE: parity 9 should be less than or equal to 8"),
+		},
+	}
+	for i, tt := range tests {
+		gotClasses, err := parseCustomClasses(tt.customEnv, 16)
+		if (err != nil) != (tt.expectedError != nil) {
+			t.Errorf("Test %d, Expected %v, got %v", i+1, tt.expectedError, err)
+			continue
+		}
+		if tt.expectedError != nil {
+			if err.Error() != tt.expectedError.Error() {
+				t.Errorf("Test %d, Expected `%v`, got `%v`", i+1, tt.expectedError, err)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(gotClasses, tt.wantClasses) {
+			t.Errorf("Test %d, Expected %v, got %v", i+1, tt.wantClasses, gotClasses)
+		}
+	}
+}
+
+func TestCustomClasses(t *testing.T) {
+	scfg := Config{
+		Standard: StorageClass{
+			Parity: 4,
+		},
+		RRS: StorageClass{
+			Parity: 2,
+		},
+		Custom: []CustomClass{
+			{Name: "ARCHIVE_EC8", Parity: 8, Pool: -1},
+			{Name: "HOT_EC2", Parity: 2, Pool: 1},
+		},
+	}
+	tests := []struct {
+		sc     string
+		valid  bool
+		custom bool
+		parity int
+		pool   int
+	}{
+		{STANDARD, true, false, 4, -1},
+		{RRS, true, false, 2, -1},
+		{"", false, false, 4, -1},
+		{"ARCHIVE_EC8", true, true, 8, -1},
+		{"HOT_EC2", true, true, 2, 1},
+		{"COLD", false, false, 4, -1},
+	}
+	for i, tt := range tests {
+		if got := scfg.IsValid(tt.sc); got != tt.valid {
+			t.Errorf("Test %d, Expected valid %t, got %t", i+1, tt.valid, got)
+		}
+		if got := scfg.IsCustom(tt.sc); got != tt.custom {
+			t.Errorf("Test %d, Expected custom %t, got %t", i+1, tt.custom, got)
+		}
+		if got := scfg.GetParityForSC(tt.sc); got != tt.parity {
+			t.Errorf("Test %d, Expected parity %d, got %d", i+1, tt.parity, got)
+		}
+		if got := scfg.GetPoolForSC(tt.sc); got != tt.pool {
+			t.Errorf("Test %d, Expected pool %d, got %d", i+1, tt.pool, got)
+		}
+	}
+	if err := scfg.ValidatePools(2); err != nil {
+		t.Errorf("Expected success, got %v", err)
+	}
+	if err := scfg.ValidatePools(1); err == nil {
+		t.Error("Expected failure, got success")
+	}
+	if err := scfg.ValidateTiers(func(name string) bool { return name == "WARM" }); err != nil {
+		t.Errorf("Expected success, got %v", err)
+	}
+	if err := scfg.ValidateTiers(func(name string) bool { return name == "HOT_EC2" }); err == nil {
+		t.Error("Expected failure, got success")
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package schedule implements recurring time windows on days of the week,
+// such as maintenance windows of background operations.
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Window is a recurring time window starting at Start from midnight on
+// each of Days, ending at End from midnight. A window ending before its
+// start spans midnight and ends on the next day.
+type Window struct {
+	Days  [7]bool // indexed by time.Weekday
+	Start time.Duration
+	End   time.Duration
+}
+
+// Windows is a list of time windows, an empty list is always open.
+type Windows []Window
+
+// Parse parses a comma separated list of windows of the form
+// "[day[-day]] [hh:mm-hh:mm]", e.g. "mon-fri 22:00-06:00,sat-sun".
+// A window without days applies to all the days of the week, a
+// window without times lasts all day.
+func Parse(s string) (Windows, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	var ws Windows
+	for _, v := range strings.Split(s, ",") {
+		w, err := parseWindow(strings.TrimSpace(v))
+		if err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	return ws, nil
+}
+
+func parseWindow(s string) (w Window, err error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 || len(fields) > 2 {
+		return w, fmt.Errorf("invalid window '%s'", s)
+	}
+
+	days, times := "", ""
+	switch {
+	case len(fields) == 2:
+		days, times = fields[0], fields[1]
+	case strings.Contains(fields[0], ":"):
+		times = fields[0]
+	default:
+		days = fields[0]
+	}
+
+	if days == "" {
+		for i := range w.Days {
+			w.Days[i] = true
+		}
+	} else {
+		from, to, found := strings.Cut(days, "-")
+		first, ok := weekdays[from]
+		if !ok {
+			return w, fmt.Errorf("invalid day '%s' in window '%s'", from, s)
+		}
+		last := first
+		if found {
+			if last, ok = weekdays[to]; !ok {
+				return w, fmt.Errorf("invalid day '%s' in window '%s'", to, s)
+			}
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			w.Days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+
+	if times == "" {
+		w.End = day
+		return w, nil
+	}
+	start, end, found := strings.Cut(times, "-")
+	if !found {
+		return w, fmt.Errorf("invalid times '%s' in window '%s'", times, s)
+	}
+	if w.Start, err = parseTime(start); err != nil {
+		return w, err
+	}
+	if w.End, err = parseTime(end); err != nil {
+		return w, err
+	}
+	if w.Start == w.End {
+		return w, fmt.Errorf("empty window '%s'", s)
+	}
+	return w, nil
+}
+
+// parseTime parses hh:mm as a duration from midnight, 24:00 is allowed
+// to end a window at midnight.
+func parseTime(s string) (time.Duration, error) {
+	if s == "24:00" {
+		return day, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time '%s'", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// midnight returns the start of the day of t.
+func midnight(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// Contains returns true if t falls in the window.
+func (w Window) Contains(t time.Time) bool {
+	since := t.Sub(midnight(t))
+	today := t.Weekday()
+	if w.Start < w.End {
+		return w.Days[today] && since >= w.Start && since < w.End
+	}
+	yesterday := (today + 6) % 7
+	return (w.Days[today] && since >= w.Start) || (w.Days[yesterday] && since < w.End)
+}
+
+// Contains returns true if t falls in any of the windows, or if there
+// are no windows.
+func (ws Windows) Contains(t time.Time) bool {
+	if len(ws) == 0 {
+		return true
+	}
+	for _, w := range ws {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the time at which the next window opens after t, t itself
+// if t falls in a window.
+func (ws Windows) Next(t time.Time) time.Time {
+	if ws.Contains(t) {
+		return t
+	}
+	var next time.Time
+	start := midnight(t)
+	for d := 0; d <= 7; d++ {
+		dayStart := start.AddDate(0, 0, d)
+		for _, w := range ws {
+			if !w.Days[dayStart.Weekday()] {
+				continue
+			}
+			open := dayStart.Add(w.Start)
+			if open.After(t) && (next.IsZero() || open.Before(next)) {
+				next = open
+			}
+		}
+		if !next.IsZero() {
+			break
+		}
+	}
+	return next
+}
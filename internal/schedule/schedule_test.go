@@ -0,0 +1,89 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		windows string
+		success bool
+	}{
+		{"", true},
+		{"22:00-06:00", true},
+		{"mon-fri 22:00-06:00,sat-sun", true},
+		{"fri-mon 00:00-24:00", true},
+		{"SAT", true},
+		{"mon-fri 22:00", false},
+		{"funday 22:00-23:00", false},
+		{"mon-fri 25:00-06:00", false},
+		{"mon 10:00-10:00", false},
+		{"mon 10:00-11:00 extra", false},
+	}
+	for i, testCase := range testCases {
+		_, err := Parse(testCase.windows)
+		if testCase.success && err != nil {
+			t.Errorf("Test %d: expected success, got %v", i+1, err)
+		}
+		if !testCase.success && err == nil {
+			t.Errorf("Test %d: expected failure", i+1)
+		}
+	}
+}
+
+func TestWindows(t *testing.T) {
+	ws, err := Parse("mon-fri 22:00-06:00,sat-sun")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2022-09-05 is a Monday.
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2022, time.September, day, hour, min, 0, 0, time.UTC)
+	}
+	testCases := []struct {
+		t        time.Time
+		contains bool
+		next     time.Time
+	}{
+		{at(5, 5, 0), false, at(5, 22, 0)},     // Monday morning, Sunday was not a weekday window
+		{at(5, 12, 0), false, at(5, 22, 0)},    // Monday noon
+		{at(5, 23, 0), true, at(5, 23, 0)},     // Monday night
+		{at(6, 5, 59), true, at(6, 5, 59)},     // Tuesday early morning
+		{at(6, 6, 0), false, at(6, 22, 0)},     // Tuesday morning
+		{at(9, 23, 0), true, at(9, 23, 0)},     // Friday night
+		{at(10, 12, 0), true, at(10, 12, 0)},   // Saturday
+		{at(11, 23, 59), true, at(11, 23, 59)}, // Sunday
+	}
+	for i, testCase := range testCases {
+		if got := ws.Contains(testCase.t); got != testCase.contains {
+			t.Errorf("Test %d: expected contains %v, got %v", i+1, testCase.contains, got)
+		}
+		if got := ws.Next(testCase.t); !got.Equal(testCase.next) {
+			t.Errorf("Test %d: expected next %v, got %v", i+1, testCase.next, got)
+		}
+	}
+
+	var none Windows
+	if !none.Contains(at(5, 12, 0)) {
+		t.Error("expected no windows to be always open")
+	}
+}